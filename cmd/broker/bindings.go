@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+)
+
+func NewBindingProcessingQueue(ctx context.Context, cfg *Config, db storage.BrokerStorage, processor *broker.BindingProcessor, logs *slog.Logger) *process.Queue {
+	queue := newProcessingQueue(processor, db, cfg, logs, "binding-processing")
+	queue.Run(ctx.Done(), cfg.Broker.Binding.AsyncWorkersAmount)
+
+	return queue
+}
+
+// queues asynchronous bindings in progress and fails bindings whose creation was interrupted, the missing queue is passed
+// as a nil interface, so asynchronous bindings are failed instead of being resumed when asynchronous bindings are disabled
+func processBindingsInProgress(processor *broker.BindingProcessor, queue *process.Queue) error {
+	if queue == nil {
+		return processor.ResumeInProgress(nil)
+	}
+	return processor.ResumeInProgress(queue)
+}
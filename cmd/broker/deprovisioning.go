@@ -27,6 +27,7 @@ func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, de
 	deprovisioningSteps := []struct {
 		disabled bool
 		step     process.Step
+		policy   process.StepPolicy
 	}{
 		{
 			step: deprovisioning.NewInitStep(db, 12*time.Hour),
@@ -38,23 +39,27 @@ func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, de
 			step: deprovisioning.NewDeleteKymaResourceStep(db, kcpClient, config.NewConfigMapConfigProvider(configProvider, cfg.RuntimeConfigurationConfigMapName, config.RuntimeConfigurationRequiredFields)),
 		},
 		{
-			step: deprovisioning.NewCheckKymaResourceDeletedStep(db, kcpClient),
+			step:   deprovisioning.NewCheckKymaResourceDeletedStep(db, kcpClient),
+			policy: checkResourceStepPolicy,
 		},
 		{
 			step: deprovisioning.NewDeleteRuntimeResourceStep(db, kcpClient),
 		},
 		{
-			step: deprovisioning.NewCheckRuntimeResourceDeletionStep(db, kcpClient, cfg.StepTimeouts.CheckRuntimeResourceDeletion),
+			step:   deprovisioning.NewCheckRuntimeResourceDeletionStep(db, kcpClient),
+			policy: resourceStatePolicy(cfg, cfg.StepTimeouts.CheckRuntimeResourceDeletion),
 		},
 		{
 			disabled: useCredentialsBinding,
 			step: steps.NewHolderStep(cfg.HoldHapSteps,
 				deprovisioning.NewFreeSubscriptionStep(db.Operations(), db.Instances(), gardenerClient, gardenerNamespace)),
+			policy: hapStepPolicy(cfg),
 		},
 		{
 			disabled: !useCredentialsBinding,
 			step: steps.NewHolderStep(cfg.HoldHapSteps,
 				deprovisioning.NewFreeCredentialsBindingStep(db.Operations(), db.Instances(), gardenerClient, gardenerNamespace)),
+			policy: hapStepPolicy(cfg),
 		},
 		{
 			step: deprovisioning.NewArchivingStep(db),
@@ -75,12 +80,12 @@ func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, de
 	deprovisionManager.DefineStages(stages)
 	for _, step := range deprovisioningSteps {
 		if !step.disabled {
-			err := deprovisionManager.AddStep(step.step.Name(), step.step, nil)
+			err := deprovisionManager.AddStep(step.step.Name(), step.step, nil, step.policy)
 			fatalOnError(err, logs)
 		}
 	}
 
-	queue := process.NewQueue(deprovisionManager, logs, "deprovisioning")
+	queue := newProcessingQueue(deprovisionManager, db, cfg, logs, "deprovisioning")
 	queue.Run(ctx.Done(), workersAmount)
 
 	return queue
@@ -17,25 +17,39 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/additionalproperties"
+	"github.com/kyma-project/kyma-environment-broker/internal/auth"
+	"github.com/kyma-project/kyma-environment-broker/internal/bindings"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	brokerBindings "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
 	"github.com/kyma-project/kyma-environment-broker/internal/btpregionsmigration"
 	kebConfig "github.com/kyma-project/kyma-environment-broker/internal/config"
 	"github.com/kyma-project/kyma-environment-broker/internal/dashboard"
+	"github.com/kyma-project/kyma-environment-broker/internal/drift"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/events"
 	eventshandler "github.com/kyma-project/kyma-environment-broker/internal/events/handler"
 	"github.com/kyma-project/kyma-environment-broker/internal/expiration"
 	"github.com/kyma-project/kyma-environment-broker/internal/health"
 	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/alicloud"
 	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/azure"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/gcp"
 	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/kyma-environment-broker/internal/machinesavailability"
 	"github.com/kyma-project/kyma-environment-broker/internal/metrics"
+	"github.com/kyma-project/kyma-environment-broker/internal/networking"
+	"github.com/kyma-project/kyma-environment-broker/internal/operations"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/provisioning"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/update"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
 	"github.com/kyma-project/kyma-environment-broker/internal/quota"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
+	"github.com/kyma-project/kyma-environment-broker/internal/resourcewatch"
 	"github.com/kyma-project/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/kyma-environment-broker/internal/suspension"
@@ -47,6 +61,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/dlmiddlecote/sqlstats"
 	shoot "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/google/uuid"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -117,6 +132,9 @@ type Config struct {
 	Provisioning   process.StagedManagerConfiguration
 	Deprovisioning process.StagedManagerConfiguration
 	Update         process.StagedManagerConfiguration
+	Queue          process.QueueConfig
+	OperationLease process.OperationLeaseConfig
+	ResourceWatch  resourcewatch.Config
 
 	RuntimeConfigurationConfigMapName string `envconfig:"default=keb-runtime-config"`
 
@@ -136,10 +154,19 @@ type Config struct {
 	Quota                               quota.Config
 	QuotaWhitelistedSubaccountsFilePath string
 
+	BindingPoliciesFilePath string
+
+	StepPoliciesFilePath string
+
+	RegionPoliciesFilePath string
+
 	// todo: remove after all SecretBinding are migrated to CredentialBinding resources
 	HoldHapSteps bool
 
+	AdminAuth auth.Config
+
 	MachinesAvailabilityEndpoint bool
+	MachinesAvailability         machinesavailability.Config
 
 	BtpRegionsMigrationSapConvergedCloudFilePath string
 }
@@ -173,6 +200,8 @@ const (
 
 var Version string
 
+var replicaName = newReplicaName()
+
 func periodicProfile(logger *slog.Logger, profiler ProfilerConfig) {
 	if !profiler.Memory {
 		return
@@ -283,7 +312,8 @@ func main() {
 		fatalOnError(err, log)
 	}
 
-	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err, log)
 
 	// create storage
 	var db storage.BrokerStorage
@@ -354,21 +384,70 @@ func main() {
 	fatalOnError(err, log)
 	fatalOnError(schemaService.Validate(), log)
 	log.Info("Plans and providers configuration is valid")
+
+	regionPolicies, err := regionpolicy.ReadPoliciesFromFile(cfg.RegionPoliciesFilePath)
+	fatalOnError(err, log)
+	fatalOnError(regionPolicies.ValidateHyperscalerRegions(providerSpec), log)
+	fatalOnError(regionPolicies.ValidateHAPRules(rulesService), log)
+	for platformRegion, policy := range regionPolicies {
+		log.Info(fmt.Sprintf("Platform region %s policy: %s", platformRegion, policy))
+	}
+	schemaService.UseRegionPolicies(regionPolicies)
 	workersProvider := workers.NewProvider(cfg.InfrastructureManager, providerSpec)
 
-	awsClientFactory := aws.NewFactory()
+	zonesClientFactory := hyperscalers.NewZonesClientFactory(aws.NewFactory(), azure.NewFactory(), gcp.NewFactory(), alicloud.NewFactory())
+
+	stepPolicies, err := process.ReadStepPoliciesFromFile(cfg.StepPoliciesFilePath)
+	fatalOnError(err, log)
+	for stepName, policy := range stepPolicies {
+		log.Info(fmt.Sprintf("Step %s policy overridden: %s", stepName, policy))
+	}
 
 	// run queues
 	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.Broker.OperationTimeout, cfg.Provisioning, log.With("provisioning", "manager"))
+	provisionManager.UseStepPolicies(stepPolicies)
+	provisionManager.RecordSteps(db.OperationSteps())
 	provisionQueue := NewProvisioningProcessingQueue(ctx, provisionManager, cfg.Provisioning.WorkersAmount, &cfg, db, configProvider,
-		skrK8sClientProvider, kcpK8sClient, gardenerClient, oidcDefaultValues, log, rulesService, workersProvider, providerSpec, awsClientFactory)
+		skrK8sClientProvider, kcpK8sClient, gardenerClient, oidcDefaultValues, log, rulesService, workersProvider, providerSpec, zonesClientFactory)
 
 	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.Broker.OperationTimeout, cfg.Deprovisioning, log.With("deprovisioning", "manager"))
+	deprovisionManager.UseStepPolicies(stepPolicies)
+	deprovisionManager.RecordSteps(db.OperationSteps())
 	deprovisionQueue := NewDeprovisioningProcessingQueue(ctx, cfg.Deprovisioning.WorkersAmount, deprovisionManager, &cfg, db,
 		skrK8sClientProvider, kcpK8sClient, configProvider, dynamicGardener, gardenerNamespace, log)
 
 	updateManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.Broker.OperationTimeout, cfg.Update, log.With("update", "manager"))
-	updateQueue := NewUpdateProcessingQueue(ctx, updateManager, cfg.Update.WorkersAmount, db, cfg, kcpK8sClient, log, workersProvider, schemaService, plansSpec, configProvider, providerSpec, gardenerClient, awsClientFactory)
+	updateManager.UseStepPolicies(stepPolicies)
+	updateManager.RecordSteps(db.OperationSteps())
+	enableOperationLease(&cfg, provisionManager, deprovisionManager, updateManager)
+	provisionManager.RecordActions(db.Actions())
+	updateManager.RecordActions(db.Actions())
+	updateQueue := NewUpdateProcessingQueue(ctx, updateManager, cfg.Update.WorkersAmount, db, cfg, kcpK8sClient, log, workersProvider, schemaService, plansSpec, configProvider, providerSpec, gardenerClient, zonesClientFactory)
+	bindingProcessor := broker.NewBindingProcessor(cfg.Broker.Binding, db, brokerBindings.NewBindingsManagers(skrK8sClientProvider, skrK8sClientProvider, oidcDefaultValues), eventBroker, log)
+	var bindingQueue *process.Queue
+	if cfg.Broker.Binding.Enabled && cfg.Broker.Binding.AsyncEnabled {
+		bindingQueue = NewBindingProcessingQueue(ctx, &cfg, db, bindingProcessor, log)
+	}
+	if cfg.Broker.Binding.Enabled {
+		go broker.NewBindingExpirationReporter(db.Bindings(), log).Run(ctx, cfg.Broker.Binding.ExpirationReportInterval)
+	}
+	if cfg.ResourceWatch.Enabled {
+		dynamicKcp, err := dynamic.NewForConfig(kcpK8sConfig)
+		fatalOnError(err, log)
+		notifier := resourcewatch.NewNotifier(db.Operations(), map[internal.OperationType]resourcewatch.Queue{
+			internal.OperationTypeProvision:   provisionQueue,
+			internal.OperationTypeDeprovision: deprovisionQueue,
+			internal.OperationTypeUpdate:      updateQueue,
+		}, log)
+		fatalOnError(notifier.Run(ctx, dynamicKcp, cfg.ResourceWatch.ResyncPeriod), log)
+	}
+	var machinesAvailability *machinesavailability.Collector
+	if cfg.MachinesAvailabilityEndpoint {
+		machinesAvailability, err = newMachinesAvailabilityCollector(&cfg, providerSpec, rulesService, gardenerClient, zonesClientFactory, log)
+		fatalOnError(err, log)
+		prometheus.MustRegister(machinesAvailability)
+		go machinesAvailability.Run(ctx)
+	}
 	/***/
 	servicesConfig, err := broker.NewServicesConfigFromFile(cfg.CatalogFilePath)
 	fatalOnError(err, log)
@@ -382,16 +461,22 @@ func main() {
 	// Apply panic recovery middleware to all HTTP endpoints
 	router.Use(httputil.PanicRecoveryMiddleware(log))
 
-	createAPI(router, schemaService, servicesConfig, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, logger, log,
+	// admin endpoints require access tokens granting their permissions if the admin authorization is enabled
+	adminAuth, err := auth.NewAuthorizer(cfg.AdminAuth, &http.Client{Timeout: 30 * time.Second}, log)
+	fatalOnError(err, log)
+
+	createAPI(router, schemaService, servicesConfig, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, bindingQueue, logger, log,
 		kcBuilder, skrK8sClientProvider, skrK8sClientProvider, kcpK8sClient, eventBroker, oidcDefaultValues,
-		providerSpec, configProvider, plansSpec, rulesService, gardenerClient, awsClientFactory)
+		providerSpec, configProvider, plansSpec, rulesService, gardenerClient, zonesClientFactory, machinesAvailability, adminAuth)
 
 	// create metrics endpoint
 	router.Handle("/metrics", promhttp.Handler())
 
+	readRuntimesRouter := router.With(adminAuth.Require(auth.PermissionReadRuntimes))
+
 	// create SKR kubeconfig endpoint
 	kcHandler := kubeconfig.NewHandler(db, kcBuilder, cfg.Kubeconfig.AllowOrigins, log.With("service", "kubeconfigHandle"))
-	kcHandler.AttachRoutes(router)
+	kcHandler.AttachRoutes(router.With(adminAuth.Require(auth.PermissionReadKubeconfig)))
 
 	if !cfg.DisableProcessOperationsInProgress {
 		err = processOperationsInProgressByType(internal.OperationTypeProvision, db.Operations(), provisionQueue, log)
@@ -400,6 +485,10 @@ func main() {
 		fatalOnError(err, log)
 		err = processOperationsInProgressByType(internal.OperationTypeUpdate, db.Operations(), updateQueue, log)
 		fatalOnError(err, log)
+		if cfg.Broker.Binding.Enabled {
+			err = processBindingsInProgress(bindingProcessor, bindingQueue)
+			fatalOnError(err, log)
+		}
 	} else {
 		log.Info("Skipping processing operation in progress on start")
 	}
@@ -416,15 +505,27 @@ func main() {
 		cfg.Broker.DefaultRequestRegion,
 		kcpK8sClient,
 		log)
-	router.HandleFunc("/runtimes", runtimeHandler.GetRuntimes)
+	readRuntimesRouter.HandleFunc("/runtimes", runtimeHandler.GetRuntimes)
+
+	// create events endpoint
+	eventsHandler := eventshandler.NewHandler(db.Events(), db.Instances())
+	readRuntimesRouter.Handle("/events", eventsHandler)
+
+	// create list bindings endpoint
+	bindingsHandler := bindings.NewHandler(db.Bindings(), cfg.MaxPaginationPage, log)
+	bindingsHandler.AttachRoutes(readRuntimesRouter)
 
 	// create list requests with additional properties endpoint
 	additionalPropertiesHandler := additionalproperties.NewHandler(log, cfg.Broker.AdditionalPropertiesPath)
-	additionalPropertiesHandler.AttachRoutes(router)
+	additionalPropertiesHandler.AttachRoutes(readRuntimesRouter)
 
 	// create expiration endpoint
 	expirationHandler := expiration.NewHandler(db.Instances(), db.Operations(), deprovisionQueue, log)
-	expirationHandler.AttachRoutes(router)
+	expirationHandler.AttachRoutes(router.With(adminAuth.Require(auth.PermissionExpire)))
+
+	// create operation cancellation, retry and timeline endpoints
+	operationsHandler := operations.NewHandler(db.Operations(), db.Actions(), db.OperationSteps(), provisionQueue, updateQueue, provisionManager, updateManager, log)
+	operationsHandler.AttachRoutes(router.With(adminAuth.RequireByMethod(auth.PermissionReadRuntimes, auth.PermissionManageOperations)))
 
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.StripPrefix("/", http.FileServer(http.Dir("/swagger"))).ServeHTTP(w, r)
@@ -440,6 +541,8 @@ func main() {
 
 func logConfiguration(logs *slog.Logger, cfg Config) {
 	logs.Info(fmt.Sprintf("Setting staged manager configuration: provisioning=%s, deprovisioning=%s, update=%s", cfg.Provisioning, cfg.Deprovisioning, cfg.Update))
+	logs.Info(fmt.Sprintf("Setting queue configuration: %s", cfg.Queue))
+	logs.Info(fmt.Sprintf("Setting operation lease configuration: %s, replica name: %s", cfg.OperationLease, replicaName))
 	logs.Info(fmt.Sprintf("EnablePlans: %s", cfg.Broker.EnablePlans))
 	logs.Info(fmt.Sprintf("Is SubaccountMovementEnabled: %t", cfg.Broker.SubaccountMovementEnabled))
 	logs.Info(fmt.Sprintf("Is UpdateCustomResourcesLabelsOnAccountMove enabled: %t", cfg.Broker.UpdateCustomResourcesLabelsOnAccountMove))
@@ -464,30 +567,44 @@ func logConfiguration(logs *slog.Logger, cfg Config) {
 	logs.Info(fmt.Sprintf("Metrics.OperationResultFinishedOperationRetentionPeriod: %s", cfg.Metrics.OperationResultFinishedOperationRetentionPeriod))
 	logs.Info(fmt.Sprintf("Metrics.BindingsStatsPollingInterval: %s", cfg.Metrics.BindingsStatsPollingInterval))
 
+	// log machines availability config
+	logs.Info(fmt.Sprintf("MachinesAvailabilityEndpoint: %t", cfg.MachinesAvailabilityEndpoint))
+	logs.Info(fmt.Sprintf("MachinesAvailability.RefreshInterval: %s", cfg.MachinesAvailability.RefreshInterval))
+	logs.Info(fmt.Sprintf("MachinesAvailability.Concurrency: %d", cfg.MachinesAvailability.Concurrency))
+	logs.Info(fmt.Sprintf("MachinesAvailability.Providers: %v", cfg.MachinesAvailability.Providers))
+	logs.Info(fmt.Sprintf("AdminAuth.Enabled: %t", cfg.AdminAuth.Enabled))
+	logs.Info(fmt.Sprintf("AdminAuth.IssuerURL: %s", cfg.AdminAuth.IssuerURL))
+
 	r, _ := cfg.GardenerSubscriptionResource()
 	logs.Info(fmt.Sprintf("Gardener resource used for subscriptions: %s", r.String()))
 }
 
+func newMachinesAvailabilityCollector(cfg *Config, providerSpec *configuration.ProviderSpec, rulesService *rules.RulesService,
+	gardenerClient *gardener.Client, zonesClientFactory hyperscalers.ZonesClientFactory, logs *slog.Logger) (*machinesavailability.Collector, error) {
+	var subscriptions machinesavailability.SubscriptionSecretProvider
+	if r, _ := cfg.GardenerSubscriptionResource(); r == gardener.SecretBindingResource {
+		subscriptions = machinesavailability.NewSecretBindingSubscriptions(rulesService, gardenerClient, logs)
+	} else {
+		subscriptions = machinesavailability.NewCredentialsBindingSubscriptions(rulesService, gardenerClient, logs)
+	}
+	return machinesavailability.NewCollector(cfg.MachinesAvailability, providerSpec, subscriptions, zonesClientFactory, logs)
+}
+
 func createAPI(router *httputil.Router, schemaService *broker.SchemaService, servicesConfig broker.ServicesConfig, cfg *Config, db storage.BrokerStorage,
-	provisionQueue, deprovisionQueue, updateQueue *process.Queue, logger lager.Logger, logs *slog.Logger, kcBuilder kubeconfig.KcBuilder, clientProvider K8sClientProvider,
+	provisionQueue, deprovisionQueue, updateQueue, bindingQueue *process.Queue, logger lager.Logger, logs *slog.Logger, kcBuilder kubeconfig.KcBuilder, clientProvider K8sClientProvider,
 	kubeconfigProvider KubeconfigProvider, kcpK8sClient client.Client, publisher event.Publisher, oidcDefaultValues pkg.OIDCConfigDTO,
 	providerSpec *configuration.ProviderSpec, configProvider kebConfig.Provider, planSpec *configuration.PlanSpecifications, rulesService *rules.RulesService,
-	gardenerClient *gardener.Client, awsClientFactory aws.ClientFactory) {
+	gardenerClient *gardener.Client, zonesClientFactory hyperscalers.ZonesClientFactory, machinesAvailability *machinesavailability.Collector, adminAuth *auth.Authorizer) {
 
 	if cfg.MachinesAvailabilityEndpoint {
-		if r, _ := cfg.GardenerSubscriptionResource(); r == gardener.SecretBindingResource {
-			machinesAvailability := machinesavailability.NewHandler(providerSpec, rulesService, gardenerClient, awsClientFactory, logs)
-			machinesAvailability.AttachRoutes(router)
-		} else {
-			machinesAvailability := machinesavailability.NewHandlerCB(providerSpec, rulesService, gardenerClient, awsClientFactory, logs)
-			machinesAvailability.AttachRoutes(router)
-		}
+		machinesavailability.NewHandler(machinesAvailability, logs).AttachRoutes(router)
 	}
 
 	regions, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	fatalOnError(err, logs)
 	logs.Info(fmt.Sprintf("Platform region mapping for trial: %v", regions))
 	valuesProvider := provider.NewPlanSpecificValuesProvider(cfg.InfrastructureManager, regions, schemaService, planSpec)
+	valuesProvider.UseRegionPolicies(schemaService.RegionPolicies())
 
 	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, logs)
 
@@ -514,6 +631,12 @@ func createAPI(router *httputil.Router, schemaService *broker.SchemaService, ser
 	fatalOnError(err, logs)
 	logs.Info(fmt.Sprintf("Number of deprecated BTP regions for SAP Converged Cloud: %d", len(btpRegionsMigrationSapConvergedCloud)))
 
+	bindingPolicies, err := broker.ReadBindingPoliciesFromFile(cfg.BindingPoliciesFilePath)
+	fatalOnError(err, logs)
+	logs.Info(fmt.Sprintf("Number of binding policies: %d plan, %d global account, %d subaccount", len(bindingPolicies.Plans), len(bindingPolicies.GlobalAccounts), len(bindingPolicies.Subaccounts)))
+
+	bindingsManagers := brokerBindings.NewBindingsManagers(clientProvider, kubeconfigProvider, oidcDefaultValues)
+
 	// create KymaEnvironmentBroker endpoints
 	kymaEnvBroker := &broker.KymaEnvironmentBroker{
 		ServicesEndpoint: broker.NewServices(cfg.Broker, schemaService, servicesConfig, logs, oidcDefaultValues, cfg.InfrastructureManager),
@@ -521,18 +644,23 @@ func createAPI(router *httputil.Router, schemaService *broker.SchemaService, ser
 			provisionQueue, defaultPlansConfig, logs, cfg.KymaDashboardConfig, kcBuilder, freemiumGlobalAccountIds,
 			schemaService, providerSpec, valuesProvider, cfg.InfrastructureManager.UseSmallerMachineTypes,
 			kebConfig.NewConfigMapConfigProvider(configProvider, cfg.Broker.GardenerSeedsCacheConfigMapName, kebConfig.ProviderConfigurationRequiredFields), quotaClient, quotaWhitelistedSubaccountIds,
-			rulesService, gardenerClient, awsClientFactory, btpRegionsMigrationSapConvergedCloud),
+			rulesService, gardenerClient, zonesClientFactory, btpRegionsMigrationSapConvergedCloud),
 		DeprovisionEndpoint: broker.NewDeprovision(db.Instances(), db.Operations(), deprovisionQueue, logs),
 		UpdateEndpoint: broker.NewUpdate(cfg.Broker, db,
 			suspensionCtxHandler, cfg.UpdateProcessingEnabled, cfg.Broker.SubaccountMovementEnabled, cfg.Broker.UpdateCustomResourcesLabelsOnAccountMove, updateQueue, defaultPlansConfig,
 			valuesProvider, logs, cfg.KymaDashboardConfig, kcBuilder, kcpK8sClient, providerSpec, planSpec, cfg.InfrastructureManager, schemaService, quotaClient, quotaWhitelistedSubaccountIds,
-			rulesService, gardenerClient, awsClientFactory),
+			rulesService, gardenerClient, zonesClientFactory),
 		GetInstanceEndpoint:          broker.NewGetInstance(cfg.Broker, db.Instances(), db.Operations(), kcBuilder, logs),
 		LastOperationEndpoint:        broker.NewLastOperation(db.Operations(), db.InstancesArchived(), logs),
-		BindEndpoint:                 broker.NewBind(cfg.Broker.Binding, db, logs, clientProvider, kubeconfigProvider, publisher),
-		UnbindEndpoint:               broker.NewUnbind(logs, db, brokerBindings.NewServiceAccountBindingsManager(clientProvider, kubeconfigProvider), publisher),
+		BindEndpoint:                 broker.NewBind(cfg.Broker.Binding, db, logs, bindingsManagers, publisher),
+		UnbindEndpoint:               broker.NewUnbind(logs, db, bindingsManagers, publisher),
 		GetBindingEndpoint:           broker.NewGetBinding(logs, db),
-		LastBindingOperationEndpoint: broker.NewLastBindingOperation(logs),
+		LastBindingOperationEndpoint: broker.NewLastBindingOperation(db, logs),
+	}
+
+	kymaEnvBroker.BindEndpoint.UseBindingPolicies(bindingPolicies)
+	if bindingQueue != nil {
+		kymaEnvBroker.BindEndpoint.UseAsyncQueue(bindingQueue)
 	}
 
 	if r, _ := cfg.GardenerSubscriptionResource(); r == gardener.CredentialsBindingResource {
@@ -540,21 +668,44 @@ func createAPI(router *httputil.Router, schemaService *broker.SchemaService, ser
 		kymaEnvBroker.UpdateEndpoint.UseCredentialsBindings()
 	}
 
+	// renders resources for dry run requests and instance resources endpoint with the same steps which create or update them
+	kymaTemplateStep := steps.NewInitKymaTemplate(db.Operations(), kebConfig.NewConfigMapConfigProvider(configProvider, cfg.RuntimeConfigurationConfigMapName, kebConfig.RuntimeConfigurationRequiredFields))
+	applyKymaStep := provisioning.NewApplyKymaStep(db.Operations(), kcpK8sClient)
+	operationPreviewer := provisioning.NewOperationPreviewer(
+		kymaTemplateStep,
+		applyKymaStep,
+		provisioning.NewCreateRuntimeResourceStep(db, kcpK8sClient, cfg.InfrastructureManager, oidcDefaultValues, workers.NewProvider(cfg.InfrastructureManager, providerSpec), providerSpec),
+	)
+	kymaEnvBroker.ProvisionEndpoint.UseOperationPreviewer(operationPreviewer)
+	kymaEnvBroker.UpdateEndpoint.UseOperationPreviewer(update.NewOperationPreviewer(
+		kymaTemplateStep,
+		applyKymaStep,
+		update.NewUpdateRuntimeStep(db, kcpK8sClient, cfg.UpdateRuntimeResourceDelay, cfg.InfrastructureManager, regions, workers.NewProvider(cfg.InfrastructureManager, providerSpec), valuesProvider),
+	))
+
+	// create instance resources and reconciliation endpoints
+	instanceResourcesHandler := drift.NewHandler(db.Instances(), db.Operations(), updateQueue, drift.NewDetector(db.Operations(), valuesProvider, operationPreviewer, kcpK8sClient), logs)
+	instanceResourcesHandler.AttachRoutes(router.With(adminAuth.RequireByMethod(auth.PermissionReadRuntimes, auth.PermissionManageOperations)))
+
 	// Wrap broker with panic recovery for all OSB endpoints
 	brokerWithPanicRecovery := broker.NewWithPanicRecovery(kymaEnvBroker, logs)
 
 	prefixes := []string{"/{region}", ""}
 	subRouter, err := router.NewSubRouter(brokerAPISubrouterName)
 	fatalOnError(err, logs)
-	broker.AttachRoutes(subRouter, brokerWithPanicRecovery, logs, cfg.Broker.Binding.CreateBindingTimeout, cfg.Broker.DefaultRequestRegion, prefixes)
+	broker.AttachRoutes(subRouter, brokerWithPanicRecovery, logs, cfg.Broker.Binding.CreateBindingTimeout, cfg.Broker.DefaultRequestRegion, prefixes, schemaService.RegionPolicies())
+	broker.AttachDryRunRoutes(subRouter, kymaEnvBroker.ProvisionEndpoint, kymaEnvBroker.UpdateEndpoint, logs, prefixes)
+	if cfg.Broker.Binding.Enabled {
+		rotateBindingEndpoint := broker.NewRotateBinding(logs, db, bindingsManagers, publisher)
+		broker.AttachBindingRotationRoutes(subRouter, rotateBindingEndpoint, cfg.Broker.Binding.CreateBindingTimeout, prefixes)
+	}
 	router.Handle("/oauth/", http.StripPrefix("/oauth", subRouter))
 
-	// create events endpoint
-	eventsHandler := eventshandler.NewHandler(db.Events(), db.Instances())
-	router.Handle("/events", eventsHandler)
-
 	versionHandler := version.NewHandler(Version)
 	versionHandler.AttachRoutes(router)
+
+	// create networking ranges suggestion endpoint used by the schema UI
+	networking.NewHandler(logs).AttachRoutes(router)
 }
 
 // queues all in progress operations by type
@@ -564,12 +715,38 @@ func processOperationsInProgressByType(opType internal.OperationType, op storage
 		return fmt.Errorf("while getting in progress operations from storage: %w", err)
 	}
 	for _, operation := range operations {
-		queue.Add(operation.ID)
+		queue.Resume(operation.ID)
 		log.Info(fmt.Sprintf("Resuming the processing of %s operation ID: %s", opType, operation.ID))
 	}
 	return nil
 }
 
+// creates a processing queue keeping scheduled operations in memory or, if enabled, in the database
+func newProcessingQueue(executor process.Executor, db storage.BrokerStorage, cfg *Config, log *slog.Logger, name string) *process.Queue {
+	if !cfg.Queue.Persistent {
+		return process.NewQueue(executor, log, name)
+	}
+	return process.NewPersistentQueue(executor, db.QueueItems(), cfg.Queue, replicaName, log, name)
+}
+
+// identifies the broker replica holding queue items and operation leases
+func newReplicaName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return hostname
+}
+
+func enableOperationLease(cfg *Config, managers ...*process.StagedManager) {
+	if !cfg.OperationLease.Enabled {
+		return
+	}
+	for _, manager := range managers {
+		manager.EnableOperationLease(replicaName, cfg.OperationLease)
+	}
+}
+
 func initClient(cfg *rest.Config) (client.Client, error) {
 	httpClient, err := rest.HTTPClientFor(cfg)
 	if err != nil {
@@ -11,8 +11,9 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/config"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/deprovisioning"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/provisioning"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
@@ -29,10 +30,49 @@ const (
 	resolveSubscriptionSecretTimeout = 1 * time.Minute
 )
 
+// resourceStateRetry returns the retry of steps waiting for the Runtime resource, which are woken up by resource changes
+// and poll less often when the resource watch is enabled
+func resourceStateRetry(cfg *Config, timeout time.Duration) internal.RetryTuple {
+	if cfg.ResourceWatch.Enabled {
+		return internal.RetryTuple{Timeout: timeout, Interval: cfg.ResourceWatch.PollInterval}
+	}
+	return internal.RetryTuple{Timeout: timeout, Interval: resourceStateRetryInterval}
+}
+
+// checkResourceStepPolicy spreads checks of resources reconciled by other components, so operations started together do not poll them at once
+var checkResourceStepPolicy = process.StepPolicy{Backoff: process.BackoffConstant, Jitter: 0.1}
+
+// policies of steps retrying calls to the database, the Kubernetes API and hyperscaler APIs, the operation fails when
+// the calls keep failing longer than the timeout
+var (
+	kymaTemplateStepPolicy          = process.StepPolicy{Interval: 10 * time.Second, Timeout: 30 * time.Second}
+	discoverZonesStepPolicy         = process.StepPolicy{Interval: 10 * time.Second, Timeout: time.Minute}
+	createRuntimeResourceStepPolicy = process.StepPolicy{Interval: 5 * time.Second, Timeout: time.Minute}
+	applyKymaStepPolicy             = process.StepPolicy{Interval: time.Second, Timeout: 10 * time.Second}
+)
+
+// resourceStatePolicy returns the policy of steps waiting for the Runtime resource, the operation fails when the resource
+// does not reach the expected state within the timeout
+func resourceStatePolicy(cfg *Config, timeout time.Duration) process.StepPolicy {
+	policy := checkResourceStepPolicy
+	policy.Interval = resourceStateRetry(cfg, timeout).Interval
+	policy.Timeout = timeout
+	return policy
+}
+
+// hapStepPolicy returns the policy of steps resolving and freeing subscriptions, steps held by the HAP hold mode
+// keep retrying until the mode is disabled
+func hapStepPolicy(cfg *Config) process.StepPolicy {
+	if cfg.HoldHapSteps {
+		return process.StepPolicy{}
+	}
+	return process.StepPolicy{Interval: resolveSubscriptionSecretRetryInterval, Timeout: resolveSubscriptionSecretTimeout}
+}
+
 func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *process.StagedManager, workersAmount int, cfg *Config,
 	db storage.BrokerStorage, configProvider config.Provider,
 	k8sClientProvider provisioning.K8sClientProvider, k8sClient client.Client, gardenerClient *gardener.Client, defaultOIDC pkg.OIDCConfigDTO, logs *slog.Logger, rulesService *rules.RulesService,
-	workersProvider *workers.Provider, providerSpec *configuration.ProviderSpec, awsClientFactory aws.ClientFactory) *process.Queue {
+	workersProvider *workers.Provider, providerSpec *configuration.ProviderSpec, zonesClientFactory hyperscalers.ZonesClientFactory) *process.Queue {
 
 	useCredentialsBinding := strings.ToLower(cfg.SubscriptionGardenerResource) == credentialsBinding
 
@@ -40,33 +80,41 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 		disabled  bool
 		step      process.Step
 		condition process.StepCondition
+		policy    process.StepPolicy
+		// compensation reverts the step when the operation is canceled
+		compensation process.Step
 	}{
 		{
 			step: provisioning.NewStartStep(db.Operations(), db.Instances()),
 		},
 		{
-			step: steps.NewInitKymaTemplate(db.Operations(), config.NewConfigMapConfigProvider(configProvider, cfg.RuntimeConfigurationConfigMapName, config.RuntimeConfigurationRequiredFields)),
+			step:   steps.NewInitKymaTemplate(db.Operations(), config.NewConfigMapConfigProvider(configProvider, cfg.RuntimeConfigurationConfigMapName, config.RuntimeConfigurationRequiredFields)),
+			policy: kymaTemplateStepPolicy,
 		},
 		{
 			step: provisioning.NewOverrideKymaModules(db.Operations()),
 		},
 		{
 			step: steps.NewHolderStep(cfg.HoldHapSteps,
-				provisioning.NewResolveSubscriptionSecretStep(db, gardenerClient, rulesService, internal.RetryTuple{Timeout: resolveSubscriptionSecretTimeout, Interval: resolveSubscriptionSecretRetryInterval})),
+				provisioning.NewResolveSubscriptionSecretStep(db, gardenerClient, rulesService)),
 			disabled: useCredentialsBinding,
+			policy:   hapStepPolicy(cfg),
 		},
 		{
 			step: steps.NewHolderStep(cfg.HoldHapSteps,
-				provisioning.NewResolveCredentialsBindingStep(db, gardenerClient, rulesService, internal.RetryTuple{Timeout: resolveSubscriptionSecretTimeout, Interval: resolveSubscriptionSecretRetryInterval})),
+				provisioning.NewResolveCredentialsBindingStep(db, gardenerClient, rulesService)),
 			disabled: !useCredentialsBinding,
+			policy:   hapStepPolicy(cfg),
 		},
 		{
-			step:     steps.NewDiscoverAvailableZonesStep(db, providerSpec, gardenerClient, awsClientFactory),
+			step:     steps.NewDiscoverAvailableZonesStep(db, providerSpec, gardenerClient, zonesClientFactory),
 			disabled: useCredentialsBinding,
+			policy:   discoverZonesStepPolicy,
 		},
 		{
-			step:     steps.NewDiscoverAvailableZonesCBStep(db, providerSpec, gardenerClient, awsClientFactory),
+			step:     steps.NewDiscoverAvailableZonesCBStep(db, providerSpec, gardenerClient, zonesClientFactory),
 			disabled: !useCredentialsBinding,
+			policy:   discoverZonesStepPolicy,
 		},
 		{
 			step: provisioning.NewGenerateRuntimeIDStep(db.Operations(), db.Instances()),
@@ -75,17 +123,22 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 			step: provisioning.NewCreateResourceNamesStep(db.Operations()),
 		},
 		{
-			step: provisioning.NewCreateRuntimeResourceStep(db, k8sClient, cfg.InfrastructureManager, defaultOIDC, workersProvider, providerSpec),
+			step:         provisioning.NewCreateRuntimeResourceStep(db, k8sClient, cfg.InfrastructureManager, defaultOIDC, workersProvider, providerSpec),
+			policy:       createRuntimeResourceStepPolicy,
+			compensation: deprovisioning.NewDeleteRuntimeResourceStep(db, k8sClient),
 		},
 		{
-			step: steps.NewCheckRuntimeResourceProvisioningStep(db.Operations(), k8sClient, internal.RetryTuple{Timeout: cfg.StepTimeouts.CheckRuntimeResourceCreate, Interval: resourceStateRetryInterval}, provisioningTakesLongThreshold),
+			step:   steps.NewCheckRuntimeResourceProvisioningStep(db.Operations(), k8sClient, resourceStateRetry(cfg, cfg.StepTimeouts.CheckRuntimeResourceCreate), provisioningTakesLongThreshold),
+			policy: checkResourceStepPolicy,
 		},
 		{
 			condition: provisioning.WhenBTPOperatorCredentialsProvided,
 			step:      provisioning.NewInjectBTPOperatorCredentialsStep(db.Operations(), k8sClientProvider),
 		},
 		{
-			step: provisioning.NewApplyKymaStep(db.Operations(), k8sClient),
+			step:         provisioning.NewApplyKymaStep(db.Operations(), k8sClient),
+			policy:       applyKymaStepPolicy,
+			compensation: deprovisioning.NewDeleteKymaResourceStep(db, k8sClient, config.NewConfigMapConfigProvider(configProvider, cfg.RuntimeConfigurationConfigMapName, config.RuntimeConfigurationRequiredFields)),
 		},
 	}
 	var stages []string
@@ -97,14 +150,17 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 	provisionManager.DefineStages(stages)
 	for _, step := range provisioningSteps {
 		if !step.disabled {
-			err := provisionManager.AddStep(step.step.Name(), step.step, step.condition)
+			err := provisionManager.AddStep(step.step.Name(), step.step, step.condition, step.policy)
 			if err != nil {
 				fatalOnError(err, logs)
 			}
+			if step.compensation != nil {
+				fatalOnError(provisionManager.AddCompensationStep(step.step.Name(), step.compensation), logs)
+			}
 		}
 	}
 
-	queue := process.NewQueue(provisionManager, logs, "provisioning")
+	queue := newProcessingQueue(provisionManager, db, cfg, logs, "provisioning")
 	queue.Run(ctx.Done(), workersAmount)
 
 	return queue
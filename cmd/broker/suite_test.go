@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/machinesavailability"
 	"github.com/kyma-project/kyma-environment-broker/internal/metrics"
 
 	"github.com/google/uuid"
@@ -283,8 +284,14 @@ func fixConfig() *Config {
 		PlansConfigurationFilePath:                   "testdata/plans.yaml",
 		RuntimeConfigurationConfigMapName:            "keb-runtime-config",
 		QuotaWhitelistedSubaccountsFilePath:          "testdata/quota_whitelist.yaml",
+		BindingPoliciesFilePath:                      "testdata/binding_policies.yaml",
 		SubscriptionGardenerResource:                 "secretbinding",
 		MachinesAvailabilityEndpoint:                 true,
 		BtpRegionsMigrationSapConvergedCloudFilePath: "testdata/btp-regions-migration-sap-converged-cloud.yaml",
+		MachinesAvailability: machinesavailability.Config{
+			RefreshInterval: time.Hour,
+			Concurrency:     2,
+			Providers:       []string{"aws"},
+		},
 	}
 }
@@ -4,12 +4,12 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/common/gardener"
-	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/kyma-environment-broker/internal/config"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
 	"github.com/kyma-project/kyma-environment-broker/internal/process/update"
@@ -21,9 +21,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// updateRuntimeStepPolicy fails the operation when the Runtime resource cannot be read or updated within the timeout
+var updateRuntimeStepPolicy = process.StepPolicy{Interval: 10 * time.Second, Timeout: time.Minute}
+
 func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManager, workersAmount int, db storage.BrokerStorage,
 	cfg Config, kcpClient client.Client, logs *slog.Logger, workersProvider *workers.Provider, schemaService *broker.SchemaService, planSpec *configuration.PlanSpecifications, configProvider config.Provider,
-	providerSpec *configuration.ProviderSpec, gardenerClient *gardener.Client, awsClientFactory aws.ClientFactory) *process.Queue {
+	providerSpec *configuration.ProviderSpec, gardenerClient *gardener.Client, zonesClientFactory hyperscalers.ZonesClientFactory) *process.Queue {
 
 	trialRegionsMapping, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	if err != nil {
@@ -32,6 +35,7 @@ func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManage
 
 	regions, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	valuesProvider := provider.NewPlanSpecificValuesProvider(cfg.InfrastructureManager, regions, schemaService, planSpec)
+	valuesProvider.UseRegionPolicies(schemaService.RegionPolicies())
 
 	useCredentialsBinding := strings.ToLower(cfg.SubscriptionGardenerResource) == credentialsBinding
 
@@ -41,6 +45,7 @@ func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManage
 		stage     string
 		step      process.Step
 		condition process.StepCondition
+		policy    process.StepPolicy
 	}{
 		{
 			stage: "cluster",
@@ -48,21 +53,25 @@ func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManage
 		},
 		{
 			stage:    "runtime_resource",
-			step:     steps.NewDiscoverAvailableZonesStep(db, providerSpec, gardenerClient, awsClientFactory),
+			step:     steps.NewDiscoverAvailableZonesStep(db, providerSpec, gardenerClient, zonesClientFactory),
 			disabled: useCredentialsBinding,
+			policy:   discoverZonesStepPolicy,
 		},
 		{
 			stage:    "runtime_resource",
-			step:     steps.NewDiscoverAvailableZonesCBStep(db, providerSpec, gardenerClient, awsClientFactory),
+			step:     steps.NewDiscoverAvailableZonesCBStep(db, providerSpec, gardenerClient, zonesClientFactory),
 			disabled: !useCredentialsBinding,
+			policy:   discoverZonesStepPolicy,
 		},
 		{
-			stage: "runtime_resource",
-			step:  update.NewUpdateRuntimeStep(db, kcpClient, cfg.UpdateRuntimeResourceDelay, cfg.InfrastructureManager, trialRegionsMapping, workersProvider, valuesProvider),
+			stage:  "runtime_resource",
+			step:   update.NewUpdateRuntimeStep(db, kcpClient, cfg.UpdateRuntimeResourceDelay, cfg.InfrastructureManager, trialRegionsMapping, workersProvider, valuesProvider),
+			policy: updateRuntimeStepPolicy,
 		},
 		{
-			stage: "check_runtime_resource",
-			step:  steps.NewCheckRuntimeResourceStep(db.Operations(), kcpClient, internal.RetryTuple{Timeout: cfg.StepTimeouts.CheckRuntimeResourceUpdate, Interval: resourceStateRetryInterval}),
+			stage:  "check_runtime_resource",
+			step:   steps.NewCheckRuntimeResourceStep(db.Operations(), kcpClient),
+			policy: resourceStatePolicy(&cfg, cfg.StepTimeouts.CheckRuntimeResourceUpdate),
 		},
 		{
 			stage: "kyma_resource",
@@ -72,13 +81,13 @@ func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManage
 
 	for _, step := range updateSteps {
 		if !step.disabled {
-			err = manager.AddStep(step.stage, step.step, step.condition)
+			err = manager.AddStep(step.stage, step.step, step.condition, step.policy)
 			if err != nil {
 				fatalOnError(err, logs)
 			}
 		}
 	}
-	queue := process.NewQueue(manager, logs, "update-processing")
+	queue := newProcessingQueue(manager, db, &cfg, logs, "update-processing")
 	queue.Run(ctx.Done(), workersAmount)
 
 	return queue
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/keyrotation"
+	"github.com/kyma-project/kyma-environment-broker/internal/schemamigrator/cleaner"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vrischmann/envconfig"
+)
+
+const (
+	AppPrefix        = "APP"
+	metricsNamespace = "kcp_keb"
+)
+
+type Config struct {
+	Database storage.Config
+	Job      JobConfig
+}
+
+type JobConfig struct {
+	DryRun      bool   `envconfig:"default=true"`
+	BatchSize   int    `envconfig:"default=100"`
+	MetricsPort string `envconfig:"default=8081"`
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	slog.Info("Starting encryption key rotation job")
+
+	var cfg Config
+	fatalOnError(envconfig.InitWithPrefix(&cfg, AppPrefix))
+
+	if cfg.Job.DryRun {
+		slog.Info("Dry run only - no changes")
+	}
+
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
+	slog.Info(fmt.Sprintf("Rotating stored data to the encryption key with ID %q", cipher.KeyID()))
+	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher)
+	fatalOnError(err)
+
+	metricsRegistry := prometheus.NewRegistry()
+	metricsRegistry.MustRegister(collectors.NewGoCollector())
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Job.MetricsPort),
+		Handler: promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{Registry: metricsRegistry}),
+	}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error(fmt.Sprintf("while serving metrics: %s", err))
+		}
+	}()
+
+	svc := keyrotation.NewService(cfg.Job.DryRun, cfg.Job.BatchSize, db.KeyRotation(), keyrotation.NewMetrics(metricsRegistry, metricsNamespace), slog.Default())
+	fatalOnError(svc.PerformRotation())
+
+	slog.Info("Encryption key rotation job finished successfully!")
+
+	logOnError(metricsServer.Close())
+	fatalOnError(conn.Close())
+	logOnError(cleaner.HaltIstioSidecar())
+	fatalOnError(cleaner.Halt())
+}
+
+func fatalOnError(err error) {
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(0)
+	}
+}
+
+func logOnError(err error) {
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/operations"
+	"github.com/spf13/cobra"
+)
+
+const accessTokenEnv = "KEB_ACCESS_TOKEN"
+
+type RetryCommand struct {
+	cobraCmd    *cobra.Command
+	url         string
+	accessToken string
+	step        string
+	httpClient  *http.Client
+}
+
+func NewRetryCmd() *cobra.Command {
+	cmd := RetryCommand{httpClient: &http.Client{Timeout: 30 * time.Second}}
+	cobraCmd := &cobra.Command{
+		Use:   "retry OPERATION_ID",
+		Short: "Resumes a failed provisioning or update operation.",
+		Long:  "Resumes a failed provisioning or update operation from the first unfinished stage or from the given step. The last error of the operation is reset.",
+		Example: `
+	# Resume the failed operation from the first unfinished stage
+	operations retry 8a7bfd9b-f2f5-43d1-bb67-177d2434053c -u https://kyma-env-broker.example.com
+
+	# Resume the failed operation from the given step
+	operations retry 8a7bfd9b-f2f5-43d1-bb67-177d2434053c -u https://kyma-env-broker.example.com -s Create_Runtime_Resource
+		`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return cmd.Run(args[0])
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.url, "url", "u", "", "The URL of Kyma Environment Broker.")
+	cobraCmd.Flags().StringVarP(&cmd.accessToken, "token", "t", os.Getenv(accessTokenEnv), fmt.Sprintf("The access token used in the Authorization header. Defaults to the %s environment variable.", accessTokenEnv))
+	cobraCmd.Flags().StringVarP(&cmd.step, "step", "s", "", "The name of the step the operation is resumed from.")
+	_ = cobraCmd.MarkFlagRequired("url")
+
+	return cobraCmd
+}
+
+func (cmd *RetryCommand) Run(operationID string) error {
+	body, err := json.Marshal(operations.RetryRequest{Step: cmd.step})
+	if err != nil {
+		return fmt.Errorf("while encoding request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/operations/%s/retry", strings.TrimSuffix(cmd.url, "/"), operationID)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if cmd.accessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+cmd.accessToken)
+	}
+
+	response, err := cmd.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("while executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+	if response.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	cmd.cobraCmd.Printf("Operation %s resumed\n", operationID)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryCommand(t *testing.T) {
+	t.Run("should send retry request with the step and the token", func(t *testing.T) {
+		// given
+		var received operations.RetryRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/operations/op-id/retry", r.URL.Path)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		cmd := NewRetryCmd()
+		output := &bytes.Buffer{}
+		cmd.SetOut(output)
+		cmd.SetArgs([]string{"op-id", "--url", server.URL + "/", "--token", "token", "--step", "Create_Runtime_Resource"})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Create_Runtime_Resource", received.Step)
+		assert.Equal(t, "Operation op-id resumed\n", output.String())
+	})
+
+	t.Run("should return error when the operation cannot be retried", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"operation in state \"in progress\" cannot be retried"}`))
+		}))
+		defer server.Close()
+
+		cmd := NewRetryCmd()
+		cmd.SetArgs([]string{"op-id", "--url", server.URL})
+
+		// when
+		err := cmd.Execute()
+
+		// then
+		assert.EqualError(t, err, `unexpected status code 409: {"error":"operation in state \"in progress\" cannot be retried"}`)
+	})
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/orphanedbindingcleanup"
+	"github.com/kyma-project/kyma-environment-broker/internal/schemamigrator/cleaner"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vrischmann/envconfig"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	AppPrefix        = "APP"
+	metricsNamespace = "kcp_keb"
+)
+
+type Config struct {
+	Database storage.Config
+	Job      JobConfig
+}
+
+type JobConfig struct {
+	DryRun        bool          `envconfig:"default=true"`
+	BindablePlans []string      `envconfig:"default=aws"`
+	MinAge        time.Duration `envconfig:"default=1h"`
+	MetricsPort   string        `envconfig:"default=8081"`
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	slog.Info("Starting orphaned binding cleanup job")
+
+	var cfg Config
+	fatalOnError(envconfig.InitWithPrefix(&cfg, AppPrefix))
+
+	if cfg.Job.DryRun {
+		slog.Info("Dry run only - no changes")
+	}
+
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err)
+	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher)
+	fatalOnError(err)
+
+	kcpK8sConfig, err := config.GetConfig()
+	fatalOnError(err)
+	kcpK8sClient, err := client.New(kcpK8sConfig, client.Options{})
+	fatalOnError(err)
+
+	metricsRegistry := prometheus.NewRegistry()
+	metricsRegistry.MustRegister(collectors.NewGoCollector())
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Job.MetricsPort),
+		Handler: promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{Registry: metricsRegistry}),
+	}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error(fmt.Sprintf("while serving metrics: %s", err))
+		}
+	}()
+
+	svc := orphanedbindingcleanup.NewService(cfg.Job.DryRun, cfg.Job.MinAge, cfg.Job.BindablePlans, db,
+		kubeconfig.NewK8sClientFromSecretProvider(kcpK8sClient), orphanedbindingcleanup.NewMetrics(metricsRegistry, metricsNamespace), slog.Default())
+	fatalOnError(svc.PerformCleanup(context.Background()))
+
+	slog.Info("Orphaned binding cleanup job finished successfully!")
+
+	logOnError(metricsServer.Close())
+	fatalOnError(conn.Close())
+	logOnError(cleaner.HaltIstioSidecar())
+	fatalOnError(cleaner.Halt())
+}
+
+func fatalOnError(err error) {
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(0)
+	}
+}
+
+func logOnError(err error) {
+	if err != nil {
+		slog.Error(err.Error())
+	}
+}
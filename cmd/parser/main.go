@@ -25,6 +25,8 @@ func main() {
 	}
 
 	rootCmd.AddCommand(NewParseCmd())
+	rootCmd.AddCommand(NewSimulateCmd())
+	rootCmd.AddCommand(NewRegionsCmd())
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -49,6 +49,9 @@ func NewParseCmd() *cobra.Command {
 
 	# Check which rule will be matched and triggered against the provided provisioning data
 	hap parse  -f ./correct-rules.yaml -m '{"plan": "aws", "platformRegion": "cf-eu11", "hyperscalerRegion": "westeurope"}'
+
+	# Match also the optional global account, subaccount labels and machine type
+	hap parse  -f ./correct-rules.yaml -m '{"plan": "aws", "platformRegion": "cf-eu11", "hyperscalerRegion": "westeurope", "hyperscaler": "aws", "globalAccountID": "ga-1", "subaccountLabels": {"pool": "large"}, "machineType": "m6i.large"}'
 		`,
 		RunE: func(_ *cobra.Command, args []string) error {
 			return cmd.Run()
@@ -59,7 +62,7 @@ func NewParseCmd() *cobra.Command {
 	cmd.cobraCmd = cobraCmd
 
 	cobraCmd.Flags().StringVarP(&cmd.rule, "entry", "e", "", "A rule to validate where each rule entry is separated by comma.")
-	cobraCmd.Flags().StringVarP(&cmd.match, "match", "m", "", "Check what rule will be matched and triggered against the provided test data. Only valid entries are taking into account when matching. Data is passed in json format, example: '{\"plan\": \"aws\", \"platformRegion\": \"cf-eu11\"}'.")
+	cobraCmd.Flags().StringVarP(&cmd.match, "match", "m", "", "Check what rule will be matched and triggered against the provided test data. Only valid entries are taking into account when matching. Data is passed in json format, example: '{\"plan\": \"aws\", \"platformRegion\": \"cf-eu11\"}'. The globalAccountID, subaccountLabels and machineType fields are optional.")
 	cobraCmd.Flags().StringVarP(&cmd.ruleFilePath, "file", "f", "", "Read rules from a file pointed to by parameter value. The file must contain a valid yaml list, where each rule entry starts with '-' and is placed in its own line.")
 	cobraCmd.MarkFlagsOneRequired("entry", "file")
 
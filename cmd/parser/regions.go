@@ -0,0 +1,91 @@
+package main
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
+	"github.com/spf13/cobra"
+)
+
+type RegionsCommand struct {
+	cobraCmd              *cobra.Command
+	policiesFilePath      string
+	ruleFilePath          string
+	providersConfFilePath string
+}
+
+func NewRegionsCmd() *cobra.Command {
+	cmd := RegionsCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "regions",
+		Aliases: []string{"r"},
+		Short:   "Validates region policies.",
+		Long:    "Validates region policies and checks that HAP rules set the HAP flags required by the policies and that the allowed hyperscaler regions are configured for their providers.",
+		Example: `
+	# Validate region policies and check that HAP rules comply with them
+	hap regions -f regionPolicies.yaml -r rules.yaml
+
+	# Check also that the allowed hyperscaler regions are defined in the providers configuration
+	hap regions -f regionPolicies.yaml -r rules.yaml -p providersConfig.yaml
+		`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return cmd.Run()
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.policiesFilePath, "file", "f", "", "Read region policies from a file pointed to by parameter value.")
+	cobraCmd.Flags().StringVarP(&cmd.ruleFilePath, "rules", "r", "", "Read HAP rules from a file pointed to by parameter value.")
+	cobraCmd.Flags().StringVarP(&cmd.providersConfFilePath, "providers", "p", "", "Read the providers configuration from a file pointed to by parameter value. If set, the allowed hyperscaler regions are validated.")
+	_ = cobraCmd.MarkFlagRequired("file")
+	_ = cobraCmd.MarkFlagRequired("rules")
+
+	return cobraCmd
+}
+
+func (cmd *RegionsCommand) Run() error {
+	policies, err := regionpolicy.ReadPoliciesFromFile(cmd.policiesFilePath)
+	if err != nil {
+		cmd.cobraCmd.Printf("Error: %s\n", err)
+		return InvalidRuleError
+	}
+
+	allowedPlans := sets.New(broker.AvailablePlans.GetAllPlanNamesAsStrings()...)
+	rulesService, err := rules.NewRulesServiceFromFile(cmd.ruleFilePath, allowedPlans, sets.New[string]())
+	if err != nil {
+		cmd.cobraCmd.Printf("Error: %s\n", err)
+		return UsageError
+	}
+	if !rulesService.IsRulesetValid() {
+		cmd.cobraCmd.Printf("There are errors in your rule configuration in file: %s\n", cmd.ruleFilePath)
+		for _, ve := range rulesService.ValidationInfo.All() {
+			cmd.cobraCmd.Printf("%s\n", ve)
+		}
+		return InvalidRuleError
+	}
+
+	if err := policies.ValidateHAPRules(rulesService); err != nil {
+		cmd.cobraCmd.Printf("Error: %s\n", err)
+		return InvalidRuleError
+	}
+
+	if cmd.providersConfFilePath != "" {
+		providerSpec, err := configuration.NewProviderSpecFromFile(cmd.providersConfFilePath)
+		if err != nil {
+			cmd.cobraCmd.Printf("Error: %s\n", err)
+			return UsageError
+		}
+		if err := policies.ValidateHyperscalerRegions(providerSpec); err != nil {
+			cmd.cobraCmd.Printf("Error: %s\n", err)
+			return InvalidRuleError
+		}
+	}
+
+	cmd.cobraCmd.Printf("Region policies of %d platform regions are OK.\n", len(policies))
+	return nil
+}
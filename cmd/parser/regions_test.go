@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionsCommand(t *testing.T) {
+	policiesFile, err := rules.CreateTempFile(`
+cf-eu11:
+  provider: aws
+  dataResidency: euAccess
+  hyperscalerRegions:
+    aws: [eu-central-1]
+  requiredHAPFlags: [EU]
+`)
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(policiesFile) }()
+
+	t.Run("should accept rules which set the required flags", func(t *testing.T) {
+		// given
+		rulesFile, err := rules.CreateTempFile("rule:\n- aws\n- aws(PR=cf-eu11) -> EU\n")
+		require.NoError(t, err)
+		defer func() { _ = os.Remove(rulesFile) }()
+
+		cmd := NewRegionsCmd()
+		out := bytes.NewBufferString("")
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-f", policiesFile, "-r", rulesFile})
+
+		// when
+		err = cmd.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Region policies of 1 platform regions are OK.")
+	})
+
+	t.Run("should reject rules which do not set the required flags", func(t *testing.T) {
+		// given
+		rulesFile, err := rules.CreateTempFile("rule:\n- aws\n")
+		require.NoError(t, err)
+		defer func() { _ = os.Remove(rulesFile) }()
+
+		cmd := NewRegionsCmd()
+		out := bytes.NewBufferString("")
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-f", policiesFile, "-r", rulesFile})
+
+		// when
+		err = cmd.Execute()
+
+		// then
+		assert.ErrorIs(t, err, InvalidRuleError)
+		assert.Contains(t, out.String(), `platform region cf-eu11: HAP rule "aws" does not set the required EU flag`)
+	})
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/kyma-project/kyma-environment-broker/internal/subscriptions"
+	"github.com/spf13/cobra"
+)
+
+type SimulateCommand struct {
+	cobraCmd            *cobra.Command
+	ruleFilePath        string
+	currentRuleFilePath string
+	instancesFilePaths  []string
+	plansFilePath       string
+}
+
+func NewSimulateCmd() *cobra.Command {
+	cmd := SimulateCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "simulate",
+		Aliases: []string{"s"},
+		Short:   "Simulates HAP rules against existing instances.",
+		Long:    "Simulates HAP rules against existing instances, reporting how many instances each rule matches, instances whose label selector changes and instances which do not match any rule.",
+		Example: `
+	# Export instances using the /runtimes API, every page to a separate file
+	curl -s -H "Authorization: Bearer $TOKEN" "$KEB_URL/runtimes?page=1" > instances-1.json
+	curl -s -H "Authorization: Bearer $TOKEN" "$KEB_URL/runtimes?page=2" > instances-2.json
+
+	# Report how many instances each rule matches and instances which do not match any rule
+	hap simulate -f rules.yaml -i instances-1.json -i instances-2.json
+
+	# Report also instances whose label selector changes compared to the currently used rules
+	hap simulate -f new-rules.yaml -c rules.yaml -i instances.json
+
+	# Match instances created without the machine type with the default machine type of their plan
+	hap simulate -f rules.yaml -i instances.json -p plansConfig.yaml
+		`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return cmd.Run()
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.ruleFilePath, "file", "f", "", "Read rules to simulate from a file pointed to by parameter value.")
+	cobraCmd.Flags().StringVarP(&cmd.currentRuleFilePath, "current", "c", "", "Read currently used rules from a file pointed to by parameter value. If set, instances whose label selector changes are reported.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.instancesFilePaths, "instances", "i", nil, "Read instances from files pointed to by parameter value. Every file must contain a page of the /runtimes API response or a JSON list of runtimes. The flag can be repeated.")
+	cobraCmd.Flags().StringVarP(&cmd.plansFilePath, "plans", "p", "", "Read the plans configuration from a file pointed to by parameter value. If set, instances created without the machine type are matched with the default machine type of their plan.")
+	_ = cobraCmd.MarkFlagRequired("file")
+	_ = cobraCmd.MarkFlagRequired("instances")
+
+	return cobraCmd
+}
+
+// SelectorChange describes an instance which is matched by a rule with a different label selector
+type SelectorChange struct {
+	InstanceID      string
+	CurrentRule     string
+	CurrentSelector string
+	NewRule         string
+	NewSelector     string
+}
+
+type RuleCount struct {
+	Rule  string
+	Count int
+}
+
+type SimulationReport struct {
+	InstancesCount int
+	RuleCounts     []RuleCount
+	Changes        []SelectorChange
+	Unmatched      []pkg.RuntimeDTO
+}
+
+func (cmd *SimulateCommand) Run() error {
+	newRules, err := cmd.loadRules(cmd.ruleFilePath)
+	if err != nil {
+		return err
+	}
+	var currentRules *rules.RulesService
+	if cmd.currentRuleFilePath != "" {
+		currentRules, err = cmd.loadRules(cmd.currentRuleFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var plans *configuration.PlanSpecifications
+	if cmd.plansFilePath != "" {
+		plans, err = configuration.NewPlanSpecificationsFromFile(cmd.plansFilePath)
+		if err != nil {
+			cmd.cobraCmd.Printf("Error: unable to read the plans configuration: %s\n", err)
+			return UsageError
+		}
+	}
+
+	var runtimes []pkg.RuntimeDTO
+	for _, filePath := range cmd.instancesFilePaths {
+		loaded, err := loadRuntimes(filePath)
+		if err != nil {
+			cmd.cobraCmd.Printf("Error: %s\n", err)
+			return UsageError
+		}
+		runtimes = append(runtimes, loaded...)
+	}
+
+	report := simulate(newRules, currentRules, runtimes, plans)
+	cmd.print(report)
+
+	if len(report.Unmatched) > 0 {
+		return InvalidRuleError
+	}
+	return nil
+}
+
+func (cmd *SimulateCommand) loadRules(filePath string) (*rules.RulesService, error) {
+	allowedPlans := sets.New(broker.AvailablePlans.GetAllPlanNamesAsStrings()...)
+	rulesService, err := rules.NewRulesServiceFromFile(filePath, allowedPlans, sets.New[string]())
+	if err != nil {
+		cmd.cobraCmd.Printf("Error: %s\n", err)
+		return nil, UsageError
+	}
+	if !rulesService.IsRulesetValid() {
+		cmd.cobraCmd.Printf("There are errors in your rule configuration in file: %s\n", filePath)
+		for _, ve := range rulesService.ValidationInfo.All() {
+			cmd.cobraCmd.Printf("%s\n", ve)
+		}
+		return nil, InvalidRuleError
+	}
+	return rulesService, nil
+}
+
+func (cmd *SimulateCommand) print(report SimulationReport) {
+	cmd.cobraCmd.Printf("Simulated %d instances with rules from file: %s\n", report.InstancesCount, cmd.ruleFilePath)
+
+	cmd.cobraCmd.Printf("\nMatched instances per rule:\n")
+	for _, ruleCount := range report.RuleCounts {
+		cmd.cobraCmd.Printf("%6d  %s\n", ruleCount.Count, ruleCount.Rule)
+	}
+
+	if cmd.currentRuleFilePath != "" {
+		cmd.cobraCmd.Printf("\nInstances with changed label selector: %d\n", len(report.Changes))
+		for _, change := range report.Changes {
+			cmd.cobraCmd.Printf("%s\n  current: %s (rule %s)\n  new:     %s (rule %s)\n", change.InstanceID, change.CurrentSelector, change.CurrentRule, change.NewSelector, change.NewRule)
+		}
+	}
+
+	cmd.cobraCmd.Printf("\nInstances not matching any rule: %d\n", len(report.Unmatched))
+	for _, runtime := range report.Unmatched {
+		cmd.cobraCmd.Printf("%s (plan: %s, platformRegion: %s, hyperscalerRegion: %s)\n", runtime.InstanceID, runtime.ServicePlanName, runtime.SubAccountRegion, runtime.ProviderRegion)
+	}
+}
+
+func simulate(newRules, currentRules *rules.RulesService, runtimes []pkg.RuntimeDTO, plans *configuration.PlanSpecifications) SimulationReport {
+	report := SimulationReport{InstancesCount: len(runtimes)}
+	counts := make(map[int]int)
+
+	for _, runtime := range runtimes {
+		attributes := provisioningAttributesFromRuntime(runtime, plans)
+		newResult, found := newRules.MatchProvisioningAttributesWithValidRuleset(attributes)
+		if !found {
+			report.Unmatched = append(report.Unmatched, runtime)
+			continue
+		}
+		counts[newResult.RawData.RuleNo]++
+
+		if currentRules == nil {
+			continue
+		}
+		change := SelectorChange{
+			InstanceID:  runtime.InstanceID,
+			NewRule:     newResult.NumberedRule(),
+			NewSelector: labelSelector(newResult),
+			CurrentRule: "none",
+		}
+		if currentResult, found := currentRules.MatchProvisioningAttributesWithValidRuleset(attributes); found {
+			change.CurrentRule = currentResult.NumberedRule()
+			change.CurrentSelector = labelSelector(currentResult)
+		}
+		if change.CurrentSelector != change.NewSelector {
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	for _, rule := range newRules.ValidRules.Rules {
+		report.RuleCounts = append(report.RuleCounts, RuleCount{Rule: rule.NumberedRule(), Count: counts[rule.RawData.RuleNo]})
+	}
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		return report.Changes[i].InstanceID < report.Changes[j].InstanceID
+	})
+
+	return report
+}
+
+func labelSelector(result rules.Result) string {
+	return subscriptions.NewLabelSelectorFromRuleset(result).BuildAnySubscription()
+}
+
+// provisioningAttributesFromRuntime maps the runtime to attributes used during provisioning, the machine type defaults
+// to the default machine type of the plan only if the plans configuration is given
+func provisioningAttributesFromRuntime(runtime pkg.RuntimeDTO, plans *configuration.PlanSpecifications) *rules.ProvisioningAttributes {
+	parameters := internal.ProvisioningParameters{
+		PlanID:         runtime.ServicePlanID,
+		PlatformRegion: runtime.SubAccountRegion,
+		ErsContext: internal.ERSContext{
+			GlobalAccountID: runtime.GlobalAccountID,
+			Labels:          runtime.SubaccountLabels,
+		},
+		Parameters: runtime.Parameters,
+	}
+	values := internal.ProviderValues{
+		Region:       runtime.ProviderRegion,
+		ProviderType: providerType(runtime.Provider),
+	}
+	if plans != nil {
+		values.DefaultMachineType = plans.DefaultMachineType(runtime.ServicePlanName)
+	}
+	return broker.NewProvisioningAttributes(parameters, values)
+}
+
+func providerType(provider string) string {
+	switch pkg.CloudProviderFromString(provider) {
+	case pkg.AWS:
+		return "aws"
+	case pkg.Azure:
+		return "azure"
+	case pkg.GCP:
+		return "gcp"
+	case pkg.SapConvergedCloud:
+		return "openstack"
+	case pkg.Alicloud:
+		return "alicloud"
+	}
+	return provider
+}
+
+func loadRuntimes(filePath string) ([]pkg.RuntimeDTO, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instances file %s: %w", filePath, err)
+	}
+
+	page := pkg.RuntimesPage{}
+	if err := json.Unmarshal(content, &page); err == nil && page.Data != nil {
+		return page.Data, nil
+	}
+	var runtimes []pkg.RuntimeDTO
+	if err := json.Unmarshal(content, &runtimes); err != nil {
+		return nil, fmt.Errorf("instances file %s must contain the /runtimes API response or a list of runtimes: %w", filePath, err)
+	}
+	return runtimes, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/provider"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	// given
+	plans := sets.New("aws", "azure")
+	currentRules, err := rules.NewRulesServiceFromSlice([]string{"aws", "aws(PR=cf-eu11) -> EU", "azure"}, plans, plans)
+	require.NoError(t, err)
+	newRules, err := rules.NewRulesServiceFromSlice([]string{"aws", "aws(PR=cf-eu11) -> EU", "aws(GA=ga-shared) -> S, P=1"}, plans, sets.New("aws"))
+	require.NoError(t, err)
+
+	runtimes := []pkg.RuntimeDTO{
+		{InstanceID: "unchanged", ServicePlanID: broker.AWSPlanID, ServicePlanName: "aws", Provider: "AWS", SubAccountRegion: "cf-us10", ProviderRegion: "us-east-1", GlobalAccountID: "ga"},
+		{InstanceID: "eu", ServicePlanID: broker.AWSPlanID, ServicePlanName: "aws", Provider: "AWS", SubAccountRegion: "cf-eu11", ProviderRegion: "eu-central-1", GlobalAccountID: "ga"},
+		{InstanceID: "shared", ServicePlanID: broker.AWSPlanID, ServicePlanName: "aws", Provider: "AWS", SubAccountRegion: "cf-us10", ProviderRegion: "us-east-1", GlobalAccountID: "ga-shared"},
+		{InstanceID: "azure", ServicePlanID: broker.AzurePlanID, ServicePlanName: "azure", Provider: "Azure", SubAccountRegion: "cf-eu20", ProviderRegion: "westeurope", GlobalAccountID: "ga"},
+	}
+
+	// when
+	report := simulate(newRules, currentRules, runtimes, nil)
+
+	// then
+	assert.Equal(t, 4, report.InstancesCount)
+	assert.Equal(t, []RuleCount{
+		{Rule: "1: aws", Count: 1},
+		{Rule: "2: aws(PR=cf-eu11) -> EU", Count: 1},
+		{Rule: "3: aws(GA=ga-shared) -> S, P=1", Count: 1},
+	}, report.RuleCounts)
+	assert.Equal(t, []SelectorChange{
+		{
+			InstanceID:      "shared",
+			CurrentRule:     "1: aws",
+			CurrentSelector: "hyperscalerType=aws,!euAccess,shared!=true,!dirty",
+			NewRule:         "3: aws(GA=ga-shared) -> S, P=1",
+			NewSelector:     "hyperscalerType=aws,!euAccess,shared=true",
+		},
+	}, report.Changes)
+	require.Len(t, report.Unmatched, 1)
+	assert.Equal(t, "azure", report.Unmatched[0].InstanceID)
+}
+
+func TestSimulate_ProvisioningAttributes(t *testing.T) {
+	// given
+	plans := sets.New("aws")
+	newRules, err := rules.NewRulesServiceFromSlice([]string{"aws", "aws(MF=m6i) -> S", "aws(PR=cf-eu10, SL=team:kyma) -> EU"}, plans, plans)
+	require.NoError(t, err)
+	require.True(t, newRules.IsRulesetValid())
+	planSpec, err := provider.NewFakePlanSpecFromFile()
+	require.NoError(t, err)
+
+	runtimes := []pkg.RuntimeDTO{
+		{InstanceID: "default-machine", ServicePlanID: broker.AWSPlanID, ServicePlanName: "aws", Provider: "AWS", SubAccountRegion: "cf-us10", ProviderRegion: "us-east-1"},
+		{InstanceID: "labeled", ServicePlanID: broker.AWSPlanID, ServicePlanName: "aws", Provider: "AWS", SubAccountRegion: "cf-eu10", ProviderRegion: "eu-central-1",
+			Parameters: pkg.ProvisioningParametersDTO{MachineType: ptr.String("m5.large")}, SubaccountLabels: map[string]string{"team": "kyma"}},
+	}
+
+	// when
+	report := simulate(newRules, nil, runtimes, planSpec)
+
+	// then
+	assert.Equal(t, []RuleCount{
+		{Rule: "1: aws", Count: 0},
+		{Rule: "2: aws(MF=m6i) -> S", Count: 1},
+		{Rule: "3: aws(PR=cf-eu10, SL=team:kyma) -> EU", Count: 1},
+	}, report.RuleCounts)
+	assert.Empty(t, report.Unmatched)
+}
+
+func TestSimulateCommand(t *testing.T) {
+	// given
+	rulesFile, err := rules.CreateTempFile("rule:\n- aws\n- aws(PR=cf-eu11) -> EU\n")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(rulesFile) }()
+	instancesFile, err := rules.CreateTempFile(`{"data": [{"instanceID": "instance-1", "servicePlanID": "361c511f-f939-4621-b228-d0fb79a1fe15", "servicePlanName": "aws", "provider": "AWS", "subAccountRegion": "cf-eu11", "region": "eu-central-1"}], "count": 1, "totalCount": 1}`)
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(instancesFile) }()
+
+	cmd := NewSimulateCmd()
+	out := bytes.NewBufferString("")
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"-f", rulesFile, "-i", instancesFile})
+
+	// when
+	err = cmd.Execute()
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Simulated 1 instances")
+	assert.Contains(t, out.String(), "     0  1: aws\n")
+	assert.Contains(t, out.String(), "     1  2: aws(PR=cf-eu11) -> EU\n")
+	assert.Contains(t, out.String(), "Instances not matching any rule: 0")
+}
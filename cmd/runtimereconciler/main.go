@@ -4,16 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	btpmanager "github.com/kyma-project/kyma-environment-broker/internal/btpmanager/credentials"
+	"github.com/kyma-project/kyma-environment-broker/internal/drift"
 	"github.com/kyma-project/kyma-environment-broker/internal/events"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/vrischmann/envconfig"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
@@ -26,11 +32,22 @@ type Config struct {
 	JobInterval            int    `envconfig:"default=24"`
 	JobReconciliationDelay string `envconfig:"default=0s"`
 	MetricsPort            string `envconfig:"default=8081"`
+	// DriftJobEnabled enables the periodic comparison of instance parameters with Runtime resources
+	DriftJobEnabled  bool `envconfig:"default=false"`
+	DriftJobInterval int  `envconfig:"default=60"`
+	// DriftUpdateEnabled enables requesting update operations for drifted Runtime resources, ignored in dry run
+	DriftUpdateEnabled bool                `envconfig:"default=false"`
+	Broker             broker.ClientConfig `envconfig:"optional"`
 }
 
 const AppPrefix = "runtime_reconciler"
 
 func main() {
+	err := imv1.AddToScheme(scheme.Scheme)
+	if err != nil {
+		panic(err)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -42,12 +59,12 @@ func main() {
 	logs.Info("runtime-reconciler debug version: 1")
 
 	var cfg Config
-	err := envconfig.InitWithPrefix(&cfg, "RUNTIME_RECONCILER")
+	err = envconfig.InitWithPrefix(&cfg, "RUNTIME_RECONCILER")
 	fatalOnError(err, logs)
 	logs.Info("runtime-reconciler config loaded")
 
-	if !cfg.JobEnabled {
-		logs.Info("job disabled, module stopped.")
+	if !cfg.JobEnabled && !cfg.DriftJobEnabled {
+		logs.Info("jobs disabled, module stopped.")
 		return
 	}
 	jobReconciliationDelay, err := time.ParseDuration(cfg.JobReconciliationDelay)
@@ -57,7 +74,8 @@ func main() {
 
 	logs.Info(fmt.Sprintf("runtime-reconciler running as dry run? %t", cfg.DryRun))
 
-	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
+	cipher, err := storage.NewEncrypterFromConfig(cfg.Database)
+	fatalOnError(err, logs)
 
 	db, _, err := storage.NewFromConfig(cfg.Database, cfg.Events, cipher)
 	fatalOnError(err, logs)
@@ -65,17 +83,36 @@ func main() {
 
 	metricsRegistry := prometheus.NewRegistry()
 	metricsRegistry.MustRegister(collectors.NewGoCollector())
+	http.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{Registry: metricsRegistry}))
+	go func() {
+		err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.MetricsPort), nil)
+		if err != nil {
+			logs.Error(fmt.Sprintf("while serving metrics: %s", err))
+		}
+	}()
 
 	kcpK8sConfig, err := config.GetConfig()
 	fatalOnError(err, logs)
 	kcpK8sClient, err := client.New(kcpK8sConfig, client.Options{})
 	fatalOnError(err, logs)
 
-	btpOperatorManager := btpmanager.NewManager(ctx, kcpK8sClient, db.Instances(), logs, cfg.DryRun)
+	if cfg.JobEnabled {
+		btpOperatorManager := btpmanager.NewManager(ctx, kcpK8sClient, db.Instances(), logs, cfg.DryRun)
 
-	btpManagerCredentialsJob := btpmanager.NewJob(btpOperatorManager, logs, metricsRegistry, cfg.MetricsPort, AppPrefix)
-	logs.Info(fmt.Sprintf("runtime-reconciler created job every %d m", cfg.JobInterval))
-	btpManagerCredentialsJob.Start(cfg.JobInterval, jobReconciliationDelay)
+		btpManagerCredentialsJob := btpmanager.NewJob(btpOperatorManager, logs, metricsRegistry, AppPrefix)
+		logs.Info(fmt.Sprintf("runtime-reconciler created job every %d m", cfg.JobInterval))
+		btpManagerCredentialsJob.Start(cfg.JobInterval, jobReconciliationDelay)
+	}
+
+	if cfg.DriftJobEnabled {
+		var updater drift.Updater
+		if cfg.DriftUpdateEnabled && !cfg.DryRun {
+			updater = broker.NewClient(ctx, cfg.Broker)
+		}
+		reconciler := drift.NewReconciler(db.Instances(), kcpK8sClient, updater, drift.NewMetrics(metricsRegistry, AppPrefix), logs)
+		logs.Info(fmt.Sprintf("runtime-reconciler created drift job every %d m, updates enabled: %t", cfg.DriftJobInterval, updater != nil))
+		drift.NewJob(reconciler, logs).Start(ctx, cfg.DriftJobInterval, jobReconciliationDelay)
+	}
 
 	<-ctx.Done()
 }
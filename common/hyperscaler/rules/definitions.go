@@ -2,20 +2,30 @@ package rules
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
 	PlatformRegionAttributeName    = "PR"
 	HyperscalerRegionAttributeName = "HR"
+	GlobalAccountAttributeName     = "GA"
+	SubaccountLabelAttributeName   = "SL"
+	MachineFamilyAttributeName     = "MF"
 	EUAccessAttributeName          = "EU"
 	SharedAttributeName            = "S"
+	PriorityAttributeName          = "P"
 	PlatformRegionSuffix           = "PR"
 	HyperscalerRegionSuffix        = "HR"
+
+	LabelSeparator = ":"
 )
 
 type Attribute struct {
 	Name   string
 	Setter func(*Rule, string) error
+	// ValueRequired is set for output attributes passed as ATTR=VAL pairs, other output attributes are flags
+	ValueRequired bool
 }
 
 var InputAttributes = []Attribute{
@@ -27,6 +37,18 @@ var InputAttributes = []Attribute{
 		Name:   HyperscalerRegionAttributeName,
 		Setter: setHyperscalerRegion,
 	},
+	{
+		Name:   GlobalAccountAttributeName,
+		Setter: setGlobalAccount,
+	},
+	{
+		Name:   SubaccountLabelAttributeName,
+		Setter: setSubaccountLabel,
+	},
+	{
+		Name:   MachineFamilyAttributeName,
+		Setter: setMachineFamily,
+	},
 }
 
 var OutputAttributes = []Attribute{
@@ -46,6 +68,11 @@ var OutputAttributes = []Attribute{
 		Name:   HyperscalerRegionSuffix,
 		Setter: setHyperscalerRegionSuffix,
 	},
+	{
+		Name:          PriorityAttributeName,
+		Setter:        setPriority,
+		ValueRequired: true,
+	},
 }
 
 func setShared(r *Rule, value string) error {
@@ -91,6 +118,21 @@ func setEuAccess(r *Rule, value string) error {
 	return nil
 }
 
+func setPriority(r *Rule, value string) error {
+	if r.Priority != 0 {
+		return fmt.Errorf("Priority already set")
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil || priority <= 0 {
+		return fmt.Errorf("Priority must be a positive integer, got %q", value)
+	}
+
+	r.ContainsOutputAttributes = true
+	r.Priority = priority
+
+	return nil
+}
+
 func (r *Rule) SetPlan(value string) (*Rule, error) {
 	if value == "" {
 		return nil, fmt.Errorf("plan name is empty")
@@ -125,3 +167,46 @@ func setHyperscalerRegion(r *Rule, value string) error {
 
 	return nil
 }
+
+func setGlobalAccount(r *Rule, value string) error {
+	if r.GlobalAccount != "" {
+		return fmt.Errorf("GlobalAccount already set")
+	} else if value == "" {
+		return fmt.Errorf("GlobalAccount is empty")
+	}
+
+	r.ContainsInputAttributes = true
+	r.GlobalAccount = value
+
+	return nil
+}
+
+func setSubaccountLabel(r *Rule, value string) error {
+	if r.SubaccountLabel != "" {
+		return fmt.Errorf("SubaccountLabel already set")
+	} else if value == "" {
+		return fmt.Errorf("SubaccountLabel is empty")
+	}
+	key, _, found := strings.Cut(value, LabelSeparator)
+	if !found || key == "" {
+		return fmt.Errorf("SubaccountLabel must have the key%svalue format", LabelSeparator)
+	}
+
+	r.ContainsInputAttributes = true
+	r.SubaccountLabel = value
+
+	return nil
+}
+
+func setMachineFamily(r *Rule, value string) error {
+	if r.MachineFamily != "" {
+		return fmt.Errorf("MachineFamily already set")
+	} else if value == "" {
+		return fmt.Errorf("MachineFamily is empty")
+	}
+
+	r.ContainsInputAttributes = true
+	r.MachineFamily = value
+
+	return nil
+}
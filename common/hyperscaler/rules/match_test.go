@@ -177,3 +177,66 @@ func TestMatch_UseValidRuleset(t *testing.T) {
 	}
 
 }
+
+func TestMatch_PriorityAndProvisioningAttributes(t *testing.T) {
+	svc, err := NewRulesServiceFromSlice([]string{
+		"aws",
+		"aws(GA=ga-dedicated) -> S",
+		"aws(SL=pool:large) -> P=5",
+		"aws(MF=g6) -> PR, P=1",
+		"aws(MF=g6e) -> HR, P=1",
+		"aws(PR=cf-eu11) -> EU",
+		"aws(PR=cf-eu11, GA=ga-dedicated) -> EU, S",
+	}, sets.New("aws"), sets.New("aws"))
+	require.NoError(t, err)
+	require.True(t, svc.IsRulesetValid(), "%v", svc.ValidationInfo)
+
+	for tn, tc := range map[string]struct {
+		given        ProvisioningAttributes
+		expectedRule string
+	}{
+		"no additional attributes": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws", MachineType: "m6i.large"},
+			expectedRule: "aws",
+		},
+		"global account": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws", GlobalAccountID: "ga-dedicated"},
+			expectedRule: "aws(GA=ga-dedicated) -> S",
+		},
+		"global account and platform region": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-eu11", HyperscalerRegion: "eu-central-1", Hyperscaler: "aws", GlobalAccountID: "ga-dedicated"},
+			expectedRule: "aws(PR=cf-eu11, GA=ga-dedicated) -> EU, S",
+		},
+		"machine family": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws", MachineType: "g6.xlarge"},
+			expectedRule: "aws(MF=g6) -> PR, P=1",
+		},
+		"another machine family": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws", MachineType: "g6e.xlarge"},
+			expectedRule: "aws(MF=g6e) -> HR, P=1",
+		},
+		"machine family starting with the rule machine family": {
+			given:        ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws", MachineType: "g6f.xlarge"},
+			expectedRule: "aws",
+		},
+		"subaccount label with priority": {
+			given: ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-eu11", HyperscalerRegion: "eu-central-1", Hyperscaler: "aws", GlobalAccountID: "ga-dedicated",
+				SubaccountLabels: map[string]string{"pool": "large", "env": "prod"}},
+			expectedRule: "aws(SL=pool:large) -> P=5",
+		},
+		"subaccount label with different value": {
+			given: ProvisioningAttributes{Plan: "aws", PlatformRegion: "cf-us10", HyperscalerRegion: "us-east-1", Hyperscaler: "aws",
+				SubaccountLabels: map[string]string{"pool": "small"}},
+			expectedRule: "aws",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			result, found := svc.MatchProvisioningAttributesWithValidRuleset(&tc.given)
+
+			// then
+			assert.True(t, found)
+			assert.Equal(t, tc.expectedRule, result.Rule())
+		})
+	}
+}
@@ -150,6 +150,22 @@ func TestParserHappyPath(t *testing.T) {
 		require.True(t, rule.EuAccess)
 		require.True(t, rule.Shared)
 	})
+
+	t.Run("with global account, subaccount label, machine family and priority", func(t *testing.T) {
+		parser := &SimpleParser{}
+		rule, err := parser.Parse("aws(GA=ga-1, SL=env:prod, MF=m6i, PR=cf-eu11)->EU, P=10")
+		require.NoError(t, err)
+
+		require.NotNil(t, rule)
+		require.Equal(t, "aws", rule.Plan)
+		require.Equal(t, "ga-1", rule.GlobalAccount)
+		require.Equal(t, "env:prod", rule.SubaccountLabel)
+		require.Equal(t, "m6i", rule.MachineFamily)
+		require.Equal(t, "cf-eu11", rule.PlatformRegion)
+
+		require.True(t, rule.EuAccess)
+		require.Equal(t, 10, rule.Priority)
+	})
 }
 
 func TestParserValidation(t *testing.T) {
@@ -354,4 +370,43 @@ func TestParserValidation(t *testing.T) {
 		require.Nil(t, rule)
 		require.Error(t, err)
 	})
+	t.Run("with invalid new input attributes", func(t *testing.T) {
+		rule, err := parser.Parse("aws(GA=ga-1,GA=ga-2)")
+		require.Nil(t, rule)
+		require.Error(t, err)
+
+		rule, err = parser.Parse("aws(SL=env)")
+		require.Nil(t, rule)
+		require.Error(t, err)
+
+		rule, err = parser.Parse("aws(SL=:prod)")
+		require.Nil(t, rule)
+		require.Error(t, err)
+
+		rule, err = parser.Parse("aws(MF=)")
+		require.Nil(t, rule)
+		require.Error(t, err)
+	})
+
+	t.Run("with invalid output attribute values", func(t *testing.T) {
+		rule, err := parser.Parse("aws->P")
+		require.Nil(t, rule)
+		require.EqualError(t, err, "output attribute P has no value")
+
+		rule, err = parser.Parse("aws->EU=true")
+		require.Nil(t, rule)
+		require.EqualError(t, err, "output attribute EU does not support values")
+
+		rule, err = parser.Parse("aws->P=0")
+		require.Nil(t, rule)
+		require.Error(t, err)
+
+		rule, err = parser.Parse("aws->P=high")
+		require.Nil(t, rule)
+		require.Error(t, err)
+
+		rule, err = parser.Parse("aws->P=1,P=2")
+		require.Nil(t, rule)
+		require.Error(t, err)
+	})
 }
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Rule struct {
@@ -10,8 +11,12 @@ type Rule struct {
 	PlatformRegionSuffix           bool
 	HyperscalerRegionSuffix        bool
 	HyperscalerRegion              string
+	GlobalAccount                  string
+	SubaccountLabel                string
+	MachineFamily                  string
 	EuAccess                       bool
 	Shared                         bool
+	Priority                       int
 	ContainsInputAttributes        bool
 	ContainsOutputAttributes       bool
 	hyperscalerNameMappingFunction func(string) string
@@ -22,10 +27,27 @@ func NewRule() *Rule {
 }
 
 type ProvisioningAttributes struct {
-	Plan              string `json:"plan"`
-	PlatformRegion    string `json:"platformRegion"`
-	HyperscalerRegion string `json:"hyperscalerRegion"`
-	Hyperscaler       string `json:"hyperscaler"`
+	Plan              string            `json:"plan"`
+	PlatformRegion    string            `json:"platformRegion"`
+	HyperscalerRegion string            `json:"hyperscalerRegion"`
+	Hyperscaler       string            `json:"hyperscaler"`
+	GlobalAccountID   string            `json:"globalAccountID"`
+	SubaccountLabels  map[string]string `json:"subaccountLabels"`
+	MachineType       string            `json:"machineType"`
+}
+
+// NewProvisioningAttributes returns attributes matched against the rules, all of them are required so that every caller
+// decides on each of them, attributes unknown to the caller are passed empty and match only rules which do not restrict them
+func NewProvisioningAttributes(plan, platformRegion, hyperscalerRegion, hyperscaler, globalAccountID string, subaccountLabels map[string]string, machineType string) *ProvisioningAttributes {
+	return &ProvisioningAttributes{
+		Plan:              plan,
+		PlatformRegion:    platformRegion,
+		HyperscalerRegion: hyperscalerRegion,
+		Hyperscaler:       hyperscaler,
+		GlobalAccountID:   globalAccountID,
+		SubaccountLabels:  subaccountLabels,
+		MachineType:       machineType,
+	}
 }
 
 func (r *Rule) SetAttributeValue(attribute, value string, attributes []Attribute) error {
@@ -37,3 +59,25 @@ func (r *Rule) SetAttributeValue(attribute, value string, attributes []Attribute
 
 	return fmt.Errorf("unknown attribute %s", attribute)
 }
+
+// SetOutputAttribute sets an output attribute given as a flag (EU) or, if the attribute requires a value, as a pair (P=10)
+func (r *Rule) SetOutputAttribute(entry string) error {
+	attribute, value, hasValue := strings.Cut(entry, Equal)
+	for _, attr := range OutputAttributes {
+		if attr.Name != attribute {
+			continue
+		}
+		if attr.ValueRequired && !hasValue {
+			return fmt.Errorf("output attribute %s has no value", attribute)
+		}
+		if !attr.ValueRequired {
+			if hasValue {
+				return fmt.Errorf("output attribute %s does not support values", attribute)
+			}
+			value = "true"
+		}
+		return attr.Setter(r, value)
+	}
+
+	return fmt.Errorf("unknown attribute %s", attribute)
+}
@@ -132,8 +132,11 @@ func (rs *RulesService) getSortedRulesForPlan(plan string) []ValidRule {
 			rulesForPlan = append(rulesForPlan, validRule)
 		}
 	}
-	//sort rules by MatchAnyCount
+	//sort rules by Priority (descending) and MatchAnyCount
 	slices.SortStableFunc(rulesForPlan, func(x, y ValidRule) int {
+		if x.Priority != y.Priority {
+			return y.Priority - x.Priority
+		}
 		return x.MatchAnyCount - y.MatchAnyCount
 	})
 	return rulesForPlan
@@ -152,7 +155,7 @@ func (rs *RulesService) MatchProvisioningAttributesWithValidRuleset(provisioning
 		return Result{}, false
 	}
 
-	//find first matching rule which is the most specific one (lowest MatchAnyCount)
+	//find first matching rule which has the highest priority and is the most specific one (lowest MatchAnyCount)
 	var result Result
 	found := false
 	for _, validRule := range rulesForPlan {
@@ -167,6 +170,26 @@ func (rs *RulesService) MatchProvisioningAttributesWithValidRuleset(provisioning
 	return result, found
 }
 
+// MatchPlatformRegionRules returns results of rules of the plan restricted to the platform region of the attributes, they
+// are selected only in the platform region, for example, for some global accounts or machine families
+func (rs *RulesService) MatchPlatformRegionRules(provisioningAttributes *ProvisioningAttributes) []Result {
+	if rs.ValidRules == nil {
+		return nil
+	}
+	var results []Result
+	for _, validRule := range rs.getSortedRulesForPlan(provisioningAttributes.Plan) {
+		if validRule.PlatformRegion.matchAny || !validRule.PlatformRegion.Match(provisioningAttributes.PlatformRegion) {
+			continue
+		}
+		attributes := *provisioningAttributes
+		if !validRule.HyperscalerRegion.matchAny {
+			attributes.HyperscalerRegion = validRule.HyperscalerRegion.literal
+		}
+		results = append(results, validRule.toResult(&attributes))
+	}
+	return results
+}
+
 func toValidRule(rule *Rule, rawRule string, ruleNo int) *ValidRule {
 	vr := &ValidRule{
 		Plan: PatternAttribute{
@@ -178,18 +201,26 @@ func toValidRule(rule *Rule, rawRule string, ruleNo int) *ValidRule {
 		HyperscalerRegion: PatternAttribute{
 			literal: rule.HyperscalerRegion,
 		},
+		GlobalAccount: PatternAttribute{
+			literal: rule.GlobalAccount,
+		},
+		SubaccountLabel: PatternAttribute{
+			literal: rule.SubaccountLabel,
+		},
+		MachineFamily: PatternAttribute{
+			literal: rule.MachineFamily,
+		},
 		Shared:                  rule.Shared,
 		EuAccess:                rule.EuAccess,
 		PlatformRegionSuffix:    rule.PlatformRegionSuffix,
 		HyperscalerRegionSuffix: rule.HyperscalerRegionSuffix,
+		Priority:                rule.Priority,
 	}
-	if vr.PlatformRegion.literal == "" {
-		vr.PlatformRegion.matchAny = true
-		vr.MatchAnyCount++
-	}
-	if vr.HyperscalerRegion.literal == "" {
-		vr.HyperscalerRegion.matchAny = true
-		vr.MatchAnyCount++
+	for _, attribute := range []*PatternAttribute{&vr.PlatformRegion, &vr.HyperscalerRegion, &vr.GlobalAccount, &vr.SubaccountLabel, &vr.MachineFamily} {
+		if attribute.literal == "" {
+			attribute.matchAny = true
+			vr.MatchAnyCount++
+		}
 	}
 	vr.RawData = RawData{
 		Rule:   rawRule,
@@ -143,6 +143,18 @@ func TestValidRuleset_CheckUniqueness(t *testing.T) {
 			ruleset:              []string{"aws", "azure", "aws"},
 			duplicateErrorsCount: 1,
 		},
+		{name: "duplicate with new attributes",
+			ruleset:              []string{"aws(GA=a,SL=env:prod,MF=m6i)", "aws(MF=m6i,GA=a,SL=env:prod)"},
+			duplicateErrorsCount: 1,
+		},
+		{name: "priority does not make rule unique",
+			ruleset:              []string{"aws(GA=a)", "aws(GA=a)->P=10"},
+			duplicateErrorsCount: 1,
+		},
+		{name: "no duplicate with different machine families",
+			ruleset:              []string{"aws(MF=m6i)", "aws(MF=m6)"},
+			duplicateErrorsCount: 0,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -231,6 +243,42 @@ func TestValidRuleset_CheckAmbiguity(t *testing.T) {
 			ruleset:             []string{"aws(PR=v)", "aws(PR=x)", "aws(HR=y)", "aws(HR=z)", "aws(PR=x,HR=y)", "azure(PR=x,HR=z)", "aws(PR=v,HR=z)", "aws(PR=v,HR=y)"},
 			ambiguityErrorCount: 1,
 		},
+		{name: "global account and machine family ambiguity",
+			ruleset:             []string{"aws(GA=a)", "aws(MF=m6i)"},
+			ambiguityErrorCount: 1,
+		},
+		{name: "global account and machine family ambiguity - disambiguation added",
+			ruleset:             []string{"aws(GA=a)", "aws(MF=m6i)", "aws(GA=a,MF=m6i)"},
+			ambiguityErrorCount: 0,
+		},
+		{name: "global account and machine family ambiguity - resolved by priority",
+			ruleset:             []string{"aws(GA=a)->P=2", "aws(MF=m6i)->P=1"},
+			ambiguityErrorCount: 0,
+		},
+		{name: "global account and machine family ambiguity - disambiguation with lower priority",
+			ruleset:             []string{"aws(GA=a)->P=2", "aws(MF=m6i)->P=2", "aws(GA=a,MF=m6i)->P=1"},
+			ambiguityErrorCount: 1,
+		},
+		{name: "different global accounts are not ambiguous",
+			ruleset:             []string{"aws(GA=a)", "aws(GA=b)"},
+			ambiguityErrorCount: 0,
+		},
+		{name: "machine families with a common prefix are not ambiguous",
+			ruleset:             []string{"aws(MF=m6)", "aws(MF=m6i)"},
+			ambiguityErrorCount: 0,
+		},
+		{name: "labels with the same key are not ambiguous",
+			ruleset:             []string{"aws(SL=env:prod)", "aws(SL=env:dev)"},
+			ambiguityErrorCount: 0,
+		},
+		{name: "labels with different keys are ambiguous",
+			ruleset:             []string{"aws(SL=env:prod)", "aws(SL=team:core)"},
+			ambiguityErrorCount: 1,
+		},
+		{name: "labels with different keys - resolved by priority",
+			ruleset:             []string{"aws(SL=env:prod)->P=1", "aws(SL=team:core)"},
+			ambiguityErrorCount: 0,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -302,3 +350,27 @@ func fixRulesService() *RulesService {
 
 	return rs
 }
+
+func TestRulesService_MatchPlatformRegionRules(t *testing.T) {
+	// given
+	rulesService, err := NewRulesServiceFromSlice([]string{
+		"aws", "aws(PR=cf-eu11) -> EU", "aws(PR=cf-eu11, HR=eu-west-1) -> HR", "aws(PR=cf-eu11, HR=eu-west-1, MF=g6)", "aws(PR=cf-us10)", "gcp(PR=cf-eu11)",
+	}, sets.New("aws", "gcp"), sets.New[string]())
+	require.NoError(t, err)
+	require.True(t, rulesService.IsRulesetValid())
+
+	// when
+	results := rulesService.MatchPlatformRegionRules(NewProvisioningAttributes("aws", "cf-eu11", "eu-central-1", "aws", "", nil, ""))
+
+	// then
+	var matched []string
+	for _, result := range results {
+		matched = append(matched, result.Rule())
+	}
+	assert.ElementsMatch(t, []string{"aws(PR=cf-eu11) -> EU", "aws(PR=cf-eu11, HR=eu-west-1) -> HR", "aws(PR=cf-eu11, HR=eu-west-1, MF=g6)"}, matched)
+	for _, result := range results {
+		if result.Rule() == "aws(PR=cf-eu11, HR=eu-west-1) -> HR" {
+			assert.Equal(t, "aws_eu-west-1", result.Hyperscaler())
+		}
+	}
+}
@@ -2,6 +2,9 @@ package rules
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -19,10 +22,14 @@ type ValidRule struct {
 	Plan                    PatternAttribute
 	PlatformRegion          PatternAttribute
 	HyperscalerRegion       PatternAttribute
+	GlobalAccount           PatternAttribute
+	SubaccountLabel         PatternAttribute
+	MachineFamily           PatternAttribute
 	Shared                  bool
 	EuAccess                bool
 	PlatformRegionSuffix    bool
 	HyperscalerRegionSuffix bool
+	Priority                int
 	MatchAnyCount           int
 	RawData                 RawData
 }
@@ -57,6 +64,47 @@ func (pa *PatternAttribute) Match(value string) bool {
 	return pa.literal == value
 }
 
+// MatchMachineFamily checks if the family of the machine type is the one held by the attribute, for example m6i matches m6i.large
+// but not m6id.large
+func (pa *PatternAttribute) MatchMachineFamily(hyperscaler, machineType string) bool {
+	if pa.matchAny {
+		return true
+	}
+	return pa.literal == machineFamily(hyperscaler, machineType)
+}
+
+// machineFamily returns the family of the machine type of the hyperscaler. Machine types of other hyperscalers
+// are split at the first "." or "-".
+func machineFamily(hyperscaler, machineType string) string {
+	family, err := runtime.MachineFamily(runtime.CloudProviderFromString(hyperscaler), machineType)
+	if err != nil {
+		family, _, _ = strings.Cut(machineType, ".")
+		family, _, _ = strings.Cut(family, "-")
+	}
+	return family
+}
+
+// MatchLabel checks if the labels contain the key:value pair held by the attribute
+func (pa *PatternAttribute) MatchLabel(labels map[string]string) bool {
+	if pa.matchAny {
+		return true
+	}
+	key, value, _ := strings.Cut(pa.literal, LabelSeparator)
+	labelValue, found := labels[key]
+	return found && labelValue == value
+}
+
+// intersect returns the attribute matching only values matched by both attributes
+func (pa PatternAttribute) intersect(other PatternAttribute) (PatternAttribute, bool) {
+	switch {
+	case pa.matchAny:
+		return other, true
+	case other.matchAny, pa.literal == other.literal:
+		return pa, true
+	}
+	return PatternAttribute{}, false
+}
+
 func (vr *ValidRule) Match(provisioningAttributes *ProvisioningAttributes) bool {
 	if !vr.Plan.Match(provisioningAttributes.Plan) {
 		return false
@@ -73,6 +121,18 @@ func (vr *ValidRule) matchInputParameters(provisioningAttributes *ProvisioningAt
 	if !vr.HyperscalerRegion.Match(provisioningAttributes.HyperscalerRegion) {
 		return false
 	}
+
+	if !vr.GlobalAccount.Match(provisioningAttributes.GlobalAccountID) {
+		return false
+	}
+
+	if !vr.SubaccountLabel.MatchLabel(provisioningAttributes.SubaccountLabels) {
+		return false
+	}
+
+	if !vr.MachineFamily.MatchMachineFamily(provisioningAttributes.Hyperscaler, provisioningAttributes.MachineType) {
+		return false
+	}
 	return true
 }
 
@@ -112,7 +172,47 @@ func NewValidationErrors() *ValidationErrors {
 }
 
 func (vr *ValidRule) keyString() string {
-	return fmt.Sprintf("%s(PR=%s,HR=%s)", vr.Plan.literal, vr.PlatformRegion.literal, vr.HyperscalerRegion.literal)
+	var optional strings.Builder
+	for _, attr := range []struct {
+		name      string
+		attribute PatternAttribute
+	}{
+		{GlobalAccountAttributeName, vr.GlobalAccount},
+		{SubaccountLabelAttributeName, vr.SubaccountLabel},
+		{MachineFamilyAttributeName, vr.MachineFamily},
+	} {
+		if attr.attribute.literal != "" {
+			optional.WriteString(fmt.Sprintf(",%s=%s", attr.name, attr.attribute.literal))
+		}
+	}
+	return fmt.Sprintf("%s(PR=%s,HR=%s%s)", vr.Plan.literal, vr.PlatformRegion.literal, vr.HyperscalerRegion.literal, optional.String())
+}
+
+// intersect returns the rule matching only provisioning attributes matched by both rules.
+// The second value is false if the rules cannot match the same provisioning attributes.
+// The third value is false if such a rule cannot be expressed, which is the case for different subaccount label keys.
+func (vr *ValidRule) intersect(other ValidRule) (ValidRule, bool, bool) {
+	intersection := ValidRule{Plan: vr.Plan, Priority: vr.Priority}
+	overlapping := true
+	var ok bool
+	intersection.PlatformRegion, ok = vr.PlatformRegion.intersect(other.PlatformRegion)
+	overlapping = overlapping && ok
+	intersection.HyperscalerRegion, ok = vr.HyperscalerRegion.intersect(other.HyperscalerRegion)
+	overlapping = overlapping && ok
+	intersection.GlobalAccount, ok = vr.GlobalAccount.intersect(other.GlobalAccount)
+	overlapping = overlapping && ok
+	intersection.MachineFamily, ok = vr.MachineFamily.intersect(other.MachineFamily)
+	overlapping = overlapping && ok
+
+	intersection.SubaccountLabel, ok = vr.SubaccountLabel.intersect(other.SubaccountLabel)
+	if !ok {
+		key, _, _ := strings.Cut(vr.SubaccountLabel.literal, LabelSeparator)
+		otherKey, _, _ := strings.Cut(other.SubaccountLabel.literal, LabelSeparator)
+		// a subaccount can have both labels if their keys differ
+		return intersection, overlapping && key != otherKey, false
+	}
+
+	return intersection, overlapping, true
 }
 
 func (vr *ValidRuleset) checkUniqueness() (bool, []error) {
@@ -129,38 +229,39 @@ func (vr *ValidRuleset) checkUniqueness() (bool, []error) {
 	return len(duplicateErrors) == 0, duplicateErrors
 }
 
-// This is 2D solution that does not scale to more than 2 attributes
+// checkUnambiguity verifies that for every pair of rules with the same plan, priority and number of attributes,
+// which can match the same provisioning attributes, there is a rule matching exactly their intersection
 func (vr *ValidRuleset) checkUnambiguity() (bool, []error) {
 	ambiguityErrors := make([]error, 0)
 
-	mostSpecificRules := make(map[string]struct{})
-	prSpecified := make([]ValidRule, 0)
-	hrSpecified := make([]ValidRule, 0)
-
+	rulesByKey := make(map[string]ValidRule)
 	for _, rule := range vr.Rules {
-		if rule.MatchAnyCount == 0 {
-			mostSpecificRules[rule.keyString()] = struct{}{}
-		}
-		if rule.MatchAnyCount == 1 {
-			if !rule.PlatformRegion.matchAny {
-				prSpecified = append(prSpecified, rule)
-			} else {
-				hrSpecified = append(hrSpecified, rule)
-			}
-		}
+		rulesByKey[rule.keyString()] = rule
 	}
 
-	for _, prRule := range prSpecified {
-		for _, hrRule := range hrSpecified {
-			if prRule.Plan.literal == hrRule.Plan.literal {
-				unionRule := ValidRule{
-					Plan:              prRule.Plan,
-					PlatformRegion:    prRule.PlatformRegion,
-					HyperscalerRegion: hrRule.HyperscalerRegion,
-				}
-				if _, ok := mostSpecificRules[unionRule.keyString()]; !ok {
-					ambiguityErrors = append(ambiguityErrors, fmt.Errorf("rules %s and %s are ambiguous: missing %s", prRule.NumberedRule(), hrRule.NumberedRule(), unionRule.keyString()))
-				}
+	for i, first := range vr.Rules {
+		for _, second := range vr.Rules[i+1:] {
+			if first.Plan.literal != second.Plan.literal || first.Priority != second.Priority || first.MatchAnyCount != second.MatchAnyCount {
+				continue
+			}
+			intersection, overlapping, expressible := first.intersect(second)
+			if !overlapping {
+				continue
+			}
+			if !expressible {
+				ambiguityErrors = append(ambiguityErrors, fmt.Errorf("rules %s and %s are ambiguous: subaccount can have both labels, set different priorities", first.NumberedRule(), second.NumberedRule()))
+				continue
+			}
+			// the intersection is one of the rules, which matches a subset of the provisioning attributes matched by the other, no disambiguation rule is needed
+			if intersection.keyString() == first.keyString() || intersection.keyString() == second.keyString() {
+				continue
+			}
+			disambiguation, found := rulesByKey[intersection.keyString()]
+			switch {
+			case !found:
+				ambiguityErrors = append(ambiguityErrors, fmt.Errorf("rules %s and %s are ambiguous: missing %s", first.NumberedRule(), second.NumberedRule(), intersection.keyString()))
+			case disambiguation.Priority < first.Priority:
+				ambiguityErrors = append(ambiguityErrors, fmt.Errorf("rules %s and %s are ambiguous: rule %s has lower priority", first.NumberedRule(), second.NumberedRule(), disambiguation.NumberedRule()))
 			}
 		}
 	}
@@ -12,47 +12,101 @@ func TestValidRule_keyString(t *testing.T) {
 		expectedKey string
 	}{
 		{
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, false, false, false, 0,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "aws(PR=cf-eu10,HR=eu-west-2)",
 		},
 		{
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, true, true, true, true, 44,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "aws(PR=cf-eu10,HR=eu-west-2)",
 		},
 		{
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "eu-west-2"}, true, true, true, true, 44,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "aws(PR=,HR=eu-west-2)",
 		},
 		{
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "", matchAny: true}, true, true, true, true, 44,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "aws(PR=,HR=)",
 		},
 		{
-			input: &ValidRule{PatternAttribute{literal: "azure"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "", matchAny: true}, true, true, true, true, 44,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "azure"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "azure(PR=,HR=)",
 		},
 		{
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "", matchAny: true}, true, true, true, true, 44,
-				RawData{"", 44}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"", 44},
+			},
 			expectedKey: "aws(PR=cf-eu10,HR=)",
 		},
+		{
+			input: &ValidRule{
+				Plan:              PatternAttribute{literal: "aws"},
+				PlatformRegion:    PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion: PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:     PatternAttribute{literal: "ga"},
+				SubaccountLabel:   PatternAttribute{literal: "", matchAny: true},
+				MachineFamily:     PatternAttribute{literal: "m6i"},
+				Priority:          10,
+			},
+			expectedKey: "aws(PR=cf-eu10,HR=,GA=ga,MF=m6i)",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.expectedKey, func(t *testing.T) {
@@ -81,11 +135,14 @@ func TestRuleToValidRuleConversion(t *testing.T) {
 				Plan:                    PatternAttribute{literal: "aws"},
 				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
 				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
 				PlatformRegionSuffix:    false,
 				HyperscalerRegionSuffix: false,
 				EuAccess:                false,
 				Shared:                  false,
-				MatchAnyCount:           2,
+				MatchAnyCount:           5,
 				RawData:                 RawData{"aws", 44},
 			},
 		},
@@ -104,11 +161,14 @@ func TestRuleToValidRuleConversion(t *testing.T) {
 				Plan:                    PatternAttribute{literal: "aws"},
 				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
 				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
 				PlatformRegionSuffix:    true,
 				HyperscalerRegionSuffix: true,
 				EuAccess:                true,
 				Shared:                  true,
-				MatchAnyCount:           2,
+				MatchAnyCount:           5,
 				RawData:                 RawData{"aws", 44},
 			},
 		},
@@ -125,11 +185,14 @@ func TestRuleToValidRuleConversion(t *testing.T) {
 				Plan:                    PatternAttribute{literal: "aws"},
 				PlatformRegion:          PatternAttribute{literal: "cf-eu10", matchAny: false},
 				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
 				PlatformRegionSuffix:    true,
 				HyperscalerRegionSuffix: true,
 				EuAccess:                false,
 				Shared:                  false,
-				MatchAnyCount:           1,
+				MatchAnyCount:           4,
 				RawData:                 RawData{"aws(PR=cf-eu10)", 44},
 			},
 		},
@@ -146,11 +209,14 @@ func TestRuleToValidRuleConversion(t *testing.T) {
 				Plan:                    PatternAttribute{literal: "aws"},
 				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
 				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2", matchAny: false},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
 				PlatformRegionSuffix:    true,
 				HyperscalerRegionSuffix: true,
 				EuAccess:                false,
 				Shared:                  false,
-				MatchAnyCount:           1,
+				MatchAnyCount:           4,
 				RawData:                 RawData{"aws(HR=eu-west-2)", 44},
 			},
 		},
@@ -167,11 +233,14 @@ func TestRuleToValidRuleConversion(t *testing.T) {
 				Plan:                    PatternAttribute{literal: "aws"},
 				PlatformRegion:          PatternAttribute{literal: "cf-eu10", matchAny: false},
 				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2", matchAny: false},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
 				PlatformRegionSuffix:    true,
 				HyperscalerRegionSuffix: true,
 				EuAccess:                false,
 				Shared:                  false,
-				MatchAnyCount:           0,
+				MatchAnyCount:           3,
 				RawData:                 RawData{"aws(HR=eu-west-2,PR=cf-eu10)", 44},
 			},
 		},
@@ -195,10 +264,17 @@ func TestValidRule_toResult(t *testing.T) {
 	}{
 		{
 			name: "simple trial",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, false, false, false, 0,
-				RawData{"trial(PR=cf-eu10, HR=eu-west-2)", 0}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{"trial(PR=cf-eu10, HR=eu-west-2)", 0},
+			},
 			expected: Result{
 				HyperscalerType: "aws",
 				EUAccess:        false,
@@ -211,10 +287,17 @@ func TestValidRule_toResult(t *testing.T) {
 		},
 		{
 			name: "trial with all suffixes",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, true, true, true, 0,
-				RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,PR,HR", 0}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  false,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           0,
+				RawData:                 RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,PR,HR", 0},
+			},
 			expected: Result{
 				HyperscalerType: "aws_cf-eu10_eu-west-2",
 				EUAccess:        true,
@@ -227,10 +310,17 @@ func TestValidRule_toResult(t *testing.T) {
 		},
 		{
 			name: "trial with platform region suffix only",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, true, true, false, 0,
-				RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,PR", 0}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  false,
+				EuAccess:                true,
+				PlatformRegionSuffix:    true,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,PR", 0},
+			},
 			expected: Result{
 				HyperscalerType: "aws_cf-eu10",
 				EUAccess:        true,
@@ -243,10 +333,17 @@ func TestValidRule_toResult(t *testing.T) {
 		},
 		{
 			name: "trial with hyperscaler region suffix only",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, true, true, false, true, 44,
-				RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,HR", 0}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				Shared:                  true,
+				EuAccess:                true,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: true,
+				MatchAnyCount:           44,
+				RawData:                 RawData{"trial(PR=cf-eu10, HR=eu-west-2)->EU,HR", 0},
+			},
 			expected: Result{
 				HyperscalerType: "aws_eu-west-2",
 				EUAccess:        true,
@@ -277,60 +374,146 @@ func TestValidRule_Match(t *testing.T) {
 	}{
 		{
 			name: "specific trial",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: true,
 		},
 		{
 			name: "general trial",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "", matchAny: true}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: true,
 		},
 		{
 			name: "plan mismatch",
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-2"}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 		{
 			name: "plan mismatch",
-			input: &ValidRule{PatternAttribute{literal: "aws"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "", matchAny: true}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "aws"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 		{
 			name: "hyperscaler region mismatch",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-eu10"},
-				PatternAttribute{literal: "eu-west-1"}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-eu10"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-1"},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 		{
 			name: "hyperscaler region mismatch",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "", matchAny: true},
-				PatternAttribute{literal: "eu-west-1"}, false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "", matchAny: true},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-1"},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 		{
 			name: "platform region mismatch",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-jp30"},
-				PatternAttribute{literal: "eu-west-2"},
-				false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-jp30"},
+				HyperscalerRegion:       PatternAttribute{literal: "eu-west-2"},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 		{
 			name: "platform region mismatch",
-			input: &ValidRule{PatternAttribute{literal: "trial"},
-				PatternAttribute{literal: "cf-jp30"},
-				PatternAttribute{literal: "", matchAny: true},
-				false, false, false, false, 0, RawData{}},
+			input: &ValidRule{
+				Plan:                    PatternAttribute{literal: "trial"},
+				PlatformRegion:          PatternAttribute{literal: "cf-jp30"},
+				HyperscalerRegion:       PatternAttribute{literal: "", matchAny: true},
+				GlobalAccount:           PatternAttribute{matchAny: true},
+				SubaccountLabel:         PatternAttribute{matchAny: true},
+				MachineFamily:           PatternAttribute{matchAny: true},
+				Shared:                  false,
+				EuAccess:                false,
+				PlatformRegionSuffix:    false,
+				HyperscalerRegionSuffix: false,
+				MatchAnyCount:           0,
+				RawData:                 RawData{},
+			},
 			expected: false,
 		},
 	}
@@ -343,3 +526,31 @@ func TestValidRule_Match(t *testing.T) {
 		})
 	}
 }
+
+func TestPatternAttribute_MatchMachineFamily(t *testing.T) {
+	for name, tc := range map[string]struct {
+		family      string
+		hyperscaler string
+		machineType string
+		expected    bool
+	}{
+		"AWS family":                    {family: "m6i", hyperscaler: "aws", machineType: "m6i.large", expected: true},
+		"AWS family with common prefix": {family: "m6", hyperscaler: "aws", machineType: "m6i.large", expected: false},
+		"GCP family":                    {family: "n2", hyperscaler: "gcp", machineType: "n2-standard-4", expected: true},
+		"GCP family with common prefix": {family: "n2", hyperscaler: "gcp", machineType: "n2d-standard-4", expected: false},
+		"Azure family":                  {family: "Ds_v5", hyperscaler: "azure", machineType: "Standard_D4s_v5", expected: true},
+		"Alicloud family":               {family: "g8i", hyperscaler: "alicloud", machineType: "ecs.g8i.large", expected: true},
+		"other hyperscaler":             {family: "g_c2_m8", hyperscaler: "openstack", machineType: "g_c2_m8", expected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			attribute := PatternAttribute{literal: tc.family}
+
+			// when
+			matched := attribute.MatchMachineFamily(tc.hyperscaler, tc.machineType)
+
+			// then
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
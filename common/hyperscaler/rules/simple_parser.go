@@ -89,7 +89,7 @@ func (g *SimpleParser) Parse(ruleEntry string) (*Rule, error) {
 				return nil, fmt.Errorf("output attribute is empty")
 			}
 
-			err = outputRule.SetAttributeValue(outputAttr, "true", OutputAttributes)
+			err = outputRule.SetOutputAttribute(outputAttr)
 			if err != nil {
 				return nil, err
 			}
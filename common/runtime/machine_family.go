@@ -0,0 +1,39 @@
+package runtime
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// azureMachineTypeRegexp matches Azure VM sizes, for example "Standard_D4s_v5" or "Standard_NC4as_T4_v3", the vCPU count is
+// the first number after the family letters
+var azureMachineTypeRegexp = regexp.MustCompile(`^Standard_([A-Z]+)\d+(.*)$`)
+
+// MachineFamily returns the family of the machine type, machine types of a family differ only in size
+func MachineFamily(provider CloudProvider, machineType string) (string, error) {
+	switch provider {
+	case AWS:
+		// For AWS, machine types follow the pattern "<family>.<size>".
+		return strings.SplitN(machineType, ".", 2)[0], nil
+	case Azure:
+		// For Azure, machine types follow the pattern "Standard_<family letters><vCPUs><additive features>_<version>".
+		matches := azureMachineTypeRegexp.FindStringSubmatch(machineType)
+		if matches == nil {
+			return "", fmt.Errorf("unexpected %s machine type %s", provider, machineType)
+		}
+		return matches[1] + matches[2], nil
+	case GCP:
+		// For GCP, machine types follow the pattern "<family>-<type>-<vCPUs>".
+		return strings.SplitN(machineType, "-", 2)[0], nil
+	case Alicloud:
+		// For Alicloud, machine types follow the pattern "ecs.<family>.<size>".
+		parts := strings.Split(machineType, ".")
+		if len(parts) != 3 || parts[0] != "ecs" {
+			return "", fmt.Errorf("unexpected %s machine type %s", provider, machineType)
+		}
+		return parts[1], nil
+	default:
+		return "", fmt.Errorf("%s provider not supported", provider)
+	}
+}
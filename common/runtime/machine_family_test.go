@@ -0,0 +1,37 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineFamily(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider    CloudProvider
+		machineType string
+		expected    string
+	}{
+		"AWS":           {provider: AWS, machineType: "g4dn.2xlarge", expected: "g4dn"},
+		"Azure":         {provider: Azure, machineType: "Standard_D48_v3", expected: "D_v3"},
+		"Azure GPU":     {provider: Azure, machineType: "Standard_NC8as_T4_v3", expected: "NCas_T4_v3"},
+		"GCP":           {provider: GCP, machineType: "c2d-highcpu-56", expected: "c2d"},
+		"Alicloud":      {provider: Alicloud, machineType: "ecs.g9i.16xlarge", expected: "g9i"},
+		"Azure invalid": {provider: Azure, machineType: "D4s_v5"},
+		"SAP CC":        {provider: SapConvergedCloud, machineType: "g_c2_m8"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			family, err := MachineFamily(tc.provider, tc.machineType)
+
+			// then
+			if tc.expected == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, family)
+		})
+	}
+}
@@ -65,6 +65,7 @@ type RuntimeDTO struct {
 	CommercialModel             *string                   `json:"commercialModel,omitempty"`
 	Actions                     []Action                  `json:"actions,omitempty"`
 	EmptyUpdates                int                       `json:"emptyUpdates,omitempty"`
+	SubaccountLabels            map[string]string         `json:"subaccountLabels,omitempty"`
 }
 
 type CloudProvider string
@@ -351,6 +352,17 @@ type BindingDTO struct {
 	ExpiresAt         time.Time `json:"expiresAt"`
 	KubeconfigExists  bool      `json:"kubeconfigExists"`
 	CreatedBy         string    `json:"createdBy"`
+	// InstanceID, Role, CredentialType and State are set only in the bindings list
+	InstanceID     string `json:"instanceID,omitempty"`
+	Role           string `json:"role,omitempty"`
+	CredentialType string `json:"credentialType,omitempty"`
+	State          string `json:"state,omitempty"`
+}
+
+type BindingsPage struct {
+	Data       []BindingDTO `json:"data"`
+	Count      int          `json:"count"`
+	TotalCount int          `json:"totalCount"`
 }
 
 type ActionType string
@@ -358,6 +370,8 @@ type ActionType string
 const (
 	PlanUpdateActionType         ActionType = "plan_update"
 	SubaccountMovementActionType ActionType = "subaccount_movement"
+	OperationCancelActionType    ActionType = "operation_cancel"
+	OperationRetryActionType     ActionType = "operation_retry"
 )
 
 type Action struct {
@@ -413,6 +427,31 @@ type Operation struct {
 	Parameters                   ProvisioningParametersDTO `json:"parameters,omitempty"`
 	Error                        *kebError.LastError       `json:"error,omitempty"`
 	UpdatedPlanName              string                    `json:"updatedPlanName,omitempty"`
+	Steps                        []OperationStep           `json:"steps,omitempty"`
+}
+
+// OperationStep describes runs of a single operation step, StartedAt is the start of the first run
+type OperationStep struct {
+	Stage           string     `json:"stage"`
+	StepName        string     `json:"stepName"`
+	State           string     `json:"state"`
+	Attempts        int        `json:"attempts"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds"`
+	LastError       string     `json:"lastError,omitempty"`
+}
+
+// OperationTimeline presents steps of the operation in the order they were started
+type OperationTimeline struct {
+	OperationID     string          `json:"operationID"`
+	InstanceID      string          `json:"instanceID"`
+	Type            string          `json:"type"`
+	State           string          `json:"state"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+	DurationSeconds float64         `json:"durationSeconds"`
+	Steps           []OperationStep `json:"steps"`
 }
 
 type RuntimesPage struct {
@@ -439,6 +478,9 @@ const (
 	BindingsParam        = "bindings"
 	WithBindingsParam    = "with_bindings"
 	ActionsParam         = "actions"
+
+	BindingCreatedByParam  = "createdBy"
+	BindingInstanceIDParam = "instanceID"
 )
 
 type OperationDetail string
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/middleware"
+)
+
+type Permission string
+
+const (
+	PermissionReadRuntimes     Permission = "readRuntimes"
+	PermissionExpire           Permission = "expire"
+	PermissionReadKubeconfig   Permission = "readKubeconfig"
+	PermissionManageOperations Permission = "manageOperations"
+)
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+type Config struct {
+	// Enabled enables validation of access tokens on admin endpoints, otherwise the endpoints rely on the external gateway
+	Enabled bool `envconfig:"default=false"`
+	// IssuerURL is the URL of the OIDC issuer of access tokens, the signing keys are discovered from its configuration
+	IssuerURL string `envconfig:"optional"`
+	// Audience is the required audience of access tokens, the audience is not checked if empty
+	Audience string `envconfig:"optional"`
+	// GroupsClaim is the name of the claim with groups of the token subject
+	GroupsClaim string `envconfig:"default=groups"`
+	// KeysRefreshInterval is the time after which the cached signing keys are fetched again
+	KeysRefreshInterval time.Duration `envconfig:"default=1h"`
+	// ReadRuntimesGrants, ExpireGrants, ReadKubeconfigGrants and ManageOperationsGrants are scopes or groups which grant the permission
+	ReadRuntimesGrants     []string `envconfig:"optional"`
+	ExpireGrants           []string `envconfig:"optional"`
+	ReadKubeconfigGrants   []string `envconfig:"optional"`
+	ManageOperationsGrants []string `envconfig:"optional"`
+}
+
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.IssuerURL == "" {
+		return fmt.Errorf("the issuer URL is required when admin endpoints authorization is enabled")
+	}
+	if c.KeysRefreshInterval <= 0 {
+		return fmt.Errorf("the signing keys refresh interval must be positive")
+	}
+	return nil
+}
+
+// Authorizer validates access tokens issued by the OIDC issuer and checks that scopes or groups of the token grant
+// the permission required by the endpoint
+type Authorizer struct {
+	config Config
+	keys   *KeySet
+	grants map[Permission][]string
+	parser *jwt.Parser
+	logger *slog.Logger
+}
+
+func NewAuthorizer(cfg Config, client *http.Client, logger *slog.Logger) (*Authorizer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Authorizer{
+		config: cfg,
+		keys:   NewKeySet(cfg.IssuerURL, cfg.KeysRefreshInterval, client),
+		grants: map[Permission][]string{
+			PermissionReadRuntimes:     nonEmpty(cfg.ReadRuntimesGrants),
+			PermissionExpire:           nonEmpty(cfg.ExpireGrants),
+			PermissionReadKubeconfig:   nonEmpty(cfg.ReadKubeconfigGrants),
+			PermissionManageOperations: nonEmpty(cfg.ManageOperationsGrants),
+		},
+		parser: jwt.NewParser(jwt.WithValidMethods(signingMethods)),
+		logger: logger.With("service", "AdminAuthorizer"),
+	}, nil
+}
+
+// Require returns the middleware which rejects requests without a valid access token granting the permission,
+// requests are passed through if the authorization is disabled
+func (a *Authorizer) Require(permission Permission) middleware.MiddlewareFunc {
+	return a.require(func(*http.Request) Permission {
+		return permission
+	})
+}
+
+// RequireByMethod returns the middleware which requires the read permission for GET and HEAD requests
+// and the modify permission for other requests
+func (a *Authorizer) RequireByMethod(read, modify Permission) middleware.MiddlewareFunc {
+	return a.require(func(req *http.Request) Permission {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return read
+		}
+		return modify
+	})
+}
+
+func (a *Authorizer) require(permissionOf func(req *http.Request) Permission) middleware.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !a.config.Enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			permission := permissionOf(req)
+			claims, err := a.authenticate(req)
+			switch {
+			case errors.Is(err, errKeysUnavailable):
+				a.logger.Error(fmt.Sprintf("unable to verify access token for %s %s: %s", req.Method, req.URL.Path, err))
+				httputil.WriteErrorResponse(w, http.StatusServiceUnavailable, fmt.Errorf("unable to verify the access token"))
+				return
+			case err != nil:
+				a.logDenied(req, permission, "", err.Error())
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				httputil.WriteErrorResponse(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid access token"))
+				return
+			}
+
+			if !a.granted(claims, permission) {
+				subject, _ := claims["sub"].(string)
+				a.logDenied(req, permission, subject, "the token does not grant the permission")
+				httputil.WriteErrorResponse(w, http.StatusForbidden, fmt.Errorf("the access token does not grant the %s permission", permission))
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func (a *Authorizer) authenticate(req *http.Request) (jwt.MapClaims, error) {
+	header := req.Header.Get("Authorization")
+	raw, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("missing bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(strings.TrimSpace(raw), claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return a.keys.Key(req.Context(), kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("invalid token: missing expiration time")
+	}
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != strings.TrimSuffix(a.config.IssuerURL, "/") {
+		return nil, fmt.Errorf("invalid token: unexpected issuer %q", iss)
+	}
+	if a.config.Audience != "" && !claims.VerifyAudience(a.config.Audience, true) {
+		return nil, fmt.Errorf("invalid token: audience does not contain %q", a.config.Audience)
+	}
+	return claims, nil
+}
+
+func (a *Authorizer) granted(claims jwt.MapClaims, permission Permission) bool {
+	values := append(stringClaim(claims["scope"]), stringClaim(claims["scp"])...)
+	values = append(values, stringClaim(claims[a.config.GroupsClaim])...)
+	for _, grant := range a.grants[permission] {
+		for _, value := range values {
+			if value == grant {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Authorizer) logDenied(req *http.Request, permission Permission, subject, reason string) {
+	a.logger.Warn(fmt.Sprintf("Access denied: method=%s path=%s permission=%s subject=%q reason=%s", req.Method, req.URL.Path, permission, subject, reason))
+}
+
+// nonEmpty drops empty grants, which are set by envconfig for empty environment variables
+func nonEmpty(grants []string) []string {
+	result := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		if grant = strings.TrimSpace(grant); grant != "" {
+			result = append(result, grant)
+		}
+	}
+	return result
+}
+
+// stringClaim returns values of a claim which is either a space-separated string or an array of strings
+func stringClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizer_Require(t *testing.T) {
+	issuer, err := NewFakeIssuer()
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	authorizer, err := NewAuthorizer(Config{
+		Enabled:                true,
+		IssuerURL:              issuer.URL,
+		Audience:               "keb",
+		GroupsClaim:            "groups",
+		KeysRefreshInterval:    time.Hour,
+		ReadRuntimesGrants:     []string{"keb:runtimes:read"},
+		ExpireGrants:           []string{"keb-operators"},
+		ReadKubeconfigGrants:   []string{"keb:kubeconfig:read"},
+		ManageOperationsGrants: []string{"keb:operations:manage"},
+	}, http.DefaultClient, log)
+	require.NoError(t, err)
+
+	router := httputil.NewRouter()
+	operationsRouter := router.With(authorizer.RequireByMethod(PermissionReadRuntimes, PermissionManageOperations))
+	operationsRouter.HandleFunc("GET /operations/{operation_id}/timeline", okHandler)
+	operationsRouter.HandleFunc("POST /operations/{operation_id}/cancel", okHandler)
+	router.With(authorizer.Require(PermissionReadRuntimes)).HandleFunc("GET /runtimes", okHandler)
+	router.With(authorizer.Require(PermissionExpire)).HandleFunc("PUT /expire/service_instance/{instance_id}", okHandler)
+	router.HandleFunc("GET /version", okHandler)
+
+	for name, tc := range map[string]struct {
+		method       string
+		path         string
+		claims       jwt.MapClaims
+		expectedCode int
+	}{
+		"scope granting the permission": {
+			method:       http.MethodGet,
+			path:         "/runtimes",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "openid keb:runtimes:read"},
+			expectedCode: http.StatusOK,
+		},
+		"scope array granting the permission": {
+			method:       http.MethodGet,
+			path:         "/runtimes",
+			claims:       jwt.MapClaims{"aud": []string{"other", "keb"}, "scp": []string{"keb:runtimes:read"}},
+			expectedCode: http.StatusOK,
+		},
+		"group granting the permission": {
+			method:       http.MethodPut,
+			path:         "/expire/service_instance/instance-1",
+			claims:       jwt.MapClaims{"aud": "keb", "groups": []string{"keb-operators"}},
+			expectedCode: http.StatusOK,
+		},
+		"token without the permission": {
+			method:       http.MethodPut,
+			path:         "/expire/service_instance/instance-1",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "keb:runtimes:read"},
+			expectedCode: http.StatusForbidden,
+		},
+		"token for another audience": {
+			method:       http.MethodGet,
+			path:         "/runtimes",
+			claims:       jwt.MapClaims{"aud": "other", "scope": "keb:runtimes:read"},
+			expectedCode: http.StatusUnauthorized,
+		},
+		"token of another issuer": {
+			method:       http.MethodGet,
+			path:         "/runtimes",
+			claims:       jwt.MapClaims{"iss": "https://other.example.com", "aud": "keb", "scope": "keb:runtimes:read"},
+			expectedCode: http.StatusUnauthorized,
+		},
+		"expired token": {
+			method:       http.MethodGet,
+			path:         "/runtimes",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "keb:runtimes:read", "exp": time.Now().Add(-time.Minute).Unix()},
+			expectedCode: http.StatusUnauthorized,
+		},
+		"read permission granting the read request": {
+			method:       http.MethodGet,
+			path:         "/operations/operation-1/timeline",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "keb:runtimes:read"},
+			expectedCode: http.StatusOK,
+		},
+		"read permission not granting the modifying request": {
+			method:       http.MethodPost,
+			path:         "/operations/operation-1/cancel",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "keb:runtimes:read"},
+			expectedCode: http.StatusForbidden,
+		},
+		"manage permission granting the modifying request": {
+			method:       http.MethodPost,
+			path:         "/operations/operation-1/cancel",
+			claims:       jwt.MapClaims{"aud": "keb", "scope": "keb:operations:manage"},
+			expectedCode: http.StatusOK,
+		},
+		"unprotected route": {
+			method:       http.MethodGet,
+			path:         "/version",
+			expectedCode: http.StatusOK,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.claims != nil {
+				token, err := issuer.Token(tc.claims)
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(resp, req)
+
+			// then
+			assert.Equal(t, tc.expectedCode, resp.Code)
+		})
+	}
+
+	t.Run("should reject request without token", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(resp, req)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Equal(t, "Bearer", resp.Header().Get("WWW-Authenticate"))
+		assert.JSONEq(t, `{"error":"missing or invalid access token"}`, resp.Body.String())
+	})
+
+	t.Run("should reject token signed with unknown key", func(t *testing.T) {
+		// given
+		other, err := NewFakeIssuer()
+		require.NoError(t, err)
+		defer other.Close()
+		token, err := other.Token(jwt.MapClaims{"iss": issuer.URL, "aud": "keb", "scope": "keb:runtimes:read"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(resp, req)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+}
+
+func TestAuthorizer_RequireDisabled(t *testing.T) {
+	// given
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	authorizer, err := NewAuthorizer(Config{}, http.DefaultClient, log)
+	require.NoError(t, err)
+
+	router := httputil.NewRouter()
+	router.With(authorizer.Require(PermissionReadRuntimes)).HandleFunc("GET /runtimes", okHandler)
+	req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+	resp := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(resp, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestAuthorizer_IssuerUnavailable(t *testing.T) {
+	// given
+	issuer, err := NewFakeIssuer()
+	require.NoError(t, err)
+	token, err := issuer.Token(jwt.MapClaims{"scope": "keb:runtimes:read"})
+	require.NoError(t, err)
+	issuer.Close()
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	authorizer, err := NewAuthorizer(Config{
+		Enabled:             true,
+		IssuerURL:           issuer.URL,
+		KeysRefreshInterval: time.Hour,
+		ReadRuntimesGrants:  []string{"keb:runtimes:read"},
+	}, http.DefaultClient, log)
+	require.NoError(t, err)
+
+	router := httputil.NewRouter()
+	router.With(authorizer.Require(PermissionReadRuntimes)).HandleFunc("GET /runtimes", okHandler)
+	req := httptest.NewRequest(http.MethodGet, "/runtimes", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(resp, req)
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.EqualError(t, Config{Enabled: true, KeysRefreshInterval: time.Hour}.Validate(), "the issuer URL is required when admin endpoints authorization is enabled")
+	assert.EqualError(t, Config{Enabled: true, IssuerURL: "https://issuer.example.com"}.Validate(), "the signing keys refresh interval must be positive")
+}
+
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+)
+
+const fakeJWKSPath = "/keys"
+
+// FakeIssuer is an in-process OIDC issuer which serves its discovery document and signing keys and issues signed tokens
+type FakeIssuer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	keys         map[string]*rsa.PrivateKey
+	currentKID   string
+	jwksRequests int
+}
+
+func NewFakeIssuer() (*FakeIssuer, error) {
+	issuer := &FakeIssuer{keys: make(map[string]*rsa.PrivateKey)}
+	if err := issuer.RotateKey(); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, issuer.getDiscoveryDocument)
+	mux.HandleFunc("GET "+fakeJWKSPath, issuer.getKeys)
+	issuer.Server = httptest.NewServer(mux)
+
+	return issuer, nil
+}
+
+// RotateKey adds a new signing key which signs the next tokens, previous keys are still served
+func (f *FakeIssuer) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("while generating signing key: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.currentKID = fmt.Sprintf("key-%d", len(f.keys)+1)
+	f.keys[f.currentKID] = key
+	return nil
+}
+
+// Token returns a token signed with the current key, the issuer and a one hour expiration time are set if not given
+func (f *FakeIssuer) Token(claims jwt.MapClaims) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	all := jwt.MapClaims{
+		"iss": f.URL,
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	for name, value := range claims {
+		all[name] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, all)
+	token.Header["kid"] = f.currentKID
+	return token.SignedString(f.keys[f.currentKID])
+}
+
+// JWKSRequests returns the number of requests for the signing keys
+func (f *FakeIssuer) JWKSRequests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.jwksRequests
+}
+
+func (f *FakeIssuer) getDiscoveryDocument(w http.ResponseWriter, _ *http.Request) {
+	httputil.WriteResponse(w, http.StatusOK, discoveryDocument{
+		Issuer:  f.URL,
+		JWKSURI: f.URL + fakeJWKSPath,
+	})
+}
+
+func (f *FakeIssuer) getKeys(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jwksRequests++
+
+	keySet := jsonWebKeySet{}
+	for kid, key := range f.keys {
+		keySet.Keys = append(keySet.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	httputil.WriteResponse(w, http.StatusOK, keySet)
+}
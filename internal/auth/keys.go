@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// minRefreshInterval limits refreshes triggered by tokens signed with unknown keys and retries of failed refreshes
+	minRefreshInterval = time.Minute
+)
+
+var errKeysUnavailable = errors.New("signing keys of the issuer are not available")
+
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet caches signing keys of the OIDC issuer. The JWKS URI is discovered from the issuer configuration, keys are fetched
+// on the first use and refreshed in the configured interval or when a token is signed with an unknown key.
+// Concurrent requests share one refresh and the cached keys are not locked while the issuer is called.
+type KeySet struct {
+	issuerURL       string
+	client          *http.Client
+	refreshInterval time.Duration
+	refreshGroup    singleflight.Group
+
+	mu          sync.Mutex
+	jwksURI     string
+	keys        map[string]crypto.PublicKey
+	refreshedAt time.Time
+	failedAt    time.Time
+	lastErr     error
+
+	now func() time.Time
+}
+
+func NewKeySet(issuerURL string, refreshInterval time.Duration, client *http.Client) *KeySet {
+	return &KeySet{
+		issuerURL:       strings.TrimSuffix(issuerURL, "/"),
+		client:          client,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// Key returns the key with the given ID, an empty ID matches the only key of the issuer
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if k.refreshDue(kid) {
+		// the refresh is not canceled with the request which started it, because other requests wait for it
+		_, _, _ = k.refreshGroup.Do("refresh", func() (interface{}, error) {
+			return nil, k.refresh(context.WithoutCancel(ctx))
+		})
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.keys == nil {
+		return nil, fmt.Errorf("%w: %w", errKeysUnavailable, k.lastErr)
+	}
+	key, found := k.lookup(kid)
+	if !found {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+	return key, nil
+}
+
+func (k *KeySet) refreshDue(kid string) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if now.Sub(k.failedAt) < minRefreshInterval {
+		return false
+	}
+	if k.keys == nil || now.Sub(k.refreshedAt) >= k.refreshInterval {
+		return true
+	}
+	_, found := k.lookup(kid)
+	return !found && now.Sub(k.refreshedAt) >= minRefreshInterval
+}
+
+func (k *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, found := k.keys[kid]
+	return key, found
+}
+
+// refresh fetches the keys and replaces the cached ones, the keys fetched before are used until the issuer is available again
+func (k *KeySet) refresh(ctx context.Context) error {
+	keys, err := k.fetch(ctx)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err != nil {
+		k.failedAt = k.now()
+		k.lastErr = err
+		return err
+	}
+	k.keys = keys
+	k.refreshedAt = k.now()
+	return nil
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	k.mu.Lock()
+	jwksURI := k.jwksURI
+	k.mu.Unlock()
+
+	if jwksURI == "" {
+		var discovery discoveryDocument
+		if err := k.get(ctx, k.issuerURL+discoveryPath, &discovery); err != nil {
+			return nil, fmt.Errorf("while discovering the issuer configuration: %w", err)
+		}
+		if strings.TrimSuffix(discovery.Issuer, "/") != k.issuerURL {
+			return nil, fmt.Errorf("issuer %s of the discovery document does not match the configured issuer %s", discovery.Issuer, k.issuerURL)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("the discovery document of the issuer %s does not contain jwks_uri", k.issuerURL)
+		}
+		jwksURI = discovery.JWKSURI
+		k.mu.Lock()
+		k.jwksURI = jwksURI
+		k.mu.Unlock()
+	}
+
+	var keySet jsonWebKeySet
+	if err := k.get(ctx, jwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("while fetching signing keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// keys of unsupported types (e.g. OKP) or invalid keys cannot verify tokens, other keys of the issuer are still used
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k *KeySet) get(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s responded with status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("while decoding response of GET %s: %w", url, err)
+	}
+	return nil
+}
+
+func (j jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
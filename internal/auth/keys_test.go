@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Key(t *testing.T) {
+	t.Run("should cache keys until the refresh interval passes", func(t *testing.T) {
+		// given
+		issuer, err := NewFakeIssuer()
+		require.NoError(t, err)
+		defer issuer.Close()
+
+		now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+		keySet := NewKeySet(issuer.URL, time.Hour, http.DefaultClient)
+		keySet.now = func() time.Time { return now }
+
+		// when
+		_, err = keySet.Key(context.Background(), "key-1")
+		require.NoError(t, err)
+		_, err = keySet.Key(context.Background(), "key-1")
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 1, issuer.JWKSRequests())
+
+		// when
+		now = now.Add(time.Hour)
+		_, err = keySet.Key(context.Background(), "key-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, issuer.JWKSRequests())
+	})
+
+	t.Run("should fetch rotated keys", func(t *testing.T) {
+		// given
+		issuer, err := NewFakeIssuer()
+		require.NoError(t, err)
+		defer issuer.Close()
+
+		now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+		keySet := NewKeySet(issuer.URL, time.Hour, http.DefaultClient)
+		keySet.now = func() time.Time { return now }
+		_, err = keySet.Key(context.Background(), "key-1")
+		require.NoError(t, err)
+		require.NoError(t, issuer.RotateKey())
+
+		// when
+		_, err = keySet.Key(context.Background(), "key-2")
+
+		// then
+		assert.EqualError(t, err, `signing key "key-2" not found`)
+		assert.Equal(t, 1, issuer.JWKSRequests())
+
+		// when
+		now = now.Add(minRefreshInterval)
+		_, err = keySet.Key(context.Background(), "key-2")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 2, issuer.JWKSRequests())
+	})
+
+	t.Run("should use cached keys when the issuer is not available", func(t *testing.T) {
+		// given
+		issuer, err := NewFakeIssuer()
+		require.NoError(t, err)
+
+		now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+		keySet := NewKeySet(issuer.URL, time.Hour, http.DefaultClient)
+		keySet.now = func() time.Time { return now }
+		_, err = keySet.Key(context.Background(), "key-1")
+		require.NoError(t, err)
+		issuer.Close()
+
+		// when
+		now = now.Add(2 * time.Hour)
+		_, err = keySet.Key(context.Background(), "key-1")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should report unavailable keys when the discovery fails", func(t *testing.T) {
+		// given
+		issuer, err := NewFakeIssuer()
+		require.NoError(t, err)
+		defer issuer.Close()
+
+		keySet := NewKeySet(issuer.URL+"/tenant", time.Hour, http.DefaultClient)
+
+		// when
+		_, err = keySet.Key(context.Background(), "key-1")
+
+		// then
+		assert.ErrorIs(t, err, errKeysUnavailable)
+	})
+
+	t.Run("should share one refresh between concurrent requests", func(t *testing.T) {
+		// given
+		release := make(chan struct{})
+		issuer, requests := newKeysServer(t, release, jsonWebKey{Kty: "RSA", Kid: "key-1", N: rsaModulus(t), E: "AQAB"})
+		defer issuer.Close()
+		keySet := NewKeySet(issuer.URL, time.Hour, http.DefaultClient)
+
+		// when
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := keySet.Key(context.Background(), "key-1")
+				errs <- err
+			}()
+		}
+		assert.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(errs)
+
+		// then
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("should skip keys of unsupported types", func(t *testing.T) {
+		// given
+		release := make(chan struct{})
+		close(release)
+		issuer, _ := newKeysServer(t, release,
+			jsonWebKey{Kty: "OKP", Kid: "key-ed25519", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+			jsonWebKey{Kty: "RSA", Kid: "key-1", N: rsaModulus(t), E: "AQAB"},
+		)
+		defer issuer.Close()
+		keySet := NewKeySet(issuer.URL, time.Hour, http.DefaultClient)
+
+		// when
+		_, err := keySet.Key(context.Background(), "key-1")
+
+		// then
+		assert.NoError(t, err)
+		_, err = keySet.Key(context.Background(), "key-ed25519")
+		assert.EqualError(t, err, `signing key "key-ed25519" not found`)
+	})
+}
+
+// newKeysServer serves the discovery document and the given keys, responses with keys wait until release is closed
+func newKeysServer(t *testing.T, release chan struct{}, keys ...jsonWebKey) (*httptest.Server, *atomic.Int32) {
+	requests := &atomic.Int32{}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	mux.HandleFunc("GET "+discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteResponse(w, http.StatusOK, discoveryDocument{Issuer: server.URL, JWKSURI: server.URL + fakeJWKSPath})
+	})
+	mux.HandleFunc("GET "+fakeJWKSPath, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		<-release
+		httputil.WriteResponse(w, http.StatusOK, jsonWebKeySet{Keys: keys})
+	})
+	return server, requests
+}
+
+func rsaModulus(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+}
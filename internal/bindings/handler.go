@@ -0,0 +1,107 @@
+package bindings
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+)
+
+type router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Handler lists bindings of all instances, credentials are never returned
+type Handler struct {
+	bindings       storage.Bindings
+	defaultMaxPage int
+	log            *slog.Logger
+}
+
+func NewHandler(bindings storage.Bindings, defaultMaxPage int, log *slog.Logger) *Handler {
+	return &Handler{
+		bindings:       bindings,
+		defaultMaxPage: defaultMaxPage,
+		log:            log.With("service", "BindingsHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(r router) {
+	r.HandleFunc("GET /bindings", h.listBindings)
+}
+
+func (h *Handler) listBindings(w http.ResponseWriter, req *http.Request) {
+	pageSize, page, err := pagination.ExtractPaginationConfigFromRequest(req, h.defaultMaxPage)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
+		return
+	}
+	filter, err := getFilter(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while getting query parameters: %w", err))
+		return
+	}
+	filter.Page = page
+	filter.PageSize = pageSize
+
+	bindings, count, totalCount, err := h.bindings.List(filter)
+	if err != nil {
+		h.log.Warn(fmt.Sprintf("unable to fetch bindings: %s", err.Error()))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while fetching bindings: %w", err))
+		return
+	}
+
+	data := make([]pkg.BindingDTO, 0, len(bindings))
+	for _, binding := range bindings {
+		data = append(data, toBindingDTO(binding))
+	}
+	httputil.WriteResponse(w, http.StatusOK, pkg.BindingsPage{
+		Data:       data,
+		Count:      count,
+		TotalCount: totalCount,
+	})
+}
+
+func getFilter(req *http.Request) (dbmodel.BindingFilter, error) {
+	query := req.URL.Query()
+	filter := dbmodel.BindingFilter{
+		InstanceIDs: query[pkg.BindingInstanceIDParam],
+		CreatedBy:   query[pkg.BindingCreatedByParam],
+	}
+	if value := query.Get(pkg.ExpiredParam); value != "" {
+		expired, err := strconv.ParseBool(value)
+		if err != nil {
+			return dbmodel.BindingFilter{}, fmt.Errorf("%s has to be a boolean", pkg.ExpiredParam)
+		}
+		filter.Expired = &expired
+	}
+	return filter, nil
+}
+
+func toBindingDTO(binding internal.Binding) pkg.BindingDTO {
+	// bindings created before the state was tracked were always created synchronously
+	state := binding.State
+	if state == "" {
+		state = domain.Succeeded
+	}
+	return pkg.BindingDTO{
+		ID:                binding.ID,
+		InstanceID:        binding.InstanceID,
+		CreatedAt:         binding.CreatedAt,
+		ExpirationSeconds: binding.ExpirationSeconds,
+		ExpiresAt:         binding.ExpiresAt,
+		KubeconfigExists:  state == domain.Succeeded,
+		CreatedBy:         binding.CreatedBy,
+		Role:              binding.Scope.RoleOrDefault(),
+		CredentialType:    binding.CredentialTypeOrDefault(),
+		State:             string(state),
+	}
+}
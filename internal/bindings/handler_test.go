@@ -0,0 +1,133 @@
+package bindings_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBindings(t *testing.T) {
+	db := storage.NewMemoryStorage()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	router := httputil.NewRouter()
+	bindings.NewHandler(db.Bindings(), 100, logger).AttachRoutes(router)
+
+	now := time.Now()
+	for _, binding := range []internal.Binding{
+		{ID: "binding-1", InstanceID: "instance-1", CreatedBy: "john@example.com", CreatedAt: now.Add(-3 * time.Hour), ExpiresAt: now.Add(-2 * time.Hour), Kubeconfig: "secret"},
+		{ID: "binding-2", InstanceID: "instance-1", CreatedBy: "jane@example.com", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(time.Hour), Kubeconfig: "secret",
+			Scope: internal.BindingScope{Role: internal.BindingRoleView}, State: domain.Succeeded},
+		{ID: "binding-3", InstanceID: "instance-2", CreatedBy: "john@example.com", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour), State: domain.InProgress},
+	} {
+		require.NoError(t, db.Bindings().Insert(&binding))
+	}
+
+	for name, tc := range map[string]struct {
+		query       string
+		expectedIDs []string
+	}{
+		"all bindings": {
+			query:       "",
+			expectedIDs: []string{"binding-3", "binding-2", "binding-1"},
+		},
+		"bindings created by the user": {
+			query:       "?createdBy=john@example.com",
+			expectedIDs: []string{"binding-3", "binding-1"},
+		},
+		"bindings of the instance": {
+			query:       "?instanceID=instance-1",
+			expectedIDs: []string{"binding-2", "binding-1"},
+		},
+		"expired bindings": {
+			query:       "?expired=true",
+			expectedIDs: []string{"binding-1"},
+		},
+		"not expired bindings created by the user": {
+			query:       "?expired=false&createdBy=john@example.com",
+			expectedIDs: []string{"binding-3"},
+		},
+	} {
+		t.Run("should list "+name, func(t *testing.T) {
+			// given
+			req := httptest.NewRequest(http.MethodGet, "/bindings"+tc.query, nil)
+			w := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(w, req)
+
+			// then
+			require.Equal(t, http.StatusOK, w.Code)
+			var page pkg.BindingsPage
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+			var ids []string
+			for _, binding := range page.Data {
+				ids = append(ids, binding.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.Equal(t, len(tc.expectedIDs), page.TotalCount)
+		})
+	}
+
+	t.Run("should return binding details without credentials", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/bindings?instanceID=instance-1", nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret")
+		var page pkg.BindingsPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, "instance-1", page.Data[0].InstanceID)
+		assert.Equal(t, "jane@example.com", page.Data[0].CreatedBy)
+		assert.Equal(t, internal.BindingRoleView, page.Data[0].Role)
+		assert.Equal(t, internal.BindingCredentialTypeServiceAccount, page.Data[0].CredentialType)
+		assert.Equal(t, string(domain.Succeeded), page.Data[0].State)
+		assert.Equal(t, internal.BindingRoleClusterAdmin, page.Data[1].Role)
+	})
+
+	t.Run("should paginate bindings", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/bindings?page_size=1", nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		require.Equal(t, http.StatusOK, w.Code)
+		var page pkg.BindingsPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, 1, page.Count)
+		assert.Equal(t, 3, page.TotalCount)
+		assert.Equal(t, "binding-3", page.Data[0].ID)
+	})
+
+	t.Run("should reject invalid expired parameter", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/bindings?expired=maybe", nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -23,6 +23,8 @@ import (
 
 const (
 	expiresAtLayout = "2006-01-02T15:04:05.0Z"
+
+	bindOperationData = "bind"
 )
 
 type BindingConfig struct {
@@ -33,6 +35,21 @@ type BindingConfig struct {
 	MinExpirationSeconds int           `envconfig:"default=600"`
 	MaxBindingsCount     int           `envconfig:"default=10"`
 	CreateBindingTimeout time.Duration `envconfig:"default=15s"`
+	// DefaultRole is granted to bindings created without the role parameter
+	DefaultRole  string     `envconfig:"default=cluster-admin"`
+	AllowedRoles StringList `envconfig:"default=cluster-admin,view,edit,namespace-admin"`
+	// CustomRuleResources lists resources in the <apiGroup>/<resource> format allowed in rules of the custom role, the core API group is empty
+	CustomRuleResources StringList `envconfig:"optional"`
+	CustomRuleVerbs     StringList `envconfig:"default=get,list,watch"`
+	// AsyncEnabled allows processing bindings in the background if the platform accepts incomplete operations
+	AsyncEnabled       bool          `envconfig:"default=false"`
+	AsyncWorkersAmount int           `envconfig:"default=5"`
+	AsyncRetryInterval time.Duration `envconfig:"default=10s"`
+	AsyncMaxRetryTime  time.Duration `envconfig:"default=10m"`
+	// AllowedCredentialTypes lists credential types which can be requested with the credentialType parameter
+	AllowedCredentialTypes StringList `envconfig:"default=service-account"`
+	// ExpirationReportInterval is the interval of checks storing events of expired bindings
+	ExpirationReportInterval time.Duration `envconfig:"default=1m"`
 }
 
 type BindEndpoint struct {
@@ -41,8 +58,10 @@ type BindEndpoint struct {
 	bindingsStorage   storage.Bindings
 	operationsStorage storage.Operations
 
-	serviceAccountBindingManager broker.BindingsManager
-	publisher                    event.Publisher
+	processor *BindingProcessor
+	queue     Queue
+	publisher event.Publisher
+	policies  BindingPolicies
 
 	log *slog.Logger
 }
@@ -62,25 +81,38 @@ func (b *BindingContext) CreatedBy() string {
 }
 
 type BindingParams struct {
-	ExpirationSeconds int `json:"expiration_seconds,omit"`
+	ExpirationSeconds int                    `json:"expiration_seconds,omit"`
+	Role              string                 `json:"role,omitempty"`
+	Namespaces        []string               `json:"namespaces,omitempty"`
+	Rules             []internal.BindingRule `json:"rules,omitempty"`
+	CredentialType    string                 `json:"credentialType,omitempty"`
 }
 
 type Credentials struct {
 	Kubeconfig string `json:"kubeconfig"`
 }
 
-func NewBind(cfg BindingConfig, db storage.BrokerStorage, log *slog.Logger, clientProvider broker.ClientProvider, kubeconfigProvider broker.KubeconfigProvider,
-	publisher event.Publisher) *BindEndpoint {
+func NewBind(cfg BindingConfig, db storage.BrokerStorage, log *slog.Logger, bindingsManagers broker.BindingsManagers, publisher event.Publisher) *BindEndpoint {
 	return &BindEndpoint{config: cfg,
-		instancesStorage:             db.Instances(),
-		bindingsStorage:              db.Bindings(),
-		publisher:                    publisher,
-		operationsStorage:            db.Operations(),
-		log:                          log.With("service", "BindEndpoint"),
-		serviceAccountBindingManager: broker.NewServiceAccountBindingsManager(clientProvider, kubeconfigProvider),
+		instancesStorage:  db.Instances(),
+		bindingsStorage:   db.Bindings(),
+		publisher:         publisher,
+		operationsStorage: db.Operations(),
+		log:               log.With("service", "BindEndpoint"),
+		processor:         NewBindingProcessor(cfg, db, bindingsManagers, publisher, log),
 	}
 }
 
+// UseAsyncQueue enables asynchronous bindings, the queue must process items with the BindingProcessor
+func (b *BindEndpoint) UseAsyncQueue(queue Queue) {
+	b.queue = queue
+}
+
+// UseBindingPolicies overrides binding limits from the configuration for plans, global accounts and subaccounts
+func (b *BindEndpoint) UseBindingPolicies(policies BindingPolicies) {
+	b.policies = policies
+}
+
 // Bind creates a new service binding
 //
 //	PUT /v2/service_instances/{instance_id}/service_bindings/{binding_id}
@@ -138,11 +170,15 @@ func (b *BindEndpoint) bind(ctx context.Context, instanceID, bindingID string, d
 		}
 	}
 
+	limits := b.policies.LimitsFor(b.config, instance)
 	expirationSeconds := b.config.ExpirationSeconds
+	if limits.MaxExpirationSecondsPolicy != defaultBindingPolicyName {
+		expirationSeconds = min(expirationSeconds, limits.MaxExpirationSeconds)
+	}
 	if parameters.ExpirationSeconds != 0 {
-		if parameters.ExpirationSeconds > b.config.MaxExpirationSeconds {
-			message := fmt.Sprintf("expiration_seconds cannot be greater than %d", b.config.MaxExpirationSeconds)
-			return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message)
+		if parameters.ExpirationSeconds > limits.MaxExpirationSeconds {
+			message := fmt.Sprintf("expiration_seconds cannot be greater than %d", limits.MaxExpirationSeconds)
+			return domain.Binding{}, b.rejectByPolicy(ctx, message, BindingRejectionReasonMaxExpirationSeconds, limits.MaxExpirationSecondsPolicy)
 		}
 		if parameters.ExpirationSeconds < b.config.MinExpirationSeconds {
 			message := fmt.Sprintf("expiration_seconds cannot be less than %d", b.config.MinExpirationSeconds)
@@ -151,6 +187,16 @@ func (b *BindEndpoint) bind(ctx context.Context, instanceID, bindingID string, d
 		expirationSeconds = parameters.ExpirationSeconds
 	}
 
+	credentialType, err := b.credentialType(parameters)
+	if err != nil {
+		return domain.Binding{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+	}
+
+	scope, err := b.bindingScope(parameters)
+	if err != nil {
+		return domain.Binding{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+	}
+
 	lastOperation, err := b.operationsStorage.GetLastOperation(instance.InstanceID)
 	if err != nil {
 		return domain.Binding{}, apiresponses.NewFailureResponse(fmt.Errorf("failed to get last operation for instance %s", instanceID), http.StatusInternalServerError, fmt.Sprintf("failed to get last operation for instance %s", instanceID))
@@ -165,7 +211,9 @@ func (b *BindEndpoint) bind(ctx context.Context, instanceID, bindingID string, d
 		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message) // Agreed with Provisioning API team to return 400
 	}
 
-	binding, err := b.searchDbForBinding(err, instanceID, bindingID, expirationSeconds)
+	async := asyncAllowed && b.config.AsyncEnabled && b.queue != nil
+
+	binding, err := b.searchDbForBinding(err, instanceID, bindingID, expirationSeconds, scope, credentialType, async)
 	if err != nil {
 		return domain.Binding{}, err
 	}
@@ -180,29 +228,36 @@ func (b *BindEndpoint) bind(ctx context.Context, instanceID, bindingID string, d
 		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
 	}
 
-	err = b.checkAgainstLimit(bindingList, instanceID)
+	err = b.checkAgainstLimit(ctx, bindingList, instanceID, limits)
 	if err != nil {
 		return domain.Binding{}, err
 	}
 
-	return b.createNewBinding(ctx, instanceID, bindingID, expirationSeconds, bindingContext, err, instance)
+	return b.createNewBinding(ctx, instanceID, bindingID, expirationSeconds, scope, credentialType, bindingContext, async, instance)
 }
 
-func (b *BindEndpoint) searchDbForBinding(err error, instanceID string, bindingID string, expirationSeconds int) (*domain.Binding, error) {
+func (b *BindEndpoint) searchDbForBinding(err error, instanceID string, bindingID string, expirationSeconds int, scope internal.BindingScope, credentialType string, async bool) (*domain.Binding, error) {
 	bindingFromDB, err := b.bindingsStorage.Get(instanceID, bindingID)
 	if err != nil && !dberr.IsNotFound(err) {
 		message := fmt.Sprintf("failed to get Kyma binding from storage: %s", err)
 		return nil, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
 	}
 	if bindingFromDB != nil {
-		if bindingFromDB.ExpirationSeconds != int64(expirationSeconds) {
+		if bindingFromDB.ExpirationSeconds != int64(expirationSeconds) || !sameScope(bindingFromDB.Scope, scope) || bindingFromDB.CredentialTypeOrDefault() != credentialType {
 			message := "binding already exists but with different parameters"
 			return nil, apiresponses.NewFailureResponse(errors.New(message), http.StatusConflict, message)
 		}
 		if bindingFromDB.ExpiresAt.After(time.Now()) {
-			if len(bindingFromDB.Kubeconfig) == 0 {
+			switch bindingFromDB.CreationState() {
+			case domain.InProgress:
+				if async {
+					return &domain.Binding{IsAsync: true, OperationData: bindOperationData}, nil
+				}
 				message := "binding creation already in progress"
 				return nil, apiresponses.NewFailureResponse(errors.New(message), http.StatusUnprocessableEntity, message)
+			case domain.Failed:
+				message := fmt.Sprintf("binding creation failed: %s", bindingFromDB.StateDescription)
+				return nil, apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message)
 			}
 			return &domain.Binding{
 				IsAsync:       false,
@@ -219,33 +274,40 @@ func (b *BindEndpoint) searchDbForBinding(err error, instanceID string, bindingI
 	return nil, nil
 }
 
-func (b *BindEndpoint) checkAgainstLimit(bindingList []internal.Binding, instanceID string) error {
+func (b *BindEndpoint) checkAgainstLimit(ctx context.Context, bindingList []internal.Binding, instanceID string, limits BindingLimits) error {
 	bindingCount := len(bindingList)
-	message := fmt.Sprintf("reaching the maximum (%d) number of non expired bindings for instance %s", b.config.MaxBindingsCount, instanceID)
-	if bindingCount == b.config.MaxBindingsCount-1 {
+	message := fmt.Sprintf("reaching the maximum (%d) number of non expired bindings for instance %s", limits.MaxBindingsCount, instanceID)
+	if bindingCount == limits.MaxBindingsCount-1 {
 		b.log.Info(message)
 	}
-	if bindingCount >= b.config.MaxBindingsCount {
+	if bindingCount >= limits.MaxBindingsCount {
 		expiredCount := 0
 		for _, binding := range bindingList {
 			if binding.ExpiresAt.Before(time.Now()) {
 				expiredCount++
 			}
 		}
-		if (bindingCount - expiredCount) == (b.config.MaxBindingsCount - 1) {
+		if (bindingCount - expiredCount) == (limits.MaxBindingsCount - 1) {
 			b.log.Info(message)
 		}
-		if (bindingCount - expiredCount) >= b.config.MaxBindingsCount {
-			message := fmt.Sprintf("maximum number of non expired bindings reached: %d", b.config.MaxBindingsCount)
+		if (bindingCount - expiredCount) >= limits.MaxBindingsCount {
+			message := fmt.Sprintf("maximum number of non expired bindings reached: %d", limits.MaxBindingsCount)
 			b.log.Info(fmt.Sprintf(message+" for instance %s", instanceID))
-			return apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message)
+			return b.rejectByPolicy(ctx, message, BindingRejectionReasonMaxBindingsCount, limits.MaxBindingsCountPolicy)
 		}
 	}
 	return nil
 }
 
-func (b *BindEndpoint) createNewBinding(ctx context.Context, instanceID string, bindingID string, expirationSeconds int, bindingContext BindingContext, err error, instance *internal.Instance) (domain.Binding, error) {
-	var kubeconfig string
+// rejectByPolicy returns an OSB error naming the binding policy which rejected the request
+func (b *BindEndpoint) rejectByPolicy(ctx context.Context, message, reason, policy string) error {
+	b.publisher.Publish(ctx, BindingRejected{Reason: reason, Policy: policy})
+	message = fmt.Sprintf("%s (binding policy: %s)", message, policy)
+	return apiresponses.NewFailureResponseBuilder(errors.New(message), http.StatusBadRequest, message).
+		WithErrorKey("BindingPolicyViolation").Build()
+}
+
+func (b *BindEndpoint) createNewBinding(ctx context.Context, instanceID string, bindingID string, expirationSeconds int, scope internal.BindingScope, credentialType string, bindingContext BindingContext, async bool, instance *internal.Instance) (domain.Binding, error) {
 	binding := &internal.Binding{
 		ID:         bindingID,
 		InstanceID: instanceID,
@@ -256,9 +318,13 @@ func (b *BindEndpoint) createNewBinding(ctx context.Context, instanceID string,
 		ExpirationSeconds: int64(expirationSeconds),
 		ExpiresAt:         time.Now().Add(time.Duration(expirationSeconds) * time.Second),
 		CreatedBy:         bindingContext.CreatedBy(),
+		Scope:             scope,
+		CredentialType:    credentialType,
+		State:             domain.InProgress,
+		Async:             async,
 	}
 
-	err = b.bindingsStorage.Insert(binding)
+	err := b.bindingsStorage.Insert(binding)
 	switch {
 	case dberr.IsAlreadyExists(err):
 		message := fmt.Sprintf("failed to insert Kyma binding into storage: %s", err)
@@ -268,30 +334,34 @@ func (b *BindEndpoint) createNewBinding(ctx context.Context, instanceID string,
 		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
 	}
 
+	if async {
+		b.queue.Add(BindingOperationID(instanceID, bindingID))
+		b.log.Info(fmt.Sprintf("Binding %s for instance %s scheduled for asynchronous creation", bindingID, instanceID))
+		return domain.Binding{IsAsync: true, OperationData: bindOperationData}, nil
+	}
+
 	// create kubeconfig for the instance
-	var expiresAt time.Time
-	kubeconfig, expiresAt, err = b.serviceAccountBindingManager.Create(ctx, instance, bindingID, expirationSeconds)
+	err = b.processor.CreateCredentials(ctx, binding, instance)
 	if err != nil {
-		message := fmt.Sprintf("failed to create a Kyma binding using service account's kubeconfig: %s", err)
+		message := fmt.Sprintf("failed to create a Kyma binding using %s credentials: %s", credentialType, err)
 		b.log.Error(fmt.Sprintf("for instance %s %s", instanceID, message))
+		if err := b.processor.Fail(binding, message); err != nil {
+			b.log.Error(err.Error())
+		}
 		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message)
 	}
 
-	binding.ExpiresAt = expiresAt
-	binding.Kubeconfig = kubeconfig
-
-	err = b.bindingsStorage.Update(binding)
+	err = b.processor.Complete(binding, instance)
 	if err != nil {
 		message := fmt.Sprintf("failed to update Kyma binding in storage: %s", err)
 		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
 	}
 	b.log.Info(fmt.Sprintf("Successfully created binding %s for instance %s", bindingID, instanceID))
-	b.publisher.Publish(context.Background(), BindingCreated{PlanID: instance.ServicePlanID})
 
 	return domain.Binding{
 		IsAsync: false,
 		Credentials: Credentials{
-			Kubeconfig: kubeconfig,
+			Kubeconfig: binding.Kubeconfig,
 		},
 		Metadata: domain.BindingMetadata{
 			ExpiresAt: binding.ExpiresAt.Format(expiresAtLayout),
@@ -300,13 +370,7 @@ func (b *BindEndpoint) createNewBinding(ctx context.Context, instanceID string,
 }
 
 func (b *BindEndpoint) IsPlanBindable(planName string) bool {
-	planNameLowerCase := strings.ToLower(planName)
-	for _, p := range b.config.BindablePlans {
-		if strings.ToLower(p) == planNameLowerCase {
-			return true
-		}
-	}
-	return false
+	return containsPlan(b.config.BindablePlans, planName)
 }
 
 type BindRequestProcessed struct {
@@ -322,3 +386,8 @@ type UnbindRequestProcessed struct {
 type BindingCreated struct {
 	PlanID string
 }
+
+type BindingRejected struct {
+	Reason string
+	Policy string
+}
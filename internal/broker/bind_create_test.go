@@ -10,7 +10,9 @@ import (
 	"testing"
 	"time"
 
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
 	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
 	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -93,7 +95,7 @@ func TestCreateBindingEndpoint_dbInsertionInCaseOfError(t *testing.T) {
 	publisher := event.NewPubSub(log)
 
 	//// api handler
-	bindEndpoint := NewBind(*bindingCfg, db, fixLogger(), &dummyProvider{}, &dummyProvider{}, publisher)
+	bindEndpoint := NewBind(*bindingCfg, db, fixLogger(), broker.NewBindingsManagers(&dummyProvider{}, &dummyProvider{}, pkg.OIDCConfigDTO{}), publisher)
 
 	// test relies on checking if got nil on kubeconfig dummyProvider but the instance got inserted either way
 	t.Run("should INSERT binding despite error on k8s api call", func(t *testing.T) {
@@ -117,6 +119,7 @@ func TestCreateBindingEndpoint_dbInsertionInCaseOfError(t *testing.T) {
 
 		require.NotNil(t, binding.ExpiresAt)
 		require.Empty(t, binding.Kubeconfig)
+		require.Equal(t, domain.Failed, binding.State)
 	})
 }
 
@@ -343,7 +346,7 @@ func TestCreateSecondBindingWithTheSameIdButDifferentParams(t *testing.T) {
 
 	publisher := event.NewPubSub(log)
 
-	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), nil, nil, publisher)
+	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), broker.NewBindingsManagers(nil, nil, pkg.OIDCConfigDTO{}), publisher)
 	params := BindingParams{
 		ExpirationSeconds: 601,
 	}
@@ -394,7 +397,7 @@ func TestCreateSecondBindingWithTheSameIdAndParams(t *testing.T) {
 
 	publisher := event.NewPubSub(log)
 
-	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), nil, nil, publisher)
+	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), broker.NewBindingsManagers(nil, nil, pkg.OIDCConfigDTO{}), publisher)
 	params := BindingParams{
 		ExpirationSeconds: 600,
 	}
@@ -446,7 +449,7 @@ func TestCreateSecondBindingWithTheSameIdAndParamsForExpired(t *testing.T) {
 	// event publisher
 	publisher := event.NewPubSub(log)
 
-	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), nil, nil, publisher)
+	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), broker.NewBindingsManagers(nil, nil, pkg.OIDCConfigDTO{}), publisher)
 	params := BindingParams{
 		ExpirationSeconds: 600,
 	}
@@ -500,7 +503,7 @@ func TestCreateSecondBindingWithTheSameIdAndParamsForBindingInProgress(t *testin
 	// event publisher
 	publisher := event.NewPubSub(log)
 
-	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), nil, nil, publisher)
+	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), broker.NewBindingsManagers(nil, nil, pkg.OIDCConfigDTO{}), publisher)
 	params := BindingParams{
 		ExpirationSeconds: 600,
 	}
@@ -551,7 +554,7 @@ func TestCreateSecondBindingWithTheSameIdAndParamsNotExplicitlyDefined(t *testin
 
 	publisher := event.NewPubSub(log)
 
-	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), nil, nil, publisher)
+	svc := NewBind(*bindingCfg, brokerStorage, fixLogger(), broker.NewBindingsManagers(nil, nil, pkg.OIDCConfigDTO{}), publisher)
 
 	// when
 	resp, err := svc.Bind(context.Background(), instanceID, bindingID, domain.BindDetails{}, false)
@@ -577,5 +580,222 @@ func prepareBindingEndpoint(t *testing.T, cfg BindingConfig) (*BindEndpoint, sto
 	err = db.Operations().InsertOperation(operation)
 	require.NoError(t, err)
 
-	return NewBind(cfg, db, log, k8sClientProvider, k8sClientProvider, event.NewPubSub(log)), db
+	return NewBind(cfg, db, log, broker.NewBindingsManagers(k8sClientProvider, k8sClientProvider, pkg.OIDCConfigDTO{}), event.NewPubSub(log)), db
+}
+
+type fakeBindingQueue struct {
+	ids []string
+}
+
+func (q *fakeBindingQueue) Add(id string) {
+	q.ids = append(q.ids, id)
+}
+
+func TestCreateBindingAsynchronously(t *testing.T) {
+	// given
+	cfg := fixBindingConfig()
+	cfg.AsyncEnabled = true
+	bindEndpoint, db := prepareBindingEndpoint(t, cfg)
+	queue := &fakeBindingQueue{}
+	bindEndpoint.UseAsyncQueue(queue)
+	details := domain.BindDetails{ServiceID: "123", PlanID: fixture.PlanId}
+
+	// when
+	response, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id", details, true)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, response.IsAsync)
+	assert.Equal(t, bindOperationData, response.OperationData)
+	assert.Equal(t, []string{BindingOperationID(instanceID1, "binding-id")}, queue.ids)
+	binding, err := db.Bindings().Get(instanceID1, "binding-id")
+	require.NoError(t, err)
+	assert.Equal(t, domain.InProgress, binding.State)
+	assert.True(t, binding.Async)
+	assert.Empty(t, binding.Kubeconfig)
+
+	t.Run("should accept the same request while the binding is in progress", func(t *testing.T) {
+		// when
+		response, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id", details, true)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, response.IsAsync)
+		assert.Len(t, queue.ids, 1)
+	})
+
+	t.Run("should reject a synchronous request while the binding is in progress", func(t *testing.T) {
+		// when
+		_, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id", details, false)
+
+		// then
+		require.Error(t, err)
+		apiErr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.ValidatedStatusCode(nil))
+	})
+
+	t.Run("should create the binding synchronously if the platform does not accept incomplete operations", func(t *testing.T) {
+		// when
+		response, err := bindEndpoint.Bind(context.Background(), instanceID1, "sync-binding-id", details, false)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, response.IsAsync)
+		assert.NotEmpty(t, response.Credentials.(Credentials).Kubeconfig)
+		binding, err := db.Bindings().Get(instanceID1, "sync-binding-id")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, binding.State)
+		assert.False(t, binding.Async)
+	})
+}
+
+func TestCreateBindingWhichFailed(t *testing.T) {
+	// given
+	bindEndpoint, db := prepareBindingEndpoint(t, fixBindingConfig())
+	err := db.Bindings().Insert(&internal.Binding{
+		ID:                "binding-id",
+		InstanceID:        instanceID1,
+		ExpirationSeconds: 600,
+		ExpiresAt:         time.Now().Add(10 * time.Minute),
+		State:             domain.Failed,
+		StateDescription:  "runtime not reachable",
+	})
+	require.NoError(t, err)
+
+	// when
+	_, err = bindEndpoint.Bind(context.Background(), instanceID1, "binding-id", domain.BindDetails{ServiceID: "123", PlanID: fixture.PlanId}, false)
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, "binding creation failed: runtime not reachable", err.Error())
+}
+
+func TestCreateBindingWithCredentialType(t *testing.T) {
+	// given
+	cfg := fixBindingConfig()
+	cfg.AllowedCredentialTypes = StringList{internal.BindingCredentialTypeOIDC}
+	bindEndpoint, db := prepareBindingEndpoint(t, cfg)
+	instance, err := db.Instances().GetByID(instanceID1)
+	require.NoError(t, err)
+	instance.Parameters.Parameters.OIDC = &pkg.OIDCConnectDTO{
+		OIDCConfigDTO: &pkg.OIDCConfigDTO{ClientID: "client-id", IssuerURL: "https://issuer.url"},
+	}
+	_, err = db.Instances().Update(*instance)
+	require.NoError(t, err)
+
+	bindDetails := func(parameters string) domain.BindDetails {
+		return domain.BindDetails{ServiceID: "123", PlanID: fixture.PlanId, RawParameters: json.RawMessage(parameters)}
+	}
+
+	t.Run("should create an OIDC binding", func(t *testing.T) {
+		// when
+		response, err := bindEndpoint.Bind(context.Background(), instanceID1, "oidc-binding-id", bindDetails(`{"credentialType": "oidc"}`), false)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, response.Credentials.(Credentials).Kubeconfig, "--oidc-issuer-url=https://issuer.url")
+		binding, err := db.Bindings().Get(instanceID1, "oidc-binding-id")
+		require.NoError(t, err)
+		assert.Equal(t, internal.BindingCredentialTypeOIDC, binding.CredentialType)
+	})
+
+	t.Run("should reject the same binding with a different credential type", func(t *testing.T) {
+		// when
+		_, err := bindEndpoint.Bind(context.Background(), instanceID1, "oidc-binding-id", bindDetails(`{}`), false)
+
+		// then
+		require.Error(t, err)
+		apiErr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusConflict, apiErr.ValidatedStatusCode(nil))
+	})
+
+	for name, tc := range map[string]struct {
+		parameters string
+		message    string
+	}{
+		"not allowed credential type": {
+			parameters: `{"credentialType": "client-certificate"}`,
+			message:    "credential type client-certificate is not allowed, allowed credential types: oidc",
+		},
+		"OIDC binding with role": {
+			parameters: `{"credentialType": "oidc", "role": "view"}`,
+			message:    "credential type oidc does not support role, namespaces and rules",
+		},
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// when
+			_, err := bindEndpoint.Bind(context.Background(), instanceID1, "rejected-binding-id", bindDetails(tc.parameters), false)
+
+			// then
+			require.Error(t, err)
+			assert.Equal(t, tc.message, err.Error())
+		})
+	}
+}
+
+func TestCreateBindingWithBindingPolicies(t *testing.T) {
+	// given
+	bindEndpoint, db := prepareBindingEndpoint(t, fixBindingConfig())
+	bindEndpoint.UseBindingPolicies(BindingPolicies{
+		Plans: []BindingPolicy{
+			{Name: "azure-plans", Plans: []string{fixture.PlanName}, MaxExpirationSeconds: 1200},
+		},
+		GlobalAccounts: []BindingPolicy{
+			{Name: "limited-ga", Whitelist: []string{fixture.GlobalAccountId}, MaxBindingsCount: 1},
+		},
+	})
+	publisher := &collectingPublisher{}
+	bindEndpoint.publisher = publisher
+	bindDetails := func(parameters string) domain.BindDetails {
+		return domain.BindDetails{ServiceID: "123", PlanID: fixture.PlanId, RawParameters: json.RawMessage(parameters)}
+	}
+
+	t.Run("should reject expiration seconds greater than the policy maximum", func(t *testing.T) {
+		// when
+		_, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id-001", bindDetails(`{"expiration_seconds": 1800}`), false)
+
+		// then
+		require.Error(t, err)
+		apiErr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.ValidatedStatusCode(nil))
+		assert.Equal(t, "expiration_seconds cannot be greater than 1200 (binding policy: azure-plans)", err.Error())
+		assert.Equal(t, "BindingPolicyViolation", apiErr.ErrorResponse().(apiresponses.ErrorResponse).Error)
+	})
+
+	t.Run("should limit the default expiration seconds to the policy maximum", func(t *testing.T) {
+		// when
+		_, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id-002", bindDetails(`{}`), false)
+
+		// then
+		require.NoError(t, err)
+		binding, err := db.Bindings().Get(instanceID1, "binding-id-002")
+		require.NoError(t, err)
+		assert.Equal(t, int64(600), binding.ExpirationSeconds)
+	})
+
+	t.Run("should reject bindings over the policy limit", func(t *testing.T) {
+		// when
+		_, err := bindEndpoint.Bind(context.Background(), instanceID1, "binding-id-003", bindDetails(`{}`), false)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, "maximum number of non expired bindings reached: 1 (binding policy: limited-ga)", err.Error())
+		assert.Equal(t, []BindingRejected{
+			{Reason: BindingRejectionReasonMaxExpirationSeconds, Policy: "azure-plans"},
+			{Reason: BindingRejectionReasonMaxBindingsCount, Policy: "limited-ga"},
+		}, publisher.rejections)
+	})
+}
+
+type collectingPublisher struct {
+	rejections []BindingRejected
+}
+
+func (p *collectingPublisher) Publish(ctx context.Context, ev interface{}) {
+	if rejected, ok := ev.(BindingRejected); ok {
+		p.rejections = append(p.rejections, rejected)
+	}
 }
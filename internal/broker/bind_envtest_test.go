@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	brokerBindings "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
@@ -105,8 +106,8 @@ func TestCreateBinding(t *testing.T) {
 		Level: slog.LevelDebug,
 	}))
 	publisher := event.NewPubSub(log)
-	svc := NewBind(bindingCfg, db, log, skrK8sClientProvider, skrK8sClientProvider, publisher)
-	unbindSvc := NewUnbind(log, db, brokerBindings.NewServiceAccountBindingsManager(skrK8sClientProvider, skrK8sClientProvider), publisher)
+	svc := NewBind(bindingCfg, db, log, brokerBindings.NewBindingsManagers(skrK8sClientProvider, skrK8sClientProvider, pkg.OIDCConfigDTO{}), publisher)
+	unbindSvc := NewUnbind(log, db, brokerBindings.NewBindingsManagers(skrK8sClientProvider, skrK8sClientProvider, pkg.OIDCConfigDTO{}), publisher)
 
 	t.Run("should create a new service binding without error", func(t *testing.T) {
 		// When
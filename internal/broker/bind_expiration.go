@@ -0,0 +1,58 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+)
+
+// BindingExpirationReporter stores an event of the instance when its binding expires. The expiration is marked as reported
+// in the storage, so every expiration is reported once by one of the broker replicas.
+type BindingExpirationReporter struct {
+	bindings storage.Bindings
+	log      *slog.Logger
+}
+
+func NewBindingExpirationReporter(bindings storage.Bindings, log *slog.Logger) *BindingExpirationReporter {
+	return &BindingExpirationReporter{
+		bindings: bindings,
+		log:      log.With("service", "BindingExpirationReporter"),
+	}
+}
+
+func (r *BindingExpirationReporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.Report()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Report stores events of bindings which expired since the last report
+func (r *BindingExpirationReporter) Report() {
+	expired, err := r.bindings.ListUnreportedExpired()
+	if err != nil {
+		r.log.Error(fmt.Sprintf("unable to list expired bindings: %s", err))
+		return
+	}
+	for _, binding := range expired {
+		marked, err := r.bindings.MarkExpirationReported(binding.InstanceID, binding.ID)
+		if err != nil {
+			r.log.Error(fmt.Sprintf("unable to mark expiration of binding %s as reported: %s", binding.ID, err))
+			continue
+		}
+		if !marked {
+			continue
+		}
+		events.Infof(binding.InstanceID, "", "binding %s created by %q expired at %s", binding.ID, binding.CreatedBy, binding.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+}
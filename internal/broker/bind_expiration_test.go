@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingExpirationReporter_Report(t *testing.T) {
+	t.Run("should report every expiration once", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		expired := fixture.FixBinding("binding-expired")
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, db.Bindings().Insert(&expired))
+		active := fixture.FixBinding("binding-active")
+		active.ExpiresAt = time.Now().Add(time.Hour)
+		require.NoError(t, db.Bindings().Insert(&active))
+		failed := fixture.FixBinding("binding-failed")
+		failed.ExpiresAt = time.Now().Add(-time.Minute)
+		failed.State = domain.Failed
+		require.NoError(t, db.Bindings().Insert(&failed))
+		reporter := NewBindingExpirationReporter(db.Bindings(), fixLogger())
+
+		unreported, err := db.Bindings().ListUnreportedExpired()
+		require.NoError(t, err)
+		require.Len(t, unreported, 1)
+		assert.Equal(t, expired.ID, unreported[0].ID)
+
+		// when
+		reporter.Report()
+
+		// then
+		unreported, err = db.Bindings().ListUnreportedExpired()
+		require.NoError(t, err)
+		assert.Empty(t, unreported)
+		marked, err := db.Bindings().MarkExpirationReported(expired.InstanceID, expired.ID)
+		require.NoError(t, err)
+		assert.False(t, marked)
+	})
+
+	t.Run("should report the expiration of rotated credentials again", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		binding := fixture.FixBinding("binding-id")
+		binding.ExpiresAt = time.Now().Add(-time.Hour)
+		require.NoError(t, db.Bindings().Insert(&binding))
+		reporter := NewBindingExpirationReporter(db.Bindings(), fixLogger())
+		reporter.Report()
+
+		// when
+		binding.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, db.Bindings().Update(&binding))
+
+		// then
+		unreported, err := db.Bindings().ListUnreportedExpired()
+		require.NoError(t, err)
+		require.Len(t, unreported, 1)
+		assert.Equal(t, binding.ID, unreported[0].ID)
+	})
+}
@@ -54,9 +54,13 @@ func (b *GetBindingEndpoint) GetBinding(_ context.Context, instanceID, bindingID
 		return domain.GetBindingSpec{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
 	}
 
-	if len(binding.Kubeconfig) == 0 {
+	switch binding.CreationState() {
+	case domain.InProgress:
 		message := "Binding creation in progress"
 		return domain.GetBindingSpec{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
+	case domain.Failed:
+		message := fmt.Sprintf("Binding creation failed: %s", binding.StateDescription)
+		return domain.GetBindingSpec{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
 	}
 
 	if err != nil {
@@ -2,18 +2,24 @@ package broker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
 )
 
 type LastBindingOperationEndpoint struct {
-	log *slog.Logger
+	bindings storage.Bindings
+	log      *slog.Logger
 }
 
-func NewLastBindingOperation(log *slog.Logger) *LastBindingOperationEndpoint {
-	return &LastBindingOperationEndpoint{log: log.With("service", "LastBindingOperationEndpoint")}
+func NewLastBindingOperation(db storage.BrokerStorage, log *slog.Logger) *LastBindingOperationEndpoint {
+	return &LastBindingOperationEndpoint{bindings: db.Bindings(), log: log.With("service", "LastBindingOperationEndpoint")}
 }
 
 // LastBindingOperation fetches last operation state for a service binding
@@ -24,5 +30,17 @@ func (b *LastBindingOperationEndpoint) LastBindingOperation(ctx context.Context,
 	b.log.Info(fmt.Sprintf("LastBindingOperation bindingID: %s", bindingID))
 	b.log.Info(fmt.Sprintf("LastBindingOperation details: %+v", details))
 
-	return domain.LastOperation{}, fmt.Errorf("not supported")
+	binding, err := b.bindings.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		return domain.LastOperation{}, apiresponses.ErrBindingNotFound
+	case err != nil:
+		message := fmt.Sprintf("failed to get Kyma binding from storage: %s", err)
+		return domain.LastOperation{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+
+	return domain.LastOperation{
+		State:       binding.CreationState(),
+		Description: binding.StateDescription,
+	}, nil
 }
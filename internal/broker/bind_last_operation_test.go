@@ -0,0 +1,49 @@
+package broker
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastBindingOperation(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{ID: "in-progress", InstanceID: instanceID1, State: domain.InProgress}))
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{ID: "failed", InstanceID: instanceID1, State: domain.Failed, StateDescription: "runtime not reachable"}))
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{ID: "legacy", InstanceID: instanceID1, Kubeconfig: "kubeconfig"}))
+	endpoint := NewLastBindingOperation(db, fixLogger())
+
+	for bindingID, expected := range map[string]domain.LastOperation{
+		"in-progress": {State: domain.InProgress},
+		"failed":      {State: domain.Failed, Description: "runtime not reachable"},
+		"legacy":      {State: domain.Succeeded},
+	} {
+		t.Run(bindingID, func(t *testing.T) {
+			// when
+			lastOperation, err := endpoint.LastBindingOperation(context.Background(), instanceID1, bindingID, domain.PollDetails{})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, expected, lastOperation)
+		})
+	}
+
+	t.Run("not existing binding", func(t *testing.T) {
+		// when
+		_, err := endpoint.LastBindingOperation(context.Background(), instanceID1, "not-existing", domain.PollDetails{})
+
+		// then
+		require.Error(t, err)
+		apiErr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.ValidatedStatusCode(nil))
+	})
+}
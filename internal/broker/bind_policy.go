@@ -0,0 +1,129 @@
+package broker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/utils"
+	"github.com/kyma-project/kyma-environment-broker/internal/whitelist"
+)
+
+const (
+	defaultBindingPolicyName = "default"
+
+	BindingRejectionReasonMaxBindingsCount     = "max_bindings_count"
+	BindingRejectionReasonMaxExpirationSeconds = "max_expiration_seconds"
+)
+
+// BindingPolicy overrides binding limits for plans or for accounts listed in the whitelist, zero limits are not overridden
+type BindingPolicy struct {
+	Name                 string   `yaml:"name"`
+	Plans                []string `yaml:"plans"`
+	Whitelist            []string `yaml:"whitelist"`
+	MaxBindingsCount     int      `yaml:"maxBindingsCount"`
+	MaxExpirationSeconds int      `yaml:"maxExpirationSeconds"`
+}
+
+// BindingPolicies are applied from the least to the most specific tier: plans, global accounts, subaccounts
+type BindingPolicies struct {
+	Plans          []BindingPolicy `yaml:"plans"`
+	GlobalAccounts []BindingPolicy `yaml:"globalAccounts"`
+	Subaccounts    []BindingPolicy `yaml:"subaccounts"`
+}
+
+// BindingLimits are limits applied to bindings of the instance together with names of policies which set them
+type BindingLimits struct {
+	MaxBindingsCount           int
+	MaxBindingsCountPolicy     string
+	MaxExpirationSeconds       int
+	MaxExpirationSecondsPolicy string
+}
+
+func ReadBindingPoliciesFromFile(filename string) (BindingPolicies, error) {
+	var policies BindingPolicies
+	err := utils.UnmarshalYamlFile(filename, &policies)
+	if err != nil {
+		return BindingPolicies{}, fmt.Errorf("while unmarshalling a file with binding policies: %w", err)
+	}
+	if err := policies.validate(); err != nil {
+		return BindingPolicies{}, fmt.Errorf("while validating binding policies: %w", err)
+	}
+	return policies, nil
+}
+
+func (p BindingPolicies) validate() error {
+	var names []string
+	for _, policy := range slices.Concat(p.Plans, p.GlobalAccounts, p.Subaccounts) {
+		if policy.Name == "" {
+			return fmt.Errorf("policy name must not be empty")
+		}
+		if policy.Name == defaultBindingPolicyName || slices.Contains(names, policy.Name) {
+			return fmt.Errorf("policy name %s must be unique", policy.Name)
+		}
+		if policy.MaxBindingsCount < 0 || policy.MaxExpirationSeconds < 0 {
+			return fmt.Errorf("limits of policy %s must not be negative", policy.Name)
+		}
+		names = append(names, policy.Name)
+	}
+	for _, policy := range p.Plans {
+		if len(policy.Plans) == 0 {
+			return fmt.Errorf("plan policy %s must contain plans", policy.Name)
+		}
+	}
+	for _, policy := range slices.Concat(p.GlobalAccounts, p.Subaccounts) {
+		if len(policy.Whitelist) == 0 {
+			return fmt.Errorf("account policy %s must contain the %s", policy.Name, whitelist.Key)
+		}
+	}
+	return nil
+}
+
+// LimitsFor returns limits for bindings of the instance, the configuration sets the default limits
+func (p BindingPolicies) LimitsFor(cfg BindingConfig, instance *internal.Instance) BindingLimits {
+	limits := BindingLimits{
+		MaxBindingsCount:           cfg.MaxBindingsCount,
+		MaxBindingsCountPolicy:     defaultBindingPolicyName,
+		MaxExpirationSeconds:       cfg.MaxExpirationSeconds,
+		MaxExpirationSecondsPolicy: defaultBindingPolicyName,
+	}
+	for _, policy := range p.Plans {
+		if containsPlan(policy.Plans, instance.ServicePlanName) {
+			limits.apply(policy)
+		}
+	}
+	for _, policy := range p.GlobalAccounts {
+		if slices.Contains(policy.Whitelist, instance.GlobalAccountID) {
+			limits.apply(policy)
+		}
+	}
+	for _, policy := range p.Subaccounts {
+		if slices.Contains(policy.Whitelist, instance.SubAccountID) {
+			limits.apply(policy)
+		}
+	}
+	return limits
+}
+
+// containsPlan checks if the plan is in the list, plan names are compared case-insensitively
+func containsPlan(plans []string, planName string) bool {
+	planNameLowerCase := strings.ToLower(planName)
+	for _, p := range plans {
+		if strings.ToLower(p) == planNameLowerCase {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *BindingLimits) apply(policy BindingPolicy) {
+	if policy.MaxBindingsCount > 0 {
+		l.MaxBindingsCount = policy.MaxBindingsCount
+		l.MaxBindingsCountPolicy = policy.Name
+	}
+	if policy.MaxExpirationSeconds > 0 {
+		l.MaxExpirationSeconds = policy.MaxExpirationSeconds
+		l.MaxExpirationSecondsPolicy = policy.Name
+	}
+}
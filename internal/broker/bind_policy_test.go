@@ -0,0 +1,113 @@
+package broker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingPolicies_LimitsFor(t *testing.T) {
+	policies := BindingPolicies{
+		Plans: []BindingPolicy{
+			{Name: "small-plans", Plans: []string{"trial", "free"}, MaxBindingsCount: 2, MaxExpirationSeconds: 3600},
+		},
+		GlobalAccounts: []BindingPolicy{
+			{Name: "premium-ga", Whitelist: []string{"premium-ga-id"}, MaxBindingsCount: 50},
+		},
+		Subaccounts: []BindingPolicy{
+			{Name: "restricted-sa", Whitelist: []string{"restricted-sa-id"}, MaxBindingsCount: 1},
+		},
+	}
+	cfg := BindingConfig{MaxBindingsCount: 10, MaxExpirationSeconds: 7200}
+
+	for name, tc := range map[string]struct {
+		instance internal.Instance
+		expected BindingLimits
+	}{
+		"default limits": {
+			instance: internal.Instance{ServicePlanName: "aws", GlobalAccountID: "ga-id", SubAccountID: "sa-id"},
+			expected: BindingLimits{MaxBindingsCount: 10, MaxBindingsCountPolicy: "default", MaxExpirationSeconds: 7200, MaxExpirationSecondsPolicy: "default"},
+		},
+		"plan limits": {
+			instance: internal.Instance{ServicePlanName: "trial", GlobalAccountID: "ga-id", SubAccountID: "sa-id"},
+			expected: BindingLimits{MaxBindingsCount: 2, MaxBindingsCountPolicy: "small-plans", MaxExpirationSeconds: 3600, MaxExpirationSecondsPolicy: "small-plans"},
+		},
+		"plan limits with plan name in different case": {
+			instance: internal.Instance{ServicePlanName: "Trial", GlobalAccountID: "ga-id", SubAccountID: "sa-id"},
+			expected: BindingLimits{MaxBindingsCount: 2, MaxBindingsCountPolicy: "small-plans", MaxExpirationSeconds: 3600, MaxExpirationSecondsPolicy: "small-plans"},
+		},
+		"global account limits override plan limits": {
+			instance: internal.Instance{ServicePlanName: "trial", GlobalAccountID: "premium-ga-id", SubAccountID: "sa-id"},
+			expected: BindingLimits{MaxBindingsCount: 50, MaxBindingsCountPolicy: "premium-ga", MaxExpirationSeconds: 3600, MaxExpirationSecondsPolicy: "small-plans"},
+		},
+		"subaccount limits override global account limits": {
+			instance: internal.Instance{ServicePlanName: "aws", GlobalAccountID: "premium-ga-id", SubAccountID: "restricted-sa-id"},
+			expected: BindingLimits{MaxBindingsCount: 1, MaxBindingsCountPolicy: "restricted-sa", MaxExpirationSeconds: 7200, MaxExpirationSecondsPolicy: "default"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			limits := policies.LimitsFor(cfg, &tc.instance)
+
+			// then
+			assert.Equal(t, tc.expected, limits)
+		})
+	}
+}
+
+func TestReadBindingPoliciesFromFile(t *testing.T) {
+	t.Run("should read binding policies", func(t *testing.T) {
+		// given
+		path := writePoliciesFile(t, `
+plans:
+  - name: trial
+    plans:
+      - trial
+    maxBindingsCount: 2
+subaccounts:
+  - name: restricted
+    whitelist:
+      - sa-id
+    maxExpirationSeconds: 1200
+`)
+
+		// when
+		policies, err := ReadBindingPoliciesFromFile(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []BindingPolicy{{Name: "trial", Plans: []string{"trial"}, MaxBindingsCount: 2}}, policies.Plans)
+		assert.Empty(t, policies.GlobalAccounts)
+		assert.Equal(t, []BindingPolicy{{Name: "restricted", Whitelist: []string{"sa-id"}, MaxExpirationSeconds: 1200}}, policies.Subaccounts)
+	})
+
+	for name, content := range map[string]string{
+		"duplicated policy name": "plans: [{name: p, plans: [trial]}]\nsubaccounts: [{name: p, whitelist: [sa-id]}]",
+		"reserved policy name":   "plans: [{name: default, plans: [trial]}]",
+		"missing plans":          "plans: [{name: p, maxBindingsCount: 1}]",
+		"missing whitelist":      "globalAccounts: [{name: p, maxBindingsCount: 1}]",
+		"negative limit":         "subaccounts: [{name: p, whitelist: [sa-id], maxBindingsCount: -1}]",
+		"missing policy name":    "plans: [{plans: [trial]}]",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// given
+			path := writePoliciesFile(t, content)
+
+			// when
+			_, err := ReadBindingPoliciesFromFile(path)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func writePoliciesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "binding_policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
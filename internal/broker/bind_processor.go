@@ -0,0 +1,210 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+)
+
+const bindingOperationIDSeparator = "/"
+
+// BindingOperationID returns the queue item identifying the binding, the binding ID is unique only within the instance
+func BindingOperationID(instanceID, bindingID string) string {
+	return instanceID + bindingOperationIDSeparator + bindingID
+}
+
+func parseBindingOperationID(operationID string) (string, string, error) {
+	instanceID, bindingID, found := strings.Cut(operationID, bindingOperationIDSeparator)
+	if !found || instanceID == "" || bindingID == "" {
+		return "", "", fmt.Errorf("invalid binding operation ID %s", operationID)
+	}
+	return instanceID, bindingID, nil
+}
+
+// BindingProcessor creates the service account, RBAC objects and token of bindings in progress. It is used directly for
+// synchronous requests and as the executor of the binding queue for asynchronous ones.
+type BindingProcessor struct {
+	config           BindingConfig
+	instances        storage.Instances
+	bindings         storage.Bindings
+	bindingsManagers broker.BindingsManagers
+	publisher        event.Publisher
+
+	log *slog.Logger
+}
+
+func NewBindingProcessor(cfg BindingConfig, db storage.BrokerStorage, bindingsManagers broker.BindingsManagers, publisher event.Publisher, log *slog.Logger) *BindingProcessor {
+	return &BindingProcessor{
+		config:           cfg,
+		instances:        db.Instances(),
+		bindings:         db.Bindings(),
+		bindingsManagers: bindingsManagers,
+		publisher:        publisher,
+		log:              log.With("service", "BindingProcessor"),
+	}
+}
+
+// Execute processes the binding identified by the operation ID created with BindingOperationID. Failed attempts are
+// retried until AsyncMaxRetryTime elapses since the binding creation, then the binding is marked as failed.
+func (p *BindingProcessor) Execute(operationID string) (time.Duration, error) {
+	instanceID, bindingID, err := parseBindingOperationID(operationID)
+	if err != nil {
+		return 0, err
+	}
+	log := p.log.With("instanceID", instanceID, "bindingID", bindingID)
+
+	binding, err := p.bindings.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		log.Info("binding does not exist, skipping")
+		return 0, nil
+	case err != nil:
+		log.Error(fmt.Sprintf("unable to get binding: %s", err))
+		return p.config.AsyncRetryInterval, nil
+	}
+	if binding.CreationState() != domain.InProgress {
+		log.Info(fmt.Sprintf("binding is in state %s, skipping", binding.CreationState()))
+		return 0, nil
+	}
+
+	instance, err := p.instances.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		return 0, p.Fail(binding, fmt.Sprintf("instance %s does not exist", instanceID))
+	case err != nil:
+		log.Error(fmt.Sprintf("unable to get instance: %s", err))
+		return p.config.AsyncRetryInterval, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.CreateBindingTimeout)
+	defer cancel()
+	err = p.CreateCredentials(ctx, binding, instance)
+	if err != nil {
+		message := fmt.Sprintf("failed to create a Kyma binding using %s credentials: %s", binding.CredentialTypeOrDefault(), err)
+		if time.Since(binding.CreatedAt) < p.config.AsyncMaxRetryTime {
+			log.Warn(fmt.Sprintf("%s, retrying in %s", message, p.config.AsyncRetryInterval))
+			return p.config.AsyncRetryInterval, nil
+		}
+		log.Error(message)
+		return 0, p.Fail(binding, message)
+	}
+
+	err = p.Complete(binding, instance)
+	if err != nil {
+		log.Error(fmt.Sprintf("unable to update binding: %s", err))
+		return p.config.AsyncRetryInterval, nil
+	}
+	log.Info("binding created")
+
+	return 0, nil
+}
+
+// CreateCredentials creates the kubeconfig of the binding in the runtime and sets it in the binding
+func (p *BindingProcessor) CreateCredentials(ctx context.Context, binding *internal.Binding, instance *internal.Instance) error {
+	bindingsManager, err := p.bindingsManagers.ForCredentialType(binding.CredentialType)
+	if err != nil {
+		return err
+	}
+	kubeconfig, expiresAt, err := bindingsManager.Create(ctx, instance, binding.ID, binding.Scope, int(binding.ExpirationSeconds))
+	if err != nil {
+		return err
+	}
+	binding.Kubeconfig = kubeconfig
+	binding.ExpiresAt = expiresAt
+
+	return nil
+}
+
+// Complete marks the binding with credentials as succeeded
+func (p *BindingProcessor) Complete(binding *internal.Binding, instance *internal.Instance) error {
+	binding.State = domain.Succeeded
+	binding.StateDescription = ""
+	binding.UpdatedAt = time.Now()
+	if err := p.bindings.Update(binding); err != nil {
+		return err
+	}
+	p.publisher.Publish(context.Background(), BindingCreated{PlanID: instance.ServicePlanID})
+	events.Infof(binding.InstanceID, "", "binding %s created by %q with role %s and %s credentials, expires at %s", binding.ID, binding.CreatedBy,
+		binding.Scope.RoleOrDefault(), binding.CredentialTypeOrDefault(), binding.ExpiresAt.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+// BindingQueue resumes processing of bindings created asynchronously
+type BindingQueue interface {
+	Resume(processId string)
+}
+
+// ResumeInProgress queues asynchronous bindings in progress and fails bindings whose creation was interrupted. A synchronous
+// binding may be still created by another replica until its request times out, so it is failed only after CreateBindingTimeout
+// since its creation. Without the queue, asynchronous bindings are failed after AsyncMaxRetryTime.
+func (p *BindingProcessor) ResumeInProgress(queue BindingQueue) error {
+	inProgress, err := p.bindings.ListInProgress()
+	if err != nil {
+		return fmt.Errorf("while getting bindings in progress from storage: %w", err)
+	}
+	for _, binding := range inProgress {
+		log := p.log.With("instanceID", binding.InstanceID, "bindingID", binding.ID)
+		if binding.Async && queue != nil {
+			queue.Resume(BindingOperationID(binding.InstanceID, binding.ID))
+			log.Info("resuming the processing of the binding")
+			continue
+		}
+
+		staleAfter := p.config.CreateBindingTimeout
+		if binding.Async {
+			staleAfter = p.config.AsyncMaxRetryTime
+		}
+		remaining := staleAfter - time.Since(binding.CreatedAt)
+		if remaining <= 0 {
+			p.failInterrupted(binding.InstanceID, binding.ID)
+			continue
+		}
+		log.Info(fmt.Sprintf("binding creation may be still in progress, checking it again in %s", remaining))
+		time.AfterFunc(remaining, func() { p.failInterrupted(binding.InstanceID, binding.ID) })
+	}
+	return nil
+}
+
+func (p *BindingProcessor) failInterrupted(instanceID, bindingID string) {
+	log := p.log.With("instanceID", instanceID, "bindingID", bindingID)
+	binding, err := p.bindings.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		return
+	case err != nil:
+		log.Error(fmt.Sprintf("unable to get binding: %s", err))
+		return
+	}
+	if binding.CreationState() != domain.InProgress {
+		return
+	}
+	log.Warn("binding creation was interrupted, marking the binding as failed")
+	if err := p.Fail(binding, "binding creation was interrupted"); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// Fail marks the binding as failed with the given description
+func (p *BindingProcessor) Fail(binding *internal.Binding, description string) error {
+	binding.State = domain.Failed
+	binding.StateDescription = description
+	binding.UpdatedAt = time.Now()
+	if err := p.bindings.Update(binding); err != nil {
+		return fmt.Errorf("while marking binding %s as failed: %w", binding.ID, err)
+	}
+	events.Errorf(binding.InstanceID, "", errors.New(description), "binding %s requested by %q failed", binding.ID, binding.CreatedBy)
+
+	return nil
+}
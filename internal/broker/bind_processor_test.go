@@ -0,0 +1,218 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingProcessor_Execute(t *testing.T) {
+	cfg := fixBindingConfig()
+	cfg.CreateBindingTimeout = 15 * time.Second
+	cfg.AsyncRetryInterval = 10 * time.Second
+	cfg.AsyncMaxRetryTime = 10 * time.Minute
+
+	t.Run("should create credentials", func(t *testing.T) {
+		// given
+		db := fixBindingProcessorStorage(t, time.Now())
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		processor := NewBindingProcessor(cfg, db, broker.NewBindingsManagers(provider, provider, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		when, err := processor.Execute(BindingOperationID(instanceID1, "binding-id"))
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+		binding, err := db.Bindings().Get(instanceID1, "binding-id")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, binding.State)
+		assert.NotEmpty(t, binding.Kubeconfig)
+	})
+
+	t.Run("should retry if the runtime is not reachable", func(t *testing.T) {
+		// given
+		db := fixBindingProcessorStorage(t, time.Now())
+		processor := NewBindingProcessor(cfg, db, broker.NewBindingsManagers(&dummyProvider{}, &dummyProvider{}, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		when, err := processor.Execute(BindingOperationID(instanceID1, "binding-id"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, cfg.AsyncRetryInterval, when)
+		binding, err := db.Bindings().Get(instanceID1, "binding-id")
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+	})
+
+	t.Run("should fail after the maximum retry time", func(t *testing.T) {
+		// given
+		db := fixBindingProcessorStorage(t, time.Now().Add(-cfg.AsyncMaxRetryTime))
+		processor := NewBindingProcessor(cfg, db, broker.NewBindingsManagers(&dummyProvider{}, &dummyProvider{}, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		when, err := processor.Execute(BindingOperationID(instanceID1, "binding-id"))
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+		binding, err := db.Bindings().Get(instanceID1, "binding-id")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, binding.State)
+		assert.Contains(t, binding.StateDescription, "failed to create a Kyma binding")
+	})
+
+	t.Run("should skip not existing binding", func(t *testing.T) {
+		// given
+		db := fixBindingProcessorStorage(t, time.Now())
+		processor := NewBindingProcessor(cfg, db, broker.NewBindingsManagers(&dummyProvider{}, &dummyProvider{}, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		when, err := processor.Execute(BindingOperationID(instanceID1, "other-binding-id"))
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, when)
+	})
+
+	t.Run("should return error for invalid operation ID", func(t *testing.T) {
+		// given
+		processor := NewBindingProcessor(cfg, storage.NewMemoryStorage(), nil, event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		_, err := processor.Execute("binding-id")
+
+		// then
+		assert.EqualError(t, err, "invalid binding operation ID binding-id")
+	})
+}
+
+func fixBindingProcessorStorage(t *testing.T, createdAt time.Time) storage.BrokerStorage {
+	db := storage.NewMemoryStorage()
+	instance := fixture.FixInstance(instanceID1)
+	instance.Parameters.Parameters.Name = "cluster"
+	require.NoError(t, db.Instances().Insert(instance))
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{
+		ID:                "binding-id",
+		InstanceID:        instanceID1,
+		CreatedAt:         createdAt,
+		ExpirationSeconds: 600,
+		ExpiresAt:         createdAt.Add(10 * time.Minute),
+		State:             domain.InProgress,
+	}))
+	return db
+}
+
+func TestBindingProcessor_ResumeInProgress(t *testing.T) {
+	cfg := fixBindingConfig()
+	cfg.CreateBindingTimeout = 15 * time.Second
+	cfg.AsyncMaxRetryTime = 10 * time.Minute
+
+	t.Run("should resume asynchronous binding and keep synchronous binding created by a live request", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		insertBindingInProgress(t, db, "async", time.Now().Add(-time.Hour), true)
+		insertBindingInProgress(t, db, "sync", time.Now(), false)
+		processor := NewBindingProcessor(cfg, db, nil, event.NewPubSub(fixLogger()), fixLogger())
+		queue := &bindingQueueStub{}
+
+		// when
+		err := processor.ResumeInProgress(queue)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{BindingOperationID(instanceID1, "async")}, queue.resumed)
+		binding, err := db.Bindings().Get(instanceID1, "sync")
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+	})
+
+	t.Run("should fail synchronous binding whose request timed out", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		insertBindingInProgress(t, db, "sync", time.Now().Add(-time.Minute), false)
+		processor := NewBindingProcessor(cfg, db, nil, event.NewPubSub(fixLogger()), fixLogger())
+		queue := &bindingQueueStub{}
+
+		// when
+		err := processor.ResumeInProgress(queue)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, queue.resumed)
+		binding, err := db.Bindings().Get(instanceID1, "sync")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, binding.State)
+		assert.Equal(t, "binding creation was interrupted", binding.StateDescription)
+	})
+
+	t.Run("should fail synchronous binding when its request times out", func(t *testing.T) {
+		// given
+		cfg := cfg
+		cfg.CreateBindingTimeout = 50 * time.Millisecond
+		db := storage.NewMemoryStorage()
+		insertBindingInProgress(t, db, "sync", time.Now(), false)
+		processor := NewBindingProcessor(cfg, db, nil, event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		err := processor.ResumeInProgress(nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			binding, err := db.Bindings().Get(instanceID1, "sync")
+			return err == nil && binding.State == domain.Failed
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should fail asynchronous binding after the maximum retry time without the queue", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		insertBindingInProgress(t, db, "async-stale", time.Now().Add(-cfg.AsyncMaxRetryTime), true)
+		insertBindingInProgress(t, db, "async", time.Now(), true)
+		processor := NewBindingProcessor(cfg, db, nil, event.NewPubSub(fixLogger()), fixLogger())
+
+		// when
+		err := processor.ResumeInProgress(nil)
+
+		// then
+		require.NoError(t, err)
+		binding, err := db.Bindings().Get(instanceID1, "async-stale")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, binding.State)
+		binding, err = db.Bindings().Get(instanceID1, "async")
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, binding.State)
+	})
+}
+
+func insertBindingInProgress(t *testing.T, db storage.BrokerStorage, bindingID string, createdAt time.Time, async bool) {
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{
+		ID:                bindingID,
+		InstanceID:        instanceID1,
+		CreatedAt:         createdAt,
+		ExpirationSeconds: 600,
+		ExpiresAt:         createdAt.Add(10 * time.Minute),
+		State:             domain.InProgress,
+		Async:             async,
+	}))
+}
+
+type bindingQueueStub struct {
+	resumed []string
+}
+
+func (q *bindingQueueStub) Resume(processId string) {
+	q.resumed = append(q.resumed, processId)
+}
@@ -0,0 +1,154 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
+)
+
+type RotateBindingEndpoint struct {
+	instancesStorage  storage.Instances
+	bindingsStorage   storage.Bindings
+	operationsStorage storage.Operations
+	bindingsManagers  broker.BindingsManagers
+	publisher         event.Publisher
+
+	log *slog.Logger
+}
+
+func NewRotateBinding(log *slog.Logger, db storage.BrokerStorage, bindingsManagers broker.BindingsManagers, publisher event.Publisher) *RotateBindingEndpoint {
+	return &RotateBindingEndpoint{
+		instancesStorage:  db.Instances(),
+		bindingsStorage:   db.Bindings(),
+		operationsStorage: db.Operations(),
+		bindingsManagers:  bindingsManagers,
+		publisher:         publisher,
+		log:               log.With("service", "RotateBindingEndpoint"),
+	}
+}
+
+// AttachBindingRotationRoutes registers the KEB-specific endpoint rotating credentials of existing bindings
+func AttachBindingRotationRoutes(router *httputil.Router, endpoint *RotateBindingEndpoint, timeout time.Duration, prefixes []string) {
+	for _, prefix := range prefixes {
+		router.Handle(buildPathPattern(http.MethodPost, prefix, "/v2/service_instances/{instance_id}/service_bindings/{binding_id}/rotate"),
+			http.TimeoutHandler(http.HandlerFunc(endpoint.rotate), timeout, fmt.Sprintf("request timeout: time exceeded %s", timeout)))
+	}
+}
+
+func (b *RotateBindingEndpoint) rotate(w http.ResponseWriter, req *http.Request) {
+	binding, err := b.Rotate(req.Context(), req.PathValue("instance_id"), req.PathValue("binding_id"))
+	if err != nil {
+		var failureResponse *apiresponses.FailureResponse
+		if errors.As(err, &failureResponse) {
+			httputil.WriteErrorResponse(w, failureResponse.ValidatedStatusCode(b.log), err)
+			return
+		}
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, apiresponses.BindingResponse{
+		Credentials: binding.Credentials,
+		Metadata:    binding.Metadata,
+	})
+}
+
+// Rotate issues a new token for the service account of an existing binding and replaces the binding's kubeconfig
+//
+//	POST /v2/service_instances/{instance_id}/service_bindings/{binding_id}/rotate
+func (b *RotateBindingEndpoint) Rotate(ctx context.Context, instanceID, bindingID string) (domain.Binding, error) {
+	b.log.Info(fmt.Sprintf("Rotate instanceID: %s", instanceID))
+	b.log.Info(fmt.Sprintf("Rotate bindingID: %s", bindingID))
+
+	instance, err := b.instancesStorage.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		message := fmt.Sprintf("instance %s does not exist", instanceID)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
+	case err != nil:
+		message := fmt.Sprintf("failed to get instance %s", instanceID)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+
+	lastOperation, err := b.operationsStorage.GetLastOperation(instanceID)
+	if err != nil {
+		message := fmt.Sprintf("failed to get last operation for instance %s", instanceID)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+	if lastOperation.Type == internal.OperationTypeDeprovision {
+		message := "Binding not found"
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
+	}
+
+	binding, err := b.bindingsStorage.Get(instanceID, bindingID)
+	switch {
+	case dberr.IsNotFound(err):
+		message := "Binding not found"
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
+	case err != nil:
+		message := fmt.Sprintf("failed to get Kyma binding from storage: %s", err)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+
+	switch binding.CreationState() {
+	case domain.InProgress:
+		message := "binding creation in progress"
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusUnprocessableEntity, message)
+	case domain.Failed:
+		message := fmt.Sprintf("binding creation failed: %s", binding.StateDescription)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusUnprocessableEntity, message)
+	}
+	if binding.ExpiresAt.Before(time.Now()) {
+		message := "Binding expired"
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusNotFound, message)
+	}
+
+	bindingsManager, err := b.bindingsManagers.ForCredentialType(binding.CredentialType)
+	if err != nil {
+		message := fmt.Sprintf("failed to renew the token of the Kyma binding: %s", err)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+	kubeconfig, expiresAt, err := bindingsManager.RenewToken(ctx, instance, bindingID, int(binding.ExpirationSeconds))
+	if err != nil {
+		message := fmt.Sprintf("failed to renew the token of the Kyma binding: %s", err)
+		b.log.Error(fmt.Sprintf("for instance %s %s", instanceID, message))
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusBadRequest, message)
+	}
+
+	binding.Kubeconfig = kubeconfig
+	binding.ExpiresAt = expiresAt
+	binding.UpdatedAt = time.Now()
+	err = b.bindingsStorage.Update(binding)
+	if err != nil {
+		message := fmt.Sprintf("failed to update Kyma binding in storage: %s", err)
+		return domain.Binding{}, apiresponses.NewFailureResponse(errors.New(message), http.StatusInternalServerError, message)
+	}
+	b.log.Info(fmt.Sprintf("Successfully rotated binding %s for instance %s", bindingID, instanceID))
+	b.publisher.Publish(context.Background(), BindingRotated{PlanID: instance.ServicePlanID})
+	events.Infof(instanceID, "", "credentials of binding %s created by %q rotated, expire at %s", bindingID, binding.CreatedBy, expiresAt.UTC().Format(time.RFC3339))
+
+	return domain.Binding{
+		Credentials: Credentials{
+			Kubeconfig: kubeconfig,
+		},
+		Metadata: domain.BindingMetadata{
+			ExpiresAt: expiresAt.Format(expiresAtLayout),
+		},
+	}, nil
+}
+
+type BindingRotated struct {
+	PlanID string
+}
@@ -0,0 +1,146 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateBinding(t *testing.T) {
+	t.Run("should issue a new token for the binding", func(t *testing.T) {
+		// given
+		db := fixRotateBindingStorage(t, fixRotatedBinding("binding-id", domain.Succeeded))
+		publisher := event.NewPubSub(fixLogger())
+		var rotated atomic.Int32
+		publisher.Subscribe(BindingRotated{}, func(ctx context.Context, ev interface{}) error {
+			rotated.Add(1)
+			return nil
+		})
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		endpoint := NewRotateBinding(fixLogger(), db, broker.NewBindingsManagers(provider, provider, pkg.OIDCConfigDTO{}), publisher)
+
+		// when
+		response, err := endpoint.Rotate(context.Background(), instanceID1, "binding-id")
+
+		// then
+		require.NoError(t, err)
+		binding, err := db.Bindings().Get(instanceID1, "binding-id")
+		require.NoError(t, err)
+		assert.NotEqual(t, "old-kubeconfig", binding.Kubeconfig)
+		assert.Equal(t, binding.Kubeconfig, response.Credentials.(Credentials).Kubeconfig)
+		assert.True(t, binding.ExpiresAt.After(time.Now().Add(9*time.Minute)))
+		assert.Equal(t, binding.ExpiresAt.Format(expiresAtLayout), response.Metadata.ExpiresAt)
+		assert.Eventually(t, func() bool { return rotated.Load() == 1 }, time.Second, 10*time.Millisecond)
+	})
+
+	for name, tc := range map[string]struct {
+		binding            internal.Binding
+		bindingID          string
+		expectedStatusCode int
+	}{
+		"binding does not exist": {
+			binding:            fixRotatedBinding("binding-id", domain.Succeeded),
+			bindingID:          "other-binding-id",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		"binding in progress": {
+			binding:            fixRotatedBinding("binding-id", domain.InProgress),
+			bindingID:          "binding-id",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		"binding failed": {
+			binding:            fixRotatedBinding("binding-id", domain.Failed),
+			bindingID:          "binding-id",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		"binding expired": {
+			binding: func() internal.Binding {
+				binding := fixRotatedBinding("binding-id", domain.Succeeded)
+				binding.ExpiresAt = time.Now().Add(-time.Minute)
+				return binding
+			}(),
+			bindingID:          "binding-id",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			db := fixRotateBindingStorage(t, tc.binding)
+			provider := kubeconfig.NewFakeK8sClientProvider(nil)
+			endpoint := NewRotateBinding(fixLogger(), db, broker.NewBindingsManagers(provider, provider, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()))
+
+			// when
+			_, err := endpoint.Rotate(context.Background(), instanceID1, tc.bindingID)
+
+			// then
+			require.Error(t, err)
+			apiErr, ok := err.(*apiresponses.FailureResponse)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedStatusCode, apiErr.ValidatedStatusCode(nil))
+		})
+	}
+}
+
+func TestRotateBindingRoute(t *testing.T) {
+	// given
+	db := fixRotateBindingStorage(t, fixRotatedBinding("binding-id", domain.Succeeded))
+	provider := kubeconfig.NewFakeK8sClientProvider(nil)
+	endpoint := NewRotateBinding(fixLogger(), db, broker.NewBindingsManagers(provider, provider, pkg.OIDCConfigDTO{}), event.NewPubSub(fixLogger()))
+	router := httputil.NewRouter()
+	AttachBindingRotationRoutes(router, endpoint, 15*time.Second, []string{"/{region}", ""})
+
+	for _, prefix := range []string{"", "/cf-eu10"} {
+		t.Run(fmt.Sprintf("prefix %q", prefix), func(t *testing.T) {
+			// given
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/v2/service_instances/%s/service_bindings/binding-id/rotate", prefix, instanceID1), nil)
+			rr := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(rr, req)
+
+			// then
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"kubeconfig"`)
+			assert.Contains(t, rr.Body.String(), `"expires_at"`)
+		})
+	}
+}
+
+func fixRotateBindingStorage(t *testing.T, binding internal.Binding) storage.BrokerStorage {
+	db := storage.NewMemoryStorage()
+	instance := fixture.FixInstance(instanceID1)
+	instance.Parameters.Parameters.Name = "cluster"
+	require.NoError(t, db.Instances().Insert(instance))
+	require.NoError(t, db.Operations().InsertOperation(fixture.FixOperation("operation-id", instanceID1, internal.OperationTypeProvision)))
+	require.NoError(t, db.Bindings().Insert(&binding))
+	return db
+}
+
+func fixRotatedBinding(bindingID string, state domain.LastOperationState) internal.Binding {
+	return internal.Binding{
+		ID:                bindingID,
+		InstanceID:        instanceID1,
+		CreatedAt:         time.Now().Add(-5 * time.Minute),
+		ExpirationSeconds: 600,
+		ExpiresAt:         time.Now().Add(5 * time.Minute),
+		Kubeconfig:        "old-kubeconfig",
+		State:             state,
+	}
+}
@@ -0,0 +1,107 @@
+package broker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// bindingScope validates the role requested in binding parameters against the configuration and returns the scope to grant
+func (b *BindEndpoint) bindingScope(parameters BindingParams) (internal.BindingScope, error) {
+	scope := internal.BindingScope{
+		Role:       parameters.Role,
+		Namespaces: parameters.Namespaces,
+		Rules:      parameters.Rules,
+	}
+	defaultRole := internal.BindingScope{Role: b.config.DefaultRole}.RoleOrDefault()
+	if scope.Role == "" {
+		scope.Role = defaultRole
+	}
+	if scope.Role != defaultRole && !slices.Contains(b.config.AllowedRoles, scope.Role) {
+		return internal.BindingScope{}, fmt.Errorf("role %s is not allowed, allowed roles: %s", scope.Role, strings.Join(b.config.AllowedRoles, ", "))
+	}
+
+	for _, namespace := range scope.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return internal.BindingScope{}, fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	if len(slices.Compact(slices.Sorted(slices.Values(scope.Namespaces)))) != len(scope.Namespaces) {
+		return internal.BindingScope{}, fmt.Errorf("namespaces must be unique")
+	}
+
+	switch scope.Role {
+	case internal.BindingRoleClusterAdmin:
+		if len(scope.Namespaces) > 0 {
+			return internal.BindingScope{}, fmt.Errorf("role %s cannot be limited to namespaces", scope.Role)
+		}
+	case internal.BindingRoleNamespaceAdmin:
+		if len(scope.Namespaces) == 0 {
+			return internal.BindingScope{}, fmt.Errorf("role %s requires namespaces", scope.Role)
+		}
+	case internal.BindingRoleView, internal.BindingRoleEdit:
+	case internal.BindingRoleCustom:
+		if len(scope.Rules) == 0 {
+			return internal.BindingScope{}, fmt.Errorf("role %s requires rules", scope.Role)
+		}
+		for _, rule := range scope.Rules {
+			if err := b.validateRule(rule); err != nil {
+				return internal.BindingScope{}, err
+			}
+		}
+		return scope, nil
+	default:
+		return internal.BindingScope{}, fmt.Errorf("unsupported role %s", scope.Role)
+	}
+	if len(scope.Rules) > 0 {
+		return internal.BindingScope{}, fmt.Errorf("rules are supported only for role %s", internal.BindingRoleCustom)
+	}
+
+	return scope, nil
+}
+
+// credentialType validates the credential type requested in binding parameters against the configuration, service account
+// tokens are always allowed
+func (b *BindEndpoint) credentialType(parameters BindingParams) (string, error) {
+	credentialType := internal.Binding{CredentialType: parameters.CredentialType}.CredentialTypeOrDefault()
+	if credentialType != internal.BindingCredentialTypeServiceAccount && !slices.Contains(b.config.AllowedCredentialTypes, credentialType) {
+		return "", fmt.Errorf("credential type %s is not allowed, allowed credential types: %s", credentialType, strings.Join(b.config.AllowedCredentialTypes, ", "))
+	}
+	// permissions of OIDC users are managed in the cluster, the broker does not create any RBAC objects for them
+	if credentialType == internal.BindingCredentialTypeOIDC && (parameters.Role != "" || len(parameters.Namespaces) > 0 || len(parameters.Rules) > 0) {
+		return "", fmt.Errorf("credential type %s does not support role, namespaces and rules", credentialType)
+	}
+	return credentialType, nil
+}
+
+// validateRule checks that every resource and verb of the rule is in the allowlist
+func (b *BindEndpoint) validateRule(rule internal.BindingRule) error {
+	if len(rule.APIGroups) == 0 || len(rule.Resources) == 0 || len(rule.Verbs) == 0 {
+		return fmt.Errorf("rule must contain apiGroups, resources and verbs")
+	}
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			if !slices.Contains(b.config.CustomRuleResources, fmt.Sprintf("%s/%s", group, resource)) {
+				return fmt.Errorf("resource %s/%s is not allowed in custom rules", group, resource)
+			}
+		}
+	}
+	for _, verb := range rule.Verbs {
+		if !slices.Contains(b.config.CustomRuleVerbs, verb) {
+			return fmt.Errorf("verb %s is not allowed in custom rules", verb)
+		}
+	}
+	return nil
+}
+
+// sameScope compares scopes, bindings created before scopes were introduced have the cluster-admin role
+func sameScope(stored, requested internal.BindingScope) bool {
+	return stored.RoleOrDefault() == requested.RoleOrDefault() &&
+		slices.Equal(stored.Namespaces, requested.Namespaces) &&
+		slices.EqualFunc(stored.Rules, requested.Rules, func(a, b internal.BindingRule) bool {
+			return slices.Equal(a.APIGroups, b.APIGroups) && slices.Equal(a.Resources, b.Resources) && slices.Equal(a.Verbs, b.Verbs)
+		})
+}
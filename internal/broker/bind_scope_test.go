@@ -0,0 +1,116 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingScope(t *testing.T) {
+	cfg := fixBindingConfig()
+	cfg.DefaultRole = internal.BindingRoleView
+	cfg.AllowedRoles = StringList{internal.BindingRoleView, internal.BindingRoleNamespaceAdmin, internal.BindingRoleCustom}
+	cfg.CustomRuleResources = StringList{"/configmaps", "apps/deployments"}
+	cfg.CustomRuleVerbs = StringList{"get", "list"}
+	bindEndpoint := &BindEndpoint{config: cfg}
+
+	for name, tc := range map[string]struct {
+		params        BindingParams
+		expectedScope internal.BindingScope
+	}{
+		"default role": {
+			params:        BindingParams{},
+			expectedScope: internal.BindingScope{Role: internal.BindingRoleView},
+		},
+		"role limited to namespaces": {
+			params:        BindingParams{Role: internal.BindingRoleNamespaceAdmin, Namespaces: []string{"app", "default"}},
+			expectedScope: internal.BindingScope{Role: internal.BindingRoleNamespaceAdmin, Namespaces: []string{"app", "default"}},
+		},
+		"custom rules": {
+			params: BindingParams{Role: internal.BindingRoleCustom, Namespaces: []string{"app"}, Rules: []internal.BindingRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			}},
+			expectedScope: internal.BindingScope{Role: internal.BindingRoleCustom, Namespaces: []string{"app"}, Rules: []internal.BindingRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			scope, err := bindEndpoint.bindingScope(tc.params)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedScope, scope)
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		params        BindingParams
+		expectedError string
+	}{
+		"role not allowed": {
+			params:        BindingParams{Role: internal.BindingRoleClusterAdmin},
+			expectedError: "role cluster-admin is not allowed, allowed roles: view, namespace-admin, custom",
+		},
+		"namespace admin without namespaces": {
+			params:        BindingParams{Role: internal.BindingRoleNamespaceAdmin},
+			expectedError: "role namespace-admin requires namespaces",
+		},
+		"invalid namespace": {
+			params:        BindingParams{Namespaces: []string{"Default"}},
+			expectedError: `invalid namespace "Default": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+		"duplicated namespaces": {
+			params:        BindingParams{Namespaces: []string{"app", "app"}},
+			expectedError: "namespaces must be unique",
+		},
+		"rules for a predefined role": {
+			params:        BindingParams{Rules: []internal.BindingRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}}},
+			expectedError: "rules are supported only for role custom",
+		},
+		"custom role without rules": {
+			params:        BindingParams{Role: internal.BindingRoleCustom},
+			expectedError: "role custom requires rules",
+		},
+		"resource not allowed": {
+			params:        BindingParams{Role: internal.BindingRoleCustom, Rules: []internal.BindingRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}}},
+			expectedError: "resource /secrets is not allowed in custom rules",
+		},
+		"wildcard not allowed": {
+			params:        BindingParams{Role: internal.BindingRoleCustom, Rules: []internal.BindingRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}}}},
+			expectedError: "resource */* is not allowed in custom rules",
+		},
+		"verb not allowed": {
+			params:        BindingParams{Role: internal.BindingRoleCustom, Rules: []internal.BindingRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"delete"}}}},
+			expectedError: "verb delete is not allowed in custom rules",
+		},
+		"incomplete rule": {
+			params:        BindingParams{Role: internal.BindingRoleCustom, Rules: []internal.BindingRule{{Resources: []string{"deployments"}, Verbs: []string{"get"}}}},
+			expectedError: "rule must contain apiGroups, resources and verbs",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := bindEndpoint.bindingScope(tc.params)
+
+			// then
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestSameScope(t *testing.T) {
+	// given
+	legacy := internal.BindingScope{}
+	clusterAdmin := internal.BindingScope{Role: internal.BindingRoleClusterAdmin}
+	view := internal.BindingScope{Role: internal.BindingRoleView, Namespaces: []string{"app"}}
+
+	// then
+	assert.True(t, sameScope(legacy, clusterAdmin))
+	assert.True(t, sameScope(view, internal.BindingScope{Role: internal.BindingRoleView, Namespaces: []string{"app"}}))
+	assert.False(t, sameScope(view, internal.BindingScope{Role: internal.BindingRoleView}))
+	assert.False(t, sameScope(clusterAdmin, view))
+}
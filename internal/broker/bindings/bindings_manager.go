@@ -3,6 +3,7 @@ package broker
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
@@ -14,7 +15,6 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
 	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
 	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,12 +23,20 @@ const (
 	BindingNamespace  = "kyma-system"
 )
 
+// builtInClusterRoles maps binding roles to default ClusterRoles available in every cluster
+var builtInClusterRoles = map[string]string{
+	internal.BindingRoleView:           "view",
+	internal.BindingRoleEdit:           "edit",
+	internal.BindingRoleNamespaceAdmin: "admin",
+}
+
 type Credentials struct {
 }
 
 type BindingsManager interface {
-	Create(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error)
-	Delete(ctx context.Context, instance *internal.Instance, bindingID string) error
+	Create(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope, expirationSeconds int) (string, time.Time, error)
+	Delete(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope) error
+	RenewToken(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error)
 }
 
 type ClientProvider interface {
@@ -51,7 +59,7 @@ func NewServiceAccountBindingsManager(clientProvider ClientProvider, kubeconfigP
 	}
 }
 
-func (c *ServiceAccountBindingsManager) Create(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error) {
+func (c *ServiceAccountBindingsManager) Create(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope, expirationSeconds int) (string, time.Time, error) {
 	clientset, err := c.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
 
 	if err != nil {
@@ -74,49 +82,28 @@ func (c *ServiceAccountBindingsManager) Create(ctx context.Context, instance *in
 		return "", time.Time{}, fmt.Errorf("while creating a service account: %v", err)
 	}
 
-	_, err = clientset.RbacV1().ClusterRoles().Create(ctx,
-		&rbacv1.ClusterRole{
-			TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
-			ObjectMeta: mv1.ObjectMeta{
-				Name:   serviceBindingName,
-				Labels: map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
-			},
-			Rules: []rbacv1.PolicyRule{
-				{
-					Verbs:     []string{"*"},
-					APIGroups: []string{"*"},
-					Resources: []string{"*"},
-				},
-			},
-		}, mv1.CreateOptions{})
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return "", time.Time{}, fmt.Errorf("while creating a cluster role: %v", err)
+	err = grantScope(ctx, clientset, serviceBindingName, scope, serviceAccountSubjects(serviceBindingName))
+	if err != nil {
+		return "", time.Time{}, err
 	}
 
-	_, err = clientset.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
-		TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
-		ObjectMeta: mv1.ObjectMeta{
-			Name:   serviceBindingName,
-			Labels: map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
-		},
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: rbacv1.GroupName,
-			Kind:     "ClusterRole",
-			Name:     serviceBindingName,
-		},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind:      rbacv1.ServiceAccountKind,
-				Namespace: "kyma-system",
-				Name:      serviceBindingName,
-			},
-		},
-	}, mv1.CreateOptions{})
+	return c.createKubeconfig(ctx, clientset, instance, serviceBindingName, expirationSeconds)
+}
 
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return "", time.Time{}, fmt.Errorf("while creating a cluster role binding: %v", err)
+// RenewToken issues a new token for the existing service account of the binding and returns a kubeconfig with the token,
+// RBAC objects of the binding are not modified
+func (c *ServiceAccountBindingsManager) RenewToken(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error) {
+	clientset, err := c.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
+
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a runtime client for token renewal: %v", err)
 	}
 
+	// the token request fails if the service account was removed from the runtime
+	return c.createKubeconfig(ctx, clientset, instance, BindingName(bindingID), expirationSeconds)
+}
+
+func (c *ServiceAccountBindingsManager) createKubeconfig(ctx context.Context, clientset kubernetes.Interface, instance *internal.Instance, serviceBindingName string, expirationSeconds int) (string, time.Time, error) {
 	tokenRequest := &authv1.TokenRequest{
 		ObjectMeta: mv1.ObjectMeta{
 			Name:      serviceBindingName,
@@ -144,7 +131,7 @@ func (c *ServiceAccountBindingsManager) Create(ctx context.Context, instance *in
 	return kubeconfigContent, expiresAt, nil
 }
 
-func (c *ServiceAccountBindingsManager) Delete(ctx context.Context, instance *internal.Instance, bindingID string) error {
+func (c *ServiceAccountBindingsManager) Delete(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope) error {
 	clientset, err := c.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
 
 	if err != nil {
@@ -153,18 +140,9 @@ func (c *ServiceAccountBindingsManager) Delete(ctx context.Context, instance *in
 
 	serviceBindingName := BindingName(bindingID)
 
-	// remove a binding
-	err = clientset.RbacV1().ClusterRoleBindings().Delete(ctx, serviceBindingName, mv1.DeleteOptions{})
-
-	if err != nil && !apierrors.IsNotFound(err) {
-		return fmt.Errorf("while removing a cluster role binding: %v", err)
-	}
-
-	// remove a role
-	err = clientset.RbacV1().ClusterRoles().Delete(ctx, serviceBindingName, mv1.DeleteOptions{})
-
-	if err != nil && !apierrors.IsNotFound(err) {
-		return fmt.Errorf("while removing a cluster role: %v", err)
+	err = revokeScope(ctx, clientset, serviceBindingName, scope)
+	if err != nil {
+		return err
 	}
 
 	// remove an account
@@ -180,3 +158,9 @@ func (c *ServiceAccountBindingsManager) Delete(ctx context.Context, instance *in
 func BindingName(bindingID string) string {
 	return fmt.Sprintf(BindingNameFormat, bindingID)
 }
+
+// BindingIDFromName returns the ID of the binding which owns the runtime object with the given name
+func BindingIDFromName(name string) (string, bool) {
+	bindingID, found := strings.CutPrefix(name, BindingName(""))
+	return bindingID, found && bindingID != ""
+}
@@ -0,0 +1,168 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	bindingID   = "binding-id"
+	bindingName = "kyma-binding-binding-id"
+)
+
+func TestServiceAccountBindingsManager_ClusterAdmin(t *testing.T) {
+	// given
+	ctx := context.Background()
+	provider := kubeconfig.NewFakeK8sClientProvider(nil)
+	manager := NewServiceAccountBindingsManager(provider, provider)
+	clientset, _ := provider.K8sClientSetForRuntimeID("runtime-id")
+
+	// when
+	_, _, err := manager.Create(ctx, fixInstance(), bindingID, internal.BindingScope{}, 600)
+
+	// then
+	require.NoError(t, err)
+	clusterRole, err := clientset.RbacV1().ClusterRoles().Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*"}, clusterRole.Rules[0].Verbs)
+	clusterRoleBinding, err := clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, bindingName, clusterRoleBinding.RoleRef.Name)
+
+	// when
+	err = manager.Delete(ctx, fixInstance(), bindingID, internal.BindingScope{})
+
+	// then
+	require.NoError(t, err)
+	_, err = clientset.RbacV1().ClusterRoles().Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.CoreV1().ServiceAccounts(BindingNamespace).Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestServiceAccountBindingsManager_BuiltInRole(t *testing.T) {
+	t.Run("should bind cluster-wide", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		manager := NewServiceAccountBindingsManager(provider, provider)
+		clientset, _ := provider.K8sClientSetForRuntimeID("runtime-id")
+
+		// when
+		_, _, err := manager.Create(ctx, fixInstance(), bindingID, internal.BindingScope{Role: internal.BindingRoleView}, 600)
+
+		// then
+		require.NoError(t, err)
+		clusterRoleBinding, err := clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "view", clusterRoleBinding.RoleRef.Name)
+		_, err = clientset.RbacV1().ClusterRoles().Get(ctx, bindingName, mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("should bind in namespaces", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		manager := NewServiceAccountBindingsManager(provider, provider)
+		clientset, _ := provider.K8sClientSetForRuntimeID("runtime-id")
+		scope := internal.BindingScope{Role: internal.BindingRoleNamespaceAdmin, Namespaces: []string{"app", "default"}}
+
+		// when
+		_, _, err := manager.Create(ctx, fixInstance(), bindingID, scope, 600)
+
+		// then
+		require.NoError(t, err)
+		for _, namespace := range scope.Namespaces {
+			roleBinding, err := clientset.RbacV1().RoleBindings(namespace).Get(ctx, bindingName, mv1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "ClusterRole", roleBinding.RoleRef.Kind)
+			assert.Equal(t, "admin", roleBinding.RoleRef.Name)
+			assert.Equal(t, bindingName, roleBinding.Subjects[0].Name)
+			assert.Equal(t, BindingNamespace, roleBinding.Subjects[0].Namespace)
+		}
+		_, err = clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+
+		// when
+		err = manager.Delete(ctx, fixInstance(), bindingID, scope)
+
+		// then
+		require.NoError(t, err)
+		for _, namespace := range scope.Namespaces {
+			_, err = clientset.RbacV1().RoleBindings(namespace).Get(ctx, bindingName, mv1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(err))
+		}
+	})
+}
+
+func TestServiceAccountBindingsManager_CustomRole(t *testing.T) {
+	// given
+	ctx := context.Background()
+	provider := kubeconfig.NewFakeK8sClientProvider(nil)
+	manager := NewServiceAccountBindingsManager(provider, provider)
+	clientset, _ := provider.K8sClientSetForRuntimeID("runtime-id")
+	scope := internal.BindingScope{
+		Role:       internal.BindingRoleCustom,
+		Namespaces: []string{"app"},
+		Rules:      []internal.BindingRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list"}}},
+	}
+
+	// when
+	_, _, err := manager.Create(ctx, fixInstance(), bindingID, scope, 600)
+
+	// then
+	require.NoError(t, err)
+	role, err := clientset.RbacV1().Roles("app").Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"apps"}, role.Rules[0].APIGroups)
+	assert.Equal(t, []string{"deployments"}, role.Rules[0].Resources)
+	assert.Equal(t, []string{"get", "list"}, role.Rules[0].Verbs)
+	roleBinding, err := clientset.RbacV1().RoleBindings("app").Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Role", roleBinding.RoleRef.Kind)
+	assert.Equal(t, bindingName, roleBinding.RoleRef.Name)
+
+	// when
+	err = manager.Delete(ctx, fixInstance(), bindingID, scope)
+
+	// then
+	require.NoError(t, err)
+	_, err = clientset.RbacV1().Roles("app").Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.RbacV1().RoleBindings("app").Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func fixInstance() *internal.Instance {
+	instance := &internal.Instance{InstanceID: "instance-id", RuntimeID: "runtime-id"}
+	instance.Parameters.Parameters.Name = "cluster"
+	return instance
+}
+
+func TestServiceAccountBindingsManager_RenewToken(t *testing.T) {
+	// given
+	ctx := context.Background()
+	provider := kubeconfig.NewFakeK8sClientProvider(nil)
+	manager := NewServiceAccountBindingsManager(provider, provider)
+	_, expiresAt, err := manager.Create(ctx, fixInstance(), bindingID, internal.BindingScope{}, 600)
+	require.NoError(t, err)
+
+	// when
+	kubeconfig, renewedExpiresAt, err := manager.RenewToken(ctx, fixInstance(), bindingID, 1200)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEmpty(t, kubeconfig)
+	assert.True(t, renewedExpiresAt.After(expiresAt))
+}
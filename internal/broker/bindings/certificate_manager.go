@@ -0,0 +1,203 @@
+package broker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	certificateIssueInterval = time.Second
+	// certificateIssueTimeout must be shorter than the binding creation timeout (15s by default), so the kubeconfig
+	// is built before the request times out
+	certificateIssueTimeout = 10 * time.Second
+)
+
+// ClientCertificateBindingsManager returns kubeconfigs with a short-lived client certificate signed by the runtime's
+// kube-apiserver client signer. Permissions are granted to the user named after the binding.
+type ClientCertificateBindingsManager struct {
+	clientProvider    ClientProvider
+	kubeconfigBuilder *kubeconfig.Builder
+	pollInterval      time.Duration
+	pollTimeout       time.Duration
+}
+
+func NewClientCertificateBindingsManager(clientProvider ClientProvider, kubeconfigProvider KubeconfigProvider) *ClientCertificateBindingsManager {
+	return &ClientCertificateBindingsManager{
+		clientProvider:    clientProvider,
+		kubeconfigBuilder: kubeconfig.NewBuilder(nil, kubeconfigProvider),
+		pollInterval:      certificateIssueInterval,
+		pollTimeout:       certificateIssueTimeout,
+	}
+}
+
+func (m *ClientCertificateBindingsManager) Create(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope, expirationSeconds int) (string, time.Time, error) {
+	clientset, err := m.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a runtime client for binding creation: %v", err)
+	}
+
+	name := BindingName(bindingID)
+	err = grantScope(ctx, clientset, name, scope, userSubjects(name))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return m.createKubeconfig(ctx, clientset, instance, name, expirationSeconds)
+}
+
+// RenewToken issues a new client certificate for the binding's user, RBAC objects of the binding are not modified
+func (m *ClientCertificateBindingsManager) RenewToken(ctx context.Context, instance *internal.Instance, bindingID string, expirationSeconds int) (string, time.Time, error) {
+	clientset, err := m.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a runtime client for certificate renewal: %v", err)
+	}
+
+	return m.createKubeconfig(ctx, clientset, instance, BindingName(bindingID), expirationSeconds)
+}
+
+// Delete removes the RBAC objects and the CertificateSigningRequest of the binding. Kubernetes does not support revoking
+// client certificates, so certificates issued for the binding stay valid until they expire, without any permissions.
+func (m *ClientCertificateBindingsManager) Delete(ctx context.Context, instance *internal.Instance, bindingID string, scope internal.BindingScope) error {
+	clientset, err := m.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
+	if err != nil {
+		return fmt.Errorf("while creating a runtime client for binding removal: %v", err)
+	}
+
+	name := BindingName(bindingID)
+	err = revokeScope(ctx, clientset, name, scope)
+	if err != nil {
+		return err
+	}
+
+	err = clientset.CertificatesV1().CertificateSigningRequests().Delete(ctx, name, mv1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while deleting a certificate signing request: %v", err)
+	}
+
+	return nil
+}
+
+func (m *ClientCertificateBindingsManager) createKubeconfig(ctx context.Context, clientset kubernetes.Interface, instance *internal.Instance, name string, expirationSeconds int) (string, time.Time, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while generating a private key: %v", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: name}}, key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a certificate request: %v", err)
+	}
+
+	certificate, err := m.issueCertificate(ctx, clientset, name, csr, expirationSeconds)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return "", time.Time{}, fmt.Errorf("issued certificate is not PEM encoded")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while parsing the issued certificate: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while marshaling the private key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+
+	kubeconfigContent, err := m.kubeconfigBuilder.BuildFromAdminKubeconfigForCertificateBinding(instance.RuntimeID,
+		base64.StdEncoding.EncodeToString(certificate), base64.StdEncoding.EncodeToString(keyPEM), instance.Parameters.Parameters.Name)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a kubeconfig: %v", err)
+	}
+
+	return kubeconfigContent, parsed.NotAfter, nil
+}
+
+// issueCertificate creates and approves a CertificateSigningRequest, the request of a previous certificate is replaced
+func (m *ClientCertificateBindingsManager) issueCertificate(ctx context.Context, clientset kubernetes.Interface, name string, csr []byte, expirationSeconds int) ([]byte, error) {
+	requests := clientset.CertificatesV1().CertificateSigningRequests()
+	err := requests.Delete(ctx, name, mv1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("while deleting a previous certificate signing request: %v", err)
+	}
+
+	expiration := int32(expirationSeconds)
+	request, err := requests.Create(ctx, &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: mv1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+			SignerName:        certificatesv1.KubeAPIServerClientSignerName,
+			ExpirationSeconds: &expiration,
+			Usages:            []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageClientAuth},
+		},
+	}, mv1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while creating a certificate signing request: %v", err)
+	}
+
+	request.Status.Conditions = append(request.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           certificatesv1.CertificateApproved,
+		Status:         v1.ConditionTrue,
+		Reason:         "KymaBindingApproved",
+		Message:        "Approved by Kyma Environment Broker for a service binding",
+		LastUpdateTime: mv1.Now(),
+	})
+	_, err = requests.UpdateApproval(ctx, name, request, mv1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while approving a certificate signing request: %v", err)
+	}
+
+	var certificate []byte
+	err = wait.PollUntilContextTimeout(ctx, m.pollInterval, m.pollTimeout, true, func(ctx context.Context) (bool, error) {
+		issued, err := requests.Get(ctx, name, mv1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, condition := range issued.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				return false, fmt.Errorf("certificate signing request %s: %s", condition.Type, condition.Message)
+			}
+		}
+		certificate = issued.Status.Certificate
+		return len(certificate) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while waiting for the client certificate: %v", err)
+	}
+
+	return certificate, nil
+}
+
+func userSubjects(name string) []rbacv1.Subject {
+	return []rbacv1.Subject{
+		{
+			Kind:     rbacv1.UserKind,
+			Name:     name,
+			APIGroup: rbacv1.GroupName,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package broker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClientCertificateBindingsManager(t *testing.T) {
+	// given
+	ctx := context.Background()
+	provider := kubeconfig.NewFakeK8sClientProvider(nil)
+	clientset, _ := provider.K8sClientSetForRuntimeID("runtime-id")
+	notAfter := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	signCertificateRequests(t, clientset.(*fake.Clientset), notAfter)
+	manager := NewClientCertificateBindingsManager(provider, provider)
+	manager.pollInterval = time.Millisecond
+
+	// when
+	content, expiresAt, err := manager.Create(ctx, fixInstance(), bindingID, internal.BindingScope{Role: internal.BindingRoleView}, 600)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, content, "client-certificate-data:")
+	assert.Contains(t, content, "client-key-data:")
+	assert.True(t, expiresAt.Equal(notAfter.UTC()))
+	request, err := clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, certificatesv1.KubeAPIServerClientSignerName, request.Spec.SignerName)
+	assert.Equal(t, int32(600), *request.Spec.ExpirationSeconds)
+	clusterRoleBinding, err := clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, rbacv1.UserKind, clusterRoleBinding.Subjects[0].Kind)
+	assert.Equal(t, bindingName, clusterRoleBinding.Subjects[0].Name)
+
+	// when
+	renewed, _, err := manager.RenewToken(ctx, fixInstance(), bindingID, 600)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEqual(t, content, renewed)
+
+	// when
+	err = manager.Delete(ctx, fixInstance(), bindingID, internal.BindingScope{Role: internal.BindingRoleView})
+
+	// then
+	require.NoError(t, err)
+	_, err = clientset.RbacV1().ClusterRoleBindings().Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, bindingName, mv1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+// signCertificateRequests makes the fake clientset return a signed certificate for every stored CertificateSigningRequest
+func signCertificateRequests(t *testing.T, clientset *fake.Clientset, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: bindingName},
+		NotBefore:    time.Now(),
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	clientset.PrependReactor("get", "certificatesigningrequests", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.GetAction).GetName()
+		obj, err := clientset.Tracker().Get(certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"), "", name)
+		if err != nil {
+			return true, nil, err
+		}
+		request := obj.(*certificatesv1.CertificateSigningRequest).DeepCopy()
+		request.Status.Certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+		return true, request, nil
+	})
+}
@@ -0,0 +1,31 @@
+package broker
+
+import (
+	"fmt"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+)
+
+// BindingsManagers maps binding credential types to managers creating the credentials
+type BindingsManagers map[string]BindingsManager
+
+func NewBindingsManagers(clientProvider ClientProvider, kubeconfigProvider KubeconfigProvider, defaultOIDC pkg.OIDCConfigDTO) BindingsManagers {
+	return BindingsManagers{
+		internal.BindingCredentialTypeServiceAccount:    NewServiceAccountBindingsManager(clientProvider, kubeconfigProvider),
+		internal.BindingCredentialTypeOIDC:              NewOIDCBindingsManager(kubeconfigProvider, defaultOIDC),
+		internal.BindingCredentialTypeClientCertificate: NewClientCertificateBindingsManager(clientProvider, kubeconfigProvider),
+	}
+}
+
+// ForCredentialType returns the manager for the credential type, an empty type means a service account token
+func (m BindingsManagers) ForCredentialType(credentialType string) (BindingsManager, error) {
+	if credentialType == "" {
+		credentialType = internal.BindingCredentialTypeServiceAccount
+	}
+	manager, found := m[credentialType]
+	if !found {
+		return nil, fmt.Errorf("unsupported credential type %s", credentialType)
+	}
+	return manager, nil
+}
@@ -0,0 +1,77 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+)
+
+// OIDCBindingsManager returns kubeconfigs authenticating with the OIDC login plugin against the OIDC configuration of the instance.
+// The kubeconfig contains no credentials, permissions are granted to the OIDC users by the cluster administrator.
+type OIDCBindingsManager struct {
+	kubeconfigBuilder *kubeconfig.Builder
+	defaultOIDC       pkg.OIDCConfigDTO
+}
+
+func NewOIDCBindingsManager(kubeconfigProvider KubeconfigProvider, defaultOIDC pkg.OIDCConfigDTO) *OIDCBindingsManager {
+	return &OIDCBindingsManager{
+		kubeconfigBuilder: kubeconfig.NewBuilder(nil, kubeconfigProvider),
+		defaultOIDC:       defaultOIDC,
+	}
+}
+
+func (m *OIDCBindingsManager) Create(_ context.Context, instance *internal.Instance, _ string, _ internal.BindingScope, expirationSeconds int) (string, time.Time, error) {
+	return m.createKubeconfig(instance, expirationSeconds)
+}
+
+func (m *OIDCBindingsManager) RenewToken(_ context.Context, instance *internal.Instance, _ string, expirationSeconds int) (string, time.Time, error) {
+	return m.createKubeconfig(instance, expirationSeconds)
+}
+
+// Delete does nothing, OIDC bindings do not create any objects in the runtime
+func (m *OIDCBindingsManager) Delete(_ context.Context, _ *internal.Instance, _ string, _ internal.BindingScope) error {
+	return nil
+}
+
+func (m *OIDCBindingsManager) createKubeconfig(instance *internal.Instance, expirationSeconds int) (string, time.Time, error) {
+	clusterName := instance.Parameters.Parameters.Name
+	var oidcConfigs []kubeconfig.OIDCConfig
+	for i, config := range m.instanceOIDCConfigs(instance) {
+		if config.IssuerURL == "" || config.ClientID == "" {
+			continue
+		}
+		name := clusterName
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d", clusterName, i+1)
+		}
+		oidcConfigs = append(oidcConfigs, kubeconfig.OIDCConfig{
+			Name:      name,
+			IssuerURL: config.IssuerURL,
+			ClientID:  config.ClientID,
+		})
+	}
+
+	kubeconfigContent, err := m.kubeconfigBuilder.BuildFromAdminKubeconfigForOIDCBinding(instance.RuntimeID, oidcConfigs, clusterName)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("while creating a kubeconfig: %v", err)
+	}
+
+	return kubeconfigContent, time.Now().Add(time.Duration(expirationSeconds) * time.Second), nil
+}
+
+// instanceOIDCConfigs returns OIDC configurations provided for the instance, the default configuration is used if none was provided
+func (m *OIDCBindingsManager) instanceOIDCConfigs(instance *internal.Instance) []pkg.OIDCConfigDTO {
+	oidc := instance.Parameters.Parameters.OIDC
+	switch {
+	case oidc != nil && len(oidc.List) > 0:
+		return oidc.List
+	case oidc != nil && oidc.OIDCConfigDTO != nil && !oidc.OIDCConfigDTO.IsEmpty():
+		return []pkg.OIDCConfigDTO{*oidc.OIDCConfigDTO}
+	default:
+		return []pkg.OIDCConfigDTO{m.defaultOIDC}
+	}
+}
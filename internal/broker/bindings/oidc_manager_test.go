@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCBindingsManager(t *testing.T) {
+	defaultOIDC := pkg.OIDCConfigDTO{ClientID: "default-client", IssuerURL: "https://default.issuer"}
+
+	t.Run("should use the default OIDC configuration", func(t *testing.T) {
+		// given
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		manager := NewOIDCBindingsManager(provider, defaultOIDC)
+
+		// when
+		content, _, err := manager.Create(context.Background(), fixInstance(), bindingID, internal.BindingScope{}, 600)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, content, "--oidc-issuer-url=https://default.issuer")
+		assert.Contains(t, content, "--oidc-client-id=default-client")
+		assert.NotContains(t, content, "token:")
+	})
+
+	t.Run("should use OIDC configurations of the instance", func(t *testing.T) {
+		// given
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		manager := NewOIDCBindingsManager(provider, defaultOIDC)
+		instance := fixInstance()
+		instance.Parameters.Parameters.OIDC = &pkg.OIDCConnectDTO{
+			List: []pkg.OIDCConfigDTO{
+				{ClientID: "first-client", IssuerURL: "https://first.issuer"},
+				{ClientID: "second-client", IssuerURL: "https://second.issuer"},
+			},
+		}
+
+		// when
+		content, _, err := manager.Create(context.Background(), instance, bindingID, internal.BindingScope{}, 600)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, content, "--oidc-issuer-url=https://first.issuer")
+		assert.Contains(t, content, "--oidc-issuer-url=https://second.issuer")
+		assert.Contains(t, content, "name: cluster-2")
+		assert.NotContains(t, content, "default-client")
+	})
+
+	t.Run("should fail without any OIDC configuration", func(t *testing.T) {
+		// given
+		provider := kubeconfig.NewFakeK8sClientProvider(nil)
+		manager := NewOIDCBindingsManager(provider, pkg.OIDCConfigDTO{})
+
+		// when
+		_, _, err := manager.Create(context.Background(), fixInstance(), bindingID, internal.BindingScope{}, 600)
+
+		// then
+		assert.ErrorContains(t, err, "OIDC configuration must not be empty")
+	})
+}
@@ -0,0 +1,190 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// grantScope creates RBAC objects binding the subjects to the role. Roles limited to namespaces are granted with
+// RoleBindings in every namespace, other roles with a ClusterRoleBinding.
+func grantScope(ctx context.Context, clientset kubernetes.Interface, name string, scope internal.BindingScope, subjects []rbacv1.Subject) error {
+	role := scope.RoleOrDefault()
+	switch role {
+	case internal.BindingRoleClusterAdmin:
+		err := createClusterRole(ctx, clientset, name, []rbacv1.PolicyRule{
+			{
+				Verbs:     []string{"*"},
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return createClusterRoleBinding(ctx, clientset, name, name, subjects)
+	case internal.BindingRoleView, internal.BindingRoleEdit, internal.BindingRoleNamespaceAdmin:
+		if len(scope.Namespaces) == 0 {
+			return createClusterRoleBinding(ctx, clientset, name, builtInClusterRoles[role], subjects)
+		}
+		for _, namespace := range scope.Namespaces {
+			err := createRoleBinding(ctx, clientset, namespace, name, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: builtInClusterRoles[role]}, subjects)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case internal.BindingRoleCustom:
+		rules := policyRules(scope.Rules)
+		if len(scope.Namespaces) == 0 {
+			if err := createClusterRole(ctx, clientset, name, rules); err != nil {
+				return err
+			}
+			return createClusterRoleBinding(ctx, clientset, name, name, subjects)
+		}
+		for _, namespace := range scope.Namespaces {
+			if err := createRole(ctx, clientset, namespace, name, rules); err != nil {
+				return err
+			}
+			err := createRoleBinding(ctx, clientset, namespace, name, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}, subjects)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported binding role %s", role)
+}
+
+func createClusterRole(ctx context.Context, clientset kubernetes.Interface, name string, rules []rbacv1.PolicyRule) error {
+	_, err := clientset.RbacV1().ClusterRoles().Create(ctx,
+		&rbacv1.ClusterRole{
+			TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: mv1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+			},
+			Rules: rules,
+		}, mv1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("while creating a cluster role: %v", err)
+	}
+	return nil
+}
+
+func createClusterRoleBinding(ctx context.Context, clientset kubernetes.Interface, name, clusterRoleName string, subjects []rbacv1.Subject) error {
+	_, err := clientset.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+		ObjectMeta: mv1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+		Subjects: subjects,
+	}, mv1.CreateOptions{})
+
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("while creating a cluster role binding: %v", err)
+	}
+	return nil
+}
+
+func createRole(ctx context.Context, clientset kubernetes.Interface, namespace, name string, rules []rbacv1.PolicyRule) error {
+	_, err := clientset.RbacV1().Roles(namespace).Create(ctx,
+		&rbacv1.Role{
+			TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: mv1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+			},
+			Rules: rules,
+		}, mv1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("while creating a role in namespace %s: %v", namespace, err)
+	}
+	return nil
+}
+
+func createRoleBinding(ctx context.Context, clientset kubernetes.Interface, namespace, name string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) error {
+	_, err := clientset.RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+		TypeMeta: mv1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: mv1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+		},
+		RoleRef:  roleRef,
+		Subjects: subjects,
+	}, mv1.CreateOptions{})
+
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("while creating a role binding in namespace %s: %v", namespace, err)
+	}
+	return nil
+}
+
+func serviceAccountSubjects(name string) []rbacv1.Subject {
+	return []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: BindingNamespace,
+			Name:      name,
+		},
+	}
+}
+
+func policyRules(rules []internal.BindingRule) []rbacv1.PolicyRule {
+	policyRules := make([]rbacv1.PolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		policyRules = append(policyRules, rbacv1.PolicyRule{
+			APIGroups: rule.APIGroups,
+			Resources: rule.Resources,
+			Verbs:     rule.Verbs,
+		})
+	}
+	return policyRules
+}
+
+// revokeScope removes RBAC objects created by grantScope, objects which do not exist are skipped
+func revokeScope(ctx context.Context, clientset kubernetes.Interface, name string, scope internal.BindingScope) error {
+	// remove bindings and roles limited to namespaces
+	for _, namespace := range scope.Namespaces {
+		err := clientset.RbacV1().RoleBindings(namespace).Delete(ctx, name, mv1.DeleteOptions{})
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("while removing a role binding in namespace %s: %v", namespace, err)
+		}
+
+		err = clientset.RbacV1().Roles(namespace).Delete(ctx, name, mv1.DeleteOptions{})
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("while removing a role in namespace %s: %v", namespace, err)
+		}
+	}
+
+	// remove a binding
+	err := clientset.RbacV1().ClusterRoleBindings().Delete(ctx, name, mv1.DeleteOptions{})
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while removing a cluster role binding: %v", err)
+	}
+
+	// remove a role
+	err = clientset.RbacV1().ClusterRoles().Delete(ctx, name, mv1.DeleteOptions{})
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while removing a cluster role: %v", err)
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ const (
 
 	instancesURL       = "/oauth/v2/service_instances"
 	expireInstanceURL  = "/expire/service_instance"
+	reconcileTmpl      = "%s/instances/%s/reconcile"
 	deprovisionTmpl    = "%s%s/%s?service_id=%s&plan_id=%s"
 	updateInstanceTmpl = "%s%s/%s"
 	getInstanceTmpl    = "%s%s/%s"
@@ -200,6 +201,19 @@ func (c *Client) GetInstanceRequest(instanceID string) (response *http.Response,
 	return resp, nil
 }
 
+// ReconcileInstance requests an update operation which applies the stored parameters of the instance to its Runtime resource
+func (c *Client) ReconcileInstance(instanceID string) (string, error) {
+	reconcileURL := fmt.Sprintf(reconcileTmpl, c.brokerConfig.URL, instanceID)
+
+	slog.Info(fmt.Sprintf("Requesting reconciliation of the environment with instance id: %q", instanceID))
+	response := serviceInstancesResponseDTO{}
+	if err := c.executeRequest(http.MethodPost, reconcileURL, http.StatusAccepted, nil, &response); err != nil {
+		return "", fmt.Errorf("while requesting reconciliation of instance %s: %w", instanceID, err)
+	}
+
+	return response.Operation, nil
+}
+
 // Unbind requests Service Binding unbinding in KEB with given details
 func (c *Client) Unbind(binding internal.Binding) error {
 	unbindURL, err := c.formatUnbindUrl(binding)
@@ -185,12 +185,47 @@ func TestClient_ExpirationRequest(t *testing.T) {
 	})
 }
 
+func TestClient_ReconcileInstance(t *testing.T) {
+	t.Run("should return update operation ID on success", func(t *testing.T) {
+		// given
+		testServer := fixHTTPServer(t, nil)
+		defer testServer.Close()
+
+		client := NewClient(context.Background(), *NewClientConfig(testServer.URL))
+		client.setHttpClient(testServer.Client())
+
+		// when
+		opID, err := client.ReconcileInstance(fixInstanceID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, fixOpID, opID)
+	})
+
+	t.Run("should return error on failed request execution", func(t *testing.T) {
+		// given
+		testServer := fixHTTPServer(t, requestFailureServerError)
+		defer testServer.Close()
+
+		client := NewClient(context.Background(), *NewClientConfig(testServer.URL))
+		client.setHttpClient(testServer.Client())
+
+		// when
+		opID, err := client.ReconcileInstance(fixInstanceID)
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, opID)
+	})
+}
+
 func fixHTTPServer(t *testing.T, requestFailureFunc func(http.ResponseWriter, *http.Request)) *httptest.Server {
 	if requestFailureFunc != nil {
 		r := httputil.NewRouter()
 		r.HandleFunc("DELETE /oauth/v2/service_instances/{instance_id}", requestFailureFunc)
 		r.HandleFunc("PATCH /oauth/v2/service_instances/{instance_id}", requestFailureFunc)
 		r.HandleFunc("PUT /expire/service_instance/{instance_id}", requestFailureFunc)
+		r.HandleFunc("POST /instances/{instance_id}/reconcile", requestFailureFunc)
 		return httptest.NewServer(r)
 	}
 
@@ -199,6 +234,7 @@ func fixHTTPServer(t *testing.T, requestFailureFunc func(http.ResponseWriter, *h
 	r.HandleFunc("DELETE /oauth/v2/service_instances/{instance_id}", clientTest.deprovision)
 	r.HandleFunc("PUT /expire/service_instance/{instance_id}", clientTest.expiration)
 	r.HandleFunc("GET /oauth/v2/service_instances/{instance_id}", clientTest.getInstance)
+	r.HandleFunc("POST /instances/{instance_id}/reconcile", clientTest.reconcile)
 	return httptest.NewServer(r)
 }
 
@@ -236,6 +272,12 @@ func (c *ClientTest) deprovision(w http.ResponseWriter, r *http.Request) {
 	assert.NoError(c.t, err)
 }
 
+func (c *ClientTest) reconcile(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+	_, err := fmt.Fprintf(w, `{"operation": "%s"}`, fixOpID)
+	assert.NoError(c.t, err)
+}
+
 func requestFailureServerError(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusInternalServerError)
 }
@@ -0,0 +1,214 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/google/uuid"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type (
+	// OperationPreview contains the resources which the processing steps would create for the operation
+	OperationPreview struct {
+		KymaTemplate string
+		Kyma         *unstructured.Unstructured
+		Runtime      *imv1.Runtime
+	}
+
+	OperationPreviewer interface {
+		Preview(operation internal.Operation, log *slog.Logger) (OperationPreview, error)
+	}
+)
+
+// DryRunResult describes the outcome of a provisioning or update request which is validated, but not executed
+type DryRunResult struct {
+	ProvisioningParameters internal.ProvisioningParameters `json:"provisioningParameters"`
+	ProviderValues         internal.ProviderValues         `json:"providerValues"`
+	HyperscalerRule        string                          `json:"hyperscalerRule,omitempty"`
+	// AvailableZonesCount is the number of zones supporting the machine type, set only for providers with zones discovery
+	AvailableZonesCount map[string]int             `json:"availableZonesCount,omitempty"`
+	KymaTemplate        string                     `json:"kymaTemplate,omitempty"`
+	Kyma                *unstructured.Unstructured `json:"kyma,omitempty"`
+	Runtime             *imv1.Runtime              `json:"runtime,omitempty"`
+}
+
+// DryRun validates the provisioning request and returns what would be provisioned, nothing is persisted
+//
+//	PUT /v2/service_instances/{instance_id}/dry_run
+func (b *ProvisionEndpoint) DryRun(ctx context.Context, instanceID string, details domain.ProvisionDetails) (DryRunResult, error) {
+	logger := b.log.With("instanceID", instanceID, "planID", details.PlanID, "dryRun", true)
+	logger.Info(fmt.Sprintf("Provision dry run called with context: %s", marshallRawContext(hideSensitiveDataFromRawContext(details.RawContext))))
+
+	provisioningParameters, err := b.provisioningParameters(ctx, details)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+	providerValues, err := b.valuesProvider.ValuesForPlanAndParameters(provisioningParameters)
+	if err != nil {
+		errMsg := fmt.Sprintf("unable to provide default values for instance %s: %s", instanceID, err)
+		return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, errMsg)
+	}
+	availableZonesCount, err := b.validate(ctx, details, provisioningParameters, logger)
+	if err != nil {
+		return DryRunResult{}, validationFailure(instanceID, err)
+	}
+
+	operation, err := b.newOperation(uuid.New().String(), instanceID, details.PlanID, provisioningParameters, providerValues)
+	if err != nil {
+		logger.Error(fmt.Sprintf("cannot create new operation: %s", err))
+		return DryRunResult{}, fmt.Errorf("cannot create new operation")
+	}
+
+	return newDryRunResult(operation.Operation, availableZonesCount, b.rulesService, b.operationPreviewer, logger)
+}
+
+// UseOperationPreviewer enables rendering of the Kyma template and the Runtime resource in dry run results
+func (b *ProvisionEndpoint) UseOperationPreviewer(previewer OperationPreviewer) {
+	b.operationPreviewer = previewer
+}
+
+// DryRun validates the update request and returns what would be updated, nothing is persisted
+//
+//	PATCH /v2/service_instances/{instance_id}/dry_run
+func (b *UpdateEndpoint) DryRun(ctx context.Context, instanceID string, details domain.UpdateDetails) (DryRunResult, error) {
+	logger := b.log.With("instanceID", instanceID, "dryRun", true)
+	logger.Info(fmt.Sprintf("Update dry run called with parameters: '%s'", string(details.RawParameters)))
+
+	instance, err := b.instanceStorage.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusNotFound, fmt.Sprintf("could not execute update dry run for instanceID %s", instanceID))
+	case err != nil:
+		logger.Error(fmt.Sprintf("unable to get instance: %s", err.Error()))
+		return DryRunResult{}, fmt.Errorf("unable to get instance")
+	}
+	var ersContext internal.ERSContext
+	if err := json.Unmarshal(details.RawContext, &ersContext); err != nil {
+		return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "unable to unmarshal context")
+	}
+	if err := b.validateWithJsonSchemaValidator(details, instance); err != nil {
+		return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "validation failed")
+	}
+	if instance.IsExpired() {
+		return DryRunResult{}, apiresponses.NewFailureResponse(fmt.Errorf("cannot update an expired instance"), http.StatusBadRequest, "")
+	}
+
+	params, err := updatingParameters(details, logger)
+	if err != nil {
+		return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+	}
+	operation, availableZonesCount, err := b.prepareOperation(ctx, uuid.New().String(), instance, details, params, ersContext, logger)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	return newDryRunResult(operation, availableZonesCount, b.rulesService, b.operationPreviewer, logger)
+}
+
+// UseOperationPreviewer enables rendering of the Kyma template and the Runtime resource in dry run results
+func (b *UpdateEndpoint) UseOperationPreviewer(previewer OperationPreviewer) {
+	b.operationPreviewer = previewer
+}
+
+func newDryRunResult(operation internal.Operation, availableZonesCount map[string]int, rulesService *rules.RulesService, previewer OperationPreviewer, logger *slog.Logger) (DryRunResult, error) {
+	result := DryRunResult{
+		ProvisioningParameters: operation.ProvisioningParameters,
+		ProviderValues:         *operation.ProviderValues,
+		AvailableZonesCount:    availableZonesCount,
+	}
+	// the result is returned to the caller, credentials passed in the request are not exposed
+	result.ProvisioningParameters.ErsContext.SMOperatorCredentials = nil
+	if result.ProvisioningParameters.Parameters.Kubeconfig != "" {
+		result.ProvisioningParameters.Parameters.Kubeconfig = maskedKubeconfig
+	}
+
+	if rulesService != nil {
+		attr := NewProvisioningAttributes(operation.ProvisioningParameters, *operation.ProviderValues)
+		if parsedRule, found := rulesService.MatchProvisioningAttributesWithValidRuleset(attr); found {
+			result.HyperscalerRule = parsedRule.Rule()
+		}
+	}
+
+	if previewer != nil {
+		preview, err := previewer.Preview(operation, logger)
+		if err != nil {
+			logger.Error(fmt.Sprintf("unable to render operation resources: %s", err))
+			return DryRunResult{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+		result.KymaTemplate = preview.KymaTemplate
+		result.Kyma = preview.Kyma
+		result.Runtime = preview.Runtime
+	}
+
+	return result, nil
+}
+
+type dryRunHandler struct {
+	provisionEndpoint *ProvisionEndpoint
+	updateEndpoint    *UpdateEndpoint
+	log               *slog.Logger
+}
+
+// AttachDryRunRoutes registers the dry run endpoints, the router must already contain the middlewares of the OSB API
+func AttachDryRunRoutes(router *httputil.Router, provisionEndpoint *ProvisionEndpoint, updateEndpoint *UpdateEndpoint, logger *slog.Logger, prefixes []string) {
+	h := &dryRunHandler{
+		provisionEndpoint: provisionEndpoint,
+		updateEndpoint:    updateEndpoint,
+		log:               logger.With("service", "DryRunEndpoint"),
+	}
+	for _, prefix := range prefixes {
+		router.HandleFunc(buildPathPattern(http.MethodPut, prefix, "/v2/service_instances/{instance_id}/dry_run"), h.provision)
+		router.HandleFunc(buildPathPattern(http.MethodPatch, prefix, "/v2/service_instances/{instance_id}/dry_run"), h.update)
+	}
+}
+
+func (h *dryRunHandler) provision(w http.ResponseWriter, req *http.Request) {
+	var details domain.ProvisionDetails
+	if err := json.NewDecoder(req.Body).Decode(&details); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusUnprocessableEntity, fmt.Errorf("while decoding request body: %w", err))
+		return
+	}
+	result, err := h.provisionEndpoint.DryRun(req.Context(), req.PathValue("instance_id"), details)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
+
+func (h *dryRunHandler) update(w http.ResponseWriter, req *http.Request) {
+	var details domain.UpdateDetails
+	if err := json.NewDecoder(req.Body).Decode(&details); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusUnprocessableEntity, fmt.Errorf("while decoding request body: %w", err))
+		return
+	}
+	result, err := h.updateEndpoint.DryRun(req.Context(), req.PathValue("instance_id"), details)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
+
+func (h *dryRunHandler) writeError(w http.ResponseWriter, err error) {
+	var failureResponse *apiresponses.FailureResponse
+	if errors.As(err, &failureResponse) {
+		httputil.WriteErrorResponse(w, failureResponse.ValidatedStatusCode(h.log), err)
+		return
+	}
+	h.log.Error(fmt.Sprintf("dry run failed: %s", err))
+	httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+}
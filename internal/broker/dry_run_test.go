@@ -0,0 +1,167 @@
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal/config"
+	kcMock "github.com/kyma-project/kyma-environment-broker/internal/kubeconfig/automock"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/whitelist"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/pivotal-cf/brokerapi/v12/domain/apiresponses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionEndpoint_DryRun(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	queue := &automock.Queue{}
+	kcBuilder := &kcMock.KcBuilder{}
+	previewer := &operationPreviewerStub{}
+	provisionEndpoint := broker.NewProvision(
+		broker.Config{EnablePlans: []string{"gcp", "azure"}, URL: brokerURL},
+		gardener.Config{Project: "test", ShootDomain: "example.com", DNSProviders: fixDNSProviders()},
+		imConfigFixture,
+		memoryStorage,
+		queue,
+		broker.PlansConfig{},
+		fixLogger(),
+		dashboardConfig,
+		kcBuilder,
+		whitelist.Set{},
+		newSchemaService(t),
+		newProviderSpec(t),
+		fixValueProvider(t),
+		false,
+		config.FakeProviderConfigProvider{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		map[string]string{},
+	)
+	provisionEndpoint.UseOperationPreviewer(previewer)
+
+	t.Run("should return resolved provisioning without persisting it", func(t *testing.T) {
+		// when
+		result, err := provisionEndpoint.DryRun(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        planID,
+			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "region": "%s"}`, clusterName, clusterRegion)),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s", "sm_operator_credentials": {"clientid": "id", "clientsecret": "secret"}}`, globalAccountID, subAccountID, userID)),
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, clusterName, result.ProvisioningParameters.Parameters.Name)
+		assert.Equal(t, "req-region", result.ProvisioningParameters.PlatformRegion)
+		assert.Nil(t, result.ProvisioningParameters.ErsContext.SMOperatorCredentials)
+		assert.Equal(t, clusterRegion, result.ProviderValues.Region)
+		assert.Equal(t, "kyma-template", result.KymaTemplate)
+		require.NotNil(t, result.Runtime)
+		assert.Equal(t, previewer.operation.ShootName, result.Runtime.Spec.Shoot.Name)
+		assert.Equal(t, fixDNSProviders(), previewer.operation.ShootDNSProviders)
+
+		_, err = memoryStorage.Instances().GetByID(instanceID)
+		assert.Error(t, err)
+		operations, err := memoryStorage.Operations().ListOperationsByInstanceID(instanceID)
+		require.NoError(t, err)
+		assert.Empty(t, operations)
+		queue.AssertNotCalled(t, "Add")
+	})
+
+	t.Run("should return validation error", func(t *testing.T) {
+		// when
+		_, err := provisionEndpoint.DryRun(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        broker.AWSPlanID,
+			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+		})
+
+		// then
+		require.Error(t, err)
+		apierr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apierr.ValidatedStatusCode(nil))
+	})
+}
+
+func TestUpdateEndpoint_DryRun(t *testing.T) {
+	// given
+	instance := internal.Instance{
+		InstanceID:    instanceID,
+		ServicePlanID: broker.AWSPlanID,
+		Parameters: internal.ProvisioningParameters{
+			PlanID: broker.AWSPlanID,
+		},
+	}
+	st := storage.NewMemoryStorage()
+	require.NoError(t, st.Instances().Insert(instance))
+	provisioningOperation := fixProvisioningOperation("01")
+	provisioningOperation.ProvisioningParameters.PlanID = broker.AWSPlanID
+	require.NoError(t, st.Operations().InsertProvisioningOperation(provisioningOperation))
+	queue := &automock.Queue{}
+	svc := broker.NewUpdate(broker.Config{EnablePlanUpgrades: true}, st, &handler{}, true, false, true, queue, broker.PlansConfig{},
+		fixValueProvider(t), fixLogger(),
+		dashboardConfig, &kcMock.KcBuilder{}, fakeKcpK8sClient, newProviderSpec(t), newPlanSpec(t), imConfigFixture, newSchemaService(t), nil, nil, nil, nil, nil)
+
+	t.Run("should return plan change without updating the instance", func(t *testing.T) {
+		// when
+		result, err := svc.DryRun(context.Background(), instanceID, domain.UpdateDetails{
+			PlanID:        broker.BuildRuntimeAWSPlanID,
+			RawParameters: json.RawMessage("{}"),
+			RawContext:    json.RawMessage("{}"),
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, broker.BuildRuntimeAWSPlanID, result.ProvisioningParameters.PlanID)
+
+		actual, err := st.Instances().GetByID(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, broker.AWSPlanID, actual.ServicePlanID)
+		operations, err := st.Operations().ListOperationsByInstanceID(instanceID)
+		require.NoError(t, err)
+		assert.Len(t, operations, 1)
+		queue.AssertNotCalled(t, "Add")
+	})
+
+	t.Run("should return 404 Not Found for missing instance", func(t *testing.T) {
+		// when
+		_, err := svc.DryRun(context.Background(), "not-existing", domain.UpdateDetails{
+			RawParameters: json.RawMessage("{}"),
+			RawContext:    json.RawMessage("{}"),
+		})
+
+		// then
+		require.Error(t, err)
+		apierr, ok := err.(*apiresponses.FailureResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apierr.ValidatedStatusCode(nil))
+	})
+}
+
+type operationPreviewerStub struct {
+	operation internal.Operation
+}
+
+func (p *operationPreviewerStub) Preview(operation internal.Operation, _ *slog.Logger) (broker.OperationPreview, error) {
+	p.operation = operation
+	runtime := &imv1.Runtime{}
+	runtime.Spec.Shoot.Name = operation.ShootName
+	return broker.OperationPreview{KymaTemplate: "kyma-template", Runtime: runtime}, nil
+}
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"net"
 	"net/http"
 	"net/netip"
 	"os"
@@ -23,8 +22,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/config"
 	"github.com/kyma-project/kyma-environment-broker/internal/dashboard"
 	error2 "github.com/kyma-project/kyma-environment-broker/internal/error"
-	"github.com/kyma-project/kyma-environment-broker/internal/euaccess"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/kyma-environment-broker/internal/networking"
@@ -100,8 +98,9 @@ type ProvisionEndpoint struct {
 	quotaWhitelist         whitelist.Set
 	rulesService           *rules.RulesService
 	gardenerClient         *gardener.Client
-	awsClientFactory       aws.ClientFactory
+	zonesClientFactory     hyperscalers.ZonesClientFactory
 	useCredentialsBindings bool
+	operationPreviewer     OperationPreviewer
 
 	btpRegionsMigrationSapConvergedCloud map[string]string
 }
@@ -133,7 +132,7 @@ func NewProvision(brokerConfig Config,
 	quotaWhitelist whitelist.Set,
 	rulesService *rules.RulesService,
 	gardenerClient *gardener.Client,
-	awsClientFactory aws.ClientFactory,
+	zonesClientFactory hyperscalers.ZonesClientFactory,
 	btpRegionsMigrationSapConvergedCloud map[string]string,
 ) *ProvisionEndpoint {
 	enabledPlanIDs := map[string]struct{}{}
@@ -167,7 +166,7 @@ func NewProvision(brokerConfig Config,
 		quotaWhitelist:                       quotaWhitelist,
 		rulesService:                         rulesService,
 		gardenerClient:                       gardenerClient,
-		awsClientFactory:                     awsClientFactory,
+		zonesClientFactory:                   zonesClientFactory,
 		btpRegionsMigrationSapConvergedCloud: btpRegionsMigrationSapConvergedCloud,
 	}
 }
@@ -180,44 +179,17 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	logger := b.log.With("instanceID", instanceID, "operationID", operationID, "planID", details.PlanID)
 	logger.Info(fmt.Sprintf("Provision called with context: %s", marshallRawContext(hideSensitiveDataFromRawContext(details.RawContext))))
 
-	region, found := middleware.RegionFromContext(ctx)
-	if !found {
-		err := fmt.Errorf("%s", "No region specified in request.")
-		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusInternalServerError, "provisioning")
-	}
-	platformProvider, found := middleware.ProviderFromContext(ctx)
-	if !found {
-		err := fmt.Errorf("%s", "No provider specified in request.")
-		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusInternalServerError, "provisioning")
-	}
-
-	// EXTRACT INPUT PARAMETERS / PROVISIONING PARAMETERS
-	parameters, err := b.extractInputParameters(details)
+	provisioningParameters, err := b.provisioningParameters(ctx, details)
 	if err != nil {
-		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "while extracting input parameters")
+		return domain.ProvisionedServiceSpec{}, err
 	}
-	ersContext, err := b.extractERSContext(details)
+	parameters := provisioningParameters.Parameters
+	ersContext := provisioningParameters.ErsContext
+	region := provisioningParameters.PlatformRegion
 	logger = logger.With("globalAccountID", ersContext.GlobalAccountID)
-	if err != nil {
-		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "while extracting context")
-	}
 	if b.config.MonitorAdditionalProperties {
 		b.monitorAdditionalProperties(instanceID, ersContext, details.RawParameters)
 	}
-	provisioningParameters := internal.ProvisioningParameters{
-		PlanID:           details.PlanID,
-		ServiceID:        details.ServiceID,
-		ErsContext:       ersContext,
-		Parameters:       parameters,
-		PlatformRegion:   region,
-		PlatformProvider: platformProvider,
-	}
-	// TODO: remove once we implemented proper filtering of parameters - removing parameters that are not supported by the plan
-	if details.PlanID == TrialPlanID {
-		provisioningParameters.Parameters.MachineType = nil
-		provisioningParameters.Parameters.AutoScalerMin = nil
-		provisioningParameters.Parameters.AutoScalerMax = nil
-	}
 	providerValues, err := b.valuesProvider.ValuesForPlanAndParameters(provisioningParameters)
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to provide default values for instance %s: %s", instanceID, err)
@@ -225,13 +197,8 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	}
 
 	// validation of incoming input
-	err = b.validate(ctx, details, provisioningParameters, logger)
-	if err != nil {
-		if error2.IsTemporaryError(err) {
-			return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("internal error"), http.StatusInternalServerError, err.Error())
-		}
-		errMsg := fmt.Sprintf("[instanceID: %s] %s", instanceID, err)
-		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, errMsg)
+	if _, err = b.validate(ctx, details, provisioningParameters, logger); err != nil {
+		return domain.ProvisionedServiceSpec{}, validationFailure(instanceID, err)
 	}
 
 	logger.Info(fmt.Sprintf("Starting provisioning runtime: Name=%s, GlobalAccountID=%s, SubAccountID=%s, PlatformRegion=%s, ProvisioningParameters.Region=%s, ProvisioningParameters.ColocateControlPlane=%t, ProvisioningParameters.MachineType=%s",
@@ -249,23 +216,12 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		return b.handleExistingOperation(existingOperation, provisioningParameters)
 	}
 
-	shootName := gardener.CreateShootName()
-	shootDomainSuffix := strings.Trim(b.shootDomain, ".")
-
-	dashboardURL := b.createDashboardURL(details.PlanID, instanceID)
-
-	// create and save new operation
-	operation, err := internal.NewProvisioningOperationWithID(operationID, instanceID, provisioningParameters)
+	operation, err := b.newOperation(operationID, instanceID, details.PlanID, provisioningParameters, providerValues)
 	if err != nil {
 		logger.Error(fmt.Sprintf("cannot create new operation: %s", err))
 		return domain.ProvisionedServiceSpec{}, fmt.Errorf("cannot create new operation")
 	}
-
-	operation.ProviderValues = &providerValues
-	operation.ShootName = shootName
-	operation.ShootDomain = fmt.Sprintf("%s.%s", shootName, shootDomainSuffix)
-	operation.ShootDNSProviders = b.shootDnsProviders
-	operation.DashboardURL = dashboardURL
+	dashboardURL := operation.DashboardURL
 	logger.Info(fmt.Sprintf("Runtime ShootDomain: %s", operation.ShootDomain))
 
 	err = b.operationsStorage.InsertOperation(operation.Operation)
@@ -313,6 +269,73 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	}, nil
 }
 
+// provisioningParameters extracts the provisioning parameters from the request
+func (b *ProvisionEndpoint) provisioningParameters(ctx context.Context, details domain.ProvisionDetails) (internal.ProvisioningParameters, error) {
+	region, found := middleware.RegionFromContext(ctx)
+	if !found {
+		err := fmt.Errorf("%s", "No region specified in request.")
+		return internal.ProvisioningParameters{}, apiresponses.NewFailureResponse(err, http.StatusInternalServerError, "provisioning")
+	}
+	platformProvider, found := middleware.ProviderFromContext(ctx)
+	if !found {
+		err := fmt.Errorf("%s", "No provider specified in request.")
+		return internal.ProvisioningParameters{}, apiresponses.NewFailureResponse(err, http.StatusInternalServerError, "provisioning")
+	}
+
+	// EXTRACT INPUT PARAMETERS / PROVISIONING PARAMETERS
+	parameters, err := b.extractInputParameters(details)
+	if err != nil {
+		return internal.ProvisioningParameters{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "while extracting input parameters")
+	}
+	ersContext, err := b.extractERSContext(details)
+	if err != nil {
+		return internal.ProvisioningParameters{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "while extracting context")
+	}
+	provisioningParameters := internal.ProvisioningParameters{
+		PlanID:           details.PlanID,
+		ServiceID:        details.ServiceID,
+		ErsContext:       ersContext,
+		Parameters:       parameters,
+		PlatformRegion:   region,
+		PlatformProvider: platformProvider,
+	}
+	// TODO: remove once we implemented proper filtering of parameters - removing parameters that are not supported by the plan
+	if details.PlanID == TrialPlanID {
+		provisioningParameters.Parameters.MachineType = nil
+		provisioningParameters.Parameters.AutoScalerMin = nil
+		provisioningParameters.Parameters.AutoScalerMax = nil
+	}
+	return provisioningParameters, nil
+}
+
+// newOperation creates the provisioning operation for the validated request
+func (b *ProvisionEndpoint) newOperation(operationID, instanceID, planID string, provisioningParameters internal.ProvisioningParameters, providerValues internal.ProviderValues) (internal.ProvisioningOperation, error) {
+	operation, err := internal.NewProvisioningOperationWithID(operationID, instanceID, provisioningParameters)
+	if err != nil {
+		return internal.ProvisioningOperation{}, err
+	}
+
+	shootName := gardener.CreateShootName()
+	shootDomainSuffix := strings.Trim(b.shootDomain, ".")
+
+	operation.ProviderValues = &providerValues
+	operation.EuAccess = b.schemaService.RegionPolicies().IsEURestrictedAccess(provisioningParameters.PlatformRegion)
+	operation.ShootName = shootName
+	operation.ShootDomain = fmt.Sprintf("%s.%s", shootName, shootDomainSuffix)
+	operation.ShootDNSProviders = b.shootDnsProviders
+	operation.DashboardURL = b.createDashboardURL(planID, instanceID)
+	return operation, nil
+}
+
+// validationFailure converts the validation error to the response returned to the platform
+func validationFailure(instanceID string, err error) error {
+	if error2.IsTemporaryError(err) {
+		return apiresponses.NewFailureResponse(fmt.Errorf("internal error"), http.StatusInternalServerError, err.Error())
+	}
+	errMsg := fmt.Sprintf("[instanceID: %s] %s", instanceID, err)
+	return apiresponses.NewFailureResponse(err, http.StatusBadRequest, errMsg)
+}
+
 // UseCredentialsBindings indicates whether to use credentials bindings when creating AWS clients, it is a deprecated func and will be removed in future releases
 // when all KCP instances are migrated to use credentials bindings
 func (b *ProvisionEndpoint) UseCredentialsBindings() {
@@ -338,26 +361,27 @@ func valueOfBoolPtr(ptr *bool) bool {
 	return *ptr
 }
 
-func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.ProvisionDetails, provisioningParameters internal.ProvisioningParameters, l *slog.Logger) error {
+// validate runs the validation chain of the provisioning request and returns the number of available zones per machine type, if zones discovery is enabled
+func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.ProvisionDetails, provisioningParameters internal.ProvisioningParameters, l *slog.Logger) (map[string]int, error) {
 	if b.config.RestrictToAllowedGlobalAccounts {
 		if !b.config.AllowedGlobalAccounts.Contains(provisioningParameters.ErsContext.GlobalAccountID) {
 			message := fmt.Sprintf("The Global Account %s is not allowed to provision a Kyma runtime", provisioningParameters.ErsContext.GlobalAccountID)
 			l.Info(message)
-			return apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
+			return nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
 		}
 	}
 
 	parameters := provisioningParameters.Parameters
 	if details.ServiceID != KymaServiceID {
-		return fmt.Errorf("service_id not recognized")
+		return nil, fmt.Errorf("service_id not recognized")
 	}
 	if _, exists := b.enabledPlanIDs[details.PlanID]; !exists {
-		return fmt.Errorf("plan ID %q is not recognized", details.PlanID)
+		return nil, fmt.Errorf("plan ID %q is not recognized", details.PlanID)
 	}
 
 	values, err := b.valuesProvider.ValuesForPlanAndParameters(provisioningParameters)
 	if err != nil {
-		return fmt.Errorf("while obtaining plan defaults: %w", err)
+		return nil, fmt.Errorf("while obtaining plan defaults: %w", err)
 	}
 
 	if details.PlanID == SapConvergedCloudPlanID {
@@ -369,13 +393,13 @@ func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.Provisi
 				provisioningParameters.PlatformRegion,
 				newPlatformRegion,
 			)
-			return apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
+			return nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
 		}
 	}
 
 	if b.config.CheckQuotaLimit && whitelist.IsNotWhitelisted(provisioningParameters.ErsContext.SubAccountID, b.quotaWhitelist) {
 		if err := validateQuotaLimit(b.instanceStorage, b.quotaClient, provisioningParameters.ErsContext.SubAccountID, provisioningParameters.PlanID, false); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -384,16 +408,16 @@ func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.Provisi
 		platformRegion, _ := middleware.RegionFromContext(ctx)
 		supportedRegions := b.schemaService.PlanRegions(AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID)), platformRegion)
 		if err := b.validateColocationRegion(strings.ToLower(values.ProviderType), valueOfPtr(parameters.Region), supportedRegions, l); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	regionsSupportingMachine, err := b.providerSpec.RegionSupportingMachine(values.ProviderType)
 	if err != nil {
-		return fmt.Errorf("while obtaining regions supporting machine: %w", err)
+		return nil, fmt.Errorf("while obtaining regions supporting machine: %w", err)
 	}
 	if !regionsSupportingMachine.IsSupported(valueOfPtr(parameters.Region), valueOfPtr(parameters.MachineType)) {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"In the region %s, the machine type %s is not available, it is supported in the %v",
 			valueOfPtr(parameters.Region),
 			valueOfPtr(parameters.MachineType),
@@ -414,68 +438,68 @@ func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.Provisi
 		}
 
 		// todo: simplify it, remove "if" when all KCP instances are migrated to use credentials bindings
-		var awsClient aws.Client
+		var zonesClient hyperscalers.ZonesClient
 		if b.useCredentialsBindings {
-			awsClient, err = newAWSClientUsingCredentialsBinding(ctx, l, b.rulesService, b.gardenerClient, b.awsClientFactory, provisioningParameters, values)
+			zonesClient, err = newZonesClientUsingCredentialsBinding(ctx, l, b.rulesService, b.gardenerClient, b.zonesClientFactory, provisioningParameters, values)
 		} else {
-			awsClient, err = newAWSClient(ctx, l, b.rulesService, b.gardenerClient, b.awsClientFactory, provisioningParameters, values)
+			zonesClient, err = newZonesClient(ctx, l, b.rulesService, b.gardenerClient, b.zonesClientFactory, provisioningParameters, values)
 		}
 		if err != nil {
-			l.Error(fmt.Sprintf("unable to create AWS client: %s", err))
-			return apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusUnprocessableEntity, FailedToValidateZonesMsg)
+			l.Error(fmt.Sprintf("unable to create zones client: %s", err))
+			return nil, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusUnprocessableEntity, FailedToValidateZonesMsg)
 		}
 
 		for machineType := range discoveredZones {
-			zonesCount, err := awsClient.AvailableZonesCount(ctx, machineType)
+			zonesCount, err := zonesClient.AvailableZonesCount(ctx, machineType)
 			if err != nil {
 				l.Error(fmt.Sprintf("unable to get available zones: %s", err))
-				return apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusUnprocessableEntity, FailedToValidateZonesMsg)
+				return nil, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusUnprocessableEntity, FailedToValidateZonesMsg)
 			}
 			discoveredZones[machineType] = zonesCount
 		}
 
 		if discoveredZones[kymaMachineType] < values.ZonesCount {
 			message := fmt.Sprintf("In the %s, the %s machine type is not available in %v zones.", values.Region, kymaMachineType, values.ZonesCount)
-			return apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
+			return nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
 		}
 	}
 
 	if err := b.validateNetworking(parameters); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := parameters.Validate(values.DefaultAutoScalerMin, values.DefaultAutoScalerMax); err != nil {
-		return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 	}
 	if parameters.OIDC.IsProvided() {
 		if err := parameters.OIDC.Validate(nil); err != nil {
-			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+			return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
 
 	if parameters.AdditionalWorkerNodePools != nil {
 		if !supportsAdditionalWorkerNodePools(details.PlanID) {
 			message := fmt.Sprintf("additional worker node pools are not supported for plan ID: %s", details.PlanID)
-			return apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
+			return nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
 		}
 
 		if !AreNamesUnique(parameters.AdditionalWorkerNodePools) {
 			message := "names of additional worker node pools must be unique"
-			return apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
+			return nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
 		}
 
 		if IsExternalLicenseType(provisioningParameters.ErsContext) {
 			if err := checkGPUMachinesUsage(parameters.AdditionalWorkerNodePools); err != nil {
-				return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+				return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 			}
 		}
 
 		if err := checkUnsupportedMachines(regionsSupportingMachine, valueOfPtr(parameters.Region), parameters.AdditionalWorkerNodePools); err != nil {
-			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+			return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 
 		if err := checkAutoScalerConfiguration(parameters.AdditionalWorkerNodePools); err != nil {
-			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+			return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 
 		if err := checkAvailableZones(
@@ -487,61 +511,61 @@ func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.Provisi
 			b.providerSpec.ZonesDiscovery(pkg.CloudProviderFromString(values.ProviderType)),
 			discoveredZones,
 		); err != nil {
-			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+			return nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
 
 	err = validateIngressFiltering(provisioningParameters, parameters.IngressFiltering, b.infrastructureManager.IngressFilteringPlans, l)
 	if err != nil {
-		return apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+		return nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 	}
 
 	planValidator, err := b.validator(&details, provisioningParameters.PlatformProvider, ctx)
 	if err != nil {
-		return fmt.Errorf("while creating plan validator: %w", err)
+		return nil, fmt.Errorf("while creating plan validator: %w", err)
 	}
 
 	var rawParameters any
 	if err = json.Unmarshal(details.RawParameters, &rawParameters); err != nil {
-		return fmt.Errorf("while unmarshaling raw parameters: %w", err)
+		return nil, fmt.Errorf("while unmarshaling raw parameters: %w", err)
 	}
 
 	if err = planValidator.Validate(rawParameters); err != nil {
-		return fmt.Errorf("while validating input parameters: %s", validator.FormatError(err))
+		return nil, fmt.Errorf("while validating input parameters: %s", validator.FormatError(err))
 	}
 
 	// EU Access
-	if isEuRestrictedAccess(ctx) {
+	if b.isEuRestrictedAccess(ctx) {
 		l.Info("EU Access restricted instance creation")
 	}
 
 	if IsTrialPlan(details.PlanID) && parameters.Region != nil && *parameters.Region != "" {
 		_, valid := validRegionsForTrial[TrialCloudRegion(*parameters.Region)]
 		if !valid {
-			return fmt.Errorf("invalid region specified in request for trial")
+			return nil, fmt.Errorf("invalid region specified in request for trial")
 		}
 	}
 
 	if IsTrialPlan(details.PlanID) && b.config.OnlySingleTrialPerGA {
 		count, err := b.instanceStorage.GetNumberOfInstancesForGlobalAccountID(provisioningParameters.ErsContext.GlobalAccountID)
 		if err != nil {
-			return fmt.Errorf("while checking if a trial Kyma instance exists for given global account: %w", err)
+			return nil, fmt.Errorf("while checking if a trial Kyma instance exists for given global account: %w", err)
 		}
 
 		if count > 0 {
 			l.Info("Provisioning Trial SKR rejected, such instance was already created for this Global Account")
-			return fmt.Errorf("trial Kyma was created for the global account, but there is only one allowed")
+			return nil, fmt.Errorf("trial Kyma was created for the global account, but there is only one allowed")
 		}
 	}
 
 	if IsFreemiumPlan(details.PlanID) && b.config.OnlyOneFreePerGA && whitelist.IsNotWhitelisted(provisioningParameters.ErsContext.GlobalAccountID, b.freemiumWhiteList) {
 		count, err := b.instanceArchivedStorage.TotalNumberOfInstancesArchivedForGlobalAccountID(provisioningParameters.ErsContext.GlobalAccountID, FreemiumPlanID)
 		if err != nil {
-			return fmt.Errorf("while checking if a free Kyma instance existed for given global account: %w", err)
+			return nil, fmt.Errorf("while checking if a free Kyma instance existed for given global account: %w", err)
 		}
 		if count > 0 {
 			l.Info("Provisioning Free SKR rejected, such instance was already created for this Global Account")
-			return fmt.Errorf("provisioning request rejected, you have already used the available free service plan quota in this global account")
+			return nil, fmt.Errorf("provisioning request rejected, you have already used the available free service plan quota in this global account")
 		}
 
 		instanceFilter := dbmodel.InstanceFilter{
@@ -551,15 +575,15 @@ func (b *ProvisionEndpoint) validate(ctx context.Context, details domain.Provisi
 		}
 		_, _, count, err = b.instanceStorage.List(instanceFilter)
 		if err != nil {
-			return fmt.Errorf("while checking if a free Kyma instance existed for given global account: %w", err)
+			return nil, fmt.Errorf("while checking if a free Kyma instance existed for given global account: %w", err)
 		}
 		if count > 0 {
 			l.Info("Provisioning Free SKR rejected, such instance was already created for this Global Account")
-			return fmt.Errorf("provisioning request rejected, you have already used the available free service plan quota in this global account")
+			return nil, fmt.Errorf("provisioning request rejected, you have already used the available free service plan quota in this global account")
 		}
 	}
 
-	return nil
+	return discoveredZones, nil
 }
 
 func validateIngressFiltering(provisioningParameters internal.ProvisioningParameters, ingressFilteringParameter *bool, plans StringList, log *slog.Logger) error {
@@ -577,9 +601,9 @@ func validateIngressFiltering(provisioningParameters internal.ProvisioningParame
 	return nil
 }
 
-func isEuRestrictedAccess(ctx context.Context) bool {
+func (b *ProvisionEndpoint) isEuRestrictedAccess(ctx context.Context) bool {
 	platformRegion, _ := middleware.RegionFromContext(ctx)
-	return euaccess.IsEURestrictedAccess(platformRegion)
+	return b.schemaService.RegionPolicies().IsEURestrictedAccess(platformRegion)
 }
 
 func supportsAdditionalWorkerNodePools(planID string) bool {
@@ -826,7 +850,10 @@ func (b *ProvisionEndpoint) handleExistingOperation(operation *internal.Provisio
 func (b *ProvisionEndpoint) validator(details *domain.ProvisionDetails, provider pkg.CloudProvider, ctx context.Context) (*jsonschema.Schema, error) {
 	platformRegion, _ := middleware.RegionFromContext(ctx)
 	plans := b.schemaService.Plans(b.plansConfig, platformRegion, provider)
-	plan := plans[details.PlanID]
+	plan, found := plans[details.PlanID]
+	if !found {
+		return nil, fmt.Errorf("plan %s is not available in the platform region %s", details.PlanID, platformRegion)
+	}
 
 	return validator.NewFromSchema(plan.Schemas.Instance.Create.Parameters)
 }
@@ -835,20 +862,19 @@ func (b *ProvisionEndpoint) createDashboardURL(planID, instanceID string) string
 	return fmt.Sprintf("%s/?kubeconfigID=%s", b.dashboardConfig.LandscapeURL, instanceID)
 }
 
-func validateCidr(cidr string) (*net.IPNet, error) {
-	ip, ipNet, err := net.ParseCIDR(cidr)
+func validateCidr(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
 	if err != nil {
-		return nil, err
+		return netip.Prefix{}, err
 	}
 	// find cases like: 10.250.0.1/19
-	if ipNet != nil {
-		if !ipNet.IP.Equal(ip) {
-			return nil, fmt.Errorf("%s must be valid canonical CIDR", ip)
-		}
+	if prefix.Masked() != prefix {
+		return netip.Prefix{}, fmt.Errorf("%s must be valid canonical CIDR", prefix.Addr())
 	}
-	return ipNet, nil
+	return prefix, nil
 }
 
+// validateNetworking checks the networking ranges with the same rules as the networking planner
 func (b *ProvisionEndpoint) validateNetworking(parameters pkg.ProvisioningParametersDTO) error {
 	var err, e error
 	if len(parameters.Zones) > 4 {
@@ -859,62 +885,30 @@ func (b *ProvisionEndpoint) validateNetworking(parameters pkg.ProvisioningParame
 		return nil
 	}
 
-	var nodes, services, pods *net.IPNet
-	if nodes, e = validateCidr(parameters.Networking.NodesCidr); e != nil {
-		err = multierror.Append(err, fmt.Errorf("while parsing nodes CIDR: %w", e))
+	ranges := networking.Ranges{
+		Pods:     netip.MustParsePrefix(networking.DefaultPodsCIDR),
+		Services: netip.MustParsePrefix(networking.DefaultServicesCIDR),
 	}
-	// error is handled before, in the validate CIDR
-	cidr, _ := netip.ParsePrefix(parameters.Networking.NodesCidr)
-	const maxSuffix = 23
-	if cidr.Bits() > maxSuffix {
-		err = multierror.Append(err, fmt.Errorf("the suffix of the node CIDR must not be greater than %d", maxSuffix))
+	if ranges.Nodes, e = validateCidr(parameters.Networking.NodesCidr); e != nil {
+		err = multierror.Append(err, fmt.Errorf("while parsing nodes CIDR: %w", e))
 	}
-
 	if parameters.Networking.PodsCidr != nil {
-		if pods, e = validateCidr(*parameters.Networking.PodsCidr); e != nil {
+		if ranges.Pods, e = validateCidr(*parameters.Networking.PodsCidr); e != nil {
 			err = multierror.Append(err, fmt.Errorf("while parsing pods CIDR: %w", e))
 		}
-	} else {
-		_, pods, _ = net.ParseCIDR(networking.DefaultPodsCIDR)
 	}
 	if parameters.Networking.ServicesCidr != nil {
-		if services, e = validateCidr(*parameters.Networking.ServicesCidr); e != nil {
+		if ranges.Services, e = validateCidr(*parameters.Networking.ServicesCidr); e != nil {
 			err = multierror.Append(err, fmt.Errorf("while parsing services CIDR: %w", e))
 		}
-	} else {
-		_, services, _ = net.ParseCIDR(networking.DefaultServicesCIDR)
-	}
-	if err != nil {
-		return err
-	}
-
-	for _, seed := range networking.GardenerSeedCIDRs {
-		_, seedCidr, _ := net.ParseCIDR(seed)
-		if e := validateOverlapping(*nodes, *seedCidr); e != nil {
-			err = multierror.Append(err, fmt.Errorf("nodes CIDR must not overlap %s", seed))
-		}
-		if e := validateOverlapping(*services, *seedCidr); e != nil {
-			err = multierror.Append(err, fmt.Errorf("services CIDR must not overlap %s", seed))
-		}
-		if e := validateOverlapping(*pods, *seedCidr); e != nil {
-			err = multierror.Append(err, fmt.Errorf("pods CIDR must not overlap %s", seed))
-		}
 	}
-
 	if err != nil {
 		return err
 	}
 
-	if e := validateOverlapping(*nodes, *pods); e != nil {
-		err = multierror.Append(err, fmt.Errorf("nodes CIDR must not overlap pods CIDR"))
+	if e := networking.Validate(ranges, nil); e != nil {
+		err = multierror.Append(err, e)
 	}
-	if e := validateOverlapping(*nodes, *services); e != nil {
-		err = multierror.Append(err, fmt.Errorf("nodes CIDR must not overlap serivces CIDR"))
-	}
-	if e := validateOverlapping(*services, *pods); e != nil {
-		err = multierror.Append(err, fmt.Errorf("services CIDR must not overlap pods CIDR"))
-	}
-
 	return err
 }
 
@@ -985,15 +979,6 @@ func insertRequest(instanceID, filePath string, ersContext internal.ERSContext,
 	return nil
 }
 
-func validateOverlapping(n1 net.IPNet, n2 net.IPNet) error {
-
-	if n1.Contains(n2.IP) || n2.Contains(n1.IP) {
-		return fmt.Errorf("%s overlaps %s", n1.String(), n2.String())
-	}
-
-	return nil
-}
-
 func validateQuotaLimit(instanceStorage storage.Instances, quotaClient QuotaClient, subAccountID, planID string, update bool) error {
 	instanceFilter := dbmodel.InstanceFilter{
 		SubAccountIDs: []string{subAccountID},
@@ -1024,22 +1009,17 @@ func validateQuotaLimit(instanceStorage storage.Instances, quotaClient QuotaClie
 	return nil
 }
 
-func newAWSClient(
+func newZonesClient(
 	ctx context.Context,
 	log *slog.Logger,
 	rulesService *rules.RulesService,
 	gardenerClient *gardener.Client,
-	awsClientFactory aws.ClientFactory,
+	zonesClientFactory hyperscalers.ZonesClientFactory,
 	provisioningParameters internal.ProvisioningParameters,
 	values internal.ProviderValues,
-) (aws.Client, error) {
+) (hyperscalers.ZonesClient, error) {
 	log.Info("Zones discovery enabled, validating zone count using subscription secret")
-	attr := &rules.ProvisioningAttributes{
-		Plan:              AvailablePlans.GetPlanNameOrEmpty(PlanIDType(provisioningParameters.PlanID)),
-		PlatformRegion:    provisioningParameters.PlatformRegion,
-		HyperscalerRegion: values.Region,
-		Hyperscaler:       values.ProviderType,
-	}
+	attr := NewProvisioningAttributes(provisioningParameters, values)
 	log.Info(fmt.Sprintf("matching provisioning attributes %q to filtering rule", attr))
 
 	parsedRule, found := rulesService.MatchProvisioningAttributesWithValidRuleset(attr)
@@ -1067,34 +1047,25 @@ func newAWSClient(
 		return nil, fmt.Errorf("unable to get secret %s/%s", secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName())
 	}
 
-	accessKeyID, secretAccessKey, err := aws.ExtractCredentials(secret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract AWS credentials")
-	}
-	client, err := awsClientFactory.New(ctx, accessKeyID, secretAccessKey, values.Region)
+	client, err := zonesClientFactory.New(ctx, pkg.CloudProviderFromString(values.ProviderType), secret, values.Region)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create AWS client")
+		return nil, fmt.Errorf("unable to create zones client: %w", err)
 	}
 
 	return client, nil
 }
 
-func newAWSClientUsingCredentialsBinding(
+func newZonesClientUsingCredentialsBinding(
 	ctx context.Context,
 	log *slog.Logger,
 	rulesService *rules.RulesService,
 	gardenerClient *gardener.Client,
-	awsClientFactory aws.ClientFactory,
+	zonesClientFactory hyperscalers.ZonesClientFactory,
 	provisioningParameters internal.ProvisioningParameters,
 	values internal.ProviderValues,
-) (aws.Client, error) {
+) (hyperscalers.ZonesClient, error) {
 	log.Info("Zones discovery enabled, validating zone count using subscription secret")
-	attr := &rules.ProvisioningAttributes{
-		Plan:              AvailablePlans.GetPlanNameOrEmpty(PlanIDType(provisioningParameters.PlanID)),
-		PlatformRegion:    provisioningParameters.PlatformRegion,
-		HyperscalerRegion: values.Region,
-		Hyperscaler:       values.ProviderType,
-	}
+	attr := NewProvisioningAttributes(provisioningParameters, values)
 	log.Info(fmt.Sprintf("matching provisioning attributes %q to filtering rule", attr))
 
 	parsedRule, found := rulesService.MatchProvisioningAttributesWithValidRuleset(attr)
@@ -1122,13 +1093,9 @@ func newAWSClientUsingCredentialsBinding(
 		return nil, fmt.Errorf("unable to get secret %s/%s", credentialsBinding.GetSecretRefNamespace(), credentialsBinding.GetSecretRefName())
 	}
 
-	accessKeyID, secretAccessKey, err := aws.ExtractCredentials(secret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract AWS credentials")
-	}
-	client, err := awsClientFactory.New(ctx, accessKeyID, secretAccessKey, values.Region)
+	client, err := zonesClientFactory.New(ctx, pkg.CloudProviderFromString(values.ProviderType), secret, values.Region)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create AWS client")
+		return nil, fmt.Errorf("unable to create zones client: %w", err)
 	}
 
 	return client, nil
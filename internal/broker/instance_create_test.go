@@ -1700,7 +1700,8 @@ func TestNetworkingValidation(t *testing.T) {
 	for tn, tc := range map[string]struct {
 		givenNetworking string
 
-		expectedError bool
+		expectedError    bool
+		expectedMessages []string
 	}{
 		"Invalid nodes CIDR": {
 			givenNetworking: `{"nodes": 1abcd"}`,
@@ -1750,6 +1751,14 @@ func TestNetworkingValidation(t *testing.T) {
 			givenNetworking: `{"nodes": "10.250.0.0/25"}`,
 			expectedError:   true,
 		},
+		"Overlaps with seed and pods cidr": {
+			givenNetworking: `{"nodes": "10.64.0.0/10"}`,
+			expectedError:   true,
+			expectedMessages: []string{
+				"nodes range 10.64.0.0/10 overlaps seed range 10.64.0.0/11",
+				"nodes range 10.64.0.0/10 overlaps pods range 10.96.0.0/13",
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			// given
@@ -1803,6 +1812,9 @@ func TestNetworkingValidation(t *testing.T) {
 
 			// then
 			assert.Equal(t, tc.expectedError, err != nil)
+			for _, message := range tc.expectedMessages {
+				assert.ErrorContains(t, err, message)
+			}
 		})
 	}
 
@@ -2017,7 +2029,7 @@ func TestSapConvergedCloudBlocking(t *testing.T) {
 		)
 
 		// when
-		_, err := provisionEndpoint.Provision(fixRequestContext(t, "cf-eu11"), instanceID, domain.ProvisionDetails{
+		_, err := provisionEndpoint.Provision(fixRequestContext(t, "cf-eu10"), instanceID, domain.ProvisionDetails{
 			ServiceID:     serviceID,
 			PlanID:        planID,
 			RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "region": "%s","oidc":{ %s }}`, clusterName, "eastus", oidcParams)),
@@ -3380,7 +3392,7 @@ func TestDiscoveryZones(t *testing.T) {
 				nil,
 				rulesService,
 				fixture.CreateGardenerClient(),
-				fixture.NewFakeAWSClientFactory(tc.zones, tc.awsError),
+				fixture.NewFakeZonesClientFactory(tc.zones, tc.awsError),
 				map[string]string{},
 			)
 
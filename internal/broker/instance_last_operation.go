@@ -98,12 +98,14 @@ func (b *LastOperationEndpoint) responseFromInstanceArchived(instanceID string,
 	}
 }
 
+// mapStateToOSBCompliantState maps KEB internal states to OSB API states, a canceled operation is reported as failed,
+// because it did not make the requested changes, see the operation cancellation documentation
 func mapStateToOSBCompliantState(opState domain.LastOperationState) domain.LastOperationState {
 	switch opState {
-	case internal.OperationStatePending, internal.OperationStateRetrying:
+	case internal.OperationStatePending, internal.OperationStateRetrying, internal.OperationStateCanceling:
 		return domain.InProgress
-	case internal.OperationStateCanceled, internal.OperationStateCanceling:
-		return domain.Succeeded
+	case internal.OperationStateCanceled:
+		return domain.Failed
 	default:
 		return opState
 	}
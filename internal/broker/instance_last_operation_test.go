@@ -115,7 +115,7 @@ func TestLastOperation_LastOperation(t *testing.T) {
 			Description: updateOp.Description,
 		}, response)
 	})
-	t.Run("Should convert operation's canceling state to succeeded", func(t *testing.T) {
+	t.Run("Should convert operation's canceling state to in progress", func(t *testing.T) {
 		// given
 		memoryStorage := storage.NewMemoryStorage()
 		updateOp := fixture.FixUpdatingOperation(operationID, instID)
@@ -131,7 +131,7 @@ func TestLastOperation_LastOperation(t *testing.T) {
 
 		// then
 		assert.Equal(t, domain.LastOperation{
-			State:       domain.Succeeded,
+			State:       domain.InProgress,
 			Description: updateOp.Description,
 		}, response)
 
@@ -141,11 +141,11 @@ func TestLastOperation_LastOperation(t *testing.T) {
 		assert.NoError(t, err)
 		// then
 		assert.Equal(t, domain.LastOperation{
-			State:       domain.Succeeded,
+			State:       domain.InProgress,
 			Description: updateOp.Description,
 		}, response)
 	})
-	t.Run("Should convert operation's canceled state to succeeded", func(t *testing.T) {
+	t.Run("Should convert operation's canceled state to failed", func(t *testing.T) {
 		// given
 		memoryStorage := storage.NewMemoryStorage()
 		updateOp := fixture.FixUpdatingOperation(operationID, instID)
@@ -161,7 +161,7 @@ func TestLastOperation_LastOperation(t *testing.T) {
 
 		// then
 		assert.Equal(t, domain.LastOperation{
-			State:       domain.Succeeded,
+			State:       domain.Failed,
 			Description: updateOp.Description,
 		}, response)
 
@@ -172,7 +172,7 @@ func TestLastOperation_LastOperation(t *testing.T) {
 
 		// then
 		assert.Equal(t, domain.LastOperation{
-			State:       domain.Succeeded,
+			State:       domain.Failed,
 			Description: updateOp.Description,
 		}, response)
 	})
@@ -19,7 +19,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/additionalproperties"
 	"github.com/kyma-project/kyma-environment-broker/internal/dashboard"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
@@ -69,17 +69,18 @@ type UpdateEndpoint struct {
 	useSmallerMachineTypes      bool
 	infrastructureManagerConfig InfrastructureManager
 
-	schemaService    *SchemaService
-	providerSpec     *configuration.ProviderSpec
-	planSpec         *configuration.PlanSpecifications
-	quotaClient      QuotaClient
-	quotaWhitelist   whitelist.Set
-	rulesService     *rules.RulesService
-	gardenerClient   *gardener.Client
-	awsClientFactory aws.ClientFactory
+	schemaService      *SchemaService
+	providerSpec       *configuration.ProviderSpec
+	planSpec           *configuration.PlanSpecifications
+	quotaClient        QuotaClient
+	quotaWhitelist     whitelist.Set
+	rulesService       *rules.RulesService
+	gardenerClient     *gardener.Client
+	zonesClientFactory hyperscalers.ZonesClientFactory
 
 	useCredentialsBindings         bool
 	syncEmptyUpdateResponseEnabled bool
+	operationPreviewer             OperationPreviewer
 }
 
 func NewUpdate(cfg Config,
@@ -103,7 +104,7 @@ func NewUpdate(cfg Config,
 	quotaWhitelist whitelist.Set,
 	rulesService *rules.RulesService,
 	gardenerClient *gardener.Client,
-	awsClientFactory aws.ClientFactory,
+	zonesClientFactory hyperscalers.ZonesClientFactory,
 ) *UpdateEndpoint {
 	return &UpdateEndpoint{
 		config:                                   cfg,
@@ -129,7 +130,7 @@ func NewUpdate(cfg Config,
 		quotaWhitelist:                           quotaWhitelist,
 		rulesService:                             rulesService,
 		gardenerClient:                           gardenerClient,
-		awsClientFactory:                         awsClientFactory,
+		zonesClientFactory:                       zonesClientFactory,
 		syncEmptyUpdateResponseEnabled:           cfg.SyncEmptyUpdateResponseEnabled,
 	}
 }
@@ -268,12 +269,124 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 	if !asyncAllowed {
 		return domain.UpdateServiceSpec{}, apiresponses.ErrAsyncRequired
 	}
+	params, err := updatingParameters(details, logger)
+	if err != nil {
+		return domain.UpdateServiceSpec{}, err
+	}
+
+	operationID := uuid.New().String()
+	logger = logger.With("operationID", operationID)
+
+	operation, _, err := b.prepareOperation(ctx, operationID, instance, details, params, ersContext, logger)
+	if err != nil {
+		return domain.UpdateServiceSpec{}, err
+	}
+
+	var updateStorage []string
+	oldPlanID := instance.ServicePlanID
+	if operation.UpdatedPlanID != "" {
+		instance.Parameters.PlanID = details.PlanID
+		instance.ServicePlanID = details.PlanID
+		instance.ServicePlanName = AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))
+		updateStorage = append(updateStorage, planChangeMessage)
+	}
+
+	if params.OIDC.IsProvided() {
+		if params.OIDC.List != nil || (params.OIDC.OIDCConfigDTO != nil && !params.OIDC.OIDCConfigDTO.IsEmpty()) {
+			instance.Parameters.Parameters.OIDC = params.OIDC
+			updateStorage = append(updateStorage, "OIDC")
+		}
+	}
+
+	if params.IngressFiltering != nil {
+		instance.Parameters.Parameters.IngressFiltering = params.IngressFiltering
+		updateStorage = append(updateStorage, "Ingress Filtering")
+	}
+
+	if len(params.RuntimeAdministrators) != 0 {
+		newAdministrators := make([]string, 0, len(params.RuntimeAdministrators))
+		newAdministrators = append(newAdministrators, params.RuntimeAdministrators...)
+		instance.Parameters.Parameters.RuntimeAdministrators = newAdministrators
+		updateStorage = append(updateStorage, "Runtime Administrators")
+	}
+
+	if params.UpdateAutoScaler(&instance.Parameters.Parameters) {
+		updateStorage = append(updateStorage, "Auto Scaler parameters")
+	}
+	if params.MachineType != nil && *params.MachineType != "" {
+		instance.Parameters.Parameters.MachineType = params.MachineType
+	}
+
+	if supportsAdditionalWorkerNodePools(details.PlanID) && params.AdditionalWorkerNodePools != nil {
+		newAdditionalWorkerNodePools := make([]pkg.AdditionalWorkerNodePool, 0, len(params.AdditionalWorkerNodePools))
+		newAdditionalWorkerNodePools = append(newAdditionalWorkerNodePools, params.AdditionalWorkerNodePools...)
+		instance.Parameters.Parameters.AdditionalWorkerNodePools = newAdditionalWorkerNodePools
+		updateStorage = append(updateStorage, "Additional Worker Node Pools")
+	}
+
+	if params.Name != nil && *params.Name != "" {
+		instance.Parameters.Parameters.Name = *params.Name
+		updateStorage = append(updateStorage, "Cluster Name")
+	}
+
+	if len(updateStorage) > 0 {
+		if err := wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+			instance, err = b.instanceStorage.Update(*instance)
+			if err != nil {
+				params := strings.Join(updateStorage, ", ")
+				logger.Warn(fmt.Sprintf("unable to update instance with new %v (%s), retrying", params, err.Error()))
+				return false, nil
+			}
+			return true, nil
+		}); err != nil {
+			response := apiresponses.NewFailureResponse(fmt.Errorf("Update operation failed"), http.StatusInternalServerError, err.Error())
+			return domain.UpdateServiceSpec{}, response
+		}
+
+		if slices.Contains(updateStorage, planChangeMessage) {
+			oldPlan := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(oldPlanID))
+			newPlan := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))
+			message := fmt.Sprintf("Plan updated from %s (PlanID: %s) to %s (PlanID: %s).", oldPlan, oldPlanID, newPlan, details.PlanID)
+			if err := b.actionStorage.InsertAction(
+				pkg.PlanUpdateActionType,
+				instance.InstanceID,
+				message,
+				oldPlanID,
+				details.PlanID,
+			); err != nil {
+				logger.Error(fmt.Sprintf("while inserting action %q with message %s for instance ID %s: %v", pkg.PlanUpdateActionType, message, instance.InstanceID, err))
+			}
+		}
+	}
+	if b.shouldResponseSynchronously(previousInstance, instance, logger) {
+		return b.processSyncResponseOk(instance, logger)
+	}
+	logger.Debug("Creating update operation in the database")
+	err = b.operationStorage.InsertOperation(operation)
+	if err != nil {
+		return domain.UpdateServiceSpec{}, err
+	}
+	logger.Debug("Adding update operation to the processing queue")
+	b.updatingQueue.Add(operationID)
+
+	return domain.UpdateServiceSpec{
+		IsAsync:       true,
+		DashboardURL:  dashboard.ProvideURL(instance, lastProvisioningOperation),
+		OperationData: operation.ID,
+		Metadata: domain.InstanceMetadata{
+			Labels: ResponseLabels(*instance, b.config.URL, b.kcBuilder),
+		},
+	}, nil
+}
+
+// updatingParameters extracts the update parameters from the request
+func updatingParameters(details domain.UpdateDetails, logger *slog.Logger) (internal.UpdatingParametersDTO, error) {
 	var params internal.UpdatingParametersDTO
 	if len(details.RawParameters) != 0 {
 		err := json.Unmarshal(details.RawParameters, &params)
 		if err != nil {
 			logger.Error(fmt.Sprintf("unable to unmarshal parameters: %s", err.Error()))
-			return domain.UpdateServiceSpec{}, fmt.Errorf("unable to unmarshal parameters")
+			return internal.UpdatingParametersDTO{}, fmt.Errorf("unable to unmarshal parameters")
 		}
 		logger.Debug(fmt.Sprintf("Updating with params: %+v", params))
 	}
@@ -283,16 +396,20 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 		params.AutoScalerMin = nil
 		params.AutoScalerMax = nil
 	}
+	return params, nil
+}
 
+// prepareOperation runs the validation chain of the update request and returns the update operation with the number of available zones per machine type, if zones discovery is enabled
+func (b *UpdateEndpoint) prepareOperation(ctx context.Context, operationID string, instance *internal.Instance, details domain.UpdateDetails, params internal.UpdatingParametersDTO, ersContext internal.ERSContext, logger *slog.Logger) (internal.Operation, map[string]int, error) {
 	providerValues, err := b.valuesProvider.ValuesForPlanAndParameters(instance.Parameters)
 	if err != nil {
 		logger.Error(fmt.Sprintf("unable to obtain dummyProvider values: %s", err.Error()))
-		return domain.UpdateServiceSpec{}, fmt.Errorf("unable to process the request")
+		return internal.Operation{}, nil, fmt.Errorf("unable to process the request")
 	}
 
 	regionsSupportingMachine, err := b.providerSpec.RegionSupportingMachine(providerValues.ProviderType)
 	if err != nil {
-		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 	}
 	if !regionsSupportingMachine.IsSupported(valueOfPtr(instance.Parameters.Parameters.Region), valueOfPtr(params.MachineType)) {
 		message := fmt.Sprintf(
@@ -301,7 +418,7 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 			valueOfPtr(params.MachineType),
 			strings.Join(regionsSupportingMachine.SupportedRegions(valueOfPtr(params.MachineType)), ", "),
 		)
-		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
+		return internal.Operation{}, nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
 	}
 
 	discoveredZones := make(map[string]int)
@@ -315,22 +432,22 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 		}
 
 		// todo: simplify it, remove "if" when all KCP instances are migrated to use credentials bindings
-		var awsClient aws.Client
+		var zonesClient hyperscalers.ZonesClient
 		if b.useCredentialsBindings {
-			awsClient, err = newAWSClientUsingCredentialsBinding(ctx, logger, b.rulesService, b.gardenerClient, b.awsClientFactory, instance.Parameters, providerValues)
+			zonesClient, err = newZonesClientUsingCredentialsBinding(ctx, logger, b.rulesService, b.gardenerClient, b.zonesClientFactory, instance.Parameters, providerValues)
 		} else {
-			awsClient, err = newAWSClient(ctx, logger, b.rulesService, b.gardenerClient, b.awsClientFactory, instance.Parameters, providerValues)
+			zonesClient, err = newZonesClient(ctx, logger, b.rulesService, b.gardenerClient, b.zonesClientFactory, instance.Parameters, providerValues)
 		}
 		if err != nil {
-			logger.Error(fmt.Sprintf("unable to create AWS client: %s", err))
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusBadRequest, FailedToValidateZonesMsg)
+			logger.Error(fmt.Sprintf("unable to create zones client: %s", err))
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusBadRequest, FailedToValidateZonesMsg)
 		}
 
 		for machineType := range discoveredZones {
-			zonesCount, err := awsClient.AvailableZonesCount(ctx, machineType)
+			zonesCount, err := zonesClient.AvailableZonesCount(ctx, machineType)
 			if err != nil {
 				logger.Error(fmt.Sprintf("unable to get available zones: %s", err))
-				return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusBadRequest, FailedToValidateZonesMsg)
+				return internal.Operation{}, nil, apiresponses.NewFailureResponse(errors.New(FailedToValidateZonesMsg), http.StatusBadRequest, FailedToValidateZonesMsg)
 			}
 			discoveredZones[machineType] = zonesCount
 		}
@@ -338,7 +455,7 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 		if params.MachineType != nil {
 			if discoveredZones[*params.MachineType] < providerValues.ZonesCount {
 				message := fmt.Sprintf("In the %s, the %s machine type is not available in %v zones.", providerValues.Region, *params.MachineType, providerValues.ZonesCount)
-				return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
+				return internal.Operation{}, nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusUnprocessableEntity, message)
 			}
 		}
 	}
@@ -346,48 +463,45 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 	if params.OIDC.IsProvided() {
 		if err := params.OIDC.Validate(instance.Parameters.Parameters.OIDC); err != nil {
 			logger.Error(fmt.Sprintf("invalid OIDC parameters: %s", err.Error()))
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 		}
 	}
 
-	operationID := uuid.New().String()
-	logger = logger.With("operationID", operationID)
-
 	logger.Debug(fmt.Sprintf("creating update operation %v", params))
 	operation := internal.NewUpdateOperation(operationID, instance, params)
 
 	if err := operation.ProvisioningParameters.Parameters.AutoScalerParameters.Validate(providerValues.DefaultAutoScalerMin, providerValues.DefaultAutoScalerMax); err != nil {
 		logger.Error(fmt.Sprintf("invalid autoscaler parameters: %s", err.Error()))
-		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+		return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 	}
 
 	if params.AdditionalWorkerNodePools != nil {
 		if !supportsAdditionalWorkerNodePools(details.PlanID) {
 			message := fmt.Sprintf("additional worker node pools are not supported for plan ID: %s", details.PlanID)
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
 		}
 
 		if !AreNamesUnique(params.AdditionalWorkerNodePools) {
 			message := "names of additional worker node pools must be unique"
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(fmt.Errorf("%s", message), http.StatusBadRequest, message)
 		}
 
 		if IsExternalLicenseType(ersContext) {
 			if err := checkGPUMachinesUsage(params.AdditionalWorkerNodePools); err != nil {
-				return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+				return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 			}
 		}
 
 		if err := checkUnsupportedMachines(regionsSupportingMachine, valueOfPtr(instance.Parameters.Parameters.Region), params.AdditionalWorkerNodePools); err != nil {
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 		}
 
 		if err := checkAutoScalerConfiguration(params.AdditionalWorkerNodePools); err != nil {
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 		}
 
 		if err := checkHAZonesUnchanged(instance.Parameters.Parameters.AdditionalWorkerNodePools, params.AdditionalWorkerNodePools); err != nil {
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 		}
 
 		if err := checkAvailableZones(
@@ -399,7 +513,7 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 			b.providerSpec.ZonesDiscovery(pkg.CloudProviderFromString(providerValues.ProviderType)),
 			discoveredZones,
 		); err != nil {
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 		}
 
 		multiError := pkg.MachineTypeMultiError{}
@@ -410,37 +524,31 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 			}
 		}
 		if multiError.IsError() {
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(&multiError, http.StatusBadRequest, multiError.Error())
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(&multiError, http.StatusBadRequest, multiError.Error())
 		}
 	}
 
 	err = validateIngressFiltering(operation.ProvisioningParameters, params.IngressFiltering, b.infrastructureManagerConfig.IngressFilteringPlans, logger)
 	if err != nil {
-		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+		return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 	}
 
-	var updateStorage []string
-	oldPlanID := instance.ServicePlanID
 	if details.PlanID != "" && details.PlanID != instance.ServicePlanID {
 		logger.Info(fmt.Sprintf("Plan change requested: %s -> %s", instance.ServicePlanID, details.PlanID))
 		if b.config.EnablePlanUpgrades && b.planSpec.IsUpgradableBetween(AvailablePlans.GetPlanNameOrEmpty(PlanIDType(instance.ServicePlanID)), AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))) {
 			if b.config.CheckQuotaLimit && whitelist.IsNotWhitelisted(ersContext.SubAccountID, b.quotaWhitelist) {
 				if err := validateQuotaLimit(b.instanceStorage, b.quotaClient, ersContext.SubAccountID, details.PlanID, true); err != nil {
-					return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+					return internal.Operation{}, nil, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
 				}
 			}
 			logger.Info("Plan change accepted.")
 			operation.UpdatedPlanID = details.PlanID
 			operation.ProvisioningParameters.PlanID = details.PlanID
-			instance.Parameters.PlanID = details.PlanID
-			instance.ServicePlanID = details.PlanID
-			instance.ServicePlanName = AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))
-			updateStorage = append(updateStorage, planChangeMessage)
 		} else {
 			logger.Info("Plan change not allowed.")
 			sourcePlanName := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(instance.ServicePlanID))
 			targetPlanName := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))
-			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(
+			return internal.Operation{}, nil, apiresponses.NewFailureResponse(
 				fmt.Errorf("plan upgrade from %s (planID: %s) to %s (planID: %s) is not allowed", sourcePlanName, instance.ServicePlanID, targetPlanName, details.PlanID),
 				http.StatusBadRequest,
 				fmt.Sprintf("plan upgrade from %s (planID: %s) to %s (planID: %s) is not allowed", sourcePlanName, instance.ServicePlanID, targetPlanName, details.PlanID),
@@ -449,92 +557,7 @@ func (b *UpdateEndpoint) processUpdateParameters(ctx context.Context, previousIn
 	}
 	operation.ProviderValues = &providerValues
 
-	if params.OIDC.IsProvided() {
-		if params.OIDC.List != nil || (params.OIDC.OIDCConfigDTO != nil && !params.OIDC.OIDCConfigDTO.IsEmpty()) {
-			instance.Parameters.Parameters.OIDC = params.OIDC
-			updateStorage = append(updateStorage, "OIDC")
-		}
-	}
-
-	if params.IngressFiltering != nil {
-		instance.Parameters.Parameters.IngressFiltering = params.IngressFiltering
-		updateStorage = append(updateStorage, "Ingress Filtering")
-	}
-
-	if len(params.RuntimeAdministrators) != 0 {
-		newAdministrators := make([]string, 0, len(params.RuntimeAdministrators))
-		newAdministrators = append(newAdministrators, params.RuntimeAdministrators...)
-		instance.Parameters.Parameters.RuntimeAdministrators = newAdministrators
-		updateStorage = append(updateStorage, "Runtime Administrators")
-	}
-
-	if params.UpdateAutoScaler(&instance.Parameters.Parameters) {
-		updateStorage = append(updateStorage, "Auto Scaler parameters")
-	}
-	if params.MachineType != nil && *params.MachineType != "" {
-		instance.Parameters.Parameters.MachineType = params.MachineType
-	}
-
-	if supportsAdditionalWorkerNodePools(details.PlanID) && params.AdditionalWorkerNodePools != nil {
-		newAdditionalWorkerNodePools := make([]pkg.AdditionalWorkerNodePool, 0, len(params.AdditionalWorkerNodePools))
-		newAdditionalWorkerNodePools = append(newAdditionalWorkerNodePools, params.AdditionalWorkerNodePools...)
-		instance.Parameters.Parameters.AdditionalWorkerNodePools = newAdditionalWorkerNodePools
-		updateStorage = append(updateStorage, "Additional Worker Node Pools")
-	}
-
-	if params.Name != nil && *params.Name != "" {
-		instance.Parameters.Parameters.Name = *params.Name
-		updateStorage = append(updateStorage, "Cluster Name")
-	}
-
-	if len(updateStorage) > 0 {
-		if err := wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
-			instance, err = b.instanceStorage.Update(*instance)
-			if err != nil {
-				params := strings.Join(updateStorage, ", ")
-				logger.Warn(fmt.Sprintf("unable to update instance with new %v (%s), retrying", params, err.Error()))
-				return false, nil
-			}
-			return true, nil
-		}); err != nil {
-			response := apiresponses.NewFailureResponse(fmt.Errorf("Update operation failed"), http.StatusInternalServerError, err.Error())
-			return domain.UpdateServiceSpec{}, response
-		}
-
-		if slices.Contains(updateStorage, planChangeMessage) {
-			oldPlan := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(oldPlanID))
-			newPlan := AvailablePlans.GetPlanNameOrEmpty(PlanIDType(details.PlanID))
-			message := fmt.Sprintf("Plan updated from %s (PlanID: %s) to %s (PlanID: %s).", oldPlan, oldPlanID, newPlan, details.PlanID)
-			if err := b.actionStorage.InsertAction(
-				pkg.PlanUpdateActionType,
-				instance.InstanceID,
-				message,
-				oldPlanID,
-				details.PlanID,
-			); err != nil {
-				logger.Error(fmt.Sprintf("while inserting action %q with message %s for instance ID %s: %v", pkg.PlanUpdateActionType, message, instance.InstanceID, err))
-			}
-		}
-	}
-	if b.shouldResponseSynchronously(previousInstance, instance, logger) {
-		return b.processSyncResponseOk(instance, logger)
-	}
-	logger.Debug("Creating update operation in the database")
-	err = b.operationStorage.InsertOperation(operation)
-	if err != nil {
-		return domain.UpdateServiceSpec{}, err
-	}
-	logger.Debug("Adding update operation to the processing queue")
-	b.updatingQueue.Add(operationID)
-
-	return domain.UpdateServiceSpec{
-		IsAsync:       true,
-		DashboardURL:  dashboard.ProvideURL(instance, lastProvisioningOperation),
-		OperationData: operation.ID,
-		Metadata: domain.InstanceMetadata{
-			Labels: ResponseLabels(*instance, b.config.URL, b.kcBuilder),
-		},
-	}, nil
+	return operation, discoveredZones, nil
 }
 
 func (b *UpdateEndpoint) shouldResponseSynchronously(previousInstance, currentInstance *internal.Instance, logger *slog.Logger) bool {
@@ -0,0 +1,24 @@
+package broker
+
+import (
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+)
+
+// NewProvisioningAttributes returns the attributes of the instance matched against HAP rules, the machine type defaults
+// to the default machine type of the plan when it is not set in the provisioning parameters
+func NewProvisioningAttributes(parameters internal.ProvisioningParameters, values internal.ProviderValues) *rules.ProvisioningAttributes {
+	machineType := values.DefaultMachineType
+	if parameters.Parameters.MachineType != nil {
+		machineType = *parameters.Parameters.MachineType
+	}
+	return rules.NewProvisioningAttributes(
+		AvailablePlans.GetPlanNameOrEmpty(PlanIDType(parameters.PlanID)),
+		parameters.PlatformRegion,
+		values.Region,
+		values.ProviderType,
+		parameters.ErsContext.GlobalAccountID,
+		parameters.ErsContext.Labels,
+		machineType,
+	)
+}
@@ -4,6 +4,7 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal/config"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 	"github.com/pivotal-cf/brokerapi/v12/domain"
 )
 
@@ -16,6 +17,7 @@ type SchemaService struct {
 
 	cfg             Config
 	channelResolver config.ChannelResolver
+	regionPolicies  regionpolicy.Policies
 }
 
 func NewSchemaService(providerSpec *configuration.ProviderSpec, planSpec *configuration.PlanSpecifications, defaultOIDCConfig *pkg.OIDCConfigDTO, cfg Config, ingressFilteringPlans StringList, channelResolver config.ChannelResolver) *SchemaService {
@@ -26,24 +28,23 @@ func NewSchemaService(providerSpec *configuration.ProviderSpec, planSpec *config
 		cfg:                   cfg,
 		ingressFilteringPlans: ingressFilteringPlans,
 		channelResolver:       channelResolver,
+		regionPolicies:        regionpolicy.DefaultPolicies(),
 	}
 }
 
+// UseRegionPolicies replaces the default region policies, which restrict hyperscaler regions offered in platform regions
+func (s *SchemaService) UseRegionPolicies(policies regionpolicy.Policies) {
+	s.regionPolicies = policies
+}
+
+func (s *SchemaService) RegionPolicies() regionpolicy.Policies {
+	return s.regionPolicies
+}
+
 func (s *SchemaService) Validate() error {
 	for planName, regions := range s.planSpec.AllRegionsByPlan() {
-		var provider pkg.CloudProvider
-		switch planName {
-		case AWSPlanName, BuildRuntimeAWSPlanName, PreviewPlanName:
-			provider = pkg.AWS
-		case GCPPlanName, BuildRuntimeGCPPlanName:
-			provider = pkg.GCP
-		case AzurePlanName, BuildRuntimeAzurePlanName, AzureLitePlanName:
-			provider = pkg.Azure
-		case SapConvergedCloudPlanName:
-			provider = pkg.SapConvergedCloud
-		case AlicloudPlanName:
-			provider = pkg.Alicloud
-		default:
+		provider, found := planProvider(planName)
+		if !found {
 			continue
 		}
 		for _, region := range regions {
@@ -56,6 +57,23 @@ func (s *SchemaService) Validate() error {
 	return nil
 }
 
+func planProvider(planName string) (pkg.CloudProvider, bool) {
+	switch planName {
+	case AWSPlanName, BuildRuntimeAWSPlanName, PreviewPlanName:
+		return pkg.AWS, true
+	case GCPPlanName, BuildRuntimeGCPPlanName:
+		return pkg.GCP, true
+	case AzurePlanName, BuildRuntimeAzurePlanName, AzureLitePlanName:
+		return pkg.Azure, true
+	case SapConvergedCloudPlanName:
+		return pkg.SapConvergedCloud, true
+	case AlicloudPlanName:
+		return pkg.Alicloud, true
+	default:
+		return pkg.UnknownProvider, false
+	}
+}
+
 func (s *SchemaService) Plans(plans PlansConfig, platformRegion string, cp pkg.CloudProvider) map[string]domain.ServicePlan {
 
 	outputPlans := map[string]domain.ServicePlan{}
@@ -125,7 +143,7 @@ func (s *SchemaService) defaultServicePlan(id, name string, plans PlansConfig, c
 }
 
 func (s *SchemaService) planSchemas(cp pkg.CloudProvider, planName, platformRegion string) (create, update *map[string]interface{}, available bool) {
-	regions := s.planSpec.Regions(planName, platformRegion)
+	regions := s.PlanRegions(planName, platformRegion)
 	if len(regions) == 0 {
 		return nil, nil, false
 	}
@@ -250,7 +268,7 @@ func (s *SchemaService) AzureLiteSchema(platformRegion string, regions []string,
 }
 
 func (s *SchemaService) AzureLiteSchemas(platformRegion string) (create, update *map[string]interface{}, available bool) {
-	regions := s.planSpec.Regions(AzureLitePlanName, platformRegion)
+	regions := s.PlanRegions(AzureLitePlanName, platformRegion)
 	if len(regions) == 0 {
 		return nil, nil, false
 	}
@@ -263,10 +281,10 @@ func (s *SchemaService) FreeSchema(provider pkg.CloudProvider, platformRegion st
 	var regionsDisplayNames map[string]string
 	switch provider {
 	case pkg.Azure:
-		regions = s.planSpec.Regions(AzurePlanName, platformRegion)
+		regions = s.PlanRegions(AzurePlanName, platformRegion)
 		regionsDisplayNames = s.providerSpec.RegionDisplayNames(pkg.Azure, regions)
 	default: // AWS and other BTP regions
-		regions = s.planSpec.Regions(AWSPlanName, platformRegion)
+		regions = s.PlanRegions(AWSPlanName, platformRegion)
 		regionsDisplayNames = s.providerSpec.RegionDisplayNames(pkg.AWS, regions)
 	}
 	flags := s.createFlags(FreemiumPlanName)
@@ -331,6 +349,11 @@ func (s *SchemaService) RandomZones(cp pkg.CloudProvider, region string, zonesCo
 	return s.providerSpec.RandomZones(cp, region, zonesCount)
 }
 
+// PlanRegions returns regions of the plan configured for the platform region, which are allowed by the region policy of the platform region
 func (s *SchemaService) PlanRegions(planName, platformRegion string) []string {
-	return s.planSpec.Regions(planName, platformRegion)
+	regions := s.planSpec.Regions(planName, platformRegion)
+	if provider, found := planProvider(planName); found {
+		return s.regionPolicies.FilterHyperscalerRegions(platformRegion, provider, regions)
+	}
+	return regions
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -106,6 +107,33 @@ gcp:
 	assert.Error(t, err)
 }
 
+func TestSchemaService_PlanRegions(t *testing.T) {
+	// Given
+	plans, err := configuration.NewPlanSpecifications(strings.NewReader(`
+aws:
+        regions:
+            cf-eu11:
+                - eu-central-1
+                - eu-west-1
+            default:
+                - eu-west-1
+                - us-east-1
+`))
+	require.NoError(t, err)
+	svc := NewSchemaService(nil, plans, nil, Config{}, StringList{}, &fixture.FakeChannelResolver{})
+	svc.UseRegionPolicies(regionpolicy.Policies{
+		"cf-eu11": {Provider: "aws", DataResidency: regionpolicy.DataResidencyEUAccess, HyperscalerRegions: map[string][]string{"aws": {"eu-central-1"}}},
+	})
+
+	// When
+	restricted := svc.PlanRegions(AWSPlanName, "cf-eu11")
+	unrestricted := svc.PlanRegions(AWSPlanName, "cf-us10")
+
+	// Then
+	assert.Equal(t, []string{"eu-central-1"}, restricted)
+	assert.Equal(t, []string{"eu-west-1", "us-east-1"}, unrestricted)
+}
+
 func TestSchemaPlans(t *testing.T) {
 	// Given
 	schemaService := createSchemaService(t)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/kyma-environment-broker/internal/middleware"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 	"github.com/pivotal-cf/brokerapi/v12/domain"
 	"github.com/pivotal-cf/brokerapi/v12/handlers"
 	"github.com/pivotal-cf/brokerapi/v12/middlewares"
@@ -23,7 +24,7 @@ func (h CreateBindingHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request)
 }
 
 // copied from github.com/pivotal-cf/brokerapi/api.go
-func AttachRoutes(router *httputil.Router, serviceBroker domain.ServiceBroker, logger *slog.Logger, createBindingTimeout time.Duration, defaultRequestRegion string, prefixes []string) *httputil.Router {
+func AttachRoutes(router *httputil.Router, serviceBroker domain.ServiceBroker, logger *slog.Logger, createBindingTimeout time.Duration, defaultRequestRegion string, prefixes []string, regionPolicies regionpolicy.Policies) *httputil.Router {
 	apiHandler := handlers.NewApiHandler(serviceBroker, logger)
 	deprovision := func(w http.ResponseWriter, req *http.Request) {
 		req2 := req.WithContext(context.WithValue(req.Context(), "User-Agent", req.Header.Get("User-Agent")))
@@ -36,7 +37,7 @@ func AttachRoutes(router *httputil.Router, serviceBroker domain.ServiceBroker, l
 	router.Use(apiVersionMiddleware.ValidateAPIVersionHdr)
 	router.Use(middlewares.AddInfoLocationToContext)
 	router.Use(middleware.AddRegionToContext(defaultRequestRegion))
-	router.Use(middleware.AddProviderToContext())
+	router.Use(middleware.AddProviderToContext(regionPolicies))
 
 	for _, prefix := range prefixes {
 		registerRoutesAndHandlers(router, &apiHandler, deprovision, createBindingTimeout, prefix)
@@ -87,6 +87,7 @@ func (se *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, err
 			Description:          class.Description,
 			Bindable:             false,
 			InstancesRetrievable: true,
+			BindingsRetrievable:  se.cfg.Binding.AsyncEnabled,
 			Tags: []string{
 				"SAP",
 				"Kyma",
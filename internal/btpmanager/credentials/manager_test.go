@@ -72,7 +72,7 @@ func InitEnvironment(ctx context.Context, t *testing.T) *Environment {
 
 	newEnvironment.createTestData()
 	newEnvironment.manager = NewManager(ctx, newEnvironment.kcp, newEnvironment.brokerStorage.Instances(), logs, false)
-	newEnvironment.job = NewJob(newEnvironment.manager, logs, prometheus.NewRegistry(), "8081", "runtime-reconciler-test")
+	newEnvironment.job = NewJob(newEnvironment.manager, logs, prometheus.NewRegistry(), "runtime-reconciler-test")
 	newEnvironment.assertNumberOfInstancesInDb(expectedAllInstancesCount)
 	return newEnvironment
 }
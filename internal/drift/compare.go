@@ -0,0 +1,87 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Difference describes a single field of the desired resource which is not reflected in the live resource
+type Difference struct {
+	Path    string `json:"path"`
+	Desired any    `json:"desired"`
+	Actual  any    `json:"actual,omitempty"`
+}
+
+// Field returns the first segment of the path, for example "spec" for "spec.shoot.name" or "additionalWorkerNodePools" for "additionalWorkerNodePools[name].machineType"
+func (d Difference) Field() string {
+	return strings.FieldsFunc(d.Path, func(r rune) bool { return r == '.' || r == '[' })[0]
+}
+
+// Compare returns the differences between labels and spec of the desired and the actual resource.
+// Only fields set in the desired resource are compared, fields added by controllers are ignored.
+// Nil values and empty strings are treated as not set, because typed resources always contain them. Fields with omitempty
+// which are not set are absent in the desired resource. Explicit false and 0 values are compared.
+func Compare(desired, actual runtime.Object) ([]Difference, error) {
+	desiredContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, fmt.Errorf("while converting desired resource: %w", err)
+	}
+	actualContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(actual)
+	if err != nil {
+		return nil, fmt.Errorf("while converting actual resource: %w", err)
+	}
+
+	var differences []Difference
+	differences = compareValues("metadata.labels", nestedValue(desiredContent, "metadata", "labels"), nestedValue(actualContent, "metadata", "labels"), differences)
+	differences = compareValues("spec", desiredContent["spec"], actualContent["spec"], differences)
+	return differences, nil
+}
+
+func nestedValue(content map[string]any, fields ...string) any {
+	var current any = content
+	for _, field := range fields {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[field]
+	}
+	return current
+}
+
+func compareValues(path string, desired, actual any, differences []Difference) []Difference {
+	if desired == nil || desired == "" {
+		return differences
+	}
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		actualValue, _ := actual.(map[string]any)
+		keys := make([]string, 0, len(desiredValue))
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			differences = compareValues(fmt.Sprintf("%s.%s", path, key), desiredValue[key], actualValue[key], differences)
+		}
+		return differences
+	case []any:
+		actualValue, ok := actual.([]any)
+		if !ok || len(actualValue) != len(desiredValue) {
+			return append(differences, Difference{Path: path, Desired: desired, Actual: actual})
+		}
+		for i := range desiredValue {
+			differences = compareValues(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], actualValue[i], differences)
+		}
+		return differences
+	default:
+		if !reflect.DeepEqual(desired, actual) {
+			return append(differences, Difference{Path: path, Desired: desired, Actual: actual})
+		}
+		return differences
+	}
+}
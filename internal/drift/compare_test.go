@@ -0,0 +1,127 @@
+package drift
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("should return no differences for fields set only in the actual resource", func(t *testing.T) {
+		// given
+		desired := fixRuntime("m6i.large")
+		actual := fixRuntime("m6i.large")
+		actual.Labels["operator.kyma-project.io/added-by-controller"] = "true"
+		actual.Spec.Shoot.Purpose = "production"
+
+		// when
+		differences, err := Compare(desired, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, differences)
+	})
+
+	t.Run("should return changed fields with their paths", func(t *testing.T) {
+		// given
+		desired := fixRuntime("m6i.large")
+		actual := fixRuntime("m6i.xlarge")
+		actual.Labels["kyma-project.io/region"] = "eu-central-1"
+
+		// when
+		differences, err := Compare(desired, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "metadata.labels.kyma-project.io/region", Desired: "eu-west-1", Actual: "eu-central-1"},
+			{Path: "spec.shoot.provider.workers[0].machine.type", Desired: "m6i.large", Actual: "m6i.xlarge"},
+		}, differences)
+	})
+
+	t.Run("should return the whole list when the number of elements differs", func(t *testing.T) {
+		// given
+		desired := fixRuntime("m6i.large")
+		actual := fixRuntime("m6i.large")
+		actual.Spec.Shoot.Provider.Workers = append(actual.Spec.Shoot.Provider.Workers, gardener.Worker{Name: "additional"})
+
+		// when
+		differences, err := Compare(desired, actual)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, differences, 1)
+		assert.Equal(t, "spec.shoot.provider.workers", differences[0].Path)
+	})
+
+	t.Run("should return explicit false and zero values of the desired resource", func(t *testing.T) {
+		// given
+		desired := fixRuntime("m6i.large")
+		desired.Spec.Security.Networking.Filter.Egress.Enabled = false
+		desired.Spec.Shoot.Provider.Workers[0].Minimum = 0
+		actual := fixRuntime("m6i.large")
+		actual.Spec.Security.Networking.Filter.Egress.Enabled = true
+		actual.Spec.Shoot.Provider.Workers[0].Minimum = 3
+
+		// when
+		differences, err := Compare(desired, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "spec.security.networking.filter.egress.enabled", Desired: false, Actual: true},
+			{Path: "spec.shoot.provider.workers[0].minimum", Desired: int64(0), Actual: int64(3)},
+		}, differences)
+	})
+
+	t.Run("should return changed fields of the Kyma resource", func(t *testing.T) {
+		// given
+		desired := fixKyma("regular", false)
+		actual := fixKyma("fast", true)
+		actual.Object["spec"].(map[string]any)["modules"] = []any{map[string]any{"name": "btp-operator"}}
+
+		// when
+		differences, err := Compare(desired, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "spec.channel", Desired: "regular", Actual: "fast"},
+			{Path: "spec.skipMaintenance", Desired: false, Actual: true},
+		}, differences)
+	})
+}
+
+func fixKyma(channel string, skipMaintenance bool) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operator.kyma-project.io/v1beta2",
+		"kind":       "Kyma",
+		"metadata": map[string]any{
+			"name":      "runtime-id",
+			"namespace": "kcp-system",
+			"labels":    map[string]any{"kyma-project.io/region": "eu-west-1"},
+		},
+		"spec": map[string]any{
+			"channel":         channel,
+			"skipMaintenance": skipMaintenance,
+		},
+	}}
+}
+
+func fixRuntime(machineType string) *imv1.Runtime {
+	runtime := &imv1.Runtime{}
+	runtime.Name = "runtime-id"
+	runtime.Namespace = "kcp-system"
+	runtime.Labels = map[string]string{"kyma-project.io/region": "eu-west-1"}
+	runtime.Spec.Shoot.Name = "c-12345"
+	runtime.Spec.Shoot.Region = "eu-west-1"
+	runtime.Spec.Shoot.Provider.Type = "aws"
+	runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+		{Name: "cpu-worker-0", Machine: gardener.Machine{Type: machineType}},
+	}
+	return runtime
+}
@@ -0,0 +1,130 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourceDiff contains the desired state of a resource and its differences to the live resource
+type ResourceDiff struct {
+	Name        string         `json:"name"`
+	Namespace   string         `json:"namespace"`
+	Exists      bool           `json:"exists"`
+	Desired     runtime.Object `json:"desired"`
+	Differences []Difference   `json:"differences"`
+}
+
+type Result struct {
+	InstanceID string       `json:"instanceID"`
+	RuntimeID  string       `json:"runtimeID"`
+	Runtime    ResourceDiff `json:"runtime"`
+	Kyma       ResourceDiff `json:"kyma"`
+}
+
+// Drifted returns true if any of the live resources differs from the desired one
+func (r Result) Drifted() bool {
+	return len(r.Runtime.Differences) > 0 || len(r.Kyma.Differences) > 0
+}
+
+// Detector renders the resources KEB would create for an instance and compares them with the live resources in KCP
+type Detector struct {
+	operations     storage.Operations
+	valuesProvider broker.ValuesProvider
+	previewer      broker.OperationPreviewer
+	kcpClient      client.Client
+}
+
+func NewDetector(operations storage.Operations, valuesProvider broker.ValuesProvider, previewer broker.OperationPreviewer, kcpClient client.Client) *Detector {
+	return &Detector{
+		operations:     operations,
+		valuesProvider: valuesProvider,
+		previewer:      previewer,
+		kcpClient:      kcpClient,
+	}
+}
+
+// Render returns the Runtime and Kyma resources built from the current parameters of the instance
+func (d *Detector) Render(instance internal.Instance, log *slog.Logger) (broker.OperationPreview, error) {
+	provisioning, err := d.operations.GetProvisioningOperationByInstanceID(instance.InstanceID)
+	if err != nil {
+		return broker.OperationPreview{}, fmt.Errorf("while getting provisioning operation: %w", err)
+	}
+	operation := provisioning.Operation
+	operation.ProvisioningParameters = instance.Parameters
+	operation.InstanceDetails = instance.InstanceDetails
+	operation.RuntimeID = instance.RuntimeID
+	if operation.ProvisioningParameters.Parameters.TargetSecret == nil && instance.SubscriptionSecretName != "" {
+		operation.ProvisioningParameters.Parameters.TargetSecret = &instance.SubscriptionSecretName
+	}
+
+	values, err := d.valuesProvider.ValuesForPlanAndParameters(instance.Parameters)
+	if err != nil {
+		return broker.OperationPreview{}, fmt.Errorf("while resolving provider values: %w", err)
+	}
+	// zones are chosen once, during provisioning
+	if stored := instance.InstanceDetails.ProviderValues; stored != nil && len(stored.Zones) > 0 {
+		values.Zones = stored.Zones
+	}
+	operation.ProviderValues = &values
+
+	return d.previewer.Preview(operation, log)
+}
+
+// Detect renders the resources of the instance and returns their differences to the live resources
+func (d *Detector) Detect(ctx context.Context, instance internal.Instance, log *slog.Logger) (Result, error) {
+	preview, err := d.Render(instance, log)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{
+		InstanceID: instance.InstanceID,
+		RuntimeID:  instance.RuntimeID,
+	}
+
+	result.Runtime, err = d.diff(ctx, preview.Runtime, &imv1.Runtime{})
+	if err != nil {
+		return Result{}, fmt.Errorf("while comparing Runtime resource: %w", err)
+	}
+	if preview.Kyma != nil {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(preview.Kyma.GroupVersionKind())
+		result.Kyma, err = d.diff(ctx, preview.Kyma, live)
+		if err != nil {
+			return Result{}, fmt.Errorf("while comparing Kyma resource: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (d *Detector) diff(ctx context.Context, desired, live client.Object) (ResourceDiff, error) {
+	resourceDiff := ResourceDiff{
+		Name:      desired.GetName(),
+		Namespace: desired.GetNamespace(),
+		Desired:   desired,
+	}
+	err := d.kcpClient.Get(ctx, client.ObjectKey{Name: desired.GetName(), Namespace: desired.GetNamespace()}, live)
+	switch {
+	case errors.IsNotFound(err):
+		return resourceDiff, nil
+	case err != nil:
+		return ResourceDiff{}, err
+	}
+	resourceDiff.Exists = true
+	resourceDiff.Differences, err = Compare(desired, live)
+	if err != nil {
+		return ResourceDiff{}, err
+	}
+	return resourceDiff, nil
+}
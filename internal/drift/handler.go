@@ -0,0 +1,147 @@
+package drift
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/suspension"
+
+	"github.com/google/uuid"
+)
+
+type router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+type reconcileResponse struct {
+	OperationID string `json:"operation"`
+}
+
+type Handler interface {
+	AttachRoutes(r router)
+}
+
+type handler struct {
+	instances   storage.Instances
+	operations  storage.Operations
+	updateQueue suspension.Adder
+	detector    *Detector
+	log         *slog.Logger
+}
+
+func NewHandler(instances storage.Instances, operations storage.Operations, updateQueue suspension.Adder, detector *Detector, log *slog.Logger) Handler {
+	return &handler{
+		instances:   instances,
+		operations:  operations,
+		updateQueue: updateQueue,
+		detector:    detector,
+		log:         log.With("service", "InstanceResourcesEndpoint"),
+	}
+}
+
+func (h *handler) AttachRoutes(r router) {
+	r.HandleFunc("GET /instances/{instance_id}/resources", h.getResources)
+	r.HandleFunc("POST /instances/{instance_id}/reconcile", h.reconcile)
+}
+
+func (h *handler) getResources(w http.ResponseWriter, req *http.Request) {
+	instanceID := req.PathValue("instance_id")
+	logger := h.log.With("instanceID", instanceID)
+
+	instance, ok := h.getInstanceWithRuntime(w, instanceID, logger)
+	if !ok {
+		return
+	}
+
+	result, err := h.detector.Detect(req.Context(), *instance, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to render resources: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("unable to render resources of instance %s: %w", instanceID, err))
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, result)
+}
+
+// reconcile creates an update operation which applies the stored parameters of the instance to its Runtime resource
+func (h *handler) reconcile(w http.ResponseWriter, req *http.Request) {
+	instanceID := req.PathValue("instance_id")
+
+	h.log.Info(fmt.Sprintf("Reconciliation triggered for instanceID: %s", instanceID))
+	logger := h.log.With("instanceID", instanceID)
+
+	instance, ok := h.getInstanceWithRuntime(w, instanceID, logger)
+	if !ok {
+		return
+	}
+	// pending operations are not returned as the last operation, all operations of the instance are checked
+	operations, err := h.operations.ListOperationsByInstanceID(instanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to list operations of the instance: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	for _, operation := range operations {
+		if !operation.IsFinished() {
+			msg := fmt.Sprintf("instance cannot be reconciled, %s operation %s is in state %q", operation.Type, operation.ID, operation.State)
+			logger.Warn(msg)
+			httputil.WriteErrorResponse(w, http.StatusConflict, errors.New(msg))
+			return
+		}
+	}
+	lastOperation, err := h.operations.GetLastOperation(instanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to get the last operation of the instance: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if lastOperation.Type == internal.OperationTypeDeprovision {
+		msg := fmt.Sprintf("instance cannot be reconciled, it is deprovisioned by operation %s", lastOperation.ID)
+		logger.Warn(msg)
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.New(msg))
+		return
+	}
+
+	parameters := instance.Parameters.Parameters
+	operation := internal.NewUpdateOperation(uuid.New().String(), instance, internal.UpdatingParametersDTO{
+		AutoScalerParameters:      parameters.AutoScalerParameters,
+		OIDC:                      parameters.OIDC,
+		MachineType:               parameters.MachineType,
+		AdditionalWorkerNodePools: parameters.AdditionalWorkerNodePools,
+	})
+	operation.Description = "Operation created to reconcile the Runtime resource"
+	if err := h.operations.InsertOperation(operation); err != nil {
+		logger.Error(fmt.Sprintf("unable to insert the update operation: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	h.updateQueue.Add(operation.ID)
+
+	events.Infof(instanceID, operation.ID, "update operation created to reconcile the Runtime resource with the instance parameters")
+	logger.Info(fmt.Sprintf("update operation %s created to reconcile the Runtime resource", operation.ID))
+	httputil.WriteResponse(w, http.StatusAccepted, reconcileResponse{OperationID: operation.ID})
+}
+
+func (h *handler) getInstanceWithRuntime(w http.ResponseWriter, instanceID string, logger *slog.Logger) (*internal.Instance, bool) {
+	instance, err := h.instances.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("instance %s not found", instanceID))
+		return nil, false
+	case err != nil:
+		logger.Error(fmt.Sprintf("unable to get instance: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("unable to get instance %s", instanceID))
+		return nil, false
+	}
+	if instance.RuntimeID == "" {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("instance %s has no runtime", instanceID))
+		return nil, false
+	}
+	return instance, true
+}
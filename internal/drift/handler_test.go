@@ -0,0 +1,177 @@
+package drift_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/drift"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	instanceID = "instance-id"
+	runtimeID  = "runtime-id"
+)
+
+func TestHandler_GetResources(t *testing.T) {
+	// given
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	db := storage.NewMemoryStorage()
+	instance := fixture.FixInstance(instanceID)
+	instance.RuntimeID = runtimeID
+	instance.Parameters.Parameters.MachineType = ptr.String("m6i.xlarge")
+	require.NoError(t, db.Instances().Insert(instance))
+	operation := fixture.FixProvisioningOperation("op-id", instanceID)
+	operation.ProviderValues = &internal.ProviderValues{Zones: []string{"eu-west-1a"}}
+	require.NoError(t, db.Operations().InsertProvisioningOperation(internal.ProvisioningOperation{Operation: operation}))
+	require.NoError(t, db.Instances().Insert(internal.Instance{InstanceID: "without-runtime"}))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+	live := fixRuntime("m6i.large")
+	kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(live).Build()
+
+	previewer := &previewerStub{}
+	queue := &queueRecorder{}
+	detector := drift.NewDetector(db.Operations(), valuesProviderStub{}, previewer, kcpClient)
+	router := httputil.NewRouter()
+	drift.NewHandler(db.Instances(), db.Operations(), queue, detector, log).AttachRoutes(router)
+
+	t.Run("should return desired resources with differences to live resources", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/instances/instance-id/resources", nil)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var result struct {
+			InstanceID string `json:"instanceID"`
+			Runtime    struct {
+				Exists      bool               `json:"exists"`
+				Differences []drift.Difference `json:"differences"`
+			} `json:"runtime"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, instanceID, result.InstanceID)
+		assert.True(t, result.Runtime.Exists)
+		assert.Equal(t, []drift.Difference{
+			{Path: "spec.shoot.provider.workers[0].machine.type", Desired: "m6i.xlarge", Actual: "m6i.large"},
+		}, result.Runtime.Differences)
+
+		assert.Equal(t, runtimeID, previewer.operation.RuntimeID)
+		assert.Equal(t, []string{"eu-west-1a"}, previewer.operation.ProviderValues.Zones)
+		assert.Equal(t, instance.SubscriptionSecretName, *previewer.operation.ProvisioningParameters.Parameters.TargetSecret)
+	})
+
+	t.Run("should create update operation applying the instance parameters", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodPost, "/instances/instance-id/reconcile", nil)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		var response struct {
+			OperationID string `json:"operation"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, []string{response.OperationID}, queue.added)
+
+		operation, err := db.Operations().GetOperationByID(response.OperationID)
+		require.NoError(t, err)
+		assert.Equal(t, internal.OperationTypeUpdate, operation.Type)
+		assert.Equal(t, "m6i.xlarge", *operation.UpdatingParameters.MachineType)
+	})
+
+	t.Run("should return 409 for instance with operation in progress", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodPost, "/instances/instance-id/reconcile", nil)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Len(t, queue.added, 1)
+	})
+
+	t.Run("should return 404 for not existing instance", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/instances/not-existing/resources", nil)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return 409 for instance without runtime", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/instances/without-runtime/resources", nil)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+type queueRecorder struct {
+	added []string
+}
+
+func (q *queueRecorder) Add(operationID string) {
+	q.added = append(q.added, operationID)
+}
+
+type valuesProviderStub struct{}
+
+func (valuesProviderStub) ValuesForPlanAndParameters(_ internal.ProvisioningParameters) (internal.ProviderValues, error) {
+	return internal.ProviderValues{ProviderType: "aws", Zones: []string{"eu-west-1b", "eu-west-1c"}}, nil
+}
+
+type previewerStub struct {
+	operation internal.Operation
+}
+
+func (p *previewerStub) Preview(operation internal.Operation, _ *slog.Logger) (broker.OperationPreview, error) {
+	p.operation = operation
+	return broker.OperationPreview{Runtime: fixRuntime(*operation.ProvisioningParameters.Parameters.MachineType)}, nil
+}
+
+func fixRuntime(machineType string) *imv1.Runtime {
+	runtime := &imv1.Runtime{}
+	runtime.Name = runtimeID
+	runtime.Namespace = "kcp-system"
+	runtime.Spec.Shoot.Provider.Type = "aws"
+	runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+		{Name: "cpu-worker-0", Machine: gardener.Machine{Type: machineType}},
+	}
+	return runtime
+}
@@ -0,0 +1,42 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+type Job struct {
+	reconciler *Reconciler
+	logs       *slog.Logger
+}
+
+func NewJob(reconciler *Reconciler, logs *slog.Logger) *Job {
+	return &Job{
+		reconciler: reconciler,
+		logs:       logs,
+	}
+}
+
+func (j *Job) Start(ctx context.Context, autoReconcileInterval int, jobReconciliationDelay time.Duration) {
+	scheduler := gocron.NewScheduler(time.UTC)
+	_, schedulerErr := scheduler.Every(autoReconcileInterval).Minutes().Do(func() {
+		j.logs.Info(fmt.Sprintf("drift-reconciler: scheduled call started at %s", time.Now()))
+		_, reconcileErr := j.reconciler.ReconcileAll(ctx, jobReconciliationDelay)
+		if reconcileErr != nil {
+			j.logs.Error(fmt.Sprintf("drift-reconciler: scheduled call finished with error: %s", reconcileErr))
+		} else {
+			j.logs.Info(fmt.Sprintf("drift-reconciler: scheduled call finished with success at %s", time.Now().String()))
+		}
+	})
+
+	if schedulerErr != nil {
+		j.logs.Error(fmt.Sprintf("drift-reconciler: scheduler failure: %s", schedulerErr))
+	}
+
+	j.logs.Info("drift-reconciler: start scheduler")
+	scheduler.StartAsync()
+}
@@ -0,0 +1,31 @@
+package drift
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type Metrics struct {
+	driftedFields    *prometheus.GaugeVec
+	driftedInstances prometheus.Gauge
+	updates          *prometheus.CounterVec
+}
+
+func NewMetrics(reg prometheus.Registerer, namespace string) *Metrics {
+	m := &Metrics{
+		driftedFields: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "drifted_fields",
+			Help:      "Number of instances whose parameter differs from the Runtime resource, by parameter.",
+		}, []string{"field"}),
+		driftedInstances: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "drifted_instances",
+			Help:      "Number of instances whose parameters differ from the Runtime resource.",
+		}),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "drift_updates_total",
+			Help:      "Update operations requested to reconcile drifted Runtime resources.",
+		}, []string{"result"}),
+	}
+	reg.MustRegister(m.driftedFields, m.driftedInstances, m.updates)
+	return m
+}
@@ -0,0 +1,122 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+)
+
+// CompareParameters returns the differences between the parameters stored for an instance and its live Runtime resource.
+// Parameters not set by the user are defaulted by KEB and are not compared.
+func CompareParameters(parameters pkg.ProvisioningParametersDTO, runtime *imv1.Runtime) []Difference {
+	var differences []Difference
+	workers := runtime.Spec.Shoot.Provider.Workers
+
+	if len(workers) > 0 {
+		if parameters.MachineType != nil && *parameters.MachineType != "" {
+			differences = compareValue("machineType", *parameters.MachineType, workers[0].Machine.Type, differences)
+		}
+		if parameters.AutoScalerMin != nil {
+			differences = compareValue("autoScalerMin", *parameters.AutoScalerMin, int(workers[0].Minimum), differences)
+		}
+		if parameters.AutoScalerMax != nil {
+			differences = compareValue("autoScalerMax", *parameters.AutoScalerMax, int(workers[0].Maximum), differences)
+		}
+	}
+	if parameters.AdditionalWorkerNodePools != nil {
+		differences = compareWorkerNodePools(parameters.AdditionalWorkerNodePools, runtime.Spec.Shoot.Provider.AdditionalWorkers, differences)
+	}
+	if parameters.OIDC != nil {
+		differences = compareOIDC(parameters.OIDC, runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig, differences)
+	}
+
+	return differences
+}
+
+func compareWorkerNodePools(pools []pkg.AdditionalWorkerNodePool, additionalWorkers *[]gardener.Worker, differences []Difference) []Difference {
+	workers := make(map[string]gardener.Worker)
+	if additionalWorkers != nil {
+		for _, worker := range *additionalWorkers {
+			workers[worker.Name] = worker
+		}
+	}
+	for _, pool := range pools {
+		path := fmt.Sprintf("additionalWorkerNodePools[%s]", pool.Name)
+		worker, found := workers[pool.Name]
+		if !found {
+			differences = append(differences, Difference{Path: path, Desired: pool})
+			continue
+		}
+		delete(workers, pool.Name)
+		differences = compareValue(path+".machineType", pool.MachineType, worker.Machine.Type, differences)
+		differences = compareValue(path+".autoScalerMin", pool.AutoScalerMin, int(worker.Minimum), differences)
+		differences = compareValue(path+".autoScalerMax", pool.AutoScalerMax, int(worker.Maximum), differences)
+	}
+	if additionalWorkers != nil {
+		for _, worker := range *additionalWorkers {
+			if _, unexpected := workers[worker.Name]; unexpected {
+				differences = append(differences, Difference{Path: fmt.Sprintf("additionalWorkerNodePools[%s]", worker.Name), Actual: worker.Machine.Type})
+			}
+		}
+	}
+	return differences
+}
+
+func compareOIDC(oidc *pkg.OIDCConnectDTO, additionalConfig *[]imv1.OIDCConfig, differences []Difference) []Difference {
+	var configs []imv1.OIDCConfig
+	if additionalConfig != nil {
+		configs = *additionalConfig
+	}
+
+	if oidc.List != nil {
+		if len(oidc.List) != len(configs) {
+			return append(differences, Difference{Path: "oidc.list", Desired: len(oidc.List), Actual: len(configs)})
+		}
+		for i, dto := range oidc.List {
+			differences = compareOIDCConfig(fmt.Sprintf("oidc.list[%d]", i), dto, configs[i], differences)
+		}
+		return differences
+	}
+
+	if oidc.OIDCConfigDTO == nil || oidc.OIDCConfigDTO.IsEmpty() {
+		return differences
+	}
+	if len(configs) == 0 {
+		return append(differences, Difference{Path: "oidc", Desired: oidc.OIDCConfigDTO.ClientID})
+	}
+	return compareOIDCConfig("oidc", *oidc.OIDCConfigDTO, configs[0], differences)
+}
+
+func compareOIDCConfig(path string, dto pkg.OIDCConfigDTO, config imv1.OIDCConfig, differences []Difference) []Difference {
+	if dto.ClientID != "" {
+		differences = compareValue(path+".clientID", dto.ClientID, ptr.ToString(config.ClientID), differences)
+	}
+	if dto.IssuerURL != "" {
+		differences = compareValue(path+".issuerURL", dto.IssuerURL, ptr.ToString(config.IssuerURL), differences)
+	}
+	if dto.GroupsClaim != "" {
+		differences = compareValue(path+".groupsClaim", dto.GroupsClaim, ptr.ToString(config.GroupsClaim), differences)
+	}
+	if dto.UsernameClaim != "" {
+		differences = compareValue(path+".usernameClaim", dto.UsernameClaim, ptr.ToString(config.UsernameClaim), differences)
+	}
+	if dto.UsernamePrefix != "" {
+		differences = compareValue(path+".usernamePrefix", dto.UsernamePrefix, ptr.ToString(config.UsernamePrefix), differences)
+	}
+	if len(dto.SigningAlgs) > 0 {
+		differences = compareValue(path+".signingAlgs", dto.SigningAlgs, config.SigningAlgs, differences)
+	}
+	return differences
+}
+
+func compareValue(path string, desired, actual any, differences []Difference) []Difference {
+	if reflect.DeepEqual(desired, actual) {
+		return differences
+	}
+	return append(differences, Difference{Path: path, Desired: desired, Actual: actual})
+}
@@ -0,0 +1,112 @@
+package drift
+
+import (
+	"testing"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareParameters(t *testing.T) {
+	t.Run("should ignore parameters which are not set", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithWorkers()
+
+		// when
+		differences := CompareParameters(pkg.ProvisioningParametersDTO{}, runtime)
+
+		// then
+		assert.Empty(t, differences)
+	})
+
+	t.Run("should return no differences for matching parameters", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithWorkers()
+
+		// when
+		differences := CompareParameters(fixParameters(), runtime)
+
+		// then
+		assert.Empty(t, differences)
+	})
+
+	t.Run("should return drifted worker pools", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithWorkers()
+		runtime.Spec.Shoot.Provider.Workers[0].Machine.Type = "m6i.2xlarge"
+		runtime.Spec.Shoot.Provider.Workers[0].Maximum = 10
+		*runtime.Spec.Shoot.Provider.AdditionalWorkers = append(*runtime.Spec.Shoot.Provider.AdditionalWorkers, gardener.Worker{Name: "manual", Machine: gardener.Machine{Type: "m6i.large"}})
+		(*runtime.Spec.Shoot.Provider.AdditionalWorkers)[0].Minimum = 1
+
+		// when
+		differences := CompareParameters(fixParameters(), runtime)
+
+		// then
+		assert.Equal(t, []Difference{
+			{Path: "machineType", Desired: "m6i.xlarge", Actual: "m6i.2xlarge"},
+			{Path: "autoScalerMax", Desired: 20, Actual: 10},
+			{Path: "additionalWorkerNodePools[worker-1].autoScalerMin", Desired: 3, Actual: 1},
+			{Path: "additionalWorkerNodePools[manual]", Actual: "m6i.large"},
+		}, differences)
+		assert.Equal(t, "additionalWorkerNodePools", differences[2].Field())
+	})
+
+	t.Run("should return drifted OIDC configuration", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithWorkers()
+		(*runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig)[0].ClientID = ptr.String("manual")
+
+		// when
+		differences := CompareParameters(fixParameters(), runtime)
+
+		// then
+		assert.Equal(t, []Difference{{Path: "oidc.clientID", Desired: "client-id", Actual: "manual"}}, differences)
+	})
+
+	t.Run("should return different number of OIDC configurations", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeWithWorkers()
+		parameters := fixParameters()
+		parameters.OIDC = &pkg.OIDCConnectDTO{List: []pkg.OIDCConfigDTO{}}
+
+		// when
+		differences := CompareParameters(parameters, runtime)
+
+		// then
+		assert.Equal(t, []Difference{{Path: "oidc.list", Desired: 0, Actual: 1}}, differences)
+	})
+}
+
+func fixParameters() pkg.ProvisioningParametersDTO {
+	return pkg.ProvisioningParametersDTO{
+		AutoScalerParameters: pkg.AutoScalerParameters{
+			AutoScalerMin: ptr.Integer(3),
+			AutoScalerMax: ptr.Integer(20),
+		},
+		MachineType: ptr.String("m6i.xlarge"),
+		AdditionalWorkerNodePools: []pkg.AdditionalWorkerNodePool{
+			{Name: "worker-1", MachineType: "m6i.large", AutoScalerMin: 3, AutoScalerMax: 5},
+		},
+		OIDC: &pkg.OIDCConnectDTO{
+			OIDCConfigDTO: &pkg.OIDCConfigDTO{ClientID: "client-id", IssuerURL: "https://issuer.local"},
+		},
+	}
+}
+
+func fixRuntimeWithWorkers() *imv1.Runtime {
+	runtime := &imv1.Runtime{}
+	runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+		{Name: "cpu-worker-0", Machine: gardener.Machine{Type: "m6i.xlarge"}, Minimum: 3, Maximum: 20},
+	}
+	runtime.Spec.Shoot.Provider.AdditionalWorkers = &[]gardener.Worker{
+		{Name: "worker-1", Machine: gardener.Machine{Type: "m6i.large"}, Minimum: 3, Maximum: 5},
+	}
+	runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = &[]imv1.OIDCConfig{
+		{OIDCConfig: gardener.OIDCConfig{ClientID: ptr.String("client-id"), IssuerURL: ptr.String("https://issuer.local")}},
+	}
+	return runtime
+}
@@ -0,0 +1,157 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Updater requests an update operation which applies the stored parameters of the instance to its Runtime resource
+type Updater interface {
+	ReconcileInstance(instanceID string) (string, error)
+}
+
+type ReconcileStats struct {
+	InstanceCnt int
+	DriftedCnt  int
+	UpdatedCnt  int
+	ErrorsCnt   int
+}
+
+// Reconciler compares the parameters of instances with their Runtime resources
+type Reconciler struct {
+	instances storage.Instances
+	kcpClient client.Client
+	// updater is nil when drifted Runtime resources are only reported
+	updater Updater
+	metrics *Metrics
+	log     *slog.Logger
+}
+
+func NewReconciler(instances storage.Instances, kcpClient client.Client, updater Updater, metrics *Metrics, log *slog.Logger) *Reconciler {
+	return &Reconciler{
+		instances: instances,
+		kcpClient: kcpClient,
+		updater:   updater,
+		metrics:   metrics,
+		log:       log.With("service", "DriftReconciler"),
+	}
+}
+
+func (r *Reconciler) ReconcileAll(ctx context.Context, delay time.Duration) (ReconcileStats, error) {
+	instances, _, _, err := r.instances.List(dbmodel.InstanceFilter{})
+	if err != nil {
+		return ReconcileStats{}, fmt.Errorf("while getting all instances: %w", err)
+	}
+
+	stats := ReconcileStats{}
+	driftedFields := make(map[string][]Difference)
+	for _, instance := range instances {
+		// instances without runtime, deprovisioned or with an operation in progress are not reconcilable
+		if !instance.Reconcilable || instance.RuntimeID == "" {
+			continue
+		}
+		time.Sleep(delay)
+		stats.InstanceCnt++
+
+		differences, err := r.Reconcile(ctx, instance)
+		if err != nil {
+			r.log.Error(fmt.Sprintf("while comparing instance %s with the Runtime resource: %s", instance.InstanceID, err))
+			stats.ErrorsCnt++
+			continue
+		}
+		if len(differences) == 0 {
+			continue
+		}
+		stats.DriftedCnt++
+		driftedFields[instance.InstanceID] = differences
+		if r.trigger(instance) {
+			stats.UpdatedCnt++
+		}
+	}
+	r.updateMetrics(instances, driftedFields)
+
+	r.log.Info(fmt.Sprintf("drift reconciliation summary: %d instances compared, %d drifted, update requested for %d, errors occurred for %d",
+		stats.InstanceCnt, stats.DriftedCnt, stats.UpdatedCnt, stats.ErrorsCnt))
+	return stats, nil
+}
+
+// Reconcile returns the differences between the parameters of the instance and its Runtime resource
+func (r *Reconciler) Reconcile(ctx context.Context, instance internal.Instance) ([]Difference, error) {
+	runtime := &imv1.Runtime{}
+	err := r.kcpClient.Get(ctx, client.ObjectKey{Name: instance.InstanceDetails.GetRuntimeResourceName(), Namespace: instance.InstanceDetails.GetRuntimeResourceNamespace()}, runtime)
+	switch {
+	case errors.IsNotFound(err):
+		r.log.Warn(fmt.Sprintf("Runtime resource for instance %s does not exist", instance.InstanceID))
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("while getting Runtime resource: %w", err)
+	}
+
+	differences := CompareParameters(instance.Parameters.Parameters, runtime)
+	if len(differences) > 0 {
+		paths := make([]string, 0, len(differences))
+		for _, difference := range differences {
+			paths = append(paths, difference.Path)
+		}
+		r.log.Info(fmt.Sprintf("Runtime resource of instance %s differs from the instance parameters: %v", instance.InstanceID, differences))
+		events.Infof(instance.InstanceID, "", "Runtime resource differs from the instance parameters: %s", strings.Join(paths, ", "))
+	}
+	return differences, nil
+}
+
+func (r *Reconciler) trigger(instance internal.Instance) bool {
+	if r.updater == nil {
+		return false
+	}
+	operationID, err := r.updater.ReconcileInstance(instance.InstanceID)
+	if err != nil {
+		r.log.Error(fmt.Sprintf("while requesting update of instance %s: %s", instance.InstanceID, err))
+		events.Errorf(instance.InstanceID, "", err, "unable to request update operation to reconcile the Runtime resource")
+		r.countUpdate("failed")
+		return false
+	}
+	r.log.Info(fmt.Sprintf("update operation %s requested for instance %s", operationID, instance.InstanceID))
+	r.countUpdate("requested")
+	return true
+}
+
+func (r *Reconciler) countUpdate(result string) {
+	if r.metrics != nil {
+		r.metrics.updates.With(prometheus.Labels{"result": result}).Inc()
+	}
+}
+
+// updateMetrics replaces the gauges after all instances are compared, so fields which are no longer drifted are removed
+func (r *Reconciler) updateMetrics(instances []internal.Instance, driftedFields map[string][]Difference) {
+	if r.metrics == nil {
+		return
+	}
+	counts := make(map[string]int)
+	for _, instance := range instances {
+		fields := make(map[string]struct{})
+		for _, difference := range driftedFields[instance.InstanceID] {
+			fields[difference.Field()] = struct{}{}
+		}
+		for field := range fields {
+			counts[field]++
+		}
+	}
+	r.metrics.driftedFields.Reset()
+	for field, count := range counts {
+		r.metrics.driftedFields.With(prometheus.Labels{"field": field}).Set(float64(count))
+	}
+	r.metrics.driftedInstances.Set(float64(len(driftedFields)))
+}
@@ -0,0 +1,107 @@
+package drift_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/drift"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_ReconcileAll(t *testing.T) {
+	// given
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	db := storage.NewMemoryStorage()
+	require.NoError(t, db.Instances().Insert(fixReconcilableInstance("drifted", "m6i.xlarge")))
+	require.NoError(t, db.Instances().Insert(fixReconcilableInstance("unchanged", "m6i.large")))
+	notReconcilable := fixReconcilableInstance("not-reconcilable", "m6i.xlarge")
+	notReconcilable.Reconcilable = false
+	require.NoError(t, db.Instances().Insert(notReconcilable))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+	kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		fixLiveRuntime("drifted", "m6i.large"),
+		fixLiveRuntime("unchanged", "m6i.large"),
+		fixLiveRuntime("not-reconcilable", "m6i.large"),
+	).Build()
+
+	registry := prometheus.NewRegistry()
+	updater := &updaterStub{}
+	reconciler := drift.NewReconciler(db.Instances(), kcpClient, updater, drift.NewMetrics(registry, "test"), log)
+
+	// when
+	stats, err := reconciler.ReconcileAll(context.Background(), 0)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, drift.ReconcileStats{InstanceCnt: 2, DriftedCnt: 1, UpdatedCnt: 1}, stats)
+	assert.Equal(t, []string{"drifted"}, updater.instanceIDs)
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP test_drifted_fields Number of instances whose parameter differs from the Runtime resource, by parameter.
+# TYPE test_drifted_fields gauge
+test_drifted_fields{field="machineType"} 1
+# HELP test_drifted_instances Number of instances whose parameters differ from the Runtime resource.
+# TYPE test_drifted_instances gauge
+test_drifted_instances 1
+`), "test_drifted_fields", "test_drifted_instances"))
+
+	t.Run("should remove metrics of instances which are no longer drifted", func(t *testing.T) {
+		// given
+		instance := fixReconcilableInstance("drifted", "m6i.large")
+		_, err := db.Instances().Update(instance)
+		require.NoError(t, err)
+
+		// when
+		stats, err := reconciler.ReconcileAll(context.Background(), 0)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, drift.ReconcileStats{InstanceCnt: 2}, stats)
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP test_drifted_instances Number of instances whose parameters differ from the Runtime resource.
+# TYPE test_drifted_instances gauge
+test_drifted_instances 0
+`), "test_drifted_fields", "test_drifted_instances"))
+	})
+}
+
+type updaterStub struct {
+	instanceIDs []string
+}
+
+func (u *updaterStub) ReconcileInstance(instanceID string) (string, error) {
+	u.instanceIDs = append(u.instanceIDs, instanceID)
+	return "operation-id", nil
+}
+
+func fixReconcilableInstance(id, machineType string) internal.Instance {
+	instance := fixture.FixInstance(id)
+	instance.RuntimeID = id
+	instance.Reconcilable = true
+	instance.InstanceDetails.RuntimeResourceName = id
+	instance.InstanceDetails.KymaResourceNamespace = "kcp-system"
+	instance.Parameters.Parameters = pkg.ProvisioningParametersDTO{MachineType: ptr.String(machineType)}
+	return instance
+}
+
+func fixLiveRuntime(name, machineType string) *imv1.Runtime {
+	runtime := fixRuntime(machineType)
+	runtime.Name = name
+	return runtime
+}
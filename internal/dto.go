@@ -87,6 +87,8 @@ type ERSContext struct {
 	Origin                *string                            `json:"origin,omitempty"`
 	Platform              *string                            `json:"platform,omitempty"`
 	Region                *string                            `json:"region,omitempty"`
+	// Labels of the subaccount, used to select the hyperscaler account pool
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func InheritMissingERSContext(currentOperation, previousOperation ERSContext) ERSContext {
@@ -108,6 +110,9 @@ func InheritMissingERSContext(currentOperation, previousOperation ERSContext) ER
 	if currentOperation.Region == nil {
 		currentOperation.Region = previousOperation.Region
 	}
+	if currentOperation.Labels == nil {
+		currentOperation.Labels = previousOperation.Labels
+	}
 	return currentOperation
 }
 
@@ -130,6 +135,9 @@ func UpdateInstanceERSContext(instance, operation ERSContext) ERSContext {
 	if operation.Region != nil {
 		instance.Region = operation.Region
 	}
+	if operation.Labels != nil {
+		instance.Labels = operation.Labels
+	}
 	return instance
 }
 
@@ -7,8 +7,9 @@ import (
 	"testing"
 
 	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
 
@@ -108,35 +109,35 @@ func FixKymaResourceWithGivenRuntimeID(kcpClient client.Client, kymaResourceName
 	}})
 }
 
-func NewFakeAWSClientFactory(zones map[string][]string, error error) *FakeAWSClientFactory {
-	fakeClient := &fakeAWSClient{
+func NewFakeZonesClientFactory(zones map[string][]string, error error) *FakeZonesClientFactory {
+	fakeClient := &fakeZonesClient{
 		zones: zones,
 		err:   error,
 	}
-	return &FakeAWSClientFactory{client: fakeClient}
+	return &FakeZonesClientFactory{client: fakeClient}
 }
 
-type FakeAWSClientFactory struct {
-	client aws.Client
+type FakeZonesClientFactory struct {
+	client hyperscalers.ZonesClient
 }
 
-func (f *FakeAWSClientFactory) New(ctx context.Context, accessKeyID, secretAccessKey, region string) (aws.Client, error) {
+func (f *FakeZonesClientFactory) New(ctx context.Context, provider runtime.CloudProvider, secret *unstructured.Unstructured, region string) (hyperscalers.ZonesClient, error) {
 	return f.client, nil
 }
 
-type fakeAWSClient struct {
+type fakeZonesClient struct {
 	zones map[string][]string
 	err   error
 }
 
-func (f *fakeAWSClient) AvailableZones(ctx context.Context, machineType string) ([]string, error) {
+func (f *fakeZonesClient) AvailableZones(ctx context.Context, machineType string) ([]string, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
 	return f.zones[machineType], nil
 }
 
-func (f *fakeAWSClient) AvailableZonesCount(ctx context.Context, machineType string) (int, error) {
+func (f *fakeZonesClient) AvailableZonesCount(ctx context.Context, machineType string) (int, error) {
 	zones, err := f.AvailableZones(ctx, machineType)
 	if err != nil {
 		return 0, err
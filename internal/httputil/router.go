@@ -40,6 +40,15 @@ func (r *Router) HandleFunc(pattern string, handleFunc func(http.ResponseWriter,
 	r.ServeMux.Handle(pattern, handler)
 }
 
+// With returns a router which registers routes in the same mux with additional middlewares
+func (r *Router) With(middlewares ...middleware.MiddlewareFunc) *Router {
+	return &Router{
+		ServeMux:    r.ServeMux,
+		subrouters:  r.subrouters,
+		middlewares: append(append([]middleware.MiddlewareFunc{}, r.middlewares...), middlewares...),
+	}
+}
+
 func (r *Router) NewSubRouter(name string) (*Router, error) {
 	if _, exists := r.subrouters[name]; exists {
 		return nil, fmt.Errorf("subrouter %s already exists", name)
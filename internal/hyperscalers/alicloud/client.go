@@ -0,0 +1,246 @@
+package alicloud
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	ecsEndpointFormat = "https://ecs.%s.aliyuncs.com"
+	ecsAPIVersion     = "2014-05-26"
+
+	describeAvailableResourceAction = "DescribeAvailableResource"
+	instanceTypeResource            = "InstanceType"
+	availableStatus                 = "Available"
+)
+
+type ClientFactory interface {
+	New(ctx context.Context, accessKeyID, accessKeySecret, region string) (Client, error)
+}
+
+type Client interface {
+	AvailableZones(ctx context.Context, machineType string) ([]string, error)
+	AvailableZonesCount(ctx context.Context, machineType string) (int, error)
+}
+
+func NewFactory() ClientFactory {
+	return AlicloudClientFactory{}
+}
+
+type AlicloudClientFactory struct{}
+
+func (AlicloudClientFactory) New(ctx context.Context, accessKeyID, accessKeySecret, region string) (Client, error) {
+	return NewClient(accessKeyID, accessKeySecret, region), nil
+}
+
+// AlicloudClient discovers zones using the DescribeAvailableResource action of the Elastic Compute Service API
+type AlicloudClient struct {
+	httpClient      *http.Client
+	endpoint        string
+	accessKeyID     string
+	accessKeySecret string
+	region          string
+	now             func() time.Time
+}
+
+func NewClient(accessKeyID, accessKeySecret, region string) *AlicloudClient {
+	return &AlicloudClient{
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		endpoint:        fmt.Sprintf(ecsEndpointFormat, region),
+		accessKeyID:     accessKeyID,
+		accessKeySecret: accessKeySecret,
+		region:          region,
+		now:             time.Now,
+	}
+}
+
+type describeAvailableResourceResponse struct {
+	AvailableZones struct {
+		AvailableZone []availableZone `json:"AvailableZone"`
+	} `json:"AvailableZones"`
+}
+
+type availableZone struct {
+	ZoneID             string `json:"ZoneId"`
+	Status             string `json:"Status"`
+	AvailableResources struct {
+		AvailableResource []availableResource `json:"AvailableResource"`
+	} `json:"AvailableResources"`
+}
+
+type availableResource struct {
+	Type               string `json:"Type"`
+	SupportedResources struct {
+		SupportedResource []supportedResource `json:"SupportedResource"`
+	} `json:"SupportedResources"`
+}
+
+type supportedResource struct {
+	Value  string `json:"Value"`
+	Status string `json:"Status"`
+}
+
+type errorResponse struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+// AvailableZones returns zones of the region in which the instance type is available, zones without stock are skipped
+func (c *AlicloudClient) AvailableZones(ctx context.Context, machineType string) ([]string, error) {
+	var response describeAvailableResourceResponse
+	err := c.call(ctx, describeAvailableResourceAction, map[string]string{
+		"RegionId":            c.region,
+		"DestinationResource": instanceTypeResource,
+		"InstanceType":        machineType,
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe available resources: %w", err)
+	}
+
+	zones := make([]string, 0)
+	for _, zone := range response.AvailableZones.AvailableZone {
+		if zone.Status != availableStatus {
+			continue
+		}
+		if zone.offers(machineType) {
+			zones = append(zones, zone.ZoneID)
+		}
+	}
+
+	sort.Strings(zones)
+	return zones, nil
+}
+
+func (c *AlicloudClient) AvailableZonesCount(ctx context.Context, machineType string) (int, error) {
+	zones, err := c.AvailableZones(ctx, machineType)
+	if err != nil {
+		return 0, err
+	}
+	return len(zones), nil
+}
+
+func (z availableZone) offers(machineType string) bool {
+	for _, resource := range z.AvailableResources.AvailableResource {
+		if resource.Type != instanceTypeResource {
+			continue
+		}
+		for _, supported := range resource.SupportedResources.SupportedResource {
+			if supported.Value == machineType && supported.Status == availableStatus {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// call executes the RPC style API action signed with the access key
+func (c *AlicloudClient) call(ctx context.Context, action string, params map[string]string, out interface{}) error {
+	query := url.Values{}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	query.Set("Action", action)
+	query.Set("Version", ecsAPIVersion)
+	query.Set("Format", "JSON")
+	query.Set("AccessKeyId", c.accessKeyID)
+	query.Set("SignatureMethod", "HMAC-SHA1")
+	query.Set("SignatureVersion", "1.0")
+	query.Set("SignatureNonce", uuid.NewString())
+	query.Set("Timestamp", c.now().UTC().Format("2006-01-02T15:04:05Z"))
+	query.Set("Signature", sign(http.MethodGet, query, c.accessKeySecret))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/?%s", c.endpoint, query.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("while executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("unexpected status code %d: %s: %s", response.StatusCode, apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+// sign computes the signature of the RPC request, see https://www.alibabacloud.com/help/en/sdk/product-overview/rpc-mechanism
+func sign(method string, query url.Values, accessKeySecret string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, percentEncode(key)+"="+percentEncode(query.Get(key)))
+	}
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(strings.Join(pairs, "&"))
+
+	mac := hmac.New(sha1.New, []byte(accessKeySecret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func percentEncode(value string) string {
+	encoded := url.QueryEscape(value)
+	encoded = strings.ReplaceAll(encoded, "+", "%20")
+	encoded = strings.ReplaceAll(encoded, "*", "%2A")
+	return strings.ReplaceAll(encoded, "%7E", "~")
+}
+
+func ExtractCredentials(secret *unstructured.Unstructured) (string, string, error) {
+	data, found, err := unstructured.NestedStringMap(secret.Object, "data")
+	if err != nil {
+		return "", "", fmt.Errorf("unable to extract data from secret: %w", err)
+	}
+	if !found {
+		return "", "", fmt.Errorf("secret does not contain data")
+	}
+
+	accessKeyID, ok := data["accessKeyID"]
+	if !ok {
+		return "", "", fmt.Errorf("secret does not contain accessKeyID")
+	}
+	accessKeySecret, ok := data["accessKeySecret"]
+	if !ok {
+		return "", "", fmt.Errorf("secret does not contain accessKeySecret")
+	}
+
+	accessKeyIDBytes, err := base64.StdEncoding.DecodeString(accessKeyID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode accessKeyID: %w", err)
+	}
+	accessKeySecretBytes, err := base64.StdEncoding.DecodeString(accessKeySecret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode accessKeySecret: %w", err)
+	}
+
+	return string(accessKeyIDBytes), string(accessKeySecretBytes), nil
+}
@@ -0,0 +1,117 @@
+package alicloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAvailableZones(t *testing.T) {
+	// given
+	recorded, err := os.ReadFile("testdata/describe_available_resource.json")
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "DescribeAvailableResource", query.Get("Action"))
+		assert.Equal(t, "eu-central-1", query.Get("RegionId"))
+		assert.Equal(t, "InstanceType", query.Get("DestinationResource"))
+		assert.Equal(t, "ecs.g6.xlarge", query.Get("InstanceType"))
+		assert.Equal(t, "access-key-id", query.Get("AccessKeyId"))
+		assert.Equal(t, "2025-01-02T03:04:05Z", query.Get("Timestamp"))
+		assert.NotEmpty(t, query.Get("Signature"))
+		_, _ = w.Write(recorded)
+	}))
+	defer server.Close()
+	client := fixClient(server)
+
+	// when
+	zones, err := client.AvailableZones(context.Background(), "ecs.g6.xlarge")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-central-1a", "eu-central-1d"}, zones)
+}
+
+func TestAvailableZones_Error(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"RequestId":"request-id","HostId":"ecs.eu-central-1.aliyuncs.com","Code":"InvalidAccessKeyId.NotFound","Message":"Specified access key is not found."}`))
+	}))
+	defer server.Close()
+	client := fixClient(server)
+
+	// when
+	count, err := client.AvailableZonesCount(context.Background(), "ecs.g6.xlarge")
+
+	// then
+	assert.EqualError(t, err, "failed to describe available resources: unexpected status code 404: InvalidAccessKeyId.NotFound: Specified access key is not found.")
+	assert.Zero(t, count)
+}
+
+func TestSign(t *testing.T) {
+	// given
+	query := url.Values{}
+	query.Set("Timestamp", "2016-02-23T12:46:24Z")
+	query.Set("Format", "XML")
+	query.Set("AccessKeyId", "testid")
+	query.Set("Action", "DescribeRegions")
+	query.Set("SignatureMethod", "HMAC-SHA1")
+	query.Set("SignatureNonce", "3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf")
+	query.Set("Version", "2014-05-26")
+	query.Set("SignatureVersion", "1.0")
+
+	// when
+	signature := sign(http.MethodGet, query, "testsecret")
+
+	// then
+	assert.Equal(t, "OLeaidS1JvxuMvnyHOwuJ+uX5qY=", signature)
+}
+
+func TestExtractCredentials(t *testing.T) {
+	t.Run("should extract credentials", func(t *testing.T) {
+		// given
+		secret := &unstructured.Unstructured{Object: map[string]interface{}{
+			"data": map[string]interface{}{"accessKeyID": "a2V5", "accessKeySecret": "c2VjcmV0"},
+		}}
+
+		// when
+		accessKeyID, accessKeySecret, err := ExtractCredentials(secret)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "key", accessKeyID)
+		assert.Equal(t, "secret", accessKeySecret)
+	})
+
+	t.Run("should fail when the access key secret is missing", func(t *testing.T) {
+		// given
+		secret := &unstructured.Unstructured{Object: map[string]interface{}{
+			"data": map[string]interface{}{"accessKeyID": "a2V5"},
+		}}
+
+		// when
+		_, _, err := ExtractCredentials(secret)
+
+		// then
+		assert.EqualError(t, err, "secret does not contain accessKeySecret")
+	})
+}
+
+func fixClient(server *httptest.Server) *AlicloudClient {
+	client := NewClient("access-key-id", "access-key-secret", "eu-central-1")
+	client.httpClient = server.Client()
+	client.endpoint = server.URL
+	client.now = func() time.Time {
+		return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+	return client
+}
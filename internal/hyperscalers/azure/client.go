@@ -0,0 +1,220 @@
+package azure
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2/clientcredentials"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	resourceManagerURL = "https://management.azure.com"
+	loginURL           = "https://login.microsoftonline.com"
+	skusAPIVersion     = "2021-07-01"
+
+	virtualMachinesResourceType = "virtualMachines"
+	locationRestrictionType     = "Location"
+	zoneRestrictionType         = "Zone"
+)
+
+type ClientFactory interface {
+	New(ctx context.Context, credentials Credentials, region string) (Client, error)
+}
+
+type Client interface {
+	AvailableZones(ctx context.Context, machineType string) ([]string, error)
+	AvailableZonesCount(ctx context.Context, machineType string) (int, error)
+}
+
+// Credentials of the service principal from the subscription secret
+type Credentials struct {
+	TenantID       string
+	SubscriptionID string
+	ClientID       string
+	ClientSecret   string
+}
+
+func NewFactory() ClientFactory {
+	return AzureClientFactory{}
+}
+
+type AzureClientFactory struct{}
+
+func (AzureClientFactory) New(ctx context.Context, credentials Credentials, region string) (Client, error) {
+	return NewClient(ctx, credentials, region), nil
+}
+
+// AzureClient discovers zones using the resource SKUs API of the Azure Resource Manager
+type AzureClient struct {
+	httpClient     *http.Client
+	baseURL        string
+	subscriptionID string
+	region         string
+}
+
+func NewClient(ctx context.Context, credentials Credentials, region string) *AzureClient {
+	cfg := clientcredentials.Config{
+		ClientID:     credentials.ClientID,
+		ClientSecret: credentials.ClientSecret,
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", loginURL, credentials.TenantID),
+		Scopes:       []string{resourceManagerURL + "/.default"},
+	}
+	return &AzureClient{
+		httpClient:     cfg.Client(ctx),
+		baseURL:        resourceManagerURL,
+		subscriptionID: credentials.SubscriptionID,
+		region:         region,
+	}
+}
+
+type resourceSkus struct {
+	Value    []resourceSku `json:"value"`
+	NextLink string        `json:"nextLink"`
+}
+
+type resourceSku struct {
+	ResourceType string                   `json:"resourceType"`
+	Name         string                   `json:"name"`
+	LocationInfo []resourceSkuLocation    `json:"locationInfo"`
+	Restrictions []resourceSkuRestriction `json:"restrictions"`
+}
+
+type resourceSkuLocation struct {
+	Location string   `json:"location"`
+	Zones    []string `json:"zones"`
+}
+
+type resourceSkuRestriction struct {
+	Type            string              `json:"type"`
+	Values          []string            `json:"values"`
+	RestrictionInfo resourceSkuLocation `json:"restrictionInfo"`
+	ReasonCode      string              `json:"reasonCode"`
+}
+
+// AvailableZones returns zones of the region offering the machine type, zones restricted for the subscription are skipped
+func (c *AzureClient) AvailableZones(ctx context.Context, machineType string) ([]string, error) {
+	query := url.Values{}
+	query.Set("api-version", skusAPIVersion)
+	query.Set("$filter", fmt.Sprintf("location eq '%s'", c.region))
+	next := fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.Compute/skus?%s", c.baseURL, c.subscriptionID, query.Encode())
+
+	zones := make(map[string]struct{})
+	for next != "" {
+		var page resourceSkus
+		if err := c.get(ctx, next, &page); err != nil {
+			return nil, fmt.Errorf("failed to list resource SKUs: %w", err)
+		}
+		for _, sku := range page.Value {
+			if sku.ResourceType != virtualMachinesResourceType || !strings.EqualFold(sku.Name, machineType) {
+				continue
+			}
+			c.collectZones(sku, zones)
+		}
+		next = page.NextLink
+	}
+
+	result := make([]string, 0, len(zones))
+	for zone := range zones {
+		result = append(result, zone)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (c *AzureClient) AvailableZonesCount(ctx context.Context, machineType string) (int, error) {
+	zones, err := c.AvailableZones(ctx, machineType)
+	if err != nil {
+		return 0, err
+	}
+	return len(zones), nil
+}
+
+func (c *AzureClient) collectZones(sku resourceSku, zones map[string]struct{}) {
+	restricted := make(map[string]struct{})
+	for _, restriction := range sku.Restrictions {
+		switch restriction.Type {
+		case locationRestrictionType:
+			for _, location := range restriction.Values {
+				if strings.EqualFold(location, c.region) {
+					return
+				}
+			}
+		case zoneRestrictionType:
+			for _, zone := range restriction.RestrictionInfo.Zones {
+				restricted[zone] = struct{}{}
+			}
+		}
+	}
+	for _, location := range sku.LocationInfo {
+		if !strings.EqualFold(location.Location, c.region) {
+			continue
+		}
+		for _, zone := range location.Zones {
+			if _, found := restricted[zone]; !found {
+				zones[zone] = struct{}{}
+			}
+		}
+	}
+}
+
+func (c *AzureClient) get(ctx context.Context, requestURL string, out interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("while executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+func ExtractCredentials(secret *unstructured.Unstructured) (Credentials, error) {
+	data, found, err := unstructured.NestedStringMap(secret.Object, "data")
+	if err != nil {
+		return Credentials{}, fmt.Errorf("unable to extract data from secret: %w", err)
+	}
+	if !found {
+		return Credentials{}, fmt.Errorf("secret does not contain data")
+	}
+
+	values := make(map[string]string)
+	for _, key := range []string{"tenantID", "subscriptionID", "clientID", "clientSecret"} {
+		encoded, ok := data[key]
+		if !ok {
+			return Credentials{}, fmt.Errorf("secret does not contain %s", key)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		values[key] = string(decoded)
+	}
+
+	return Credentials{
+		TenantID:       values["tenantID"],
+		SubscriptionID: values["subscriptionID"],
+		ClientID:       values["clientID"],
+		ClientSecret:   values["clientSecret"],
+	}, nil
+}
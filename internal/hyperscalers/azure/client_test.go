@@ -0,0 +1,132 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const skusPath = "/subscriptions/subscription-id/providers/Microsoft.Compute/skus"
+
+func TestAvailableZones(t *testing.T) {
+	// given
+	server := fixResourceSkusServer(t)
+	defer server.Close()
+	client := &AzureClient{httpClient: server.Client(), baseURL: server.URL, subscriptionID: "subscription-id", region: "westeurope"}
+
+	for name, tc := range map[string]struct {
+		machineType string
+		expected    []string
+	}{
+		"machine type available in all zones":     {machineType: "Standard_D4s_v5", expected: []string{"1", "2", "3"}},
+		"machine type with restricted zone":       {machineType: "Standard_NC4as_T4_v3", expected: []string{"1", "2"}},
+		"machine type restricted in the region":   {machineType: "Standard_M416ms_v2", expected: []string{}},
+		"machine type returned on the next page":  {machineType: "Standard_D8s_v5", expected: []string{"1", "3"}},
+		"machine type not offered in the region":  {machineType: "Standard_E4s_v5", expected: []string{}},
+		"machine type with different letter case": {machineType: "standard_d4s_v5", expected: []string{"1", "2", "3"}},
+		"resource which is not a virtual machine": {machineType: "Premium_LRS", expected: []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			zones, err := client.AvailableZones(context.Background(), tc.machineType)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, zones)
+		})
+	}
+}
+
+func TestAvailableZones_Error(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"no access"}}`))
+	}))
+	defer server.Close()
+	client := &AzureClient{httpClient: server.Client(), baseURL: server.URL, subscriptionID: "subscription-id", region: "westeurope"}
+
+	// when
+	count, err := client.AvailableZonesCount(context.Background(), "Standard_D4s_v5")
+
+	// then
+	assert.EqualError(t, err, `failed to list resource SKUs: unexpected status code 403: {"error":{"code":"AuthorizationFailed","message":"no access"}}`)
+	assert.Zero(t, count)
+}
+
+func TestExtractCredentials(t *testing.T) {
+	t.Run("should extract credentials", func(t *testing.T) {
+		// given
+		secret := fixSecret(map[string]interface{}{
+			"tenantID":       "dGVuYW50",
+			"subscriptionID": "c3Vic2NyaXB0aW9u",
+			"clientID":       "Y2xpZW50",
+			"clientSecret":   "c2VjcmV0",
+		})
+
+		// when
+		credentials, err := ExtractCredentials(secret)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Credentials{TenantID: "tenant", SubscriptionID: "subscription", ClientID: "client", ClientSecret: "secret"}, credentials)
+	})
+
+	t.Run("should fail when the secret does not contain data", func(t *testing.T) {
+		// when
+		_, err := ExtractCredentials(&unstructured.Unstructured{Object: map[string]interface{}{}})
+
+		// then
+		assert.EqualError(t, err, "secret does not contain data")
+	})
+
+	t.Run("should fail when the client secret is missing", func(t *testing.T) {
+		// given
+		secret := fixSecret(map[string]interface{}{
+			"tenantID":       "dGVuYW50",
+			"subscriptionID": "c3Vic2NyaXB0aW9u",
+			"clientID":       "Y2xpZW50",
+		})
+
+		// when
+		_, err := ExtractCredentials(secret)
+
+		// then
+		assert.EqualError(t, err, "secret does not contain clientSecret")
+	})
+}
+
+func fixResourceSkusServer(t *testing.T) *httptest.Server {
+	firstPage, err := os.ReadFile("testdata/resource_skus.json")
+	require.NoError(t, err)
+	nextPage, err := os.ReadFile("testdata/resource_skus_next.json")
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != skusPath || r.URL.Query().Get("api-version") != skusAPIVersion {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write(nextPage)
+			return
+		}
+		assert.Equal(t, "location eq 'westeurope'", r.URL.Query().Get("$filter"))
+		nextLink := fmt.Sprintf("%s%s?api-version=%s&page=2", server.URL, skusPath, skusAPIVersion)
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(firstPage), "{{.NextLink}}", nextLink)))
+	}))
+	return server
+}
+
+func fixSecret(data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{"data": data}}
+}
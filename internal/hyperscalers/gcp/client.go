@@ -0,0 +1,202 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2/jwt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	computeURL      = "https://compute.googleapis.com"
+	defaultTokenURL = "https://oauth2.googleapis.com/token"
+	computeScope    = "https://www.googleapis.com/auth/compute.readonly"
+
+	serviceAccountKey = "serviceaccount.json"
+	zonesScopePrefix  = "zones/"
+
+	obsoleteState = "OBSOLETE"
+	deletedState  = "DELETED"
+)
+
+type ClientFactory interface {
+	New(ctx context.Context, serviceAccountJSON []byte, region string) (Client, error)
+}
+
+type Client interface {
+	AvailableZones(ctx context.Context, machineType string) ([]string, error)
+	AvailableZonesCount(ctx context.Context, machineType string) (int, error)
+}
+
+func NewFactory() ClientFactory {
+	return GCPClientFactory{}
+}
+
+type GCPClientFactory struct{}
+
+func (GCPClientFactory) New(ctx context.Context, serviceAccountJSON []byte, region string) (Client, error) {
+	return NewClient(ctx, serviceAccountJSON, region)
+}
+
+// GCPClient discovers zones using the aggregated list of machine types of the Compute Engine API
+type GCPClient struct {
+	httpClient *http.Client
+	baseURL    string
+	projectID  string
+	region     string
+}
+
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+func NewClient(ctx context.Context, serviceAccountJSON []byte, region string) (*GCPClient, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(serviceAccountJSON, &account); err != nil {
+		return nil, fmt.Errorf("while unmarshalling service account: %w", err)
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("service account must contain project_id, client_email and private_key")
+	}
+	tokenURL := account.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+	cfg := jwt.Config{
+		Email:        account.ClientEmail,
+		PrivateKey:   []byte(account.PrivateKey),
+		PrivateKeyID: account.PrivateKeyID,
+		Scopes:       []string{computeScope},
+		TokenURL:     tokenURL,
+	}
+	return &GCPClient{
+		httpClient: cfg.Client(ctx),
+		baseURL:    computeURL,
+		projectID:  account.ProjectID,
+		region:     region,
+	}, nil
+}
+
+type aggregatedMachineTypes struct {
+	Items         map[string]machineTypesScope `json:"items"`
+	NextPageToken string                       `json:"nextPageToken"`
+}
+
+type machineTypesScope struct {
+	MachineTypes []machineType `json:"machineTypes"`
+}
+
+type machineType struct {
+	Name       string      `json:"name"`
+	Zone       string      `json:"zone"`
+	Deprecated *deprecated `json:"deprecated"`
+}
+
+type deprecated struct {
+	State string `json:"state"`
+}
+
+// available checks if the machine type can be used to create instances, deprecated machine types can still be used
+func (mt machineType) available() bool {
+	return mt.Deprecated == nil || (mt.Deprecated.State != obsoleteState && mt.Deprecated.State != deletedState)
+}
+
+// AvailableZones returns zones of the region offering the machine type, zones with the obsolete or deleted machine type are skipped
+func (c *GCPClient) AvailableZones(ctx context.Context, machineTypeName string) ([]string, error) {
+	query := url.Values{}
+	query.Set("filter", fmt.Sprintf("name = %s", machineTypeName))
+
+	zones := make([]string, 0)
+	for {
+		var page aggregatedMachineTypes
+		requestURL := fmt.Sprintf("%s/compute/v1/projects/%s/aggregated/machineTypes?%s", c.baseURL, c.projectID, query.Encode())
+		if err := c.get(ctx, requestURL, &page); err != nil {
+			return nil, fmt.Errorf("failed to list machine types: %w", err)
+		}
+		for scope, item := range page.Items {
+			zone := strings.TrimPrefix(scope, zonesScopePrefix)
+			if !strings.HasPrefix(zone, c.region+"-") {
+				continue
+			}
+			for _, mt := range item.MachineTypes {
+				if mt.Name == machineTypeName && mt.available() {
+					zones = append(zones, zone)
+					break
+				}
+			}
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		query.Set("pageToken", page.NextPageToken)
+	}
+
+	sort.Strings(zones)
+	return zones, nil
+}
+
+func (c *GCPClient) AvailableZonesCount(ctx context.Context, machineType string) (int, error) {
+	zones, err := c.AvailableZones(ctx, machineType)
+	if err != nil {
+		return 0, err
+	}
+	return len(zones), nil
+}
+
+func (c *GCPClient) get(ctx context.Context, requestURL string, out interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("while executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("while reading response body: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+// ExtractCredentials returns the service account JSON from the subscription secret
+func ExtractCredentials(secret *unstructured.Unstructured) ([]byte, error) {
+	data, found, err := unstructured.NestedStringMap(secret.Object, "data")
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract data from secret: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("secret does not contain data")
+	}
+
+	encoded, ok := data[serviceAccountKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain %s", serviceAccountKey)
+	}
+	serviceAccountJSON, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", serviceAccountKey, err)
+	}
+
+	return serviceAccountJSON, nil
+}
@@ -0,0 +1,127 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const machineTypesPath = "/compute/v1/projects/project-id/aggregated/machineTypes"
+
+func TestAvailableZones(t *testing.T) {
+	// given
+	server := fixMachineTypesServer(t)
+	defer server.Close()
+
+	for name, tc := range map[string]struct {
+		region   string
+		expected []string
+	}{
+		"zones of the region offering the machine type": {region: "europe-west3", expected: []string{"europe-west3-a", "europe-west3-b", "europe-west3-e"}},
+		"zones of the other region":                     {region: "europe-west4", expected: []string{"europe-west4-a"}},
+		"region without the machine type":               {region: "us-east1", expected: []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &GCPClient{httpClient: server.Client(), baseURL: server.URL, projectID: "project-id", region: tc.region}
+
+			// when
+			zones, err := client.AvailableZones(context.Background(), "n2-standard-4")
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, zones)
+		})
+	}
+}
+
+func TestAvailableZones_Error(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"Required 'compute.machineTypes.list' permission"}}`))
+	}))
+	defer server.Close()
+	client := &GCPClient{httpClient: server.Client(), baseURL: server.URL, projectID: "project-id", region: "europe-west3"}
+
+	// when
+	count, err := client.AvailableZonesCount(context.Background(), "n2-standard-4")
+
+	// then
+	assert.EqualError(t, err, `failed to list machine types: unexpected status code 403: {"error":{"code":403,"message":"Required 'compute.machineTypes.list' permission"}}`)
+	assert.Zero(t, count)
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("should create client for the project of the service account", func(t *testing.T) {
+		// when
+		client, err := NewClient(context.Background(), []byte(`{"type":"service_account","project_id":"project-id","client_email":"sa@project-id.iam.gserviceaccount.com","private_key":"key"}`), "europe-west3")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "project-id", client.projectID)
+		assert.Equal(t, "europe-west3", client.region)
+	})
+
+	t.Run("should fail when the service account is incomplete", func(t *testing.T) {
+		// when
+		_, err := NewClient(context.Background(), []byte(`{"type":"service_account","project_id":"project-id"}`), "europe-west3")
+
+		// then
+		assert.EqualError(t, err, "service account must contain project_id, client_email and private_key")
+	})
+}
+
+func TestExtractCredentials(t *testing.T) {
+	t.Run("should extract service account", func(t *testing.T) {
+		// given
+		secret := &unstructured.Unstructured{Object: map[string]interface{}{
+			"data": map[string]interface{}{"serviceaccount.json": "eyJwcm9qZWN0X2lkIjoicHJvamVjdC1pZCJ9"},
+		}}
+
+		// when
+		serviceAccountJSON, err := ExtractCredentials(secret)
+
+		// then
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"project_id":"project-id"}`, string(serviceAccountJSON))
+	})
+
+	t.Run("should fail when the service account is missing", func(t *testing.T) {
+		// given
+		secret := &unstructured.Unstructured{Object: map[string]interface{}{
+			"data": map[string]interface{}{"accessKeyID": "a2V5"},
+		}}
+
+		// when
+		_, err := ExtractCredentials(secret)
+
+		// then
+		assert.EqualError(t, err, "secret does not contain serviceaccount.json")
+	})
+}
+
+func fixMachineTypesServer(t *testing.T) *httptest.Server {
+	firstPage, err := os.ReadFile("testdata/aggregated_machine_types.json")
+	require.NoError(t, err)
+	nextPage, err := os.ReadFile("testdata/aggregated_machine_types_next.json")
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != machineTypesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "name = n2-standard-4", r.URL.Query().Get("filter"))
+		if r.URL.Query().Get("pageToken") == "next-page" {
+			_, _ = w.Write(nextPage)
+			return
+		}
+		_, _ = w.Write(firstPage)
+	}))
+}
@@ -0,0 +1,101 @@
+package hyperscalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/alicloud"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/azure"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/gcp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ZonesClient discovers zones of a region in which a machine type is available
+type ZonesClient interface {
+	AvailableZones(ctx context.Context, machineType string) ([]string, error)
+	AvailableZonesCount(ctx context.Context, machineType string) (int, error)
+}
+
+// ZonesClientFactory creates the zones client of the provider for the region, using credentials from the subscription secret
+// resolved from the secret binding or the credentials binding
+type ZonesClientFactory interface {
+	New(ctx context.Context, provider runtime.CloudProvider, secret *unstructured.Unstructured, region string) (ZonesClient, error)
+}
+
+// SupportsZonesDiscovery checks if zones of the provider can be discovered
+func SupportsZonesDiscovery(provider runtime.CloudProvider) bool {
+	switch provider {
+	case runtime.AWS, runtime.Azure, runtime.GCP, runtime.Alicloud:
+		return true
+	}
+	return false
+}
+
+// CredentialsError means the subscription secret does not contain valid credentials of the provider
+type CredentialsError struct {
+	provider runtime.CloudProvider
+	err      error
+}
+
+func (e *CredentialsError) Error() string {
+	return fmt.Sprintf("failed to extract %s credentials: %s", e.provider, e.err)
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.err
+}
+
+func IsCredentialsError(err error) bool {
+	var credentialsError *CredentialsError
+	return errors.As(err, &credentialsError)
+}
+
+type zonesClientFactory struct {
+	aws      aws.ClientFactory
+	azure    azure.ClientFactory
+	gcp      gcp.ClientFactory
+	alicloud alicloud.ClientFactory
+}
+
+func NewZonesClientFactory(awsFactory aws.ClientFactory, azureFactory azure.ClientFactory, gcpFactory gcp.ClientFactory, alicloudFactory alicloud.ClientFactory) ZonesClientFactory {
+	return &zonesClientFactory{
+		aws:      awsFactory,
+		azure:    azureFactory,
+		gcp:      gcpFactory,
+		alicloud: alicloudFactory,
+	}
+}
+
+func (f *zonesClientFactory) New(ctx context.Context, provider runtime.CloudProvider, secret *unstructured.Unstructured, region string) (ZonesClient, error) {
+	switch provider {
+	case runtime.AWS:
+		accessKeyID, secretAccessKey, err := aws.ExtractCredentials(secret)
+		if err != nil {
+			return nil, &CredentialsError{provider: provider, err: err}
+		}
+		return f.aws.New(ctx, accessKeyID, secretAccessKey, region)
+	case runtime.Azure:
+		credentials, err := azure.ExtractCredentials(secret)
+		if err != nil {
+			return nil, &CredentialsError{provider: provider, err: err}
+		}
+		return f.azure.New(ctx, credentials, region)
+	case runtime.GCP:
+		serviceAccountJSON, err := gcp.ExtractCredentials(secret)
+		if err != nil {
+			return nil, &CredentialsError{provider: provider, err: err}
+		}
+		return f.gcp.New(ctx, serviceAccountJSON, region)
+	case runtime.Alicloud:
+		accessKeyID, accessKeySecret, err := alicloud.ExtractCredentials(secret)
+		if err != nil {
+			return nil, &CredentialsError{provider: provider, err: err}
+		}
+		return f.alicloud.New(ctx, accessKeyID, accessKeySecret, region)
+	default:
+		return nil, fmt.Errorf("zones discovery is not supported for the %s provider", provider)
+	}
+}
@@ -0,0 +1,113 @@
+package hyperscalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/alicloud"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/azure"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/gcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestZonesClientFactory(t *testing.T) {
+	// given
+	recorder := &factoryRecorder{}
+	factory := NewZonesClientFactory(awsRecorder{recorder}, azureRecorder{recorder}, gcpRecorder{recorder}, alicloudRecorder{recorder})
+
+	t.Run("should create AWS client", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.AWS, fixSecret(map[string]interface{}{"accessKeyID": "a2V5", "secretAccessKey": "c2VjcmV0"}), "eu-central-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws", "key", "secret", "eu-central-1"}, recorder.last)
+	})
+
+	t.Run("should create Azure client", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.Azure, fixSecret(map[string]interface{}{
+			"tenantID": "dGVuYW50", "subscriptionID": "c3Vic2NyaXB0aW9u", "clientID": "Y2xpZW50", "clientSecret": "c2VjcmV0",
+		}), "westeurope")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"azure", "tenant", "subscription", "client", "secret", "westeurope"}, recorder.last)
+	})
+
+	t.Run("should create GCP client", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.GCP, fixSecret(map[string]interface{}{"serviceaccount.json": "e30="}), "europe-west3")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gcp", "{}", "europe-west3"}, recorder.last)
+	})
+
+	t.Run("should create Alicloud client", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.Alicloud, fixSecret(map[string]interface{}{"accessKeyID": "a2V5", "accessKeySecret": "c2VjcmV0"}), "eu-central-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alicloud", "key", "secret", "eu-central-1"}, recorder.last)
+	})
+
+	t.Run("should return credentials error when the secret does not match the provider", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.Azure, fixSecret(map[string]interface{}{"accessKeyID": "a2V5", "secretAccessKey": "c2VjcmV0"}), "westeurope")
+
+		// then
+		assert.True(t, IsCredentialsError(err))
+		assert.EqualError(t, err, "failed to extract Azure credentials: secret does not contain tenantID")
+	})
+
+	t.Run("should fail for provider without zones discovery", func(t *testing.T) {
+		// when
+		_, err := factory.New(context.Background(), runtime.SapConvergedCloud, fixSecret(map[string]interface{}{}), "eu-de-1")
+
+		// then
+		assert.EqualError(t, err, "zones discovery is not supported for the SapConvergedCloud provider")
+		assert.False(t, IsCredentialsError(err))
+	})
+}
+
+type factoryRecorder struct {
+	last []string
+}
+
+type awsRecorder struct{ *factoryRecorder }
+
+func (r awsRecorder) New(_ context.Context, accessKeyID, secretAccessKey, region string) (aws.Client, error) {
+	r.last = []string{"aws", accessKeyID, secretAccessKey, region}
+	return nil, nil
+}
+
+type azureRecorder struct{ *factoryRecorder }
+
+func (r azureRecorder) New(_ context.Context, credentials azure.Credentials, region string) (azure.Client, error) {
+	r.last = []string{"azure", credentials.TenantID, credentials.SubscriptionID, credentials.ClientID, credentials.ClientSecret, region}
+	return nil, nil
+}
+
+type gcpRecorder struct{ *factoryRecorder }
+
+func (r gcpRecorder) New(_ context.Context, serviceAccountJSON []byte, region string) (gcp.Client, error) {
+	r.last = []string{"gcp", string(serviceAccountJSON), region}
+	return nil, nil
+}
+
+type alicloudRecorder struct{ *factoryRecorder }
+
+func (r alicloudRecorder) New(_ context.Context, accessKeyID, accessKeySecret, region string) (alicloud.Client, error) {
+	r.last = []string{"alicloud", accessKeyID, accessKeySecret, region}
+	return nil, nil
+}
+
+func fixSecret(data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{"data": data}}
+}
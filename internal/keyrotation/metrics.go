@@ -0,0 +1,43 @@
+package keyrotation
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	resultScanned  = "scanned"
+	resultRotated  = "rotated"
+	resultConflict = "conflict"
+	resultFailed   = "failed"
+)
+
+type Metrics struct {
+	records  *prometheus.CounterVec
+	finished *prometheus.GaugeVec
+}
+
+func NewMetrics(reg prometheus.Registerer, namespace string) *Metrics {
+	m := &Metrics{
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "key_rotation_records_total",
+			Help:      "Records processed by the encryption key rotation.",
+		}, []string{"kind", "result"}),
+		finished: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "key_rotation_finished",
+			Help:      "Set to 1 when all records of the kind are processed by the encryption key rotation.",
+		}, []string{"kind"}),
+	}
+	reg.MustRegister(m.records, m.finished)
+	return m
+}
+
+func (m *Metrics) batchProcessed(kind string, scanned, rotated, conflicts, failed int) {
+	m.records.WithLabelValues(kind, resultScanned).Add(float64(scanned))
+	m.records.WithLabelValues(kind, resultRotated).Add(float64(rotated))
+	m.records.WithLabelValues(kind, resultConflict).Add(float64(conflicts))
+	m.records.WithLabelValues(kind, resultFailed).Add(float64(failed))
+}
+
+func (m *Metrics) kindFinished(kind string) {
+	m.finished.WithLabelValues(kind).Set(1)
+}
@@ -0,0 +1,94 @@
+package keyrotation
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+)
+
+const (
+	kindInstances  = "instances"
+	kindOperations = "operations"
+	kindBindings   = "bindings"
+)
+
+type rotateFunc func(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error)
+
+type Service struct {
+	dryRun      bool
+	batchSize   int
+	keyRotation storage.KeyRotation
+	metrics     *Metrics
+	log         *slog.Logger
+}
+
+func NewService(dryRun bool, batchSize int, keyRotation storage.KeyRotation, metrics *Metrics, log *slog.Logger) *Service {
+	return &Service{
+		dryRun:      dryRun,
+		batchSize:   batchSize,
+		keyRotation: keyRotation,
+		metrics:     metrics,
+		log:         log,
+	}
+}
+
+// PerformRotation re-encrypts instances, operations and bindings with the current key. Records which cannot be
+// rotated are logged and the rotation continues, an error is returned at the end if any record failed.
+func (s *Service) PerformRotation() error {
+	failed := 0
+	for _, kind := range []struct {
+		name   string
+		rotate rotateFunc
+	}{
+		{name: kindInstances, rotate: s.keyRotation.RotateInstances},
+		{name: kindOperations, rotate: s.keyRotation.RotateOperations},
+		{name: kindBindings, rotate: s.keyRotation.RotateBindings},
+	} {
+		kindFailed, err := s.rotateAll(kind.name, kind.rotate)
+		if err != nil {
+			return fmt.Errorf("while rotating encryption key of %s: %w", kind.name, err)
+		}
+		failed += kindFailed
+	}
+	if failed > 0 {
+		return fmt.Errorf("encryption key rotation failed for %d records", failed)
+	}
+	return nil
+}
+
+func (s *Service) rotateAll(kind string, rotate rotateFunc) (int, error) {
+	log := s.log.With("kind", kind)
+	cursor := dbmodel.KeyRotationCursor{}
+	scanned, rotated, conflicts, failed := 0, 0, 0, 0
+	for {
+		batch, err := rotate(cursor, s.batchSize, s.dryRun)
+		if err != nil {
+			return failed, err
+		}
+		for _, id := range batch.Conflicts {
+			log.Info(fmt.Sprintf("record %s was modified during the rotation, skipping", id))
+		}
+		for _, failure := range batch.Failed {
+			log.Error(fmt.Sprintf("unable to rotate encryption key of record %s: %s", failure.ID, failure.Reason))
+		}
+		s.metrics.batchProcessed(kind, batch.Scanned, batch.Rotated, len(batch.Conflicts), len(batch.Failed))
+
+		scanned += batch.Scanned
+		rotated += batch.Rotated
+		conflicts += len(batch.Conflicts)
+		failed += len(batch.Failed)
+		cursor = batch.Cursor
+		log.Info(fmt.Sprintf("processed %d records: rotated %d, conflicts %d, failed %d", scanned, rotated, conflicts, failed))
+
+		if batch.Scanned < s.batchSize {
+			break
+		}
+	}
+	s.metrics.kindFinished(kind)
+	if s.dryRun {
+		log.Info(fmt.Sprintf("dry run: %d of %d records would be rotated", rotated, scanned))
+	}
+	return failed, nil
+}
@@ -0,0 +1,147 @@
+package keyrotation
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PerformRotation(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("should rotate all records in batches", func(t *testing.T) {
+		// given
+		keyRotation := &keyRotationStub{
+			instances:  []string{"i-1", "i-2", "i-3"},
+			operations: []string{"o-1"},
+			conflicts:  map[string]bool{"o-1": true},
+		}
+		registry := prometheus.NewRegistry()
+		svc := NewService(false, 2, keyRotation, NewMetrics(registry, "test"), log)
+
+		// when
+		err := svc.PerformRotation()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"i-1", "i-2", "i-3"}, keyRotation.rotated)
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP test_key_rotation_records_total Records processed by the encryption key rotation.
+# TYPE test_key_rotation_records_total counter
+test_key_rotation_records_total{kind="bindings",result="conflict"} 0
+test_key_rotation_records_total{kind="bindings",result="failed"} 0
+test_key_rotation_records_total{kind="bindings",result="rotated"} 0
+test_key_rotation_records_total{kind="bindings",result="scanned"} 0
+test_key_rotation_records_total{kind="instances",result="conflict"} 0
+test_key_rotation_records_total{kind="instances",result="failed"} 0
+test_key_rotation_records_total{kind="instances",result="rotated"} 3
+test_key_rotation_records_total{kind="instances",result="scanned"} 3
+test_key_rotation_records_total{kind="operations",result="conflict"} 1
+test_key_rotation_records_total{kind="operations",result="failed"} 0
+test_key_rotation_records_total{kind="operations",result="rotated"} 0
+test_key_rotation_records_total{kind="operations",result="scanned"} 1
+`), "test_key_rotation_records_total"))
+	})
+
+	t.Run("should not rotate records in dry run", func(t *testing.T) {
+		// given
+		keyRotation := &keyRotationStub{bindings: []string{"b-1", "b-2"}}
+		svc := NewService(true, 2, keyRotation, NewMetrics(prometheus.NewRegistry(), "test"), log)
+
+		// when
+		err := svc.PerformRotation()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, keyRotation.rotated)
+		assert.Equal(t, 2, keyRotation.dryRunRotated)
+	})
+
+	t.Run("should continue after failed records and return an error", func(t *testing.T) {
+		// given
+		keyRotation := &keyRotationStub{
+			instances: []string{"i-1", "i-2"},
+			bindings:  []string{"b-1"},
+			failures:  map[string]bool{"i-1": true},
+		}
+		svc := NewService(false, 10, keyRotation, NewMetrics(prometheus.NewRegistry(), "test"), log)
+
+		// when
+		err := svc.PerformRotation()
+
+		// then
+		assert.EqualError(t, err, "encryption key rotation failed for 1 records")
+		assert.Equal(t, []string{"i-2", "b-1"}, keyRotation.rotated)
+	})
+
+	t.Run("should stop when a batch cannot be read", func(t *testing.T) {
+		// given
+		keyRotation := &keyRotationStub{instances: []string{"i-1"}, err: fmt.Errorf("connection refused")}
+		svc := NewService(false, 10, keyRotation, NewMetrics(prometheus.NewRegistry(), "test"), log)
+
+		// when
+		err := svc.PerformRotation()
+
+		// then
+		assert.EqualError(t, err, "while rotating encryption key of instances: connection refused")
+	})
+}
+
+type keyRotationStub struct {
+	instances  []string
+	operations []string
+	bindings   []string
+	conflicts  map[string]bool
+	failures   map[string]bool
+	err        error
+
+	rotated       []string
+	dryRunRotated int
+}
+
+func (k *keyRotationStub) RotateInstances(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	return k.rotate(k.instances, cursor, limit, dryRun)
+}
+
+func (k *keyRotationStub) RotateOperations(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	return k.rotate(k.operations, cursor, limit, dryRun)
+}
+
+func (k *keyRotationStub) RotateBindings(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	return k.rotate(k.bindings, cursor, limit, dryRun)
+}
+
+func (k *keyRotationStub) rotate(ids []string, cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	if k.err != nil {
+		return dbmodel.KeyRotationBatch{}, k.err
+	}
+	batch := dbmodel.KeyRotationBatch{Cursor: cursor}
+	for _, id := range ids {
+		if id <= cursor.ID || batch.Scanned == limit {
+			continue
+		}
+		batch.Scanned++
+		batch.Cursor = dbmodel.KeyRotationCursor{ID: id}
+		switch {
+		case k.conflicts[id]:
+			batch.Conflicts = append(batch.Conflicts, id)
+		case k.failures[id]:
+			batch.Failed = append(batch.Failed, dbmodel.KeyRotationFailure{ID: id, Reason: "unknown secret key ID"})
+		case dryRun:
+			k.dryRunRotated++
+			batch.Rotated++
+		default:
+			k.rotated = append(k.rotated, id)
+			batch.Rotated++
+		}
+	}
+	return batch, nil
+}
@@ -38,6 +38,9 @@ type kubeconfigData struct {
 	ServerURL   string
 	OIDCConfigs []OIDCConfig
 	Token       string
+
+	ClientCertificateData string
+	ClientKeyData         string
 }
 
 type OIDCConfig struct {
@@ -65,6 +68,51 @@ func (b *Builder) BuildFromAdminKubeconfigForBinding(runtimeID string, token str
 	}, kubeconfigTemplateForKymaBindings)
 }
 
+// BuildFromAdminKubeconfigForCertificateBinding returns a kubeconfig authenticating with the client certificate, the certificate
+// and the key are base64 encoded PEM data
+func (b *Builder) BuildFromAdminKubeconfigForCertificateBinding(runtimeID string, certificateData string, keyData string, clusterName string) (string, error) {
+	adminKubeconfig, err := b.kubeconfigProvider.KubeconfigForRuntimeID(runtimeID)
+	if err != nil {
+		return "", err
+	}
+
+	kubeCfg, err := b.unmarshal(adminKubeconfig)
+	if err != nil {
+		return "", err
+	}
+
+	return b.parseTemplate(kubeconfigData{
+		ContextName:           clusterName,
+		CAData:                kubeCfg.Clusters[0].Cluster.CertificateAuthorityData,
+		ServerURL:             kubeCfg.Clusters[0].Cluster.Server,
+		ClientCertificateData: certificateData,
+		ClientKeyData:         keyData,
+	}, kubeconfigTemplateForKymaCertificateBindings)
+}
+
+// BuildFromAdminKubeconfigForOIDCBinding returns a kubeconfig authenticating with the OIDC login plugin, the kubeconfig contains no credentials
+func (b *Builder) BuildFromAdminKubeconfigForOIDCBinding(runtimeID string, oidcConfigs []OIDCConfig, clusterName string) (string, error) {
+	if len(oidcConfigs) == 0 {
+		return "", fmt.Errorf("OIDC configuration must not be empty")
+	}
+	adminKubeconfig, err := b.kubeconfigProvider.KubeconfigForRuntimeID(runtimeID)
+	if err != nil {
+		return "", err
+	}
+
+	kubeCfg, err := b.unmarshal(adminKubeconfig)
+	if err != nil {
+		return "", err
+	}
+
+	return b.parseTemplate(kubeconfigData{
+		ContextName: clusterName,
+		CAData:      kubeCfg.Clusters[0].Cluster.CertificateAuthorityData,
+		ServerURL:   kubeCfg.Clusters[0].Cluster.Server,
+		OIDCConfigs: oidcConfigs,
+	}, kubeconfigTemplate)
+}
+
 func (b *Builder) unmarshal(kubeconfigContent []byte) (*kubeconfig, error) {
 	var kubeCfg kubeconfig
 
@@ -182,6 +182,50 @@ func TestBuilder_BuildFromRuntimeResource_MultipleAdditionalOIDC(t *testing.T) {
 	})
 }
 
+func TestBuilder_BuildFromAdminKubeconfigForCertificateBinding(t *testing.T) {
+	// given
+	builder := NewBuilder(nil, NewFakeKubeconfigProvider(skrKubeconfig()))
+
+	// when
+	kubeconfig, err := builder.BuildFromAdminKubeconfigForCertificateBinding(runtimeID, "Y2VydA==", "a2V5", clusterName)
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, kubeconfig, "server: https://api.ac0d8d9.kyma-dev.shoot.canary.k8s-hana.ondemand.com")
+	assert.Contains(t, kubeconfig, "client-certificate-data: Y2VydA==")
+	assert.Contains(t, kubeconfig, "client-key-data: a2V5")
+	assert.NotContains(t, kubeconfig, "token:")
+}
+
+func TestBuilder_BuildFromAdminKubeconfigForOIDCBinding(t *testing.T) {
+	t.Run("should build kubeconfig with OIDC users", func(t *testing.T) {
+		// given
+		builder := NewBuilder(nil, NewFakeKubeconfigProvider(skrKubeconfig()))
+		oidcConfigs := []OIDCConfig{
+			{Name: clusterName, IssuerURL: issuerURL, ClientID: clientID},
+			{Name: clusterName + "-2", IssuerURL: issuer2URL, ClientID: client2ID},
+		}
+
+		// when
+		kubeconfig, err := builder.BuildFromAdminKubeconfigForOIDCBinding(runtimeID, oidcConfigs, clusterName)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, newKubeconfigWithMultipleContexts(), kubeconfig)
+	})
+
+	t.Run("should fail without OIDC configuration", func(t *testing.T) {
+		// given
+		builder := NewBuilder(nil, NewFakeKubeconfigProvider(skrKubeconfig()))
+
+		// when
+		_, err := builder.BuildFromAdminKubeconfigForOIDCBinding(runtimeID, nil, clusterName)
+
+		// then
+		assert.EqualError(t, err, "OIDC configuration must not be empty")
+	})
+}
+
 func skrKubeconfig() *string {
 	kc := `
 ---
@@ -103,3 +103,25 @@ users:
   user:
     token: {{ .Token }}
   `
+
+const kubeconfigTemplateForKymaCertificateBindings = `
+---
+apiVersion: v1
+kind: Config
+current-context: {{ .ContextName }}
+clusters:
+- name: {{ .ContextName }}
+  cluster:
+    certificate-authority-data: {{ .CAData }}
+    server: {{ .ServerURL }}
+contexts:
+- name: {{ .ContextName }}
+  context:
+    cluster: {{ .ContextName }}
+    user: {{ .ContextName }}
+users:
+- name: {{ .ContextName }}
+  user:
+    client-certificate-data: {{ .ClientCertificateData }}
+    client-key-data: {{ .ClientKeyData }}
+  `
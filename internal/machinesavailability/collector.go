@@ -0,0 +1,310 @@
+package machinesavailability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
+	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Config struct {
+	// RefreshInterval is the time between two refreshes of the availability of all providers
+	RefreshInterval time.Duration `envconfig:"default=1h"`
+	// Concurrency is the maximum number of parallel requests to the provider API
+	Concurrency int `envconfig:"default=4"`
+	// Providers are the providers for which the availability is collected
+	Providers []string `envconfig:"default=aws"`
+}
+
+// Collector refreshes the availability of machine families in regions of the configured providers in the background and
+// keeps the last successfully collected data of each provider. It provides metrics which describe the staleness of the data:
+// - kcp_keb_v2_machines_availability_last_refresh_timestamp_seconds{provider}
+// - kcp_keb_v2_machines_availability_staleness_seconds{provider}
+// - kcp_keb_v2_machines_availability_refresh_failures_total{provider}
+// - kcp_keb_v2_machines_availability_region_refresh_failures_total{provider, region}
+type Collector struct {
+	cfg           Config
+	providers     []runtime.CloudProvider
+	providerSpec  *configuration.ProviderSpec
+	secrets       SubscriptionSecretProvider
+	clientFactory hyperscalers.ZonesClientFactory
+	logger        *slog.Logger
+	now           func() time.Time
+
+	mu    sync.RWMutex
+	cache map[runtime.CloudProvider]Provider
+
+	lastRefreshTimestamp *prometheus.GaugeVec
+	stalenessDesc        *prometheus.Desc
+	refreshFailures      *prometheus.CounterVec
+	regionFailures       *prometheus.CounterVec
+}
+
+func NewCollector(
+	cfg Config,
+	providerSpec *configuration.ProviderSpec,
+	secrets SubscriptionSecretProvider,
+	clientFactory hyperscalers.ZonesClientFactory,
+	logger *slog.Logger,
+) (*Collector, error) {
+	if cfg.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be greater than 0, got %d", cfg.Concurrency)
+	}
+	providers := make([]runtime.CloudProvider, 0, len(cfg.Providers))
+	for _, name := range cfg.Providers {
+		provider := runtime.CloudProviderFromString(name)
+		if !hyperscalers.SupportsZonesDiscovery(provider) {
+			return nil, fmt.Errorf("machines availability is not supported for the %s provider", name)
+		}
+		providers = append(providers, provider)
+	}
+
+	return &Collector{
+		cfg:           cfg,
+		providers:     providers,
+		providerSpec:  providerSpec,
+		secrets:       secrets,
+		clientFactory: clientFactory,
+		logger:        logger.With("service", "MachinesAvailabilityCollector"),
+		now:           time.Now,
+		cache:         make(map[runtime.CloudProvider]Provider),
+		lastRefreshTimestamp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "kcp",
+			Subsystem: "keb_v2",
+			Name:      "machines_availability_last_refresh_timestamp_seconds",
+			Help:      "The time of the last successful refresh of the machines availability",
+		}, []string{"provider"}),
+		stalenessDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("kcp", "keb_v2", "machines_availability_staleness_seconds"),
+			"The age of the served machines availability",
+			[]string{"provider"}, nil),
+		refreshFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "kcp",
+			Subsystem: "keb_v2",
+			Name:      "machines_availability_refresh_failures_total",
+			Help:      "The total number of failed refreshes of the machines availability",
+		}, []string{"provider"}),
+		regionFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "kcp",
+			Subsystem: "keb_v2",
+			Name:      "machines_availability_region_refresh_failures_total",
+			Help:      "The total number of refreshes which failed to check the machines availability in the region",
+		}, []string{"provider", "region"}),
+	}, nil
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.lastRefreshTimestamp.Describe(ch)
+	c.refreshFailures.Describe(ch)
+	c.regionFailures.Describe(ch)
+	ch <- c.stalenessDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.lastRefreshTimestamp.Collect(ch)
+	c.refreshFailures.Collect(ch)
+	c.regionFailures.Collect(ch)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := c.now()
+	for provider, data := range c.cache {
+		ch <- prometheus.MustNewConstMetric(c.stalenessDesc, prometheus.GaugeValue, now.Sub(data.LastRefreshed).Seconds(), string(provider))
+	}
+}
+
+// Run refreshes the availability immediately and then in the configured interval until the context is done
+func (c *Collector) Run(ctx context.Context) {
+	c.Refresh(ctx)
+
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.Refresh(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh collects the availability of all providers, a provider which fails keeps serving its previous data and a region which
+// fails keeps serving its previous data within the refreshed provider
+func (c *Collector) Refresh(ctx context.Context) {
+	for _, provider := range c.providers {
+		data, err := c.collect(ctx, provider)
+		if err != nil {
+			c.refreshFailures.WithLabelValues(string(provider)).Inc()
+			c.logger.Error(fmt.Sprintf("unable to refresh machines availability of the %s provider: %s", provider, err))
+			continue
+		}
+
+		c.mu.Lock()
+		c.cache[provider] = data
+		c.mu.Unlock()
+		c.lastRefreshTimestamp.WithLabelValues(string(provider)).Set(float64(data.LastRefreshed.Unix()))
+		if len(data.FailedRegions) > 0 {
+			c.logger.Warn(fmt.Sprintf("machines availability of the %s provider refreshed except regions: %s", provider, strings.Join(data.FailedRegions, ", ")))
+			continue
+		}
+		c.logger.Info(fmt.Sprintf("machines availability of the %s provider refreshed", provider))
+	}
+}
+
+// ProvidersData returns the collected availability of providers in the configured order, providers which have not been
+// refreshed yet are skipped
+func (c *Collector) ProvidersData() (ProvidersData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	providersData := ProvidersData{Providers: []Provider{}}
+	for _, provider := range c.providers {
+		if data, found := c.cache[provider]; found {
+			providersData.Providers = append(providersData.Providers, data)
+		}
+	}
+	return providersData, len(providersData.Providers) > 0
+}
+
+type availabilityCheck struct {
+	family      string
+	machineType string
+	region      string
+}
+
+func (c *Collector) collect(ctx context.Context, provider runtime.CloudProvider) (Provider, error) {
+	regionSupportingMachine, err := c.providerSpec.RegionSupportingMachine(string(provider))
+	if err != nil {
+		return Provider{}, err
+	}
+
+	families, err := machineFamilies(provider, c.providerSpec.MachineTypes(provider))
+	if err != nil {
+		return Provider{}, err
+	}
+
+	secret, err := c.secrets.SubscriptionSecret(strings.ToLower(string(provider)))
+	if err != nil {
+		return Provider{}, err
+	}
+
+	var checks []availabilityCheck
+	clients := make(map[string]hyperscalers.ZonesClient)
+	regionErrs := make(map[string][]error)
+	for family, machineType := range families {
+		regions := regionSupportingMachine.SupportedRegions(machineType)
+		if len(regions) == 0 {
+			regions = c.providerSpec.Regions(provider)
+		}
+		for _, region := range regions {
+			if _, found := clients[region]; !found && len(regionErrs[region]) == 0 {
+				client, err := c.clientFactory.New(ctx, provider, secret, region)
+				if err != nil {
+					regionErrs[region] = append(regionErrs[region], fmt.Errorf("while creating the client in %s: %w", region, err))
+					continue
+				}
+				clients[region] = client
+			}
+			if _, found := clients[region]; found {
+				checks = append(checks, availabilityCheck{family: family, machineType: machineType, region: region})
+			}
+		}
+	}
+
+	highAvailability := make([]bool, len(checks))
+	errs := make([]error, len(checks))
+	semaphore := make(chan struct{}, c.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			count, err := clients[check.region].AvailableZonesCount(ctx, check.machineType)
+			if err != nil {
+				errs[i] = fmt.Errorf("while checking %s in %s: %w", check.machineType, check.region, err)
+				return
+			}
+			highAvailability[i] = count >= highAvailabilityThreshold
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for i, check := range checks {
+		if errs[i] != nil {
+			regionErrs[check.region] = append(regionErrs[check.region], errs[i])
+			continue
+		}
+		succeeded++
+	}
+	if succeeded == 0 {
+		var allErrs []error
+		for _, err := range regionErrs {
+			allErrs = append(allErrs, err...)
+		}
+		return Provider{}, errors.Join(allErrs...)
+	}
+
+	previous := c.previousAvailability(provider)
+	machineTypes := make(map[string]*MachineType)
+	for i, check := range checks {
+		entry, found := machineTypes[check.family]
+		if !found {
+			entry = &MachineType{Name: check.family, Regions: []Region{}}
+			machineTypes[check.family] = entry
+		}
+		if errs[i] == nil {
+			entry.Regions = append(entry.Regions, Region{Name: check.region, HighAvailability: highAvailability[i]})
+		} else if region, found := previous[check.family][check.region]; found {
+			entry.Regions = append(entry.Regions, region)
+		}
+	}
+
+	providerEntry := Provider{
+		Name:          provider,
+		MachineTypes:  []MachineType{},
+		LastRefreshed: c.now().UTC(),
+	}
+	for region, errs := range regionErrs {
+		c.regionFailures.WithLabelValues(string(provider), region).Inc()
+		c.logger.Error(fmt.Sprintf("unable to refresh machines availability of the %s provider in %s: %s", provider, region, errors.Join(errs...)))
+		providerEntry.FailedRegions = append(providerEntry.FailedRegions, region)
+	}
+	sort.Strings(providerEntry.FailedRegions)
+	for _, entry := range machineTypes {
+		providerEntry.MachineTypes = append(providerEntry.MachineTypes, *entry)
+	}
+	sort.Slice(providerEntry.MachineTypes, func(i, j int) bool {
+		return providerEntry.MachineTypes[i].Name < providerEntry.MachineTypes[j].Name
+	})
+
+	return providerEntry, nil
+}
+
+// previousAvailability returns regions of the last collected availability of the provider by machine family and region name
+func (c *Collector) previousAvailability(provider runtime.CloudProvider) map[string]map[string]Region {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	previous := make(map[string]map[string]Region)
+	for _, machineType := range c.cache[provider].MachineTypes {
+		previous[machineType.Name] = make(map[string]Region)
+		for _, region := range machineType.Regions {
+			previous[machineType.Name][region.Name] = region
+		}
+	}
+	return previous
+}
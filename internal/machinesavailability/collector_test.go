@@ -0,0 +1,228 @@
+package machinesavailability
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
+	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestCollector(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	providerSpec, err := configuration.NewProviderSpecFromFile("testdata/providers.yaml")
+	require.NoError(t, err)
+	rulesService, err := rules.NewRulesServiceFromSlice([]string{"aws", "azure"}, sets.New("aws", "azure"), sets.New("aws", "azure"))
+	require.NoError(t, err)
+
+	t.Run("should collect availability of all providers", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{"m6i.large": 3, "Standard_D2s_v5": 3, "Standard_NC4as_T4_v3": 1})
+		collector := fixCollector(t, Config{Concurrency: 2, Providers: []string{"aws", "azure"}}, providerSpec,
+			NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), factory, log)
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		data, collected := collector.ProvidersData()
+		require.True(t, collected)
+		require.Len(t, data.Providers, 2)
+		assert.Equal(t, runtime.AWS, data.Providers[0].Name)
+		assert.Equal(t, runtime.Azure, data.Providers[1].Name)
+		assert.Equal(t, []MachineType{
+			{Name: "Ds_v5", Regions: []Region{{Name: "westeurope", HighAvailability: true}}},
+			{Name: "NCas_T4_v3", Regions: []Region{{Name: "westeurope", HighAvailability: false}}},
+		}, data.Providers[1].MachineTypes)
+		assert.Equal(t, fixRefreshTime(), data.Providers[1].LastRefreshed)
+		assert.Equal(t, float64(fixRefreshTime().Unix()), testutil.ToFloat64(collector.lastRefreshTimestamp.WithLabelValues("Azure")))
+		assert.Equal(t, 2, testutil.CollectAndCount(collector, "kcp_keb_v2_machines_availability_staleness_seconds"))
+	})
+
+	t.Run("should collect availability using credentials bindings", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{"m6i.large": 3})
+		collector := fixCollector(t, Config{Concurrency: 1, Providers: []string{"aws"}}, providerSpec,
+			NewCredentialsBindingSubscriptions(rulesService, fixture.CreateGardenerClientWithCredentialsBindings(), log), factory, log)
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		data, collected := collector.ProvidersData()
+		require.True(t, collected)
+		assert.Equal(t, "m6i", data.Providers[0].MachineTypes[3].Name)
+		assert.Equal(t, []Region{{Name: "eu-central-1", HighAvailability: true}, {Name: "eu-west-2", HighAvailability: true}}, data.Providers[0].MachineTypes[3].Regions)
+	})
+
+	t.Run("should keep previous availability of the provider which failed to refresh", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{"m6i.large": 3, "Standard_D2s_v5": 3})
+		collector := fixCollector(t, Config{Concurrency: 2, Providers: []string{"aws", "azure"}}, providerSpec,
+			NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), factory, log)
+		collector.Refresh(context.Background())
+
+		factory.failingProviders[runtime.Azure] = true
+		collector.now = func() time.Time { return fixRefreshTime().Add(time.Hour) }
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		data, _ := collector.ProvidersData()
+		assert.Equal(t, fixRefreshTime().Add(time.Hour), data.Providers[0].LastRefreshed)
+		assert.Equal(t, fixRefreshTime(), data.Providers[1].LastRefreshed)
+		assert.Equal(t, float64(1), testutil.ToFloat64(collector.refreshFailures.WithLabelValues("Azure")))
+		assert.Equal(t, float64(0), testutil.ToFloat64(collector.refreshFailures.WithLabelValues("AWS")))
+	})
+
+	t.Run("should refresh regions which succeeded and keep previous availability of regions which failed", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{"m6i.large": 3})
+		collector := fixCollector(t, Config{Concurrency: 2, Providers: []string{"aws"}}, providerSpec,
+			NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), factory, log)
+		collector.Refresh(context.Background())
+
+		factory.zonesCount["m6i.large"] = 1
+		factory.failingRegions["eu-west-2"] = true
+		collector.now = func() time.Time { return fixRefreshTime().Add(time.Hour) }
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		data, _ := collector.ProvidersData()
+		assert.Equal(t, fixRefreshTime().Add(time.Hour), data.Providers[0].LastRefreshed)
+		assert.Equal(t, "m6i", data.Providers[0].MachineTypes[3].Name)
+		assert.Equal(t, []Region{{Name: "eu-central-1", HighAvailability: false}, {Name: "eu-west-2", HighAvailability: true}}, data.Providers[0].MachineTypes[3].Regions)
+		assert.Equal(t, []string{"eu-west-2"}, data.Providers[0].FailedRegions)
+		assert.Equal(t, float64(1), testutil.ToFloat64(collector.regionFailures.WithLabelValues("AWS", "eu-west-2")))
+		assert.Equal(t, float64(0), testutil.ToFloat64(collector.refreshFailures.WithLabelValues("AWS")))
+	})
+
+	t.Run("should skip provider which has never been refreshed", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{})
+		factory.failingProviders[runtime.AWS] = true
+		collector := fixCollector(t, Config{Concurrency: 2, Providers: []string{"aws", "azure"}}, providerSpec,
+			NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), factory, log)
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		data, collected := collector.ProvidersData()
+		require.True(t, collected)
+		require.Len(t, data.Providers, 1)
+		assert.Equal(t, runtime.Azure, data.Providers[0].Name)
+	})
+
+	t.Run("should not exceed the configured concurrency", func(t *testing.T) {
+		// given
+		factory := newFakeZonesClientFactory(map[string]int{})
+		factory.delay = 10 * time.Millisecond
+		collector := fixCollector(t, Config{Concurrency: 2, Providers: []string{"aws"}}, providerSpec,
+			NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), factory, log)
+
+		// when
+		collector.Refresh(context.Background())
+
+		// then
+		assert.Equal(t, 7, factory.calls)
+		assert.Equal(t, 2, factory.maxInFlight)
+	})
+}
+
+func TestNewCollector(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	t.Run("should fail for provider without zones discovery", func(t *testing.T) {
+		// when
+		_, err := NewCollector(Config{Concurrency: 1, Providers: []string{"sap-converged-cloud"}}, nil, nil, nil, log)
+
+		// then
+		assert.EqualError(t, err, "machines availability is not supported for the sap-converged-cloud provider")
+	})
+
+	t.Run("should fail for invalid concurrency", func(t *testing.T) {
+		// when
+		_, err := NewCollector(Config{Concurrency: 0, Providers: []string{"aws"}}, nil, nil, nil, log)
+
+		// then
+		assert.EqualError(t, err, "concurrency must be greater than 0, got 0")
+	})
+}
+
+func fixRefreshTime() time.Time {
+	return time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+}
+
+func fixCollector(t *testing.T, cfg Config, providerSpec *configuration.ProviderSpec, secrets SubscriptionSecretProvider, factory hyperscalers.ZonesClientFactory, log *slog.Logger) *Collector {
+	collector, err := NewCollector(cfg, providerSpec, secrets, factory, log)
+	require.NoError(t, err)
+	collector.now = fixRefreshTime
+	return collector
+}
+
+type fakeZonesClientFactory struct {
+	zonesCount       map[string]int
+	failingProviders map[runtime.CloudProvider]bool
+	failingRegions   map[string]bool
+	delay            time.Duration
+
+	mu          sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func newFakeZonesClientFactory(zonesCount map[string]int) *fakeZonesClientFactory {
+	return &fakeZonesClientFactory{zonesCount: zonesCount, failingProviders: map[runtime.CloudProvider]bool{}, failingRegions: map[string]bool{}}
+}
+
+func (f *fakeZonesClientFactory) New(_ context.Context, provider runtime.CloudProvider, _ *unstructured.Unstructured, region string) (hyperscalers.ZonesClient, error) {
+	return &fakeZonesClient{factory: f, fail: f.failingProviders[provider] || f.failingRegions[region]}, nil
+}
+
+type fakeZonesClient struct {
+	factory *fakeZonesClientFactory
+	fail    bool
+}
+
+func (c *fakeZonesClient) AvailableZones(ctx context.Context, machineType string) ([]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (c *fakeZonesClient) AvailableZonesCount(_ context.Context, machineType string) (int, error) {
+	f := c.factory
+	f.mu.Lock()
+	f.calls++
+	f.inFlight++
+	f.maxInFlight = max(f.maxInFlight, f.inFlight)
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	if c.fail {
+		return 0, fmt.Errorf("request limit exceeded")
+	}
+	return f.zonesCount[machineType], nil
+}
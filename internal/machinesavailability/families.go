@@ -0,0 +1,26 @@
+package machinesavailability
+
+import (
+	"sort"
+
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+)
+
+// machineFamilies groups machine types by family and returns the first machine type of each family in alphabetical order,
+// which represents the family when checking the availability
+func machineFamilies(provider runtime.CloudProvider, machineTypes []string) (map[string]string, error) {
+	sorted := append([]string{}, machineTypes...)
+	sort.Strings(sorted)
+
+	families := make(map[string]string)
+	for _, machineType := range sorted {
+		family, err := runtime.MachineFamily(provider, machineType)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := families[family]; !found {
+			families[family] = machineType
+		}
+	}
+	return families, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
@@ -25,7 +26,7 @@ func TestMachinesAvailabilityHandler(t *testing.T) {
 	rulesService, err := rules.NewRulesServiceFromSlice([]string{"aws"}, sets.New("aws"), sets.New("aws"))
 	require.NoError(t, err)
 
-	fakeAWSClientFactory := fixture.NewFakeAWSClientFactory(map[string][]string{
+	fakeAWSClientFactory := fixture.NewFakeZonesClientFactory(map[string][]string{
 		"m6i.large":    {"a", "b", "c", "d"},
 		"m6i.xlarge":   {"a", "b", "c", "d"},
 		"c7i.large":    {"a", "b"},
@@ -38,24 +39,48 @@ func TestMachinesAvailabilityHandler(t *testing.T) {
 
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 
-	handler := NewHandler(providerSpec, rulesService, fixture.CreateGardenerClient(), fakeAWSClientFactory, log)
+	collector, err := NewCollector(Config{Concurrency: 2, Providers: []string{"aws"}}, providerSpec,
+		NewSecretBindingSubscriptions(rulesService, fixture.CreateGardenerClient(), log), fakeAWSClientFactory, log)
+	require.NoError(t, err)
+	collector.now = func() time.Time { return time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC) }
+
+	handler := NewHandler(collector, log)
 
 	router := httputil.NewRouter()
 	handler.AttachRoutes(router)
 
-	req := httptest.NewRequest(http.MethodGet, "/oauth/v2/machines_availability", nil)
-	resp := httptest.NewRecorder()
-	router.ServeHTTP(resp, req)
-	require.Equal(t, http.StatusOK, resp.Code)
+	t.Run("should return service unavailable before the first refresh", func(t *testing.T) {
+		// when
+		req := httptest.NewRequest(http.MethodGet, "/oauth/v2/machines_availability", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
 
-	actualJSON := resp.Body.Bytes()
+		// then
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		assert.JSONEq(t, `{"error":"machines availability has not been collected yet"}`, resp.Body.String())
+	})
 
-	expectedJSON, err := os.ReadFile("testdata/machines-availability.json")
-	require.NoError(t, err)
+	t.Run("should return collected machines availability", func(t *testing.T) {
+		// given
+		collector.Refresh(t.Context())
+
+		// when
+		req := httptest.NewRequest(http.MethodGet, "/oauth/v2/machines_availability", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		actualJSON := resp.Body.Bytes()
+
+		expectedJSON, err := os.ReadFile("testdata/machines-availability.json")
+		require.NoError(t, err)
 
-	var actualData, expectedData interface{}
-	require.NoError(t, json.Unmarshal(actualJSON, &actualData))
-	require.NoError(t, json.Unmarshal(expectedJSON, &expectedData))
+		var actualData, expectedData interface{}
+		require.NoError(t, json.Unmarshal(actualJSON, &actualData))
+		require.NoError(t, json.Unmarshal(expectedJSON, &expectedData))
 
-	assert.Equal(t, expectedData, actualData)
+		assert.Equal(t, expectedData, actualData)
+	})
 }
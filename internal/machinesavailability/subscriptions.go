@@ -0,0 +1,131 @@
+package machinesavailability
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/kyma-project/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/internal/subscriptions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SubscriptionSecretProvider returns a hyperscaler subscription secret of the provider, the credentials from the secret are used
+// to query the provider API
+type SubscriptionSecretProvider interface {
+	SubscriptionSecret(provider string) (*unstructured.Unstructured, error)
+}
+
+type SecretBindingSubscriptions struct {
+	rulesService   *rules.RulesService
+	gardenerClient *gardener.Client
+	logger         *slog.Logger
+}
+
+func NewSecretBindingSubscriptions(rulesService *rules.RulesService, gardenerClient *gardener.Client, logger *slog.Logger) *SecretBindingSubscriptions {
+	return &SecretBindingSubscriptions{
+		rulesService:   rulesService,
+		gardenerClient: gardenerClient,
+		logger:         logger.With("service", "MachinesAvailabilitySubscriptions"),
+	}
+}
+
+func (s *SecretBindingSubscriptions) SubscriptionSecret(provider string) (*unstructured.Unstructured, error) {
+	matchedRule, err := matchRule(s.rulesService, provider, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	secretBinding, err := s.getSecretBindingForRule(matchedRule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(fmt.Sprintf("getting subscription secret with name %s/%s", secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName()))
+	secret, err := s.gardenerClient.GetSecret(secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get secret %s/%s", secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName())
+	}
+
+	return secret, nil
+}
+
+func (s *SecretBindingSubscriptions) getSecretBindingForRule(matchedRule rules.Result) (*gardener.SecretBinding, error) {
+	labelSelectorBuilder := subscriptions.NewLabelSelectorFromRuleset(matchedRule)
+	labelSelector := labelSelectorBuilder.BuildAnySubscription()
+
+	s.logger.Info(fmt.Sprintf("getting secret binding with selector %q", labelSelector))
+	secretBindings, err := s.gardenerClient.GetSecretBindings(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("while getting secret bindings with selector %q: %w", labelSelector, err)
+	}
+	if secretBindings == nil || len(secretBindings.Items) == 0 {
+		return nil, fmt.Errorf("no secret bindings found for selector %q", labelSelector)
+	}
+
+	return gardener.NewSecretBinding(secretBindings.Items[0]), nil
+}
+
+type CredentialsBindingSubscriptions struct {
+	rulesService   *rules.RulesService
+	gardenerClient *gardener.Client
+	logger         *slog.Logger
+}
+
+func NewCredentialsBindingSubscriptions(rulesService *rules.RulesService, gardenerClient *gardener.Client, logger *slog.Logger) *CredentialsBindingSubscriptions {
+	return &CredentialsBindingSubscriptions{
+		rulesService:   rulesService,
+		gardenerClient: gardenerClient,
+		logger:         logger.With("service", "MachinesAvailabilitySubscriptions"),
+	}
+}
+
+func (s *CredentialsBindingSubscriptions) SubscriptionSecret(provider string) (*unstructured.Unstructured, error) {
+	matchedRule, err := matchRule(s.rulesService, provider, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsBinding, err := s.getCredentialsBindingForRule(matchedRule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(fmt.Sprintf("getting subscription secret with name %s/%s", credentialsBinding.GetSecretRefNamespace(), credentialsBinding.GetSecretRefName()))
+	secret, err := s.gardenerClient.GetSecret(credentialsBinding.GetSecretRefNamespace(), credentialsBinding.GetSecretRefName())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get secret %s/%s", credentialsBinding.GetSecretRefNamespace(), credentialsBinding.GetSecretRefName())
+	}
+
+	return secret, nil
+}
+
+func (s *CredentialsBindingSubscriptions) getCredentialsBindingForRule(matchedRule rules.Result) (*gardener.CredentialsBinding, error) {
+	labelSelectorBuilder := subscriptions.NewLabelSelectorFromRuleset(matchedRule)
+	labelSelector := labelSelectorBuilder.BuildAnySubscription()
+
+	s.logger.Info(fmt.Sprintf("getting credentials binding with selector %q", labelSelector))
+	credentialsBindings, err := s.gardenerClient.GetCredentialsBindings(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("while getting credentials bindings with selector %q: %w", labelSelector, err)
+	}
+	if credentialsBindings == nil || len(credentialsBindings.Items) == 0 {
+		return nil, fmt.Errorf("no credentials bindings found for selector %q", labelSelector)
+	}
+
+	return gardener.NewCredentialsBinding(credentialsBindings.Items[0]), nil
+}
+
+func matchRule(rulesService *rules.RulesService, provider string, logger *slog.Logger) (rules.Result, error) {
+	// machines availability is collected for the provider, not for an instance, so only rules which are not restricted
+	// to a platform region, hyperscaler region, global account, subaccount label or machine family match
+	attr := rules.NewProvisioningAttributes(provider, "", "", provider, "", nil, "")
+
+	matchedRule, found := rulesService.MatchProvisioningAttributesWithValidRuleset(attr)
+	if !found {
+		return rules.Result{}, fmt.Errorf("no matching rule for provisioning attributes %q", attr)
+	}
+
+	logger.Info(fmt.Sprintf("matched rule: %q", matchedRule.Rule()))
+	return matchedRule, nil
+}
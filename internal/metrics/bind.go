@@ -94,6 +94,68 @@ func (c *BindingCreationCollector) OnBindingCreated(ctx context.Context, ev inte
 	return nil
 }
 
+type BindingRotationCollector struct {
+	bindingRotated *prometheus.CounterVec
+}
+
+// BindingRotationCollector provides a counter which shows the total number of bindings with rotated credentials:
+// - kcp_keb_v2_binding_rotated_total{plan_id}
+func NewBindingRotationCollector() *BindingRotationCollector {
+	return &BindingRotationCollector{
+		bindingRotated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespaceV2,
+			Subsystem: prometheusSubsystemV2,
+			Name:      "binding_rotated_total",
+			Help:      "The total number of bindings with rotated credentials",
+		}, []string{"plan_id"}),
+	}
+}
+
+func (c *BindingRotationCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.bindingRotated.Describe(ch)
+}
+
+func (c *BindingRotationCollector) Collect(ch chan<- prometheus.Metric) {
+	c.bindingRotated.Collect(ch)
+}
+
+func (c *BindingRotationCollector) OnBindingRotated(ctx context.Context, ev interface{}) error {
+	obj := ev.(broker.BindingRotated)
+	c.bindingRotated.WithLabelValues(obj.PlanID).Inc()
+	return nil
+}
+
+type BindingRejectionCollector struct {
+	bindingRejected *prometheus.CounterVec
+}
+
+// BindingRejectionCollector provides a counter which shows the total number of bindings rejected by binding policies:
+// - kcp_keb_v2_binding_rejected_total{reason,policy}
+func NewBindingRejectionCollector() *BindingRejectionCollector {
+	return &BindingRejectionCollector{
+		bindingRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespaceV2,
+			Subsystem: prometheusSubsystemV2,
+			Name:      "binding_rejected_total",
+			Help:      "The total number of bindings rejected by binding policies",
+		}, []string{"reason", "policy"}),
+	}
+}
+
+func (c *BindingRejectionCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.bindingRejected.Describe(ch)
+}
+
+func (c *BindingRejectionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.bindingRejected.Collect(ch)
+}
+
+func (c *BindingRejectionCollector) OnBindingRejected(ctx context.Context, ev interface{}) error {
+	obj := ev.(broker.BindingRejected)
+	c.bindingRejected.WithLabelValues(obj.Reason, obj.Policy).Inc()
+	return nil
+}
+
 type BindingStatitics struct {
 	db     storage.Bindings
 	logger *slog.Logger
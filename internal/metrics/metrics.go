@@ -72,6 +72,12 @@ func Register(ctx context.Context, sub event.Subscriber, db storage.BrokerStorag
 	bindCrestedCollector := NewBindingCreationCollector()
 	prometheus.MustRegister(bindCrestedCollector)
 
+	bindingRotationCollector := NewBindingRotationCollector()
+	prometheus.MustRegister(bindingRotationCollector)
+
+	bindingRejectionCollector := NewBindingRejectionCollector()
+	prometheus.MustRegister(bindingRejectionCollector)
+
 	stepDurationCollector := NewStepDurationCollector()
 	prometheus.MustRegister(stepDurationCollector)
 
@@ -86,6 +92,8 @@ func Register(ctx context.Context, sub event.Subscriber, db storage.BrokerStorag
 	sub.Subscribe(broker.BindRequestProcessed{}, bindDurationCollector.OnBindingExecuted)
 	sub.Subscribe(broker.UnbindRequestProcessed{}, bindDurationCollector.OnUnbindingExecuted)
 	sub.Subscribe(broker.BindingCreated{}, bindCrestedCollector.OnBindingCreated)
+	sub.Subscribe(broker.BindingRotated{}, bindingRotationCollector.OnBindingRotated)
+	sub.Subscribe(broker.BindingRejected{}, bindingRejectionCollector.OnBindingRejected)
 
 	logger.Info(fmt.Sprintf("%s -> enabled", logPrefix))
 
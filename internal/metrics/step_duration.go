@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
@@ -14,10 +15,15 @@ import (
 // - kcp_keb_v2_provisioning_step_duration_seconds
 // - kcp_keb_v2_update_step_duration_seconds
 // - kcp_keb_v2_deprovisioning_step_duration_seconds
+// and the effective retry policy of steps together with the number of retries:
+// - kcp_keb_v2_step_policy_info
+// - kcp_keb_v2_step_retries_total
 type StepDurationCollector struct {
 	provisioningStepHistogram   *prometheus.HistogramVec
 	updateStepHistogram         *prometheus.HistogramVec
 	deprovisioningStepHistogram *prometheus.HistogramVec
+	stepPolicyInfo              *prometheus.GaugeVec
+	stepRetries                 *prometheus.CounterVec
 }
 
 func NewStepDurationCollector() *StepDurationCollector {
@@ -55,6 +61,18 @@ func NewStepDurationCollector() *StepDurationCollector {
 				20,    // ~10 minutes
 			),
 		}, []string{"plan_id", "step_name"}),
+		stepPolicyInfo: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prometheusNamespaceV2,
+			Subsystem: prometheusSubsystemV2,
+			Name:      "step_policy_info",
+			Help:      "The effective retry policy of the step",
+		}, []string{"operation_type", "step_name", "interval", "backoff", "max_attempts", "max_interval", "jitter", "timeout", "retry_forever"}),
+		stepRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prometheusNamespaceV2,
+			Subsystem: prometheusSubsystemV2,
+			Name:      "step_retries_total",
+			Help:      "The number of step runs which needed a retry",
+		}, []string{"operation_type", "step_name"}),
 	}
 }
 
@@ -62,12 +80,16 @@ func (c *StepDurationCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.provisioningStepHistogram.Describe(ch)
 	c.updateStepHistogram.Describe(ch)
 	c.deprovisioningStepHistogram.Describe(ch)
+	c.stepPolicyInfo.Describe(ch)
+	c.stepRetries.Describe(ch)
 }
 
 func (c *StepDurationCollector) Collect(ch chan<- prometheus.Metric) {
 	c.provisioningStepHistogram.Collect(ch)
 	c.updateStepHistogram.Collect(ch)
 	c.deprovisioningStepHistogram.Collect(ch)
+	c.stepPolicyInfo.Collect(ch)
+	c.stepRetries.Collect(ch)
 }
 
 func (c *StepDurationCollector) OnOperationStepProcessed(_ context.Context, ev interface{}) error {
@@ -95,5 +117,13 @@ func (c *StepDurationCollector) OnOperationStepProcessed(_ context.Context, ev i
 			Observe(stepProcessed.Duration.Seconds())
 	}
 
+	operationType := string(stepProcessed.Operation.Type)
+	policy := stepProcessed.Policy
+	c.stepPolicyInfo.WithLabelValues(operationType, stepProcessed.StepName, policy.Interval.String(), string(policy.EffectiveBackoff()), strconv.Itoa(policy.MaxAttempts),
+		policy.MaxInterval.String(), strconv.FormatFloat(policy.Jitter, 'f', -1, 64), policy.Timeout.String(), strconv.FormatBool(policy.RetryForever)).Set(1)
+	if stepProcessed.When > 0 {
+		c.stepRetries.WithLabelValues(operationType, stepProcessed.StepName).Inc()
+	}
+
 	return nil
 }
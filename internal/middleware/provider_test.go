@@ -0,0 +1,48 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/middleware"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestProviderKey(t *testing.T) {
+	policies := regionpolicy.Policies{"cf-eu30": {Provider: "azure"}}
+
+	for region, expected := range map[string]pkg.CloudProvider{
+		"cf-eu10": pkg.AWS,
+		"cf-eu30": pkg.Azure,
+	} {
+		t.Run(region, func(t *testing.T) {
+			// given
+			req, err := http.NewRequest(http.MethodGet, "http://url.dev/endpoint/"+region, nil)
+			require.NoError(t, err)
+
+			var gotCtx context.Context
+			spyHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				gotCtx = req.Context()
+			})
+
+			router := httputil.NewRouter()
+			router.Use(middleware.AddProviderToContext(policies))
+			router.HandleFunc("/endpoint/{region}", spyHandler)
+
+			// when
+			router.ServeHTTP(httptest.NewRecorder(), req)
+			gotProvider, found := middleware.ProviderFromContext(gotCtx)
+
+			// then
+			assert.True(t, found)
+			assert.Equal(t, expected, gotProvider)
+		})
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/kyma-project/kyma-environment-broker/internal/euaccess"
-
 	"github.com/google/uuid"
 	"github.com/kyma-project/kyma-environment-broker/common/gardener"
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
@@ -153,6 +151,24 @@ type Operation struct {
 
 	// DiscoveredZones stores availability zones per machine type, resolved at runtime
 	DiscoveredZones map[string][]string `json:"discovered_zones"`
+
+	// ResumedAt is set when a failed operation is resumed manually, the operation timeout is counted from this time
+	ResumedAt *time.Time `json:"resumed_at,omitempty"`
+	// ResumeFromStep is the name of the step the resumed operation starts from, steps before it are skipped
+	ResumeFromStep string `json:"resume_from_step,omitempty"`
+
+	// StepRetries counts runs of steps which needed a retry, by step name, the counters are used by step retry policies
+	StepRetries map[string]StepRetries `json:"step_retries,omitempty"`
+}
+
+// StepRetries tracks runs of the step which needed a retry
+type StepRetries struct {
+	Attempts   int       `json:"attempts"`
+	FirstRunAt time.Time `json:"first_run_at"`
+	// ResumedAt is the resume time of the operation when the counting started, a resumed operation counts retries from scratch
+	ResumedAt *time.Time `json:"resumed_at,omitempty"`
+	// LastError is the cause of the last run which needed a retry, the operation fails with it when the retry policy is exhausted
+	LastError *kebError.LastError `json:"last_error,omitempty"`
 }
 
 // ProviderValues contains values which are specific to particular plans (and provisioning parameters)
@@ -181,6 +197,14 @@ func (o *Operation) IsFinished() bool {
 	return o.State != OperationStateInProgress && o.State != OperationStatePending && o.State != OperationStateCanceling && o.State != OperationStateRetrying
 }
 
+// ProcessingStartedAt returns the time the operation timeout is counted from
+func (o *Operation) ProcessingStartedAt() time.Time {
+	if o.ResumedAt != nil {
+		return *o.ResumedAt
+	}
+	return o.CreatedAt
+}
+
 func (o *Operation) EventInfof(fmt string, args ...any) {
 	events.Infof(o.InstanceID, o.ID, fmt, args...)
 }
@@ -354,7 +378,6 @@ func NewProvisioningOperationWithID(operationID, instanceID string, parameters P
 			InstanceDetails: InstanceDetails{
 				SubAccountID: parameters.ErsContext.SubAccountID,
 				Kubeconfig:   parameters.Parameters.Kubeconfig,
-				EuAccess:     euaccess.IsEURestrictedAccess(parameters.PlatformRegion),
 			},
 			FinishedStages: make([]string, 0),
 			LastError:      kebError.LastError{},
@@ -514,8 +537,116 @@ type Binding struct {
 	Kubeconfig        string
 	ExpirationSeconds int64
 	CreatedBy         string
+
+	// Scope describes permissions granted to the binding, an empty role means the cluster-admin role
+	Scope BindingScope
+	// CredentialType selects how the binding authenticates, an empty type means a service account token
+	CredentialType string
+
+	// State of the binding creation, empty for bindings created before the state was tracked
+	State            domain.LastOperationState
+	StateDescription string
+	// Async is set for bindings created in the background by the binding queue
+	Async bool
+}
+
+// CreationState returns the state of the binding creation, bindings without the state are in progress until the kubeconfig is set
+func (b Binding) CreationState() domain.LastOperationState {
+	switch {
+	case b.State != "":
+		return b.State
+	case b.Kubeconfig != "":
+		return domain.Succeeded
+	default:
+		return domain.InProgress
+	}
+}
+
+const (
+	BindingRoleClusterAdmin   = "cluster-admin"
+	BindingRoleView           = "view"
+	BindingRoleEdit           = "edit"
+	BindingRoleNamespaceAdmin = "namespace-admin"
+	BindingRoleCustom         = "custom"
+)
+
+// BindingScope describes permissions granted to the binding's service account. Namespaces limit the role to the listed
+// namespaces, rules are set only for the custom role.
+type BindingScope struct {
+	Role       string        `json:"role"`
+	Namespaces []string      `json:"namespaces,omitempty"`
+	Rules      []BindingRule `json:"rules,omitempty"`
+}
+
+type BindingRule struct {
+	APIGroups []string `json:"apiGroups"`
+	Resources []string `json:"resources"`
+	Verbs     []string `json:"verbs"`
+}
+
+// CredentialTypeOrDefault returns the credential type, bindings created before credential types were introduced use service account tokens
+func (b Binding) CredentialTypeOrDefault() string {
+	if b.CredentialType == "" {
+		return BindingCredentialTypeServiceAccount
+	}
+	return b.CredentialType
 }
 
+const (
+	BindingCredentialTypeServiceAccount    = "service-account"
+	BindingCredentialTypeOIDC              = "oidc"
+	BindingCredentialTypeClientCertificate = "client-certificate"
+)
+
+// RoleOrDefault returns the role, bindings created before scopes were introduced have the cluster-admin role
+func (s BindingScope) RoleOrDefault() string {
+	if s.Role == "" {
+		return BindingRoleClusterAdmin
+	}
+	return s.Role
+}
+
+// QueueItem is an operation scheduled in a persistent processing queue.
+// LeaseOwner and LeaseExpiresAt are set when a broker replica claims the item.
+type QueueItem struct {
+	QueueName   string
+	OperationID string
+
+	NextRunAt      time.Time
+	LeaseOwner     string
+	LeaseExpiresAt *time.Time
+	// Dirty marks an item scheduled again while it was leased, the lease owner keeps it in the queue when releasing it
+	Dirty bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OperationStep is the record of a step processed within an operation, updated with every run of the step.
+// StartedAt is the start of the first run, FinishedAt is set when the step no longer needs a retry.
+type OperationStep struct {
+	OperationID string
+	InstanceID  string
+	Stage       string
+	StepName    string
+
+	State     OperationStepState
+	Attempts  int
+	LastError string
+
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	UpdatedAt  time.Time
+}
+
+type OperationStepState string
+
+const (
+	OperationStepStateSucceeded OperationStepState = "succeeded"
+	OperationStepStateRetrying  OperationStepState = "retrying"
+	OperationStepStateFailed    OperationStepState = "failed"
+)
+
 type RetryTuple struct {
 	Timeout  time.Duration
 	Interval time.Duration
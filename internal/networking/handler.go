@@ -0,0 +1,136 @@
+package networking
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+)
+
+const suggestPath = "GET /networking/suggest"
+
+// Suggestion is the response of the networking suggest endpoint, the fields match networking provisioning parameters
+type Suggestion struct {
+	Nodes     string          `json:"nodes"`
+	Pods      string          `json:"pods"`
+	Services  string          `json:"services"`
+	DualStack bool            `json:"dualStack"`
+	IPv6      *IPv6Suggestion `json:"ipv6,omitempty"`
+}
+
+type IPv6Suggestion struct {
+	Nodes    string `json:"nodes,omitempty"`
+	Pods     string `json:"pods,omitempty"`
+	Services string `json:"services,omitempty"`
+}
+
+// Handler serves ranges proposed by the planner, the endpoint is read-only and used by the schema UI
+type Handler struct {
+	logger *slog.Logger
+}
+
+func NewHandler(logger *slog.Logger) *Handler {
+	return &Handler{
+		logger: logger.With("service", "NetworkingHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *httputil.Router) {
+	router.HandleFunc(suggestPath, h.suggest)
+}
+
+func (h *Handler) suggest(w http.ResponseWriter, req *http.Request) {
+	request, err := parseRequest(req)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ranges, err := Suggest(request)
+	if err != nil {
+		h.logger.Info(fmt.Sprintf("unable to suggest networking ranges: %s", err))
+		httputil.WriteErrorResponse(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	suggestion := Suggestion{
+		Nodes:     ranges.Nodes.String(),
+		Pods:      ranges.Pods.String(),
+		Services:  ranges.Services.String(),
+		DualStack: request.DualStack,
+	}
+	if request.IPv6 != (IPv6Ranges{}) {
+		suggestion.IPv6 = &IPv6Suggestion{
+			Nodes:    prefixString(request.IPv6.Nodes),
+			Pods:     prefixString(request.IPv6.Pods),
+			Services: prefixString(request.IPv6.Services),
+		}
+	}
+	httputil.WriteResponse(w, http.StatusOK, suggestion)
+}
+
+func parseRequest(req *http.Request) (Request, error) {
+	query := req.URL.Query()
+	request := Request{NodesPrefixLength: netip.MustParsePrefix(DefaultNodesCIDR).Bits()}
+
+	if value := query.Get("nodesPrefixLength"); value != "" {
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			return Request{}, fmt.Errorf("invalid nodesPrefixLength %q: must be a number", value)
+		}
+		request.NodesPrefixLength = length
+	}
+	if value := query.Get("dualStack"); value != "" {
+		dualStack, err := strconv.ParseBool(value)
+		if err != nil {
+			return Request{}, fmt.Errorf("invalid dualStack %q: must be a boolean", value)
+		}
+		request.DualStack = dualStack
+	}
+	for _, values := range query["reserved"] {
+		for _, value := range strings.Split(values, ",") {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(value))
+			if err != nil {
+				return Request{}, fmt.Errorf("invalid reserved range %q: %w", value, err)
+			}
+			request.Reserved = append(request.Reserved, prefix)
+		}
+	}
+
+	var err error
+	if request.IPv6.Nodes, err = parseOptionalPrefix(query.Get("ipv6Nodes"), "ipv6Nodes"); err != nil {
+		return Request{}, err
+	}
+	if request.IPv6.Pods, err = parseOptionalPrefix(query.Get("ipv6Pods"), "ipv6Pods"); err != nil {
+		return Request{}, err
+	}
+	if request.IPv6.Services, err = parseOptionalPrefix(query.Get("ipv6Services"), "ipv6Services"); err != nil {
+		return Request{}, err
+	}
+	return request, nil
+}
+
+func parseOptionalPrefix(value, name string) (*netip.Prefix, error) {
+	if value == "" {
+		return nil, nil
+	}
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return &prefix, nil
+}
+
+func prefixString(prefix *netip.Prefix) string {
+	if prefix == nil {
+		return ""
+	}
+	return prefix.String()
+}
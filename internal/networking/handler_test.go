@@ -0,0 +1,72 @@
+package networking
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Suggest(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	router := httputil.NewRouter()
+	NewHandler(log).AttachRoutes(router)
+
+	for name, tc := range map[string]struct {
+		query        string
+		expectedCode int
+		expectedBody string
+	}{
+		"default ranges": {
+			query:        "",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"nodes":"10.250.0.0/16","pods":"10.96.0.0/13","services":"10.104.0.0/13","dualStack":false}`,
+		},
+		"ranges avoiding reserved ranges": {
+			query:        "?nodesPrefixLength=20&reserved=10.96.0.0/16,10.250.0.0/24&reserved=10.104.0.0/16",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"nodes":"10.16.0.0/20","pods":"10.0.0.0/13","services":"10.8.0.0/13","dualStack":false}`,
+		},
+		"dual-stack ranges": {
+			query:        "?dualStack=true&ipv6Pods=fd00:20::/56&ipv6Services=fd00:30::/112",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"nodes":"10.250.0.0/16","pods":"10.96.0.0/13","services":"10.104.0.0/13","dualStack":true,"ipv6":{"pods":"fd00:20::/56","services":"fd00:30::/112"}}`,
+		},
+		"invalid nodes prefix length": {
+			query:        "?nodesPrefixLength=big",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid nodesPrefixLength \"big\": must be a number"}`,
+		},
+		"invalid reserved range": {
+			query:        "?reserved=10.96.0.0",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid reserved range \"10.96.0.0\": netip.ParsePrefix(\"10.96.0.0\"): no '/'"}`,
+		},
+		"invalid IPv6 ranges": {
+			query:        "?dualStack=true&ipv6Services=fd00:30::/64",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"the prefix length of the IPv6 services range must not be less than 108"}`,
+		},
+		"IPv6 ranges without dual-stack": {
+			query:        "?ipv6Pods=fd00:20::/56",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"error":"IPv6 ranges require dual-stack networking"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			req := httptest.NewRequest(http.MethodGet, "/networking/suggest"+tc.query, nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			// then
+			assert.Equal(t, tc.expectedCode, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package networking
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+const (
+	// MaxNodesPrefixLength is the longest prefix of the nodes range, which can be divided between zones and nodes
+	MaxNodesPrefixLength = 23
+	// MinNodesPrefixLength is the shortest prefix of the nodes range proposed by the planner
+	MinNodesPrefixLength = 12
+
+	// maxIPv6NodesPrefixLength, maxIPv6PodsPrefixLength and minIPv6ServicesPrefixLength limit IPv6 ranges, nodes and pods
+	// ranges must be divisible into /64 ranges and the services range must not be larger than /108
+	maxIPv6NodesPrefixLength    = 64
+	maxIPv6PodsPrefixLength     = 56
+	minIPv6ServicesPrefixLength = 108
+)
+
+// privateIPv4Ranges are ranges searched for free ranges, in order
+var privateIPv4Ranges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// Request describes ranges which the planner proposes
+type Request struct {
+	// NodesPrefixLength is the prefix length of the nodes range
+	NodesPrefixLength int
+	// DualStack enables validation of IPv6 ranges
+	DualStack bool
+	// Reserved are ranges which must not overlap proposed ranges, for example ranges of networks peered with the cluster
+	Reserved []netip.Prefix
+	// IPv6 are IPv6 ranges validated for dual-stack, ranges which are not set are assigned by the provider
+	IPv6 IPv6Ranges
+}
+
+type IPv6Ranges struct {
+	Nodes    *netip.Prefix
+	Pods     *netip.Prefix
+	Services *netip.Prefix
+}
+
+// Ranges are IPv4 ranges of the cluster
+type Ranges struct {
+	Nodes    netip.Prefix
+	Pods     netip.Prefix
+	Services netip.Prefix
+}
+
+// Suggest proposes nodes, pods, and services ranges which do not overlap each other, seed ranges, and reserved ranges.
+// Default ranges are proposed if they are free, otherwise the first free ranges of the same size in private address space.
+func Suggest(request Request) (Ranges, error) {
+	if request.NodesPrefixLength < MinNodesPrefixLength || request.NodesPrefixLength > MaxNodesPrefixLength {
+		return Ranges{}, fmt.Errorf("the prefix length of the nodes range must be between %d and %d", MinNodesPrefixLength, MaxNodesPrefixLength)
+	}
+	reservedIPv4, reservedIPv6 := splitByFamily(request.Reserved)
+	if len(reservedIPv6) > 0 && !request.DualStack {
+		return Ranges{}, fmt.Errorf("IPv6 reserved ranges require dual-stack networking")
+	}
+	if request.DualStack {
+		if err := ValidateIPv6(request.IPv6, reservedIPv6); err != nil {
+			return Ranges{}, err
+		}
+	} else if request.IPv6 != (IPv6Ranges{}) {
+		return Ranges{}, fmt.Errorf("IPv6 ranges require dual-stack networking")
+	}
+
+	used := append(seedRanges(), reservedIPv4...)
+	defaultPods := netip.MustParsePrefix(DefaultPodsCIDR)
+	pods, err := allocate(defaultPods, used)
+	if err != nil {
+		return Ranges{}, fmt.Errorf("while proposing pods range: %w", err)
+	}
+	used = append(used, pods)
+
+	defaultServices := netip.MustParsePrefix(DefaultServicesCIDR)
+	services, err := allocate(defaultServices, used)
+	if err != nil {
+		return Ranges{}, fmt.Errorf("while proposing services range: %w", err)
+	}
+	used = append(used, services)
+
+	defaultNodes := netip.PrefixFrom(netip.MustParsePrefix(DefaultNodesCIDR).Addr(), request.NodesPrefixLength).Masked()
+	nodes, err := allocate(defaultNodes, used)
+	if err != nil {
+		return Ranges{}, fmt.Errorf("while proposing nodes range: %w", err)
+	}
+
+	ranges := Ranges{Nodes: nodes, Pods: pods, Services: services}
+	if err := Validate(ranges, reservedIPv4); err != nil {
+		return Ranges{}, fmt.Errorf("proposed ranges are not valid: %w", err)
+	}
+	return ranges, nil
+}
+
+// Validate checks that the ranges do not overlap each other, seed ranges and reserved ranges, all conflicts are reported
+func Validate(ranges Ranges, reserved []netip.Prefix) error {
+	var errs []error
+	named := []struct {
+		name  string
+		value netip.Prefix
+	}{{"nodes", ranges.Nodes}, {"pods", ranges.Pods}, {"services", ranges.Services}}
+
+	for i, r := range named {
+		if !r.value.Addr().Is4() {
+			errs = append(errs, fmt.Errorf("%s range %s must be an IPv4 range", r.name, r.value))
+			continue
+		}
+		for _, seed := range seedRanges() {
+			if r.value.Overlaps(seed) {
+				errs = append(errs, fmt.Errorf("%s range %s overlaps seed range %s", r.name, r.value, seed))
+			}
+		}
+		for _, res := range reserved {
+			if r.value.Overlaps(res) {
+				errs = append(errs, fmt.Errorf("%s range %s overlaps reserved range %s", r.name, r.value, res))
+			}
+		}
+		for _, other := range named[i+1:] {
+			if r.value.Overlaps(other.value) {
+				errs = append(errs, fmt.Errorf("%s range %s overlaps %s range %s", r.name, r.value, other.name, other.value))
+			}
+		}
+	}
+	if ranges.Nodes.Bits() > MaxNodesPrefixLength {
+		errs = append(errs, fmt.Errorf("the prefix length of the nodes range must not be greater than %d", MaxNodesPrefixLength))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateIPv6 checks that IPv6 ranges of a dual-stack cluster are global unicast or unique local ranges of valid sizes,
+// which do not overlap each other and reserved ranges, all conflicts are reported
+func ValidateIPv6(ranges IPv6Ranges, reserved []netip.Prefix) error {
+	var errs []error
+	var named []namedPrefix
+	for _, r := range []struct {
+		name      string
+		value     *netip.Prefix
+		valid     func(bits int) bool
+		sizeError string
+	}{
+		{"nodes", ranges.Nodes, func(bits int) bool { return bits <= maxIPv6NodesPrefixLength }, fmt.Sprintf("must not be greater than %d", maxIPv6NodesPrefixLength)},
+		{"pods", ranges.Pods, func(bits int) bool { return bits <= maxIPv6PodsPrefixLength }, fmt.Sprintf("must not be greater than %d", maxIPv6PodsPrefixLength)},
+		{"services", ranges.Services, func(bits int) bool { return bits >= minIPv6ServicesPrefixLength }, fmt.Sprintf("must not be less than %d", minIPv6ServicesPrefixLength)},
+	} {
+		if r.value == nil {
+			continue
+		}
+		prefix := *r.value
+		if !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+			errs = append(errs, fmt.Errorf("IPv6 %s range %s must be an IPv6 range", r.name, prefix))
+			continue
+		}
+		if prefix != prefix.Masked() {
+			errs = append(errs, fmt.Errorf("IPv6 %s range %s must be a canonical range", r.name, prefix))
+		}
+		if !prefix.Addr().IsGlobalUnicast() {
+			errs = append(errs, fmt.Errorf("IPv6 %s range %s must be a global unicast or unique local range", r.name, prefix))
+		}
+		if !r.valid(prefix.Bits()) {
+			errs = append(errs, fmt.Errorf("the prefix length of the IPv6 %s range %s", r.name, r.sizeError))
+		}
+		named = append(named, namedPrefix{name: r.name, value: prefix.Masked()})
+	}
+
+	for i, r := range named {
+		for _, res := range reserved {
+			if r.value.Overlaps(res) {
+				errs = append(errs, fmt.Errorf("IPv6 %s range %s overlaps reserved range %s", r.name, r.value, res))
+			}
+		}
+		for _, other := range named[i+1:] {
+			if r.value.Overlaps(other.value) {
+				errs = append(errs, fmt.Errorf("IPv6 %s range %s overlaps IPv6 %s range %s", r.name, r.value, other.name, other.value))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type namedPrefix struct {
+	name  string
+	value netip.Prefix
+}
+
+// allocate returns the preferred range if it is free, otherwise the first free range of the same size in private ranges
+func allocate(preferred netip.Prefix, used []netip.Prefix) (netip.Prefix, error) {
+	if !overlapsAny(preferred, used) {
+		return preferred, nil
+	}
+	size := uint64(1) << (32 - preferred.Bits())
+	for _, cidr := range privateIPv4Ranges {
+		private := netip.MustParsePrefix(cidr)
+		if private.Bits() > preferred.Bits() {
+			continue
+		}
+		start := ipv4ToUint(private.Addr())
+		end := start + uint64(1)<<(32-private.Bits())
+		for next := start; next < end; next += size {
+			candidate := netip.PrefixFrom(uintToIPv4(next), preferred.Bits())
+			if !overlapsAny(candidate, used) {
+				return candidate, nil
+			}
+		}
+	}
+	return netip.Prefix{}, fmt.Errorf("no free range with the prefix length %d", preferred.Bits())
+}
+
+func overlapsAny(prefix netip.Prefix, others []netip.Prefix) bool {
+	for _, other := range others {
+		if prefix.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+func seedRanges() []netip.Prefix {
+	seeds := make([]netip.Prefix, 0, len(GardenerSeedCIDRs))
+	for _, seed := range GardenerSeedCIDRs {
+		seeds = append(seeds, netip.MustParsePrefix(seed))
+	}
+	return seeds
+}
+
+func splitByFamily(prefixes []netip.Prefix) (ipv4, ipv6 []netip.Prefix) {
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is4() {
+			ipv4 = append(ipv4, prefix.Masked())
+		} else {
+			ipv6 = append(ipv6, prefix.Masked())
+		}
+	}
+	return ipv4, ipv6
+}
+
+func ipv4ToUint(addr netip.Addr) uint64 {
+	b := addr.As4()
+	return uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+}
+
+func uintToIPv4(value uint64) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
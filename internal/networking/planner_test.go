@@ -0,0 +1,172 @@
+package networking
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		request  Request
+		expected Ranges
+	}{
+		"default ranges": {
+			request:  Request{NodesPrefixLength: 16},
+			expected: fixRanges("10.250.0.0/16", "10.96.0.0/13", "10.104.0.0/13"),
+		},
+		"default ranges with smaller nodes range": {
+			request:  Request{NodesPrefixLength: 22},
+			expected: fixRanges("10.250.0.0/22", "10.96.0.0/13", "10.104.0.0/13"),
+		},
+		"pods range moved out of reserved range": {
+			request:  Request{NodesPrefixLength: 16, Reserved: fixPrefixes("10.96.0.0/16")},
+			expected: fixRanges("10.250.0.0/16", "10.0.0.0/13", "10.104.0.0/13"),
+		},
+		"nodes range moved out of reserved range": {
+			request:  Request{NodesPrefixLength: 16, Reserved: fixPrefixes("10.250.128.0/24")},
+			expected: fixRanges("10.0.0.0/16", "10.96.0.0/13", "10.104.0.0/13"),
+		},
+		"ranges outside of reserved 10.0.0.0/8": {
+			request:  Request{NodesPrefixLength: 23, Reserved: fixPrefixes("10.0.0.0/8")},
+			expected: fixRanges("192.168.0.0/23", "172.16.0.0/13", "172.24.0.0/13"),
+		},
+		"dual-stack with IPv6 ranges": {
+			request: Request{NodesPrefixLength: 16, DualStack: true, IPv6: IPv6Ranges{
+				Nodes:    fixPrefix("fd00:10::/64"),
+				Pods:     fixPrefix("fd00:20::/56"),
+				Services: fixPrefix("fd00:30::/112"),
+			}},
+			expected: fixRanges("10.250.0.0/16", "10.96.0.0/13", "10.104.0.0/13"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			ranges, err := Suggest(tc.request)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ranges)
+			assert.NoError(t, Validate(ranges, tc.request.Reserved))
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		request       Request
+		expectedError string
+	}{
+		"too large nodes range": {
+			request:       Request{NodesPrefixLength: 8},
+			expectedError: "the prefix length of the nodes range must be between 12 and 23",
+		},
+		"too small nodes range": {
+			request:       Request{NodesPrefixLength: 24},
+			expectedError: "the prefix length of the nodes range must be between 12 and 23",
+		},
+		"no free nodes range": {
+			request:       Request{NodesPrefixLength: 16, Reserved: fixPrefixes("10.0.0.0/8")},
+			expectedError: "while proposing nodes range: no free range with the prefix length 16",
+		},
+		"IPv6 ranges without dual-stack": {
+			request:       Request{NodesPrefixLength: 16, IPv6: IPv6Ranges{Pods: fixPrefix("fd00:20::/56")}},
+			expectedError: "IPv6 ranges require dual-stack networking",
+		},
+		"IPv6 reserved ranges without dual-stack": {
+			request:       Request{NodesPrefixLength: 16, Reserved: fixPrefixes("fd00::/48")},
+			expectedError: "IPv6 reserved ranges require dual-stack networking",
+		},
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// when
+			_, err := Suggest(tc.request)
+
+			// then
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should report all conflicts", func(t *testing.T) {
+		// given
+		ranges := fixRanges("10.242.0.0/24", "10.96.0.0/13", "10.100.0.0/16")
+
+		// when
+		err := Validate(ranges, fixPrefixes("10.96.0.0/16"))
+
+		// then
+		assert.EqualError(t, err, "nodes range 10.242.0.0/24 overlaps seed range 10.242.0.0/16\n"+
+			"pods range 10.96.0.0/13 overlaps reserved range 10.96.0.0/16\n"+
+			"pods range 10.96.0.0/13 overlaps services range 10.100.0.0/16\n"+
+			"the prefix length of the nodes range must not be greater than 23")
+	})
+}
+
+func TestValidateIPv6(t *testing.T) {
+	t.Run("should accept unset ranges", func(t *testing.T) {
+		// when
+		err := ValidateIPv6(IPv6Ranges{}, nil)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should report all conflicts", func(t *testing.T) {
+		// given
+		ranges := IPv6Ranges{
+			Nodes:    fixPrefix("fe80::/64"),
+			Pods:     fixPrefix("fd00:20::/64"),
+			Services: fixPrefix("fd00:20::1/112"),
+		}
+
+		// when
+		err := ValidateIPv6(ranges, fixPrefixes("fd00:20::/48"))
+
+		// then
+		assert.EqualError(t, err, "IPv6 nodes range fe80::/64 must be a global unicast or unique local range\n"+
+			"the prefix length of the IPv6 pods range must not be greater than 56\n"+
+			"IPv6 services range fd00:20::1/112 must be a canonical range\n"+
+			"IPv6 pods range fd00:20::/64 overlaps reserved range fd00:20::/48\n"+
+			"IPv6 pods range fd00:20::/64 overlaps IPv6 services range fd00:20::/112\n"+
+			"IPv6 services range fd00:20::/112 overlaps reserved range fd00:20::/48")
+	})
+
+	t.Run("should reject IPv4 ranges", func(t *testing.T) {
+		// when
+		err := ValidateIPv6(IPv6Ranges{Services: fixPrefix("10.104.0.0/13")}, nil)
+
+		// then
+		assert.EqualError(t, err, "IPv6 services range 10.104.0.0/13 must be an IPv6 range")
+	})
+
+	t.Run("should reject too large services range", func(t *testing.T) {
+		// when
+		err := ValidateIPv6(IPv6Ranges{Services: fixPrefix("fd00:30::/64")}, nil)
+
+		// then
+		assert.EqualError(t, err, "the prefix length of the IPv6 services range must not be less than 108")
+	})
+}
+
+func fixRanges(nodes, pods, services string) Ranges {
+	return Ranges{
+		Nodes:    netip.MustParsePrefix(nodes),
+		Pods:     netip.MustParsePrefix(pods),
+		Services: netip.MustParsePrefix(services),
+	}
+}
+
+func fixPrefix(cidr string) *netip.Prefix {
+	prefix := netip.MustParsePrefix(cidr)
+	return &prefix
+}
+
+func fixPrefixes(cidrs ...string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefixes = append(prefixes, netip.MustParsePrefix(cidr))
+	}
+	return prefixes
+}
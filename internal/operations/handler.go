@@ -0,0 +1,258 @@
+package operations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/suspension"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+)
+
+// maxUpdateAttempts limits retries when the operation is modified concurrently by a processing step
+const maxUpdateAttempts = 3
+
+type router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+type operationResponse struct {
+	OperationID string                    `json:"operation"`
+	State       domain.LastOperationState `json:"state"`
+}
+
+// RetryRequest is the optional body of the retry request
+type RetryRequest struct {
+	// Step is the name of the step the operation is resumed from, by default the operation is resumed from the first unfinished stage
+	Step string `json:"step,omitempty"`
+}
+
+// Resumer prepares a failed operation to be processed again
+type Resumer interface {
+	ResumeOperation(operation *internal.Operation, stepName string) error
+}
+
+type Handler interface {
+	AttachRoutes(r router)
+}
+
+type handler struct {
+	operations storage.Operations
+	actions    storage.Actions
+	steps      storage.OperationSteps
+	queues     map[internal.OperationType]suspension.Adder
+	resumers   map[internal.OperationType]Resumer
+	log        *slog.Logger
+}
+
+func NewHandler(operationsStorage storage.Operations, actionsStorage storage.Actions, stepsStorage storage.OperationSteps, provisioningQueue, updateQueue suspension.Adder, provisioningResumer, updateResumer Resumer, log *slog.Logger) Handler {
+	return &handler{
+		operations: operationsStorage,
+		actions:    actionsStorage,
+		steps:      stepsStorage,
+		queues: map[internal.OperationType]suspension.Adder{
+			internal.OperationTypeProvision: provisioningQueue,
+			internal.OperationTypeUpdate:    updateQueue,
+		},
+		resumers: map[internal.OperationType]Resumer{
+			internal.OperationTypeProvision: provisioningResumer,
+			internal.OperationTypeUpdate:    updateResumer,
+		},
+		log: log.With("service", "OperationsEndpoint"),
+	}
+}
+
+func (h *handler) AttachRoutes(r router) {
+	r.HandleFunc("POST /operations/{operation_id}/cancel", h.cancelOperation)
+	r.HandleFunc("POST /operations/{operation_id}/retry", h.retryOperation)
+	r.HandleFunc("GET /operations/{operation_id}/timeline", h.getTimeline)
+}
+
+func (h *handler) cancelOperation(w http.ResponseWriter, req *http.Request) {
+	operationID := req.PathValue("operation_id")
+
+	h.log.Info(fmt.Sprintf("Cancellation triggered for operationID: %s", operationID))
+	logger := h.log.With("operationID", operationID)
+
+	for attempt := 1; ; attempt++ {
+		operation, err := h.operations.GetOperationByID(operationID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("unable to get operation: %s", err.Error()))
+			switch {
+			case dberr.IsNotFound(err):
+				httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+			default:
+				httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		logger = logger.With("instanceID", operation.InstanceID)
+
+		queue, supported := h.queues[operation.Type]
+		if !supported {
+			msg := fmt.Sprintf("cancellation of %s operations is not supported", operation.Type)
+			logger.Warn(msg)
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.New(msg))
+			return
+		}
+
+		switch operation.State {
+		case internal.OperationStateCanceling, internal.OperationStateCanceled:
+			logger.Info(fmt.Sprintf("operation is already %s", operation.State))
+			httputil.WriteResponse(w, http.StatusAccepted, operationResponse{OperationID: operation.ID, State: operation.State})
+			return
+		case domain.InProgress:
+		default:
+			msg := fmt.Sprintf("operation in state %q cannot be canceled", operation.State)
+			logger.Warn(msg)
+			httputil.WriteErrorResponse(w, http.StatusConflict, errors.New(msg))
+			return
+		}
+
+		operation.State = internal.OperationStateCanceling
+		operation.Description = "Operation cancellation requested"
+		_, err = h.operations.UpdateOperation(*operation)
+		switch {
+		case dberr.IsConflict(err) && attempt < maxUpdateAttempts:
+			logger.Info("operation modified concurrently, retrying")
+			continue
+		case err != nil:
+			logger.Error(fmt.Sprintf("unable to mark the operation as canceling: %s", err.Error()))
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// the operation can wait in the queue for a retry, process it now to stop at the next step boundary
+		queue.Add(operation.ID)
+		logger.Info("operation marked as canceling")
+		httputil.WriteResponse(w, http.StatusAccepted, operationResponse{OperationID: operation.ID, State: internal.OperationStateCanceling})
+		return
+	}
+}
+
+func (h *handler) retryOperation(w http.ResponseWriter, req *http.Request) {
+	operationID := req.PathValue("operation_id")
+
+	h.log.Info(fmt.Sprintf("Retry triggered for operationID: %s", operationID))
+	logger := h.log.With("operationID", operationID)
+
+	var retryRequest RetryRequest
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(&retryRequest); err != nil {
+			logger.Warn(fmt.Sprintf("unable to decode request body: %s", err.Error()))
+			httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding request body: %w", err))
+			return
+		}
+	}
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to get operation: %s", err.Error()))
+		switch {
+		case dberr.IsNotFound(err):
+			httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	logger = logger.With("instanceID", operation.InstanceID)
+
+	resumer, supported := h.resumers[operation.Type]
+	if !supported {
+		msg := fmt.Sprintf("retry of %s operations is not supported", operation.Type)
+		logger.Warn(msg)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, errors.New(msg))
+		return
+	}
+	if operation.State != domain.Failed {
+		msg := fmt.Sprintf("operation in state %q cannot be retried", operation.State)
+		logger.Warn(msg)
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.New(msg))
+		return
+	}
+	lastOperation, err := h.operations.GetLastOperation(operation.InstanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to get the last operation of the instance: %s", err.Error()))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	if lastOperation.ID != operation.ID {
+		msg := fmt.Sprintf("operation cannot be retried, operation %s was created later for the instance", lastOperation.ID)
+		logger.Warn(msg)
+		httputil.WriteErrorResponse(w, http.StatusConflict, errors.New(msg))
+		return
+	}
+
+	if err := resumer.ResumeOperation(operation, retryRequest.Step); err != nil {
+		logger.Warn(fmt.Sprintf("unable to resume the operation: %s", err.Error()))
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	_, err = h.operations.UpdateOperation(*operation)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to update the resumed operation: %s", err.Error()))
+		switch {
+		case dberr.IsConflict(err):
+			httputil.WriteErrorResponse(w, http.StatusConflict, err)
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("%s operation %s retried", operation.Type, operation.ID)
+	if retryRequest.Step != "" {
+		message = fmt.Sprintf("%s from step %s", message, retryRequest.Step)
+	}
+	if err := h.actions.InsertAction(pkg.OperationRetryActionType, operation.InstanceID, message, string(domain.Failed), string(domain.InProgress)); err != nil {
+		logger.Error(fmt.Sprintf("while inserting action %q for instance ID %s: %v", pkg.OperationRetryActionType, operation.InstanceID, err))
+	}
+
+	h.queues[operation.Type].Add(operation.ID)
+	logger.Info(message)
+	httputil.WriteResponse(w, http.StatusAccepted, operationResponse{OperationID: operation.ID, State: domain.InProgress})
+}
+
+func (h *handler) getTimeline(w http.ResponseWriter, req *http.Request) {
+	operationID := req.PathValue("operation_id")
+	logger := h.log.With("operationID", operationID)
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to get operation: %s", err.Error()))
+		switch {
+		case dberr.IsNotFound(err):
+			httputil.WriteErrorResponse(w, http.StatusNotFound, err)
+		default:
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	steps, err := h.steps.ListByOperationID(operationID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to list operation steps: %s", err.Error()))
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, pkg.OperationTimeline{
+		OperationID:     operation.ID,
+		InstanceID:      operation.InstanceID,
+		Type:            string(operation.Type),
+		State:           string(operation.State),
+		CreatedAt:       operation.CreatedAt,
+		UpdatedAt:       operation.UpdatedAt,
+		DurationSeconds: operation.UpdatedAt.Sub(operation.CreatedAt).Seconds(),
+		Steps:           runtime.NewOperationStepDTOs(steps),
+	})
+}
@@ -0,0 +1,305 @@
+package operations_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/kyma-environment-broker/internal/operations"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	cancelPathFormat   = "/operations/%s/cancel"
+	retryPathFormat    = "/operations/%s/retry"
+	timelinePathFormat = "/operations/%s/timeline"
+)
+
+func TestCancelOperation(t *testing.T) {
+	router := httputil.NewRouter()
+	provisioningQueue := &queueRecorder{}
+	updateQueue := &queueRecorder{}
+	storage := storage.NewMemoryStorage()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	handler := operations.NewHandler(storage.Operations(), storage.Actions(), storage.OperationSteps(), provisioningQueue, updateQueue, &resumerStub{}, &resumerStub{}, logger)
+	handler.AttachRoutes(router)
+
+	t.Run("should receive 404 Not Found response", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(cancelPathFormat, "op-404-not-found"), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("should mark in progress provisioning operation as canceling", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-provisioning", "inst-1")
+		operation.State = domain.InProgress
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(cancelPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+		actual, err := storage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LastOperationState(internal.OperationStateCanceling), actual.State)
+		assert.Equal(t, []string{operation.ID}, provisioningQueue.added)
+	})
+
+	t.Run("should accept cancellation of already canceling operation", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-canceling", "inst-2")
+		operation.State = internal.OperationStateCanceling
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(cancelPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+	})
+
+	t.Run("should receive 409 Conflict response when operation is finished", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-succeeded", "inst-3")
+		operation.State = domain.Succeeded
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(cancelPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("should receive 400 Bad Request response for deprovisioning operation", func(t *testing.T) {
+		// given
+		operation := fixture.FixDeprovisioningOperation("op-deprovisioning", "inst-4")
+		operation.State = domain.InProgress
+		require.NoError(t, storage.Operations().InsertDeprovisioningOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(cancelPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestRetryOperation(t *testing.T) {
+	router := httputil.NewRouter()
+	provisioningQueue := &queueRecorder{}
+	updateQueue := &queueRecorder{}
+	provisioningResumer := &resumerStub{}
+	storage := storage.NewMemoryStorage()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	handler := operations.NewHandler(storage.Operations(), storage.Actions(), storage.OperationSteps(), provisioningQueue, updateQueue, provisioningResumer, &resumerStub{}, logger)
+	handler.AttachRoutes(router)
+
+	t.Run("should receive 404 Not Found response", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(retryPathFormat, "op-404-not-found"), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("should resume failed provisioning operation from the given step", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-provisioning-failed", "inst-1")
+		operation.State = domain.Failed
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(retryPathFormat, operation.ID), strings.NewReader(`{"step": "Create_Runtime_Resource"}`))
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusAccepted, w.Result().StatusCode)
+		assert.Equal(t, "Create_Runtime_Resource", provisioningResumer.step)
+		assert.Equal(t, []string{operation.ID}, provisioningQueue.added)
+		actual, err := storage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, actual.State)
+
+		actions, err := storage.Actions().ListActionsByInstanceID("inst-1")
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, pkg.OperationRetryActionType, actions[0].Type)
+	})
+
+	t.Run("should receive 409 Conflict response when operation is in progress", func(t *testing.T) {
+		// given
+		operation := fixture.FixProvisioningOperation("op-in-progress", "inst-2")
+		operation.State = domain.InProgress
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(retryPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("should receive 409 Conflict response when a newer operation exists", func(t *testing.T) {
+		// given
+		provisioning := fixture.FixProvisioningOperation("op-provisioning-outdated", "inst-3")
+		provisioning.State = domain.Failed
+		provisioning.CreatedAt = time.Now().Add(-time.Hour)
+		require.NoError(t, storage.Operations().InsertOperation(provisioning))
+		update := fixture.FixUpdatingOperation("op-update-newer", "inst-3")
+		update.State = domain.Succeeded
+		require.NoError(t, storage.Operations().InsertUpdatingOperation(update))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(retryPathFormat, provisioning.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+
+	t.Run("should receive 400 Bad Request response for deprovisioning operation", func(t *testing.T) {
+		// given
+		operation := fixture.FixDeprovisioningOperation("op-deprovisioning-failed", "inst-4")
+		operation.State = domain.Failed
+		require.NoError(t, storage.Operations().InsertDeprovisioningOperation(operation))
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf(retryPathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestOperationTimeline(t *testing.T) {
+	router := httputil.NewRouter()
+	storage := storage.NewMemoryStorage()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+	handler := operations.NewHandler(storage.Operations(), storage.Actions(), storage.OperationSteps(), &queueRecorder{}, &queueRecorder{}, &resumerStub{}, &resumerStub{}, logger)
+	handler.AttachRoutes(router)
+
+	t.Run("should receive 404 Not Found response", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf(timelinePathFormat, "op-404-not-found"), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("should return steps of the operation in the order they were started", func(t *testing.T) {
+		// given
+		start := time.Now().Add(-time.Hour).Truncate(time.Second)
+		operation := fixture.FixProvisioningOperation("op-timeline", "inst-timeline")
+		operation.CreatedAt = start
+		operation.UpdatedAt = start.Add(10 * time.Minute)
+		require.NoError(t, storage.Operations().InsertOperation(operation))
+		finishedAt := start.Add(time.Minute)
+		require.NoError(t, storage.OperationSteps().Upsert(internal.OperationStep{
+			OperationID: operation.ID,
+			InstanceID:  operation.InstanceID,
+			Stage:       "create_runtime",
+			StepName:    "Check_RuntimeResource_Provisioning",
+			State:       internal.OperationStepStateRetrying,
+			StartedAt:   start.Add(2 * time.Minute),
+			UpdatedAt:   start.Add(5 * time.Minute),
+		}))
+		require.NoError(t, storage.OperationSteps().Upsert(internal.OperationStep{
+			OperationID: operation.ID,
+			InstanceID:  operation.InstanceID,
+			Stage:       "start",
+			StepName:    "Start",
+			State:       internal.OperationStepStateSucceeded,
+			StartedAt:   start,
+			FinishedAt:  &finishedAt,
+			UpdatedAt:   finishedAt,
+		}))
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf(timelinePathFormat, operation.ID), nil)
+		w := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(w, req)
+
+		// then
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+		var timeline pkg.OperationTimeline
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&timeline))
+		assert.Equal(t, operation.ID, timeline.OperationID)
+		assert.Equal(t, "inst-timeline", timeline.InstanceID)
+		assert.Equal(t, float64(600), timeline.DurationSeconds)
+		require.Len(t, timeline.Steps, 2)
+		assert.Equal(t, "Start", timeline.Steps[0].StepName)
+		assert.Equal(t, float64(60), timeline.Steps[0].DurationSeconds)
+		assert.Equal(t, "Check_RuntimeResource_Provisioning", timeline.Steps[1].StepName)
+		assert.Equal(t, string(internal.OperationStepStateRetrying), timeline.Steps[1].State)
+		assert.Nil(t, timeline.Steps[1].FinishedAt)
+		assert.Equal(t, float64(180), timeline.Steps[1].DurationSeconds)
+	})
+}
+
+type resumerStub struct {
+	step string
+}
+
+func (r *resumerStub) ResumeOperation(operation *internal.Operation, stepName string) error {
+	r.step = stepName
+	operation.State = domain.InProgress
+	return nil
+}
+
+type queueRecorder struct {
+	added []string
+}
+
+func (q *queueRecorder) Add(operationID string) {
+	q.added = append(q.added, operationID)
+}
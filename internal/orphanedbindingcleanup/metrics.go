@@ -0,0 +1,40 @@
+package orphanedbindingcleanup
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	resultScanned = "scanned"
+	resultFound   = "found"
+	resultDeleted = "deleted"
+	resultFailed  = "failed"
+)
+
+type Metrics struct {
+	runtimes *prometheus.CounterVec
+	objects  *prometheus.CounterVec
+}
+
+func NewMetrics(reg prometheus.Registerer, namespace string) *Metrics {
+	m := &Metrics{
+		runtimes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orphaned_binding_cleanup_runtimes_total",
+			Help:      "Runtimes processed by the orphaned binding cleanup.",
+		}, []string{"result"}),
+		objects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orphaned_binding_cleanup_objects_total",
+			Help:      "Orphaned binding objects found and removed by the orphaned binding cleanup.",
+		}, []string{"kind", "result"}),
+	}
+	reg.MustRegister(m.runtimes, m.objects)
+	return m
+}
+
+func (m *Metrics) runtimeProcessed(result string) {
+	m.runtimes.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) objectProcessed(kind, result string) {
+	m.objects.WithLabelValues(kind, result).Inc()
+}
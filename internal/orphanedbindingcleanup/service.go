@@ -0,0 +1,274 @@
+package orphanedbindingcleanup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	broker "github.com/kyma-project/kyma-environment-broker/internal/broker/bindings"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	managedByLabelSelector = "app.kubernetes.io/managed-by=kcp-kyma-environment-broker"
+	instancesPageSize      = 100
+
+	kindServiceAccount     = "ServiceAccount"
+	kindClusterRole        = "ClusterRole"
+	kindClusterRoleBinding = "ClusterRoleBinding"
+	kindRole               = "Role"
+	kindRoleBinding        = "RoleBinding"
+
+	kindCertificateSigningRequest = "CertificateSigningRequest"
+)
+
+type ClientProvider interface {
+	K8sClientSetForRuntimeID(runtimeID string) (kubernetes.Interface, error)
+}
+
+// resourceKind lists and deletes runtime objects of one kind, bindings are removed before roles, service accounts and certificate signing requests
+type resourceKind struct {
+	name   string
+	list   func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error)
+	delete func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error
+}
+
+var resourceKinds = []resourceKind{
+	{
+		name: kindRoleBinding,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.RbacV1().RoleBindings("").List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.RbacV1().RoleBindings(object.Namespace).Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+	{
+		name: kindClusterRoleBinding,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.RbacV1().ClusterRoleBindings().Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+	{
+		name: kindRole,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.RbacV1().Roles("").List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.RbacV1().Roles(object.Namespace).Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+	{
+		name: kindClusterRole,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.RbacV1().ClusterRoles().List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.RbacV1().ClusterRoles().Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+	{
+		name: kindServiceAccount,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.CoreV1().ServiceAccounts("").List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.CoreV1().ServiceAccounts(object.Namespace).Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+	{
+		name: kindCertificateSigningRequest,
+		list: func(ctx context.Context, clientset kubernetes.Interface) ([]mv1.ObjectMeta, error) {
+			list, err := clientset.CertificatesV1().CertificateSigningRequests().List(ctx, listOptions())
+			if err != nil {
+				return nil, err
+			}
+			objects := make([]mv1.ObjectMeta, 0, len(list.Items))
+			for _, item := range list.Items {
+				objects = append(objects, item.ObjectMeta)
+			}
+			return objects, nil
+		},
+		delete: func(ctx context.Context, clientset kubernetes.Interface, object mv1.ObjectMeta) error {
+			return clientset.CertificatesV1().CertificateSigningRequests().Delete(ctx, object.Name, mv1.DeleteOptions{})
+		},
+	},
+}
+
+func listOptions() mv1.ListOptions {
+	return mv1.ListOptions{LabelSelector: managedByLabelSelector}
+}
+
+type Service struct {
+	dryRun           bool
+	minAge           time.Duration
+	plans            []string
+	instancesStorage storage.Instances
+	bindingsStorage  storage.Bindings
+	clientProvider   ClientProvider
+	metrics          *Metrics
+	log              *slog.Logger
+}
+
+func NewService(dryRun bool, minAge time.Duration, plans []string, db storage.BrokerStorage, clientProvider ClientProvider, metrics *Metrics, log *slog.Logger) *Service {
+	return &Service{
+		dryRun:           dryRun,
+		minAge:           minAge,
+		plans:            plans,
+		instancesStorage: db.Instances(),
+		bindingsStorage:  db.Bindings(),
+		clientProvider:   clientProvider,
+		metrics:          metrics,
+		log:              log,
+	}
+}
+
+// PerformCleanup removes binding objects from runtimes of instances with bindable plans which have no binding in the
+// database. Unreachable runtimes are logged and skipped, an error is returned at the end if any object was not removed.
+func (s *Service) PerformCleanup(ctx context.Context) error {
+	failed := 0
+	for page := 1; ; page++ {
+		instances, count, totalCount, err := s.instancesStorage.List(dbmodel.InstanceFilter{Plans: s.plans, Page: page, PageSize: instancesPageSize})
+		if err != nil {
+			return fmt.Errorf("while listing instances: %w", err)
+		}
+		for _, instance := range instances {
+			failed += s.cleanupRuntime(ctx, instance)
+		}
+		if count == 0 || (page-1)*instancesPageSize+count >= totalCount {
+			break
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to remove %d orphaned binding objects", failed)
+	}
+	return nil
+}
+
+func (s *Service) cleanupRuntime(ctx context.Context, instance internal.Instance) int {
+	if instance.RuntimeID == "" {
+		return 0
+	}
+	log := s.log.With("instanceID", instance.InstanceID, "runtimeID", instance.RuntimeID)
+
+	clientset, err := s.clientProvider.K8sClientSetForRuntimeID(instance.RuntimeID)
+	if kubeconfig.IsNotFound(err) {
+		log.Info("kubeconfig of the runtime does not exist, skipping")
+		return 0
+	}
+	if err != nil {
+		log.Warn(fmt.Sprintf("unable to create a runtime client: %s", err))
+		s.metrics.runtimeProcessed(resultFailed)
+		return 0
+	}
+
+	bindings, err := s.bindingsStorage.ListByInstanceID(instance.InstanceID)
+	if err != nil {
+		log.Warn(fmt.Sprintf("unable to list bindings of the instance: %s", err))
+		s.metrics.runtimeProcessed(resultFailed)
+		return 0
+	}
+	bindingIDs := make(map[string]struct{}, len(bindings))
+	for _, binding := range bindings {
+		bindingIDs[binding.ID] = struct{}{}
+	}
+
+	failed := 0
+	for _, kind := range resourceKinds {
+		objects, err := kind.list(ctx, clientset)
+		if err != nil {
+			log.Warn(fmt.Sprintf("unable to list %s objects: %s", kind.name, err))
+			s.metrics.runtimeProcessed(resultFailed)
+			return failed
+		}
+		for _, object := range objects {
+			if !s.isOrphaned(object, bindingIDs) {
+				continue
+			}
+			s.metrics.objectProcessed(kind.name, resultFound)
+			if s.dryRun {
+				log.Info(fmt.Sprintf("dry run: orphaned %s %s would be removed", kind.name, objectKey(object)))
+				continue
+			}
+			if err := kind.delete(ctx, clientset, object); err != nil {
+				log.Error(fmt.Sprintf("unable to remove orphaned %s %s: %s", kind.name, objectKey(object), err))
+				s.metrics.objectProcessed(kind.name, resultFailed)
+				failed++
+				continue
+			}
+			log.Info(fmt.Sprintf("orphaned %s %s removed", kind.name, objectKey(object)))
+			s.metrics.objectProcessed(kind.name, resultDeleted)
+		}
+	}
+	s.metrics.runtimeProcessed(resultScanned)
+	return failed
+}
+
+// isOrphaned checks if the object belongs to a binding which does not exist in the database. Recently created objects
+// are skipped, because their binding can still be in creation.
+func (s *Service) isOrphaned(object mv1.ObjectMeta, bindingIDs map[string]struct{}) bool {
+	bindingID, ok := broker.BindingIDFromName(object.Name)
+	if !ok {
+		return false
+	}
+	if _, exists := bindingIDs[bindingID]; exists {
+		return false
+	}
+	return object.CreationTimestamp.Time.Before(time.Now().Add(-s.minAge))
+}
+
+func objectKey(object mv1.ObjectMeta) string {
+	if object.Namespace == "" {
+		return object.Name
+	}
+	return fmt.Sprintf("%s/%s", object.Namespace, object.Name)
+}
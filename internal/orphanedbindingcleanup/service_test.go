@@ -0,0 +1,150 @@
+package orphanedbindingcleanup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/kubeconfig"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	instanceID = "instance-id"
+	runtimeID  = "runtime-id"
+)
+
+type fakeClientProvider struct {
+	clientsets map[string]kubernetes.Interface
+}
+
+func (p *fakeClientProvider) K8sClientSetForRuntimeID(runtimeID string) (kubernetes.Interface, error) {
+	clientset, found := p.clientsets[runtimeID]
+	if !found {
+		return nil, kubeconfig.NewNotFoundError(fmt.Sprintf("secret not found for runtime id %s", runtimeID))
+	}
+	return clientset, nil
+}
+
+func TestService_PerformCleanup(t *testing.T) {
+	t.Run("should remove objects of bindings which do not exist", func(t *testing.T) {
+		// given
+		clientset := fixRuntimeObjects()
+		svc, metrics := fixService(t, false, clientset)
+
+		// when
+		err := svc.PerformCleanup(context.Background())
+
+		// then
+		require.NoError(t, err)
+		ctx := context.Background()
+		_, err = clientset.CoreV1().ServiceAccounts("kyma-system").Get(ctx, "kyma-binding-orphaned", mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = clientset.RbacV1().ClusterRoles().Get(ctx, "kyma-binding-orphaned", mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = clientset.RbacV1().ClusterRoleBindings().Get(ctx, "kyma-binding-orphaned", mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = clientset.RbacV1().RoleBindings("default").Get(ctx, "kyma-binding-orphaned", mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, "kyma-binding-orphaned-certificate", mv1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, "kyma-binding-existing", mv1.GetOptions{})
+		assert.NoError(t, err)
+
+		_, err = clientset.CoreV1().ServiceAccounts("kyma-system").Get(ctx, "kyma-binding-existing", mv1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = clientset.CoreV1().ServiceAccounts("kyma-system").Get(ctx, "kyma-binding-recent", mv1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = clientset.CoreV1().ServiceAccounts("kyma-system").Get(ctx, "not-a-binding", mv1.GetOptions{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.objects.WithLabelValues(kindServiceAccount, resultDeleted)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.objects.WithLabelValues(kindRoleBinding, resultDeleted)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.objects.WithLabelValues(kindCertificateSigningRequest, resultDeleted)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.runtimes.WithLabelValues(resultScanned)))
+	})
+
+	t.Run("should only report orphaned objects in dry run mode", func(t *testing.T) {
+		// given
+		clientset := fixRuntimeObjects()
+		svc, metrics := fixService(t, true, clientset)
+
+		// when
+		err := svc.PerformCleanup(context.Background())
+
+		// then
+		require.NoError(t, err)
+		_, err = clientset.CoreV1().ServiceAccounts("kyma-system").Get(context.Background(), "kyma-binding-orphaned", mv1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.objects.WithLabelValues(kindServiceAccount, resultFound)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.objects.WithLabelValues(kindServiceAccount, resultDeleted)))
+	})
+
+	t.Run("should skip runtimes without kubeconfig", func(t *testing.T) {
+		// given
+		svc, metrics := fixService(t, false, nil)
+
+		// when
+		err := svc.PerformCleanup(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.runtimes.WithLabelValues(resultScanned)))
+	})
+}
+
+func fixService(t *testing.T, dryRun bool, clientset kubernetes.Interface) (*Service, *Metrics) {
+	db := storage.NewMemoryStorage()
+	require.NoError(t, db.Instances().Insert(internal.Instance{InstanceID: instanceID, RuntimeID: runtimeID, ServicePlanName: "aws"}))
+	require.NoError(t, db.Instances().Insert(internal.Instance{InstanceID: "not-bindable-instance-id", RuntimeID: "other-runtime-id", ServicePlanName: "trial"}))
+	require.NoError(t, db.Bindings().Insert(&internal.Binding{ID: "existing", InstanceID: instanceID}))
+
+	provider := &fakeClientProvider{clientsets: map[string]kubernetes.Interface{}}
+	if clientset != nil {
+		provider.clientsets[runtimeID] = clientset
+	}
+	metrics := NewMetrics(prometheus.NewRegistry(), "kcp_keb")
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return NewService(dryRun, time.Hour, []string{"aws"}, db, provider, metrics, log), metrics
+}
+
+func fixRuntimeObjects() *fake.Clientset {
+	old := mv1.NewTime(time.Now().Add(-2 * time.Hour))
+	recent := mv1.NewTime(time.Now())
+	meta := func(name, namespace string, created mv1.Time) mv1.ObjectMeta {
+		return mv1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: created,
+			Labels:            map[string]string{"app.kubernetes.io/managed-by": "kcp-kyma-environment-broker"},
+		}
+	}
+	objects := []runtime.Object{
+		&v1.ServiceAccount{ObjectMeta: meta("kyma-binding-orphaned", "kyma-system", old)},
+		&rbacv1.ClusterRole{ObjectMeta: meta("kyma-binding-orphaned", "", old)},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: meta("kyma-binding-orphaned", "", old)},
+		&rbacv1.RoleBinding{ObjectMeta: meta("kyma-binding-orphaned", "default", old)},
+		&v1.ServiceAccount{ObjectMeta: meta("kyma-binding-existing", "kyma-system", old)},
+		&v1.ServiceAccount{ObjectMeta: meta("kyma-binding-recent", "kyma-system", recent)},
+		&v1.ServiceAccount{ObjectMeta: meta("not-a-binding", "kyma-system", old)},
+		&certificatesv1.CertificateSigningRequest{ObjectMeta: meta("kyma-binding-orphaned-certificate", "", old)},
+		&certificatesv1.CertificateSigningRequest{ObjectMeta: meta("kyma-binding-existing", "", old)},
+	}
+	return fake.NewSimpleClientset(objects...)
+}
@@ -19,15 +19,15 @@ import (
 )
 
 type CheckRuntimeResourceDeletionStep struct {
-	operationManager                        *process.OperationManager
-	kcpClient                               client.Client
-	checkRuntimeResourceDeletionStepTimeout time.Duration
+	operationManager *process.OperationManager
+	kcpClient        client.Client
 }
 
-func NewCheckRuntimeResourceDeletionStep(db storage.BrokerStorage, kcpClient client.Client, checkRuntimeResourceDeletionStepTimeout time.Duration) *CheckRuntimeResourceDeletionStep {
+// NewCheckRuntimeResourceDeletionStep returns the step waiting for the Runtime resource removal, the retry interval and
+// the timeout are declared in the step policy
+func NewCheckRuntimeResourceDeletionStep(db storage.BrokerStorage, kcpClient client.Client) *CheckRuntimeResourceDeletionStep {
 	step := &CheckRuntimeResourceDeletionStep{
-		kcpClient:                               kcpClient,
-		checkRuntimeResourceDeletionStepTimeout: checkRuntimeResourceDeletionStepTimeout,
+		kcpClient: kcpClient,
 	}
 	step.operationManager = process.NewOperationManager(db.Operations(), step.Name(), kebError.InfrastructureManagerDependency)
 	return step
@@ -67,7 +67,7 @@ func (step *CheckRuntimeResourceDeletionStep) Run(operation internal.Operation,
 
 	if err == nil {
 		logger.Info("Runtime resource still exists")
-		return step.operationManager.RetryOperation(operation, "Runtime resource still exists", nil, 20*time.Second, step.checkRuntimeResourceDeletionStepTimeout, logger)
+		return step.operationManager.RetryStep(operation, "Runtime resource still exists", nil, logger)
 	}
 
 	if !errors.IsNotFound(err) {
@@ -77,7 +77,7 @@ func (step *CheckRuntimeResourceDeletionStep) Run(operation internal.Operation,
 		}
 
 		logger.Warn(fmt.Sprintf("unable to check Runtime resource existence: %s", err))
-		return step.operationManager.RetryOperation(operation, "unable to check Runtime resource existence", err, backoffForK8SOperation, timeoutForK8sOperation, logger)
+		return step.operationManager.RetryStep(operation, "unable to check Runtime resource existence", err, logger)
 	}
 
 	return step.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
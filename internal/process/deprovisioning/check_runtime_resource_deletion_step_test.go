@@ -2,7 +2,6 @@ package deprovisioning
 
 import (
 	"testing"
-	"time"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
@@ -26,7 +25,7 @@ func TestCheckRuntimeResourceDeletionStep_ResourceNotExists(t *testing.T) {
 	kcpClient := fake.NewClientBuilder().Build()
 
 	// when
-	step := NewCheckRuntimeResourceDeletionStep(memoryStorage, kcpClient, time.Minute)
+	step := NewCheckRuntimeResourceDeletionStep(memoryStorage, kcpClient)
 	_, backoff, err := step.Run(op, fixLogger())
 
 	// then
@@ -46,7 +45,7 @@ func TestCheckRuntimeResourceDeletionStep_Run(t *testing.T) {
 	kcpClient := fake.NewClientBuilder().WithRuntimeObjects(fixRuntimeResource(kymaNamespace, "runtime-name")).Build()
 
 	// when
-	step := NewCheckRuntimeResourceDeletionStep(memoryStorage, kcpClient, time.Minute)
+	step := NewCheckRuntimeResourceDeletionStep(memoryStorage, kcpClient)
 	_, backoff, err := step.Run(op, fixLogger())
 
 	// then
@@ -57,7 +57,7 @@ func (s *FreeCredentialsBindingStep) Run(operation internal.Operation, logger *s
 	credentialsBindingName, err := s.findCredentialsBindingName(operation, logger)
 	if err != nil {
 		logger.Info(fmt.Sprintf("Failed to find the subscription secret name: %s", err.Error()))
-		return s.operationManager.RetryOperation(operation, "finding the subscription secret name", err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, "finding the subscription secret name", err, logger)
 	}
 	if credentialsBindingName == "" {
 		logger.Info("Subscription not assigned, nothing to release")
@@ -66,7 +66,7 @@ func (s *FreeCredentialsBindingStep) Run(operation internal.Operation, logger *s
 	credentialsBinding, err := s.gardenerClient.Resource(gardener.CredentialsBindingResource).Namespace(s.gardenerNS).Get(context.Background(), credentialsBindingName, metav1.GetOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("getting secret binding %s in namespace %s", credentialsBindingName, s.gardenerNS)
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 
 	// check if shared
@@ -90,7 +90,7 @@ func (s *FreeCredentialsBindingStep) Run(operation internal.Operation, logger *s
 	shootlist, err := s.gardenerClient.Resource(gardener.ShootResource).Namespace(s.gardenerNS).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("listing Gardener shoots in namespace %s", s.gardenerNS)
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 
 	for _, shoot := range shootlist.Items {
@@ -113,7 +113,7 @@ func (s *FreeCredentialsBindingStep) Run(operation internal.Operation, logger *s
 	_, err = s.gardenerClient.Resource(gardener.CredentialsBindingResource).Namespace(s.gardenerNS).Update(context.Background(), credentialsBinding, metav1.UpdateOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("marking secret binding %s as dirty failed: %s", credentialsBinding.GetName(), err.Error())
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 	logger.Info(fmt.Sprintf("Subscription released, credentialsBindingName binding name: %s", credentialsBinding.GetName()))
 
@@ -53,7 +53,7 @@ func (s *FreeSubscriptionStep) Run(operation internal.Operation, logger *slog.Lo
 	secretBindingName, err := s.findSecretBindingName(operation, logger)
 	if err != nil {
 		logger.Info(fmt.Sprintf("Failed to find the subscription secret name: %s", err.Error()))
-		return s.operationManager.RetryOperation(operation, "finding the subscription secret name", err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, "finding the subscription secret name", err, logger)
 	}
 	if secretBindingName == "" {
 		logger.Info("Subscription not assigned, nothing to release")
@@ -62,7 +62,7 @@ func (s *FreeSubscriptionStep) Run(operation internal.Operation, logger *slog.Lo
 	secretBinding, err := s.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(s.gardenerNS).Get(context.Background(), secretBindingName, metav1.GetOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("getting secret binding %s in namespace %s", secretBindingName, s.gardenerNS)
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 
 	// check if shared
@@ -86,7 +86,7 @@ func (s *FreeSubscriptionStep) Run(operation internal.Operation, logger *slog.Lo
 	shootlist, err := s.gardenerClient.Resource(gardener.ShootResource).Namespace(s.gardenerNS).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("listing Gardener shoots in namespace %s", s.gardenerNS)
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 
 	for _, shoot := range shootlist.Items {
@@ -108,7 +108,7 @@ func (s *FreeSubscriptionStep) Run(operation internal.Operation, logger *slog.Lo
 	_, err = s.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(s.gardenerNS).Update(context.Background(), secretBinding, metav1.UpdateOptions{})
 	if err != nil {
 		msg := fmt.Sprintf("marking secret binding %s as dirty failed: %s", secretBinding.GetName(), err.Error())
-		return s.operationManager.RetryOperation(operation, msg, err, 10*time.Second, time.Minute, logger)
+		return s.operationManager.RetryStep(operation, msg, err, logger)
 	}
 	logger.Info(fmt.Sprintf("Subscription released, secret binding name: %s", secretBinding.GetName()))
 
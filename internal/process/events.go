@@ -11,6 +11,9 @@ type StepProcessed struct {
 	Duration time.Duration
 	When     time.Duration
 	Error    error
+	// Policy is the effective retry policy of the step, Attempt is the number of step runs counted by the policy
+	Policy  StepPolicy
+	Attempt int
 }
 
 type ProvisioningStepProcessed struct {
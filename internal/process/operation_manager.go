@@ -16,6 +16,9 @@ import (
 const (
 	timeStampGCInterval = time.Hour
 	timeStampTTL        = 48 * time.Hour
+
+	// DefaultStepRetryInterval is the retry interval of steps retried with RetryStep which do not declare a policy interval
+	DefaultStepRetryInterval = 10 * time.Second
 )
 
 type OperationManager struct {
@@ -110,7 +113,7 @@ func (om *OperationManager) RetryOperation(operation internal.Operation, errorMe
 	log.Info(fmt.Sprintf("Retry Operation was called with message: %s", errorMessage))
 
 	log.Debug(fmt.Sprintf("Retry Operation map size is: %d", len(om.retryTimestamps)))
-	om.storeTimestampIfMissing(operation.ID)
+	om.storeTimestampIfMissing(operation.ID, operation.ResumedAt)
 	if !om.isTimeoutOccurred(operation.ID, maxTime) {
 		remainingTime := om.getRemainingTime(operation.ID, maxTime)
 		log.Info(fmt.Sprintf("Retrying for %s in %s intervals %d minutes left", maxTime.String(), retryInterval.String(), int(remainingTime.Round(time.Second).Minutes())))
@@ -152,6 +155,25 @@ func (om *OperationManager) RetryOperationWithCreatedAt(operation internal.Opera
 	return op, retry, err
 }
 
+// RetryStep returns the default retry interval of the step, the interval and the timeout after which the operation fails
+// are declared in the step policy, see StepPolicy. The cause is kept with the step retries, the operation fails with it
+// when the policy is exhausted.
+func (om *OperationManager) RetryStep(operation internal.Operation, errorMessage string, err error, log *slog.Logger) (internal.Operation, time.Duration, error) {
+	cause := kebErr.LastError{
+		Reason:    kebErr.Reason(errorMessage),
+		Component: om.component,
+		Step:      om.step,
+	}
+	if err != nil {
+		cause.Message = err.Error()
+		log.Info(fmt.Sprintf("Retrying the step: %s: %s", errorMessage, err))
+	} else {
+		log.Info(fmt.Sprintf("Retrying the step: %s", errorMessage))
+	}
+	recordRetryCause(&operation, om.step, cause)
+	return operation, DefaultStepRetryInterval, nil
+}
+
 // RetryOperationWithoutFail checks if operation should be retried or updates the status to InProgress, but omits setting the operation to failed if maxTime is reached
 func (om *OperationManager) RetryOperationWithoutFail(operation internal.Operation, stepName string, description string, retryInterval, maxTime time.Duration, log *slog.Logger, opErr error) (internal.Operation, time.Duration, error) {
 
@@ -160,7 +182,7 @@ func (om *OperationManager) RetryOperationWithoutFail(operation internal.Operati
 	}
 
 	log.Info(fmt.Sprintf("retrying for %s in %s steps", maxTime.String(), retryInterval.String()))
-	om.storeTimestampIfMissing(operation.ID)
+	om.storeTimestampIfMissing(operation.ID, operation.ResumedAt)
 	if !om.isTimeoutOccurred(operation.ID, maxTime) {
 		return operation, retryInterval, nil
 	}
@@ -238,10 +260,11 @@ func (om *OperationManager) update(operation internal.Operation, state domain.La
 	}, log)
 }
 
-func (om *OperationManager) storeTimestampIfMissing(id string) {
+// storeTimestampIfMissing stores the time of the first retry, the timestamp stored before the operation was resumed is replaced
+func (om *OperationManager) storeTimestampIfMissing(id string, resumedAt *time.Time) {
 	om.mu.Lock()
 	defer om.mu.Unlock()
-	if om.retryTimestamps[id].IsZero() {
+	if om.retryTimestamps[id].IsZero() || (resumedAt != nil && resumedAt.After(om.retryTimestamps[id])) {
 		om.retryTimestamps[id] = time.Now()
 	}
 }
@@ -0,0 +1,213 @@
+package process
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+type QueueConfig struct {
+	// Persistent enables keeping scheduled operations in the database instead of memory
+	Persistent bool `envconfig:"default=false"`
+	// PollInterval is the time a worker waits before checking the storage for due items again
+	PollInterval time.Duration `envconfig:"default=1s"`
+	// LeaseDuration is the time after which an item claimed by a replica can be taken over by another one,
+	// the lease is renewed while the item is processed
+	LeaseDuration time.Duration `envconfig:"default=5m"`
+}
+
+func (c QueueConfig) String() string {
+	return fmt.Sprintf("(Persistent=%t; PollInterval=%s; LeaseDuration=%s)", c.Persistent, c.PollInterval, c.LeaseDuration)
+}
+
+// persistentQueue is a queueBackend which stores operation IDs and their next run time in the storage.
+// Workers claim due items with a lease, which is renewed while the item is processed. An item whose lease expired
+// (e.g. the replica died) is claimed again. Items added while they are leased keep the lease and are processed again
+// after the lease owner releases them.
+type persistentQueue struct {
+	name  string
+	owner string
+	items storage.QueueItems
+	cfg   QueueConfig
+	log   *slog.Logger
+
+	notify       chan struct{}
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+
+	mu         sync.Mutex
+	heartbeats map[string]chan struct{}
+}
+
+func newPersistentQueue(items storage.QueueItems, cfg QueueConfig, owner, name string, log *slog.Logger) *persistentQueue {
+	return &persistentQueue{
+		name:       name,
+		owner:      owner,
+		items:      items,
+		cfg:        cfg,
+		log:        log,
+		notify:     make(chan struct{}, 1),
+		shutdown:   make(chan struct{}),
+		heartbeats: make(map[string]chan struct{}),
+	}
+}
+
+func (q *persistentQueue) Add(item interface{}) {
+	q.AddAfter(item, 0)
+}
+
+func (q *persistentQueue) AddAfter(item interface{}, duration time.Duration) {
+	operationID := item.(string)
+	err := q.items.Upsert(internal.QueueItem{
+		QueueName:   q.name,
+		OperationID: operationID,
+		NextRunAt:   time.Now().Add(duration),
+	})
+	if err != nil {
+		q.log.Error(fmt.Sprintf("unable to schedule item %s in the queue %s: %s", operationID, q.name, err))
+		return
+	}
+	if duration == 0 {
+		q.wakeUp()
+	}
+}
+
+// AddIfAbsent schedules the item for immediate processing only if it is not stored yet
+func (q *persistentQueue) AddIfAbsent(item interface{}) {
+	operationID := item.(string)
+	err := q.items.Insert(internal.QueueItem{
+		QueueName:   q.name,
+		OperationID: operationID,
+		NextRunAt:   time.Now(),
+	})
+	switch {
+	case dberr.IsAlreadyExists(err):
+		return
+	case err != nil:
+		q.log.Error(fmt.Sprintf("unable to schedule item %s in the queue %s: %s", operationID, q.name, err))
+		return
+	}
+	q.wakeUp()
+}
+
+// Wake schedules the stored item for immediate processing unless it is leased
+func (q *persistentQueue) Wake(item interface{}) {
+	operationID := item.(string)
+	if err := q.items.Wake(q.name, operationID); err != nil {
+		q.log.Error(fmt.Sprintf("unable to wake item %s in the queue %s: %s", operationID, q.name, err))
+		return
+	}
+	q.wakeUp()
+}
+
+// Get blocks until an item is claimed or the queue is shut down
+func (q *persistentQueue) Get() (interface{}, bool) {
+	for {
+		select {
+		case <-q.shutdown:
+			return nil, true
+		default:
+		}
+
+		item, err := q.items.Claim(q.name, q.owner, q.cfg.LeaseDuration)
+		if err == nil {
+			q.startHeartbeat(item.OperationID)
+			return item.OperationID, false
+		}
+		if !dberr.IsNotFound(err) {
+			q.log.Error(fmt.Sprintf("unable to claim an item from the queue %s: %s", q.name, err))
+		}
+
+		select {
+		case <-q.shutdown:
+			return nil, true
+		case <-q.notify:
+		case <-time.After(q.cfg.PollInterval):
+		}
+	}
+}
+
+// Reschedule releases the lease of the processed item and schedules its next run
+func (q *persistentQueue) Reschedule(item interface{}, duration time.Duration) {
+	operationID := item.(string)
+	q.stopHeartbeat(operationID)
+	if err := q.items.Release(q.name, operationID, q.owner, time.Now().Add(duration)); err != nil {
+		q.log.Error(fmt.Sprintf("unable to reschedule item %s in the queue %s: %s", operationID, q.name, err))
+		return
+	}
+	if duration == 0 {
+		q.wakeUp()
+	}
+}
+
+// Done stops renewing the lease, the lease is released by Forget or Reschedule
+func (q *persistentQueue) Done(item interface{}) {
+	q.stopHeartbeat(item.(string))
+}
+
+func (q *persistentQueue) Forget(item interface{}) {
+	operationID := item.(string)
+	q.stopHeartbeat(operationID)
+	if err := q.items.Delete(q.name, operationID, q.owner); err != nil {
+		q.log.Error(fmt.Sprintf("unable to remove item %s from the queue %s: %s", operationID, q.name, err))
+	}
+}
+
+func (q *persistentQueue) Len() int {
+	count, err := q.items.Count(q.name)
+	if err != nil {
+		q.log.Warn(fmt.Sprintf("unable to count items in the queue %s: %s", q.name, err))
+		return 0
+	}
+	return count
+}
+
+func (q *persistentQueue) ShutDown() {
+	q.shutdownOnce.Do(func() {
+		close(q.shutdown)
+	})
+}
+
+// startHeartbeat renews the lease of the claimed item until the worker finishes processing it
+func (q *persistentQueue) startHeartbeat(operationID string) {
+	stop := make(chan struct{})
+	q.mu.Lock()
+	q.heartbeats[operationID] = stop
+	q.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(q.cfg.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := q.items.Renew(q.name, operationID, q.owner, q.cfg.LeaseDuration); err != nil {
+					q.log.Warn(fmt.Sprintf("unable to renew the lease of item %s in the queue %s: %s", operationID, q.name, err))
+				}
+			}
+		}
+	}()
+}
+
+func (q *persistentQueue) stopHeartbeat(operationID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if stop, found := q.heartbeats[operationID]; found {
+		close(stop)
+		delete(q.heartbeats, operationID)
+	}
+}
+
+func (q *persistentQueue) wakeUp() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
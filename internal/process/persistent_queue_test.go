@@ -0,0 +1,201 @@
+package process
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/driver/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type retryingExecutor struct {
+	mu       sync.Mutex
+	calls    map[string]int
+	retries  int
+	finished chan string
+}
+
+func (e *retryingExecutor) Execute(operationID string) (time.Duration, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls[operationID]++
+	if e.calls[operationID] <= e.retries {
+		return time.Millisecond, nil
+	}
+	e.finished <- operationID
+	return 0, nil
+}
+
+type blockingExecutor struct {
+	started chan string
+	release chan struct{}
+}
+
+func (e *blockingExecutor) Execute(operationID string) (time.Duration, error) {
+	e.started <- operationID
+	<-e.release
+	return 0, nil
+}
+
+func TestPersistentQueue(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	cfg := QueueConfig{Persistent: true, PollInterval: 10 * time.Millisecond, LeaseDuration: time.Minute}
+
+	t.Run("should process and retry items until they are finished", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		executor := &retryingExecutor{calls: map[string]int{}, retries: 2, finished: make(chan string, 2)}
+		queue := NewPersistentQueue(executor, items, cfg, "replica-1", log, "test")
+		stop := make(chan struct{})
+
+		// when
+		queue.Add("op-1")
+		queue.AddAfter("op-2", 0)
+		queue.Run(stop, 2)
+
+		// then
+		finished := []string{<-executor.finished, <-executor.finished}
+		assert.ElementsMatch(t, []string{"op-1", "op-2"}, finished)
+		assert.Equal(t, 3, executor.calls["op-1"])
+		assert.Equal(t, 3, executor.calls["op-2"])
+		assert.Eventually(t, func() bool {
+			count, err := items.Count("test")
+			return err == nil && count == 0
+		}, time.Second, 10*time.Millisecond)
+
+		queue.ShutDown()
+		close(stop)
+		queue.waitGroup.Wait()
+	})
+
+	t.Run("should keep the retry time when the item is resumed", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		queue := NewPersistentQueue(&retryingExecutor{}, items, cfg, "replica-1", log, "test")
+		queue.AddAfter("op-1", time.Hour)
+
+		// when
+		queue.Resume("op-1")
+		queue.Resume("op-2")
+
+		// then
+		_, err := items.Claim("test", "replica-2", time.Minute)
+		require.NoError(t, err)
+		_, err = items.Claim("test", "replica-2", time.Minute)
+		assert.Error(t, err)
+		count, err := items.Count("test")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("should wake only scheduled items which are not leased", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		queue := NewPersistentQueue(&retryingExecutor{}, items, cfg, "replica-1", log, "test")
+		queue.AddAfter("op-1", time.Hour)
+		queue.AddAfter("op-2", time.Hour)
+		require.NoError(t, items.Upsert(internal.QueueItem{QueueName: "test", OperationID: "op-3", NextRunAt: time.Now()}))
+		_, err := items.Claim("test", "replica-2", time.Minute)
+		require.NoError(t, err)
+
+		// when
+		queue.Wake("op-1")
+		queue.Wake("op-3")
+		queue.Wake("op-4")
+
+		// then
+		claimed, err := items.Claim("test", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", claimed.OperationID)
+		_, err = items.Claim("test", "replica-2", time.Minute)
+		assert.Error(t, err)
+		count, err := items.Count("test")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("should keep the lease when the item is added while it is processed", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		require.NoError(t, items.Upsert(internal.QueueItem{QueueName: "test", OperationID: "op-1", NextRunAt: time.Now()}))
+		_, err := items.Claim("test", "replica-1", time.Minute)
+		require.NoError(t, err)
+
+		// when
+		require.NoError(t, items.Upsert(internal.QueueItem{QueueName: "test", OperationID: "op-1", NextRunAt: time.Now()}))
+
+		// then
+		_, err = items.Claim("test", "replica-2", time.Minute)
+		assert.Error(t, err)
+
+		// when the lease owner finishes processing
+		require.NoError(t, items.Delete("test", "op-1", "replica-1"))
+
+		// then the item is processed again
+		claimed, err := items.Claim("test", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", claimed.OperationID)
+	})
+
+	t.Run("should renew the lease while the item is processed", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		executor := &blockingExecutor{started: make(chan string, 1), release: make(chan struct{})}
+		queue := NewPersistentQueue(executor, items, QueueConfig{PollInterval: 10 * time.Millisecond, LeaseDuration: 60 * time.Millisecond}, "replica-1", log, "test")
+		stop := make(chan struct{})
+		queue.Add("op-1")
+		queue.Run(stop, 1)
+		<-executor.started
+
+		// when
+		time.Sleep(200 * time.Millisecond)
+
+		// then
+		_, err := items.Claim("test", "replica-2", time.Minute)
+		assert.Error(t, err)
+
+		close(executor.release)
+		assert.Eventually(t, func() bool {
+			count, err := items.Count("test")
+			return err == nil && count == 0
+		}, time.Second, 10*time.Millisecond)
+
+		queue.ShutDown()
+		close(stop)
+		queue.waitGroup.Wait()
+	})
+
+	t.Run("should take over the item when the lease expires", func(t *testing.T) {
+		// given
+		items := memory.NewQueueItems()
+		require.NoError(t, items.Upsert(internal.QueueItem{QueueName: "test", OperationID: "op-1", NextRunAt: time.Now()}))
+		_, err := items.Claim("test", "replica-1", 20*time.Millisecond)
+		require.NoError(t, err)
+
+		executor := &retryingExecutor{calls: map[string]int{}, finished: make(chan string, 1)}
+		queue := NewPersistentQueue(executor, items, cfg, "replica-2", log, "test")
+		stop := make(chan struct{})
+
+		// when
+		queue.Run(stop, 1)
+
+		// then
+		select {
+		case id := <-executor.finished:
+			assert.Equal(t, "op-1", id)
+		case <-time.After(time.Second):
+			assert.Fail(t, fmt.Sprintf("item was not taken over, calls: %v", executor.calls))
+		}
+
+		queue.ShutDown()
+		close(stop)
+		queue.waitGroup.Wait()
+	})
+}
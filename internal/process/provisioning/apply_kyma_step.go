@@ -67,23 +67,33 @@ func (a *ApplyKymaStep) Run(operation internal.Operation, logger *slog.Logger) (
 		err = a.k8sClient.Update(context.Background(), &existingKyma)
 		if err != nil {
 			logger.Error(fmt.Sprintf("unable to update a Kyma resource: %s", err.Error()))
-			return a.operationManager.RetryOperation(operation, "unable to update the Kyma resource", err, time.Second, 10*time.Second, logger)
+			return a.operationManager.RetryStep(operation, "unable to update the Kyma resource", err, logger)
 		}
 	case errors.IsNotFound(err):
 		logger.Info(fmt.Sprintf("creating Kyma resource: %s in namespace: %s", template.GetName(), template.GetNamespace()))
 		err := a.k8sClient.Create(context.Background(), template)
 		if err != nil {
 			logger.Error(fmt.Sprintf("unable to create a Kyma resource: %s", err.Error()))
-			return a.operationManager.RetryOperation(operation, "unable to create the Kyma resource", err, time.Second, 10*time.Second, logger)
+			return a.operationManager.RetryStep(operation, "unable to create the Kyma resource", err, logger)
 		}
 	default:
 		logger.Error(fmt.Sprintf("Unable to get Kyma: %s", err.Error()))
-		return a.operationManager.RetryOperation(operation, "unable to get the Kyma resource", err, time.Second, 10*time.Second, logger)
+		return a.operationManager.RetryStep(operation, "unable to get the Kyma resource", err, logger)
 	}
 
 	return operation, 0, nil
 }
 
+// Preview renders the Kyma resource for the operation without applying it
+func (a *ApplyKymaStep) Preview(operation internal.Operation) (*unstructured.Unstructured, error) {
+	template, err := steps.DecodeKymaTemplate(operation.KymaTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a kyma template: %w", err)
+	}
+	a.addLabelsAndName(operation, template)
+	return template, nil
+}
+
 func (a *ApplyKymaStep) addLabelsAndName(operation internal.Operation, obj *unstructured.Unstructured) bool {
 	oldLabels := obj.GetLabels()
 	steps.ApplyLabelsAndAnnotationsForLM(obj, operation)
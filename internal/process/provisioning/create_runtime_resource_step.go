@@ -30,13 +30,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const (
-	kcpRetryInterval = 3 * time.Second
-	kcpRetryTimeout  = 20 * time.Second
-	dbRetryInterval  = 10 * time.Second
-	dbRetryTimeout   = 1 * time.Minute
-)
-
 type CreateRuntimeResourceStep struct {
 	operationManager  *process.OperationManager
 	instanceStorage   storage.Instances
@@ -77,7 +70,7 @@ func (s *CreateRuntimeResourceStep) Run(operation internal.Operation, log *slog.
 	runtimeCR, err := s.getEmptyOrExistingRuntimeResource(runtimeResourceName, kymaResourceNamespace)
 	if err != nil {
 		log.Error(fmt.Sprintf("unable to get Runtime resource %s/%s", operation.KymaResourceNamespace, runtimeResourceName))
-		return s.operationManager.RetryOperation(operation, "unable to get Runtime resource", err, kcpRetryInterval, kcpRetryTimeout, log)
+		return s.operationManager.RetryStep(operation, "unable to get Runtime resource", err, log)
 	}
 
 	if runtimeCR.GetResourceVersion() != "" {
@@ -92,7 +85,7 @@ func (s *CreateRuntimeResourceStep) Run(operation internal.Operation, log *slog.
 		err = s.k8sClient.Create(context.Background(), runtimeCR)
 		if err != nil {
 			log.Error(fmt.Sprintf("unable to create Runtime resource: %s/%s: %s", operation.KymaResourceNamespace, runtimeResourceName, err.Error()))
-			return s.operationManager.RetryOperation(operation, "unable to create Runtime resource", err, kcpRetryInterval, kcpRetryTimeout, log)
+			return s.operationManager.RetryStep(operation, "unable to create Runtime resource", err, log)
 		}
 		log.Info(fmt.Sprintf("Runtime resource %s/%s creation process finished successfully", operation.KymaResourceNamespace, runtimeResourceName))
 
@@ -101,7 +94,7 @@ func (s *CreateRuntimeResourceStep) Run(operation internal.Operation, log *slog.
 			op.CloudProvider = operation.CloudProvider
 		}, log)
 		if backoff > 0 {
-			return s.operationManager.RetryOperation(operation, "cannot update operation", err, dbRetryInterval, dbRetryTimeout, log)
+			return s.operationManager.RetryStep(operation, "cannot update operation", err, log)
 		}
 
 		err = s.updateInstance(operation.InstanceID, runtimeCR.Spec.Shoot.Region)
@@ -112,16 +105,41 @@ func (s *CreateRuntimeResourceStep) Run(operation internal.Operation, log *slog.
 			err = s.updateInstance(operation.InstanceID, runtimeCR.Spec.Shoot.Region)
 			if err != nil {
 				log.Error(fmt.Sprintf("cannot update instance: %s", err))
-				return s.operationManager.RetryOperation(operation, "cannot update instance", err, dbRetryInterval, dbRetryTimeout, log)
+				return s.operationManager.RetryStep(operation, "cannot update instance", err, log)
 			}
 		default:
 			log.Error(fmt.Sprintf("cannot update instance: %s", err))
-			return s.operationManager.RetryOperation(operation, "cannot update instance", err, dbRetryInterval, dbRetryTimeout, log)
+			return s.operationManager.RetryStep(operation, "cannot update instance", err, log)
 		}
 		return operation, 0, nil
 	}
 }
 
+// Preview renders the Runtime resource for the operation without creating it
+func (s *CreateRuntimeResourceStep) Preview(operation internal.Operation, log *slog.Logger) (*imv1.Runtime, error) {
+	values := *operation.ProviderValues
+	operation.CloudProvider = string(provider.ProviderToCloudProvider(values.ProviderType))
+	if operation.ProvisioningParameters.Parameters.TargetSecret == nil {
+		// the subscription is assigned while the operation is processed
+		operation.ProvisioningParameters.Parameters.TargetSecret = ptr.String("")
+	}
+	if s.providerSpec.ZonesDiscovery(pkg.CloudProviderFromString(values.ProviderType)) && len(operation.DiscoveredZones) == 0 {
+		// zones are discovered while the operation is processed, the default zones of the region are rendered instead
+		operation.DiscoveredZones = map[string][]string{
+			DefaultIfParamNotSet(values.DefaultMachineType, operation.ProvisioningParameters.Parameters.MachineType): values.Zones,
+		}
+		for _, pool := range operation.ProvisioningParameters.Parameters.AdditionalWorkerNodePools {
+			operation.DiscoveredZones[pool.MachineType] = values.Zones
+		}
+	}
+
+	runtime := &imv1.Runtime{}
+	if err := s.updateRuntimeResourceObject(log, values, runtime, operation, steps.KymaRuntimeResourceName(operation), operation.CloudProvider); err != nil {
+		return nil, fmt.Errorf("while creating Runtime CR object: %w", err)
+	}
+	return runtime, nil
+}
+
 func (s *CreateRuntimeResourceStep) updateRuntimeResourceObject(log *slog.Logger, values internal.ProviderValues, runtime *imv1.Runtime, operation internal.Operation, runtimeName, cloudProvider string) error {
 
 	runtime.ObjectMeta.Name = runtimeName
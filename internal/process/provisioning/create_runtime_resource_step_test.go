@@ -26,6 +26,7 @@ import (
 	"github.com/pivotal-cf/brokerapi/v12/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -1428,6 +1429,40 @@ func TestCreateRuntimeResourceStep_AWS_ZonesDiscovery(t *testing.T) {
 	assert.Subset(t, []string{"zone-i", "zone-j", "zone-k", "zone-l"}, (*runtime.Spec.Shoot.Provider.AdditionalWorkers)[1].Zones)
 }
 
+func TestCreateRuntimeResourceStep_Preview(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+
+	err := imv1.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	inputConfig := broker.InfrastructureManager{MultiZoneCluster: true, ControlPlaneFailureTolerance: "zone", DefaultGardenerShootPurpose: provider.PurposeProduction}
+
+	_, operation := fixInstanceAndOperation(broker.AWSPlanID, "eu-west-2", "platform-region", inputConfig, pkg.AWS)
+	operation.ProvisioningParameters.Parameters.TargetSecret = nil
+	operation.KymaResourceNamespace = "kyma-system"
+
+	cli := getClientForTests(t)
+	step := NewCreateRuntimeResourceStep(memoryStorage, cli, inputConfig, defaultOIDSConfig, workers.NewProvider(broker.InfrastructureManager{}, fixture.NewProviderSpecWithZonesDiscovery(t, true)), fixture.NewProviderSpecWithZonesDiscovery(t, true))
+
+	// when
+	runtime, err := step.Preview(operation, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, operation.RuntimeID, runtime.Name)
+	assert.Equal(t, "eu-west-2", runtime.Spec.Shoot.Region)
+	assert.Empty(t, runtime.Spec.Shoot.SecretBindingName)
+	require.Len(t, runtime.Spec.Shoot.Provider.Workers, 1)
+	assert.Subset(t, operation.ProviderValues.Zones, runtime.Spec.Shoot.Provider.Workers[0].Zones)
+
+	err = cli.Get(context.Background(), client.ObjectKey{
+		Namespace: "kyma-system",
+		Name:      operation.RuntimeID,
+	}, &imv1.Runtime{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
 func TestCreateRuntimeResourceStep_Free_ZonesDiscovery(t *testing.T) {
 	// given
 	memoryStorage := storage.NewMemoryStorage()
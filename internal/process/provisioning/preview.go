@@ -0,0 +1,51 @@
+package provisioning
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/process/steps"
+)
+
+// OperationPreviewer renders the resources created by the provisioning steps without applying them
+type OperationPreviewer struct {
+	kymaTemplateStep    *steps.InitKymaTemplate
+	kymaStep            *ApplyKymaStep
+	runtimeResourceStep *CreateRuntimeResourceStep
+}
+
+var _ broker.OperationPreviewer = &OperationPreviewer{}
+
+func NewOperationPreviewer(kymaTemplateStep *steps.InitKymaTemplate, kymaStep *ApplyKymaStep, runtimeResourceStep *CreateRuntimeResourceStep) *OperationPreviewer {
+	return &OperationPreviewer{
+		kymaTemplateStep:    kymaTemplateStep,
+		kymaStep:            kymaStep,
+		runtimeResourceStep: runtimeResourceStep,
+	}
+}
+
+func (p *OperationPreviewer) Preview(operation internal.Operation, log *slog.Logger) (broker.OperationPreview, error) {
+	kymaTemplate, namespace, err := p.kymaTemplateStep.Resolve(operation, log)
+	if err != nil {
+		return broker.OperationPreview{}, fmt.Errorf("while resolving Kyma template: %w", err)
+	}
+	operation.KymaTemplate = kymaTemplate
+	operation.KymaResourceNamespace = namespace
+
+	kyma, err := p.kymaStep.Preview(operation)
+	if err != nil {
+		return broker.OperationPreview{}, err
+	}
+	runtime, err := p.runtimeResourceStep.Preview(operation, log)
+	if err != nil {
+		return broker.OperationPreview{}, err
+	}
+
+	return broker.OperationPreview{
+		KymaTemplate: kymaTemplate,
+		Kyma:         kyma,
+		Runtime:      runtime,
+	}, nil
+}
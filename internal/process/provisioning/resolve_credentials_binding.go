@@ -23,17 +23,15 @@ type ResolveCredentialsBindingStep struct {
 	opStorage        storage.Operations
 	instanceStorage  storage.Instances
 	rulesService     *rules.RulesService
-	stepRetryTuple   internal.RetryTuple
 	mu               sync.Mutex
 }
 
-func NewResolveCredentialsBindingStep(brokerStorage storage.BrokerStorage, gardenerClient *gardener.Client, rulesService *rules.RulesService, stepRetryTuple internal.RetryTuple) *ResolveCredentialsBindingStep {
+func NewResolveCredentialsBindingStep(brokerStorage storage.BrokerStorage, gardenerClient *gardener.Client, rulesService *rules.RulesService) *ResolveCredentialsBindingStep {
 	step := &ResolveCredentialsBindingStep{
 		opStorage:       brokerStorage.Operations(),
 		instanceStorage: brokerStorage.Instances(),
 		gardenerClient:  gardenerClient,
 		rulesService:    rulesService,
-		stepRetryTuple:  stepRetryTuple,
 	}
 	step.operationManager = process.NewOperationManager(brokerStorage.Operations(), step.Name(), kebError.AccountPoolDependency)
 	return step
@@ -51,7 +49,7 @@ func (s *ResolveCredentialsBindingStep) Run(operation internal.Operation, log *s
 	targetSecretName, err := s.resolveSecretName(operation, log)
 	if err != nil {
 		msg := "resolving secret name"
-		return s.operationManager.RetryOperation(operation, msg, err, s.stepRetryTuple.Interval, s.stepRetryTuple.Timeout, log)
+		return s.operationManager.RetryStep(operation, msg, err, log)
 	}
 
 	if targetSecretName == "" {
@@ -62,7 +60,7 @@ func (s *ResolveCredentialsBindingStep) Run(operation internal.Operation, log *s
 	err = s.updateInstance(operation.InstanceID, targetSecretName)
 	if err != nil {
 		log.Error(fmt.Sprintf("failed to update instance with subscription secret name: %s", err.Error()))
-		return s.operationManager.RetryOperation(operation, "updating instance", err, s.stepRetryTuple.Interval, s.stepRetryTuple.Timeout, log)
+		return s.operationManager.RetryStep(operation, "updating instance", err, log)
 	}
 
 	return s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
@@ -124,12 +122,7 @@ func (s *ResolveCredentialsBindingStep) resolveSecretName(operation internal.Ope
 }
 
 func (s *ResolveCredentialsBindingStep) provisioningAttributesFromOperationData(operation internal.Operation) *rules.ProvisioningAttributes {
-	return &rules.ProvisioningAttributes{
-		Plan:              broker.AvailablePlans.GetPlanNameOrEmpty(broker.PlanIDType(operation.ProvisioningParameters.PlanID)),
-		PlatformRegion:    operation.ProvisioningParameters.PlatformRegion,
-		HyperscalerRegion: operation.ProviderValues.Region,
-		Hyperscaler:       operation.ProviderValues.ProviderType,
-	}
+	return broker.NewProvisioningAttributes(operation.ProvisioningParameters, *operation.ProviderValues)
 }
 
 func (s *ResolveCredentialsBindingStep) matchProvisioningAttributesToRule(attr *rules.ProvisioningAttributes) (subscriptions.ParsedRule, error) {
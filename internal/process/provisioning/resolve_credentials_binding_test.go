@@ -3,14 +3,15 @@ package provisioning
 import (
 	"log/slog"
 	"os"
-	"strings"
 	"testing"
 	"time"
 
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/pivotal-cf/brokerapi/v12/domain"
 	"github.com/stretchr/testify/assert"
@@ -22,14 +23,6 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 	brokerStorage := storage.NewMemoryStorage()
 	gardenerClient := fixture.CreateGardenerClientWithCredentialsBindings()
 	rulesService := createRulesService(t)
-	stepRetryTuple := internal.RetryTuple{
-		Timeout:  2 * time.Second,
-		Interval: 1 * time.Second,
-	}
-	immediateTimeout := internal.RetryTuple{
-		Timeout:  -1 * time.Second,
-		Interval: 1 * time.Second,
-	}
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 
 	t.Run("should resolve secret name for aws hyperscaler and existing tenant", func(t *testing.T) {
@@ -52,7 +45,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -87,7 +80,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -121,7 +114,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -155,7 +148,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -189,7 +182,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -223,15 +216,16 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
-		_, backoff, err := step.Run(operation, log)
+		failedOperation := runUntilRetryPolicyExhausted(t, brokerStorage, step, operation.ID)
 
 		// then
-		assert.Error(t, err)
-		assert.Zero(t, backoff)
-		assert.True(t, strings.Contains(err.Error(), "no matching rule for provisioning attributes"))
+		assert.Equal(t, domain.Failed, failedOperation.State)
+		assert.Contains(t, failedOperation.Description, "resolving secret name")
+		assert.Equal(t, step.Name(), failedOperation.LastError.GetStep())
+		assert.Contains(t, failedOperation.LastError.Error(), "no matching rule for provisioning attributes")
 
 		updatedInstance, err := brokerStorage.Instances().GetByID(instanceID)
 		require.NoError(t, err)
@@ -257,15 +251,16 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
-		_, backoff, err := step.Run(operation, log)
+		failedOperation := runUntilRetryPolicyExhausted(t, brokerStorage, step, operation.ID)
 
 		// then
-		assert.Error(t, err)
-		assert.Zero(t, backoff)
-		assert.True(t, strings.Contains(err.Error(), "failed to find unassigned secret binding with selector"))
+		assert.Equal(t, domain.Failed, failedOperation.State)
+		assert.Contains(t, failedOperation.Description, "resolving secret name")
+		assert.Equal(t, step.Name(), failedOperation.LastError.GetStep())
+		assert.Contains(t, failedOperation.LastError.Error(), "failed to find unassigned secret binding with selector")
 
 		updatedInstance, err := brokerStorage.Instances().GetByID(instanceID)
 		require.NoError(t, err)
@@ -291,7 +286,7 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveCredentialsBindingStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -307,3 +302,17 @@ func TestResolveCredentialsBindingStep(t *testing.T) {
 		assert.Empty(t, updatedInstance.SubscriptionSecretName)
 	})
 }
+
+// runUntilRetryPolicyExhausted runs the step with the staged manager until the step retry policy is exhausted
+func runUntilRetryPolicyExhausted(t *testing.T, brokerStorage storage.BrokerStorage, step process.Step, operationID string) internal.Operation {
+	manager := process.NewStagedManager(brokerStorage.Operations(), event.NewPubSub(nil), time.Hour, process.StagedManagerConfiguration{MaxStepProcessingTime: time.Second}, slog.New(slog.DiscardHandler))
+	manager.SpeedUp(100000)
+	manager.DefineStages([]string{"resolve"})
+	require.NoError(t, manager.AddStep("resolve", step, nil, process.StepPolicy{MaxAttempts: 2}))
+
+	_, _ = manager.Execute(operationID)
+
+	operation, err := brokerStorage.Operations().GetOperationByID(operationID)
+	require.NoError(t, err)
+	return *operation
+}
@@ -23,17 +23,15 @@ type ResolveSubscriptionSecretStep struct {
 	opStorage        storage.Operations
 	instanceStorage  storage.Instances
 	rulesService     *rules.RulesService
-	stepRetryTuple   internal.RetryTuple
 	mu               sync.Mutex
 }
 
-func NewResolveSubscriptionSecretStep(brokerStorage storage.BrokerStorage, gardenerClient *gardener.Client, rulesService *rules.RulesService, stepRetryTuple internal.RetryTuple) *ResolveSubscriptionSecretStep {
+func NewResolveSubscriptionSecretStep(brokerStorage storage.BrokerStorage, gardenerClient *gardener.Client, rulesService *rules.RulesService) *ResolveSubscriptionSecretStep {
 	step := &ResolveSubscriptionSecretStep{
 		opStorage:       brokerStorage.Operations(),
 		instanceStorage: brokerStorage.Instances(),
 		gardenerClient:  gardenerClient,
 		rulesService:    rulesService,
-		stepRetryTuple:  stepRetryTuple,
 	}
 	step.operationManager = process.NewOperationManager(brokerStorage.Operations(), step.Name(), kebError.AccountPoolDependency)
 	return step
@@ -51,7 +49,7 @@ func (step *ResolveSubscriptionSecretStep) Run(operation internal.Operation, log
 	targetSecretName, err := step.resolveSecretName(operation, log)
 	if err != nil {
 		msg := "resolving secret name"
-		return step.operationManager.RetryOperation(operation, msg, err, step.stepRetryTuple.Interval, step.stepRetryTuple.Timeout, log)
+		return step.operationManager.RetryStep(operation, msg, err, log)
 	}
 
 	if targetSecretName == "" {
@@ -62,7 +60,7 @@ func (step *ResolveSubscriptionSecretStep) Run(operation internal.Operation, log
 	err = step.updateInstance(operation.InstanceID, targetSecretName)
 	if err != nil {
 		log.Error(fmt.Sprintf("failed to update instance with subscription secret name: %step", err.Error()))
-		return step.operationManager.RetryOperation(operation, "updating instance", err, step.stepRetryTuple.Interval, step.stepRetryTuple.Timeout, log)
+		return step.operationManager.RetryStep(operation, "updating instance", err, log)
 	}
 
 	return step.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
@@ -124,12 +122,7 @@ func (step *ResolveSubscriptionSecretStep) resolveSecretName(operation internal.
 }
 
 func (step *ResolveSubscriptionSecretStep) provisioningAttributesFromOperationData(operation internal.Operation) *rules.ProvisioningAttributes {
-	return &rules.ProvisioningAttributes{
-		Plan:              broker.AvailablePlans.GetPlanNameOrEmpty(broker.PlanIDType(operation.ProvisioningParameters.PlanID)),
-		PlatformRegion:    operation.ProvisioningParameters.PlatformRegion,
-		HyperscalerRegion: operation.ProviderValues.Region,
-		Hyperscaler:       operation.ProviderValues.ProviderType,
-	}
+	return broker.NewProvisioningAttributes(operation.ProvisioningParameters, *operation.ProviderValues)
 }
 
 func (step *ResolveSubscriptionSecretStep) matchProvisioningAttributesToRule(attr *rules.ProvisioningAttributes) (subscriptions.ParsedRule, error) {
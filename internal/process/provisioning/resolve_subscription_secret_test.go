@@ -3,9 +3,7 @@ package provisioning
 import (
 	"log/slog"
 	"os"
-	"strings"
 	"testing"
-	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -25,14 +23,6 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 	brokerStorage := storage.NewMemoryStorage()
 	gardenerClient := fixture.CreateGardenerClient()
 	rulesService := createRulesService(t)
-	stepRetryTuple := internal.RetryTuple{
-		Timeout:  2 * time.Second,
-		Interval: 1 * time.Second,
-	}
-	immediateTimeout := internal.RetryTuple{
-		Timeout:  -1 * time.Second,
-		Interval: 1 * time.Second,
-	}
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 
 	t.Run("should resolve secret name for aws hyperscaler and existing tenant", func(t *testing.T) {
@@ -55,7 +45,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -90,7 +80,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -124,7 +114,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -158,7 +148,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -192,7 +182,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, stepRetryTuple)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -226,15 +216,16 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
-		_, backoff, err := step.Run(operation, log)
+		failedOperation := runUntilRetryPolicyExhausted(t, brokerStorage, step, operation.ID)
 
 		// then
-		assert.Error(t, err)
-		assert.Zero(t, backoff)
-		assert.True(t, strings.Contains(err.Error(), "no matching rule for provisioning attributes"))
+		assert.Equal(t, domain.Failed, failedOperation.State)
+		assert.Contains(t, failedOperation.Description, "resolving secret name")
+		assert.Equal(t, step.Name(), failedOperation.LastError.GetStep())
+		assert.Contains(t, failedOperation.LastError.Error(), "no matching rule for provisioning attributes")
 
 		updatedInstance, err := brokerStorage.Instances().GetByID(instanceID)
 		require.NoError(t, err)
@@ -260,15 +251,16 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
-		_, backoff, err := step.Run(operation, log)
+		failedOperation := runUntilRetryPolicyExhausted(t, brokerStorage, step, operation.ID)
 
 		// then
-		assert.Error(t, err)
-		assert.Zero(t, backoff)
-		assert.True(t, strings.Contains(err.Error(), "failed to find unassigned secret binding with selector"))
+		assert.Equal(t, domain.Failed, failedOperation.State)
+		assert.Contains(t, failedOperation.Description, "resolving secret name")
+		assert.Equal(t, step.Name(), failedOperation.LastError.GetStep())
+		assert.Contains(t, failedOperation.LastError.Error(), "failed to find unassigned secret binding with selector")
 
 		updatedInstance, err := brokerStorage.Instances().GetByID(instanceID)
 		require.NoError(t, err)
@@ -294,7 +286,7 @@ func TestResolveSubscriptionSecretStep(t *testing.T) {
 		instance.SubscriptionSecretName = ""
 		require.NoError(t, brokerStorage.Instances().Insert(instance))
 
-		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService, immediateTimeout)
+		step := NewResolveSubscriptionSecretStep(brokerStorage, gardenerClient, rulesService)
 
 		// when
 		operation, backoff, err := step.Run(operation, log)
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -17,8 +19,45 @@ type Executor interface {
 	Execute(operationID string) (time.Duration, error)
 }
 
+// queueBackend keeps the items scheduled for processing. It is satisfied by the in-memory memoryQueue
+// and by the storage backed persistentQueue.
+type queueBackend interface {
+	Add(item interface{})
+	AddAfter(item interface{}, duration time.Duration)
+	// AddIfAbsent adds the item only if it is not scheduled yet
+	AddIfAbsent(item interface{})
+	// Wake moves a scheduled item which is not being processed to the front of the queue
+	Wake(item interface{})
+	// Reschedule is called by the worker which processed the item to schedule its next run
+	Reschedule(item interface{}, duration time.Duration)
+	Get() (item interface{}, shutdown bool)
+	Done(item interface{})
+	Forget(item interface{})
+	Len() int
+	ShutDown()
+}
+
+// memoryQueue is the queueBackend keeping items in memory of the broker instance
+type memoryQueue struct {
+	workqueue.RateLimitingInterface
+}
+
+// AddIfAbsent adds the item, the workqueue does not add items which are already queued
+func (q memoryQueue) AddIfAbsent(item interface{}) {
+	q.Add(item)
+}
+
+// Wake adds the item, the workqueue processes an item added while it is processed once more after it is done
+func (q memoryQueue) Wake(item interface{}) {
+	q.Add(item)
+}
+
+func (q memoryQueue) Reschedule(item interface{}, duration time.Duration) {
+	q.AddAfter(item, duration)
+}
+
 type Queue struct {
-	queue     workqueue.RateLimitingInterface
+	queue     queueBackend
 	executor  Executor
 	waitGroup sync.WaitGroup
 	log       *slog.Logger
@@ -38,7 +77,7 @@ var queueWorkersInUseMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
 func NewQueue(executor Executor, log *slog.Logger, name string) *Queue {
 	// add queue name field that could be logged later on
 	return &Queue{
-		queue:             workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{Name: "operations"}),
+		queue:             memoryQueue{workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{Name: "operations"})},
 		executor:          executor,
 		waitGroup:         sync.WaitGroup{},
 		log:               log.With("queueName", name),
@@ -48,14 +87,35 @@ func NewQueue(executor Executor, log *slog.Logger, name string) *Queue {
 	}
 }
 
+// NewPersistentQueue creates a queue which keeps scheduled operations in the storage, so pending retries
+// survive restarts and items can be shared between broker replicas. The owner identifies the replica holding a lease.
+func NewPersistentQueue(executor Executor, items storage.QueueItems, cfg QueueConfig, owner string, log *slog.Logger, name string) *Queue {
+	queue := NewQueue(executor, log, name)
+	queue.queue = newPersistentQueue(items, cfg, owner, name, queue.log)
+	return queue
+}
+
 func (q *Queue) Add(processId string) {
 	q.queue.Add(processId)
-	q.log.Info(fmt.Sprintf("added item %s to the queue %s, queue length is %d", processId, q.name, q.queue.Len()))
+	q.log.Info(fmt.Sprintf("added item %s to the queue %s", processId, q.name))
 }
 
 func (q *Queue) AddAfter(processId string, duration time.Duration) {
 	q.queue.AddAfter(processId, duration)
-	q.log.Info(fmt.Sprintf("item %s will be added to the queue %s after duration of %d, queue length is %d", processId, q.name, duration, q.queue.Len()))
+	q.log.Info(fmt.Sprintf("item %s will be added to the queue %s after duration of %d", processId, q.name, duration))
+}
+
+// Resume adds the item unless it is already scheduled, so the retry time stored by the persistent queue is kept
+func (q *Queue) Resume(processId string) {
+	q.queue.AddIfAbsent(processId)
+	q.log.Info(fmt.Sprintf("item %s resumed in the queue %s", processId, q.name))
+}
+
+// Wake processes the scheduled item as soon as possible. The persistent queue does not store items which are not scheduled
+// and does not change items leased by a replica, so all replicas can wake the same item.
+func (q *Queue) Wake(processId string) {
+	q.queue.Wake(processId)
+	q.log.Debug(fmt.Sprintf("item %s woken up in the queue %s", processId, q.name))
 }
 
 func (q *Queue) ShutDown() {
@@ -81,14 +141,14 @@ func (q *Queue) SpeedUp(speedFactor int64) {
 	q.log.Info(fmt.Sprintf("queue speed factor set to %d", speedFactor))
 }
 
-func (q *Queue) createWorker(queue workqueue.RateLimitingInterface, process func(id string) (time.Duration, error), stopCh <-chan struct{}, waitGroup *sync.WaitGroup, log *slog.Logger, nameId string) {
+func (q *Queue) createWorker(queue queueBackend, process func(id string) (time.Duration, error), stopCh <-chan struct{}, waitGroup *sync.WaitGroup, log *slog.Logger, nameId string) {
 	go func() {
 		wait.Until(q.worker(queue, process, log, nameId), time.Second, stopCh)
 		waitGroup.Done()
 	}()
 }
 
-func (q *Queue) worker(queue workqueue.RateLimitingInterface, process func(key string) (time.Duration, error), log *slog.Logger, workerNameId string) func() {
+func (q *Queue) worker(queue queueBackend, process func(key string) (time.Duration, error), log *slog.Logger, workerNameId string) func() {
 	return func() {
 		exit := false
 		for !exit {
@@ -102,7 +162,7 @@ func (q *Queue) worker(queue workqueue.RateLimitingInterface, process func(key s
 				q.workersInUseGauge.Inc()
 				id := key.(string)
 				workerLogger := log.With("operationID", id)
-				workerLogger.Info(fmt.Sprintf("about to process item %s, queue length is %d", id, q.queue.Len()))
+				workerLogger.Info(fmt.Sprintf("about to process item %s", id))
 
 				defer func() {
 					q.workersInUseGauge.Dec()
@@ -115,9 +175,9 @@ func (q *Queue) worker(queue workqueue.RateLimitingInterface, process func(key s
 
 				when, err := process(id)
 				if err == nil && when != 0 {
-					workerLogger.Info(fmt.Sprintf("Adding %q item after %s, queue length %d", id, when, q.queue.Len()))
+					workerLogger.Info(fmt.Sprintf("Adding %q item after %s", id, when))
 					afterDuration := time.Duration(int64(when) / q.speedFactor)
-					queue.AddAfter(key, afterDuration)
+					queue.Reschedule(key, afterDuration)
 					return false
 				}
 				if err != nil {
@@ -2,13 +2,17 @@ package process
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"reflect"
 	"sync"
 	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 
+	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
@@ -29,6 +33,16 @@ type StagedManager struct {
 
 	speedFactor int64
 	cfg         StagedManagerConfiguration
+
+	leaseOwner string
+	leaseCfg   OperationLeaseConfig
+
+	actionStorage storage.Actions
+
+	// policies override step policies declared with AddStep
+	policies StepPolicies
+
+	stepStorage storage.OperationSteps
 }
 
 type StagedManagerConfiguration struct {
@@ -41,6 +55,19 @@ func (c StagedManagerConfiguration) String() string {
 	return fmt.Sprintf("(MaxStepProcessingTime=%s; WorkersAmount=%d)", c.MaxStepProcessingTime, c.WorkersAmount)
 }
 
+type OperationLeaseConfig struct {
+	// Enabled makes the manager lease an operation before processing it, so broker replicas never process the same operation concurrently
+	Enabled bool `envconfig:"default=false"`
+	// Duration after which a lease held by a replica which stopped is taken over
+	Duration time.Duration `envconfig:"default=10m"`
+	// RetryInterval is the time after which an operation leased by another replica is checked again
+	RetryInterval time.Duration `envconfig:"default=1m"`
+}
+
+func (c OperationLeaseConfig) String() string {
+	return fmt.Sprintf("(Enabled=%t; Duration=%s; RetryInterval=%s)", c.Enabled, c.Duration, c.RetryInterval)
+}
+
 type Step interface {
 	Name() string
 	Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error)
@@ -51,17 +78,21 @@ type StepCondition func(operation internal.Operation) bool
 type StepWithCondition struct {
 	Step
 	condition StepCondition
+	policy    StepPolicy
 }
 
 type stage struct {
 	name  string
 	steps []StepWithCondition
+	// compensations are run when the operation is canceled after the stage was started
+	compensations []Step
 }
 
-func (s *stage) AddStep(step Step, cnd StepCondition) {
+func (s *stage) AddStep(step Step, cnd StepCondition, policy StepPolicy) {
 	s.steps = append(s.steps, StepWithCondition{
 		Step:      step,
 		condition: cnd,
+		policy:    policy,
 	})
 }
 
@@ -82,6 +113,18 @@ func (m *StagedManager) SpeedUp(speedFactor int64) {
 	m.speedFactor = speedFactor
 }
 
+// EnableOperationLease makes the manager process only operations leased by the given owner.
+// The lease is renewed before every step and released when the processing returns to the queue.
+func (m *StagedManager) EnableOperationLease(owner string, cfg OperationLeaseConfig) {
+	m.leaseOwner = owner
+	m.leaseCfg = cfg
+}
+
+// UseStepPolicies overrides policies of steps with the given names, declared with AddStep
+func (m *StagedManager) UseStepPolicies(policies StepPolicies) {
+	m.policies = policies
+}
+
 func (m *StagedManager) DefineStages(names []string) {
 	m.stages = make([]*stage, len(names))
 	for i, n := range names {
@@ -89,16 +132,79 @@ func (m *StagedManager) DefineStages(names []string) {
 	}
 }
 
-func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition) error {
+// AddStep adds the step to the given stage. The policy declares how the manager retries the step, see StepPolicy.
+func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition, policy StepPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid policy of step %s: %w", step.Name(), err)
+	}
 	for _, s := range m.stages {
 		if s.name == stageName {
-			s.AddStep(step, cnd)
+			s.AddStep(step, cnd, policy)
 			return nil
 		}
 	}
 	return fmt.Errorf("stage %s not defined", stageName)
 }
 
+// AddCompensationStep adds a step which reverts the changes made by the given stage when the operation is canceled.
+// Compensation steps can be run multiple times, so they must be idempotent.
+func (m *StagedManager) AddCompensationStep(stageName string, step Step) error {
+	for _, s := range m.stages {
+		if s.name == stageName {
+			s.compensations = append(s.compensations, step)
+			return nil
+		}
+	}
+	return fmt.Errorf("stage %s not defined", stageName)
+}
+
+// RecordSteps makes the manager store every run of operation steps, so the operation timeline can be presented
+func (m *StagedManager) RecordSteps(steps storage.OperationSteps) {
+	m.stepStorage = steps
+}
+
+// RecordActions makes the manager store the result of an operation cancellation as an instance action
+func (m *StagedManager) RecordActions(actions storage.Actions) {
+	m.actionStorage = actions
+}
+
+// ResumeOperation prepares a failed operation to be processed again from the first unfinished stage.
+// If the step name is given, the stage of the step and all following stages are processed again, skipping steps preceding the given one.
+func (m *StagedManager) ResumeOperation(operation *internal.Operation, stepName string) error {
+	if stepName != "" {
+		stageIndex := m.stageIndexOfStep(stepName)
+		if stageIndex < 0 {
+			return fmt.Errorf("step %s not defined", stepName)
+		}
+		var finishedStages []string
+		for _, s := range m.stages[:stageIndex] {
+			if operation.IsStageFinished(s.name) {
+				finishedStages = append(finishedStages, s.name)
+			}
+		}
+		operation.FinishedStages = finishedStages
+	}
+
+	now := time.Now()
+	operation.State = domain.InProgress
+	operation.Description = "Operation resumed"
+	operation.LastError = kebError.LastError{}
+	operation.ResumedAt = &now
+	operation.ResumeFromStep = stepName
+	return nil
+}
+
+func (m *StagedManager) stageIndexOfStep(stepName string) int {
+	for i, s := range m.stages {
+		for _, step := range s.steps {
+			if step.Name() == stepName {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 func (m *StagedManager) GetAllStages() []string {
 	var all []string
 	for _, s := range m.stages {
@@ -108,6 +214,18 @@ func (m *StagedManager) GetAllStages() []string {
 }
 
 func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
+	if m.leaseCfg.Enabled {
+		err := m.operationStorage.AcquireOperationLease(operationID, m.leaseOwner, m.leaseCfg.Duration)
+		switch {
+		case dberr.IsNotFound(err):
+			m.log.Info(fmt.Sprintf("Operation %s does not exist, nothing to process", operationID))
+			return 0, nil
+		case err != nil:
+			m.log.Info(fmt.Sprintf("Unable to lease operation %s, retrying in %s: %s", operationID, m.leaseCfg.RetryInterval, err))
+			return m.leaseCfg.RetryInterval, nil
+		}
+		defer m.releaseLease(operationID)
+	}
 
 	operation, err := m.operationStorage.GetOperationByID(operationID)
 	if err != nil {
@@ -116,13 +234,25 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	}
 
 	logOperation := m.log.With("operationID", operationID, "instanceID", operation.InstanceID, "planID", operation.ProvisioningParameters.PlanID)
+	if m.leaseCfg.Enabled && (operation.State == domain.Succeeded || operation.State == domain.Failed) {
+		// the operation was finished by another replica while this one was waiting for the lease
+		logOperation.Info(fmt.Sprintf("Operation already finished with state %s", operation.State))
+		return 0, nil
+	}
+	switch operation.State {
+	case internal.OperationStateCanceled:
+		logOperation.Info("Operation already canceled")
+		return 0, nil
+	case internal.OperationStateCanceling:
+		return m.cancel(*operation, logOperation)
+	}
 	logOperation.Info(fmt.Sprintf("Start process operation steps for GlobalAccount=%s, ", operation.ProvisioningParameters.ErsContext.GlobalAccountID))
-	if time.Since(operation.CreatedAt) > m.operationTimeout {
+	if time.Since(operation.ProcessingStartedAt()) > m.operationTimeout {
 		timeoutErr := kebError.TimeoutError("operation has reached the time limit", string(kebError.KEBDependency))
 		operation.LastError = timeoutErr
 		defer m.publishEventOnFail(operation, err)
-		logOperation.Info(fmt.Sprintf("operation has reached the time limit: operation was created at: %s, timeout: %s elapsed %s",
-			operation.CreatedAt.Format(time.RFC3339Nano), m.operationTimeout.String(), time.Since(operation.CreatedAt).String()))
+		logOperation.Info(fmt.Sprintf("operation has reached the time limit: operation was created at: %s, processing started at: %s, timeout: %s elapsed %s",
+			operation.CreatedAt.Format(time.RFC3339Nano), operation.ProcessingStartedAt().Format(time.RFC3339Nano), m.operationTimeout.String(), time.Since(operation.ProcessingStartedAt()).String()))
 		operation.State = domain.Failed
 		_, err = m.operationStorage.UpdateOperation(*operation)
 		if err != nil {
@@ -137,6 +267,7 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 
 	var when time.Duration
 	processedOperation := *operation
+	resumeFromStep := processedOperation.ResumeFromStep
 
 	for _, stage := range m.stages {
 		if processedOperation.IsStageFinished(stage.name) {
@@ -146,13 +277,27 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 		for _, step := range stage.steps {
 			logStep := logOperation.With("step", step.Name()).
 				With("stage", stage.name)
+			if resumeFromStep != "" {
+				if step.Name() != resumeFromStep {
+					logStep.Debug(fmt.Sprintf("Skipping, the operation is resumed from step %s", resumeFromStep))
+					continue
+				}
+				resumeFromStep = ""
+			}
 			if step.condition != nil && !step.condition(processedOperation) {
 				logStep.Debug("Skipping")
 				continue
 			}
 			operation.EventInfof("processing step: %v", step.Name())
 
-			processedOperation, when, err = m.runStep(step, processedOperation, logStep)
+			if !m.renewLease(operationID, logStep) {
+				return m.leaseCfg.RetryInterval, nil
+			}
+			if canceling, ok := m.cancellationRequested(operationID, logStep); ok {
+				return m.cancel(*canceling, logOperation)
+			}
+
+			processedOperation, when, err = m.runStep(stage.name, step, m.policyOf(step.Name(), step.policy), processedOperation, logStep)
 			if err != nil {
 				logStep.Error(fmt.Sprintf("Process operation failed: %s", err))
 				operation.EventErrorf(err, "step %v processing returned error", step.Name())
@@ -174,6 +319,9 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 			logStep.Info(fmt.Sprintf("Step %q processed successfully", step.Name()))
 		}
 
+		if resumeFromStep == "" {
+			processedOperation.ResumeFromStep = ""
+		}
 		processedOperation, err = m.saveFinishedStage(processedOperation, stage, logOperation)
 
 		// it is ok, when operation does not exist in the DB - it can happen at the end of a deprovisioning process
@@ -199,6 +347,142 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// cancellationRequested checks, at the step boundary, if the operation was marked as canceling in the storage
+func (m *StagedManager) cancellationRequested(operationID string, log *slog.Logger) (*internal.Operation, bool) {
+	operation, err := m.operationStorage.GetOperationByID(operationID)
+	if err != nil {
+		// it is ok, when operation does not exist in the DB - it can happen at the end of a deprovisioning process
+		if !dberr.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("Unable to check if the operation cancellation was requested: %s", err))
+		}
+		return nil, false
+	}
+	return operation, operation.State == internal.OperationStateCanceling
+}
+
+// cancel runs compensation steps of the stages started by the operation in the reverse order and marks the operation as canceled
+func (m *StagedManager) cancel(operation internal.Operation, log *slog.Logger) (time.Duration, error) {
+	log.Info("Operation cancellation requested, running compensation steps")
+	operation.EventInfof("operation canceling")
+	previousState := operation.State
+
+	stages := m.startedStages(operation)
+	for i := len(stages) - 1; i >= 0; i-- {
+		for _, step := range stages[i].compensations {
+			logStep := log.With("step", step.Name()).With("stage", stages[i].name)
+			compensation := &errorRecordingStep{Step: step}
+			processedOperation, when, err := m.runStep(stages[i].name, compensation, m.policyOf(step.Name(), StepPolicy{}), operation, logStep)
+			if err == nil {
+				err = compensation.err
+			}
+			if err != nil || processedOperation.State == domain.Failed {
+				return m.cancellationFailed(processedOperation, step, previousState, err, logStep)
+			}
+			if when > 0 {
+				logStep.Warn(fmt.Sprintf("retrying compensation step %s by restarting the operation in %d s", step.Name(), int64(when.Seconds())))
+				return when, nil
+			}
+			operation = processedOperation
+		}
+	}
+
+	operation.State = internal.OperationStateCanceled
+	operation.Description = "Operation canceled"
+	updatedOperation, err := m.operationStorage.UpdateOperation(operation)
+	if err != nil {
+		log.Info(fmt.Sprintf("Unable to save canceled operation: %s", err))
+		return time.Second, nil
+	}
+
+	log.Info("Operation canceled")
+	operation.EventInfof("operation processing %v", updatedOperation.State)
+	m.publishOperationFinishedEvent(*updatedOperation)
+	m.recordCancellation(*updatedOperation, previousState, internal.OperationStateCanceled, log)
+	return 0, nil
+}
+
+// cancellationFailed marks the operation as failed if the compensation step did not do it, so the operation is not left
+// in the canceling state, which is reported as in progress
+func (m *StagedManager) cancellationFailed(operation internal.Operation, step Step, previousState domain.LastOperationState, stepErr error, log *slog.Logger) (time.Duration, error) {
+	if stepErr != nil {
+		log.Error(fmt.Sprintf("Compensation step failed: %s", stepErr))
+		operation.EventErrorf(stepErr, "compensation step %v processing returned error", step.Name())
+	}
+	if operation.State != domain.Failed {
+		om := &OperationManager{storage: m.operationStorage, component: kebError.KEBDependency, step: step.Name()}
+		failed, when, _ := om.OperationFailed(operation, fmt.Sprintf("operation cancellation failed in compensation step %s", step.Name()), stepErr, log)
+		if when > 0 {
+			// the compensation steps are run again when the failed operation cannot be saved
+			return when, nil
+		}
+		operation = failed
+	}
+
+	operation.EventInfof("operation processing %v", operation.State)
+	m.publishOperationFinishedEvent(operation)
+	m.recordCancellation(operation, previousState, domain.Failed, log)
+	return 0, stepErr
+}
+
+// errorRecordingStep remembers the error returned by the step, runStep does not return errors of steps if the operation
+// with the last error was saved
+type errorRecordingStep struct {
+	Step
+	err error
+}
+
+func (s *errorRecordingStep) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
+	processedOperation, when, err := s.Step.Run(operation, logger)
+	s.err = err
+	return processedOperation, when, err
+}
+
+// startedStages returns finished stages and the first not finished one, which could be interrupted by the cancellation
+func (m *StagedManager) startedStages(operation internal.Operation) []*stage {
+	var started []*stage
+	for _, s := range m.stages {
+		started = append(started, s)
+		if !operation.IsStageFinished(s.name) {
+			break
+		}
+	}
+	return started
+}
+
+// recordCancellation stores the transition of the operation from the state it had when the cancellation was processed to the result
+func (m *StagedManager) recordCancellation(operation internal.Operation, previousState, result domain.LastOperationState, log *slog.Logger) {
+	if m.actionStorage == nil {
+		return
+	}
+	message := fmt.Sprintf("%s operation %s canceled", operation.Type, operation.ID)
+	if result != internal.OperationStateCanceled {
+		message = fmt.Sprintf("%s operation %s cancellation failed", operation.Type, operation.ID)
+	}
+	err := m.actionStorage.InsertAction(pkg.OperationCancelActionType, operation.InstanceID, message, string(previousState), string(result))
+	if err != nil {
+		log.Error(fmt.Sprintf("while inserting action %q for instance ID %s: %v", pkg.OperationCancelActionType, operation.InstanceID, err))
+	}
+}
+
+func (m *StagedManager) renewLease(operationID string, log *slog.Logger) bool {
+	if !m.leaseCfg.Enabled {
+		return true
+	}
+	err := m.operationStorage.AcquireOperationLease(operationID, m.leaseOwner, m.leaseCfg.Duration)
+	// it is ok, when operation does not exist in the DB - it can happen at the end of a deprovisioning process
+	if err != nil && !dberr.IsNotFound(err) {
+		log.Warn(fmt.Sprintf("Unable to renew the lease, the operation is taken over by another broker instance: %s", err))
+		return false
+	}
+	return true
+}
+
+func (m *StagedManager) releaseLease(operationID string) {
+	if err := m.operationStorage.ReleaseOperationLease(operationID, m.leaseOwner); err != nil {
+		m.log.Warn(fmt.Sprintf("Unable to release the lease of operation %s: %s", operationID, err))
+	}
+}
+
 func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage, log *slog.Logger) (internal.Operation, error) {
 	operation.FinishStage(s.name)
 	op, err := m.operationStorage.UpdateOperation(operation)
@@ -211,7 +495,15 @@ func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage
 	return *op, nil
 }
 
-func (m *StagedManager) runStep(step Step, operation internal.Operation, logger *slog.Logger) (processedOperation internal.Operation, backoff time.Duration, err error) {
+// policyOf returns the policy of the step overridden by the configuration, if any
+func (m *StagedManager) policyOf(stepName string, declared StepPolicy) StepPolicy {
+	if policy, found := m.policies[stepName]; found {
+		return policy
+	}
+	return declared
+}
+
+func (m *StagedManager) runStep(stageName string, step Step, policy StepPolicy, operation internal.Operation, logger *slog.Logger) (processedOperation internal.Operation, backoff time.Duration, err error) {
 	var start time.Time
 	defer func() {
 		if pErr := recover(); pErr != nil {
@@ -228,14 +520,25 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 		start = time.Now()
 		logger.Info("Start step")
 		stepLogger := logger.With("step", step.Name(), "operationID", processedOperation.ID)
+		forgetRetryCause(&processedOperation, step.Name())
 		processedOperation, backoff, err = step.Run(processedOperation, stepLogger)
+		attempt := 1
+		if err == nil && backoff > 0 {
+			processedOperation, backoff, attempt, err = m.applyPolicy(step, policy, processedOperation, backoff, start, stepLogger)
+		} else {
+			forgetRetries(&processedOperation, step.Name())
+		}
+		m.recordStepRun(stageName, step.Name(), processedOperation, start, backoff, err, stepLogger)
 		if err != nil {
 			logOperation := stepLogger.With("error_component", processedOperation.LastError.GetComponent(), "error_reason", processedOperation.LastError.GetReason())
 			logOperation.Warn(fmt.Sprintf("Last error from step: %s", processedOperation.LastError.Error()))
 			// only save to storage, skip for alerting if error
-			_, err = m.operationStorage.UpdateOperation(processedOperation)
+			var updated *internal.Operation
+			updated, err = m.operationStorage.UpdateOperation(processedOperation)
 			if err != nil {
 				logOperation.Error("unable to save operation with resolved last error from step, additionally, see previous logs for earlier errors")
+			} else {
+				processedOperation = *updated
 			}
 		}
 
@@ -245,6 +548,8 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 				Duration: time.Since(start),
 				When:     backoff,
 				Error:    err,
+				Policy:   policy,
+				Attempt:  attempt,
 			},
 			Operation:    processedOperation,
 			OldOperation: operation,
@@ -266,6 +571,152 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 	}
 }
 
+// recordStepRun stores the result of the step run, failures are only logged to not interrupt the operation
+func (m *StagedManager) recordStepRun(stageName, stepName string, operation internal.Operation, start time.Time, backoff time.Duration, err error, log *slog.Logger) {
+	if m.stepStorage == nil {
+		return
+	}
+	now := time.Now()
+	record := internal.OperationStep{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		Stage:       stageName,
+		StepName:    stepName,
+		State:       internal.OperationStepStateSucceeded,
+		StartedAt:   start,
+		FinishedAt:  &now,
+		UpdatedAt:   now,
+	}
+	switch {
+	case err != nil || operation.State == domain.Failed:
+		record.State = internal.OperationStepStateFailed
+		switch {
+		case operation.LastError.Error() != "":
+			record.LastError = operation.LastError.Error()
+		case operation.LastError.GetReason() != "":
+			record.LastError = string(operation.LastError.GetReason())
+		case err != nil:
+			record.LastError = err.Error()
+		}
+	case backoff > 0:
+		record.State = internal.OperationStepStateRetrying
+		record.FinishedAt = nil
+		if cause := operation.StepRetries[stepName].LastError; cause != nil {
+			record.LastError = retryMessage(*cause)
+		}
+	}
+	err = m.stepStorage.Upsert(record)
+	// it is ok, when operation does not exist in the DB - it can happen at the end of a deprovisioning process
+	if err != nil && !dberr.IsNotFound(err) {
+		log.Warn(fmt.Sprintf("Unable to record the step run: %s", err))
+	}
+}
+
+// applyPolicy counts the step run which needs a retry and returns the retry interval computed by the policy.
+// When the policy is exhausted, the operation fails unless the policy allows retrying forever.
+// The counters are stored with the operation, so they survive restarts and are shared by broker replicas.
+func (m *StagedManager) applyPolicy(step Step, policy StepPolicy, operation internal.Operation, backoff time.Duration, start time.Time, log *slog.Logger) (internal.Operation, time.Duration, int, error) {
+	retries := recordRetry(&operation, step.Name(), start)
+	if policy.countsRetries() {
+		updated, err := m.operationStorage.UpdateOperation(operation)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Unable to save retries of the step: %s", err))
+			return operation, backoff, retries.Attempts, nil
+		}
+		operation = *updated
+	}
+	if policy.exhausted(retries.Attempts, retries.FirstRunAt) {
+		if !policy.RetryForever {
+			forgetRetries(&operation, step.Name())
+			component := kebError.KEBDependency
+			description := fmt.Sprintf("step %s exhausted its retry policy %s after %d attempts", step.Name(), policy, retries.Attempts)
+			var cause error
+			if retries.LastError != nil {
+				if retries.LastError.GetComponent() != "" {
+					component = retries.LastError.GetComponent()
+				}
+				if retries.LastError.GetReason() != "" {
+					description = fmt.Sprintf("%s: %s", description, retries.LastError.GetReason())
+				}
+				if retries.LastError.Error() != "" {
+					cause = errors.New(retries.LastError.Error())
+				}
+			}
+			om := &OperationManager{storage: m.operationStorage, component: component, step: step.Name()}
+			failed, when, err := om.OperationFailed(operation, description, cause, log)
+			return failed, when, retries.Attempts, err
+		}
+		log.Warn(fmt.Sprintf("Step exhausted its retry policy %s after %d attempts, retrying", policy, retries.Attempts))
+	}
+	return operation, policy.interval(backoff, retries.Attempts), retries.Attempts, nil
+}
+
+// recordRetry counts the step run in the operation
+func recordRetry(operation *internal.Operation, stepName string, start time.Time) internal.StepRetries {
+	retries, found := operation.StepRetries[stepName]
+	// a resumed operation starts counting retries from scratch, the cause of the current run is kept
+	if !found || retries.Attempts == 0 || !reflect.DeepEqual(retries.ResumedAt, operation.ResumedAt) {
+		retries = internal.StepRetries{FirstRunAt: start, ResumedAt: operation.ResumedAt, LastError: retries.LastError}
+	}
+	retries.Attempts++
+	// the map is copied, it is shared with copies of the operation made before the step run
+	counters := maps.Clone(operation.StepRetries)
+	if counters == nil {
+		counters = map[string]internal.StepRetries{}
+	}
+	counters[stepName] = retries
+	operation.StepRetries = counters
+	return retries
+}
+
+// recordRetryCause stores the cause of the step run which needs a retry
+func recordRetryCause(operation *internal.Operation, stepName string, cause kebError.LastError) {
+	if stepName == "" {
+		return
+	}
+	counters := maps.Clone(operation.StepRetries)
+	if counters == nil {
+		counters = map[string]internal.StepRetries{}
+	}
+	retries := counters[stepName]
+	retries.LastError = &cause
+	counters[stepName] = retries
+	operation.StepRetries = counters
+}
+
+// forgetRetryCause removes the cause of the previous step run, so only the cause of the current run is reported
+func forgetRetryCause(operation *internal.Operation, stepName string) {
+	retries, found := operation.StepRetries[stepName]
+	if !found || retries.LastError == nil {
+		return
+	}
+	counters := maps.Clone(operation.StepRetries)
+	retries.LastError = nil
+	counters[stepName] = retries
+	operation.StepRetries = counters
+}
+
+// retryMessage returns the message of the cause of the step retry
+func retryMessage(cause kebError.LastError) string {
+	if cause.Error() == "" {
+		return string(cause.GetReason())
+	}
+	if cause.GetReason() == "" {
+		return cause.Error()
+	}
+	return fmt.Sprintf("%s: %s", cause.GetReason(), cause.Error())
+}
+
+// forgetRetries removes the counters of the step which does not need a retry anymore
+func forgetRetries(operation *internal.Operation, stepName string) {
+	if _, found := operation.StepRetries[stepName]; !found {
+		return
+	}
+	counters := maps.Clone(operation.StepRetries)
+	delete(counters, stepName)
+	operation.StepRetries = counters
+}
+
 func (m *StagedManager) publishEventOnFail(operation *internal.Operation, err error) {
 	logOperation := m.log.With("operationID", operation.ID, "error_component", operation.LastError.GetComponent(), "error_reason", operation.LastError.GetReason())
 	logOperation.Error(fmt.Sprintf("Last error: %s", operation.LastError.Error()))
@@ -20,9 +20,11 @@ import (
 
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -35,13 +37,13 @@ func TestHappyPath(t *testing.T) {
 	const opID = "op-0001234"
 	operation := FixOperation("op-0001234")
 	mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	// when
@@ -60,17 +62,17 @@ func TestHappyPathWithStepCondition(t *testing.T) {
 	const opID = "op-0001234"
 	operation := FixOperation("op-0001234")
 	mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, func(_ internal.Operation) bool {
 		return false
-	})
+	}, process.StepPolicy{})
 	assert.NoError(t, err)
 	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, func(_ internal.Operation) bool {
 		return true
-	})
+	}, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	// when
@@ -89,15 +91,15 @@ func TestWithRetry(t *testing.T) {
 	const opID = "op-0001234"
 	operation := FixOperation("op-0001234")
 	mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &onceRetryingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &onceRetryingStep{name: "first-2", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &testingStep{name: "second-2", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &testingStep{name: "second-2", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	// when
@@ -116,15 +118,15 @@ func TestWithPanic(t *testing.T) {
 	const opID = "op-0001234"
 	operation := FixOperation("op-0001234")
 	mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &panicStep{name: "first-2-panic", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &panicStep{name: "first-2-panic", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &testingStep{name: "second-2-after-panic", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &testingStep{name: "second-2-after-panic", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	// when
@@ -144,13 +146,13 @@ func TestSkipFinishedStage(t *testing.T) {
 	operation.FinishStage("stage-1")
 
 	mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-1", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
-	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	err = mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	// when
@@ -164,6 +166,423 @@ func TestSkipFinishedStage(t *testing.T) {
 	assert.True(t, op.IsStageFinished("stage-2"))
 }
 
+func TestOperationLease(t *testing.T) {
+	leaseCfg := process.OperationLeaseConfig{Enabled: true, Duration: time.Minute, RetryInterval: 5 * time.Second}
+
+	t.Run("should not process operation leased by another replica", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		mgr.EnableOperationLease("replica-1", leaseCfg)
+		err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
+		assert.NoError(t, err)
+		err = operationStorage.AcquireOperationLease(operation.ID, "replica-2", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, leaseCfg.RetryInterval, retry)
+		eventCollector.AssertProcessedSteps(t, []string{})
+	})
+
+	t.Run("should take over operation when the lease expired and release it afterwards", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		mgr.EnableOperationLease("replica-1", leaseCfg)
+		err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
+		assert.NoError(t, err)
+		err = operationStorage.AcquireOperationLease(operation.ID, "replica-2", -time.Second)
+		assert.NoError(t, err)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Succeeded, op.State)
+		assert.NoError(t, operationStorage.AcquireOperationLease(operation.ID, "replica-2", time.Minute))
+	})
+
+	t.Run("should skip operation finished by another replica", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = domain.Succeeded
+		mgr, _, eventCollector := SetupStagedManager(t, operation)
+		mgr.EnableOperationLease("replica-1", leaseCfg)
+		err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{})
+		assert.NoError(t, err)
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{})
+	})
+
+	t.Run("should finish processing when operation does not exist", func(t *testing.T) {
+		// given
+		mgr, _, _ := SetupStagedManager(t, FixOperation("op-0001234"))
+		mgr.EnableOperationLease("replica-1", leaseCfg)
+
+		// when
+		retry, err := mgr.Execute("not-existing")
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+	})
+}
+
+func TestOperationCancellation(t *testing.T) {
+	t.Run("should stop at the step boundary and run compensation steps of started stages", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, memoryStorage, eventCollector := setupStagedManagerWithActions(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &cancelingStep{name: "second", operations: memoryStorage.Operations(), eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddCompensationStep("stage-1", &testingStep{name: "undo-first", eventPublisher: eventCollector}))
+		require.NoError(t, mgr.AddCompensationStep("stage-2", &testingStep{name: "undo-second", eventPublisher: eventCollector}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first", "second", "undo-second", "undo-first"})
+		op, err := memoryStorage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LastOperationState(internal.OperationStateCanceled), op.State)
+
+		actions, err := memoryStorage.Actions().ListActionsByInstanceID(operation.InstanceID)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, pkg.OperationCancelActionType, actions[0].Type)
+		assert.Equal(t, internal.OperationStateCanceling, actions[0].OldValue)
+		assert.Equal(t, internal.OperationStateCanceled, actions[0].NewValue)
+	})
+
+	t.Run("should cancel operation marked as canceling before processing", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = internal.OperationStateCanceling
+		mgr, memoryStorage, eventCollector := setupStagedManagerWithActions(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddCompensationStep("stage-1", &testingStep{name: "undo-first", eventPublisher: eventCollector}))
+		require.NoError(t, mgr.AddCompensationStep("stage-2", &testingStep{name: "undo-second", eventPublisher: eventCollector}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"undo-first"})
+		op, err := memoryStorage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.LastOperationState(internal.OperationStateCanceled), op.State)
+	})
+
+	t.Run("should fail operation when compensation step returns error", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = internal.OperationStateCanceling
+		mgr, memoryStorage, eventCollector := setupStagedManagerWithActions(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddCompensationStep("stage-1", &failingStep{name: "undo-first", eventPublisher: eventCollector}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.Error(t, err)
+		assert.Zero(t, retry)
+		op, err := memoryStorage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Equal(t, "operation cancellation failed in compensation step undo-first", op.Description)
+		assert.Equal(t, "undo-first", op.LastError.GetStep())
+		assert.Contains(t, op.LastError.Error(), "unable to revert")
+
+		actions, err := memoryStorage.Actions().ListActionsByInstanceID(operation.InstanceID)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, internal.OperationStateCanceling, actions[0].OldValue)
+		assert.Equal(t, string(domain.Failed), actions[0].NewValue)
+	})
+
+	t.Run("should not process canceled operation", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = internal.OperationStateCanceled
+		mgr, _, eventCollector := setupStagedManagerWithActions(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{})
+	})
+}
+
+func TestResumeOperation(t *testing.T) {
+	t.Run("should resume failed operation from the first unfinished stage", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = domain.Failed
+		operation.FinishedStages = []string{"stage-1"}
+		operation.LastError = kebError.LastError{Message: "step failed"}
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		err := mgr.ResumeOperation(&operation, "")
+		require.NoError(t, err)
+		_, err = operationStorage.UpdateOperation(operation)
+		require.NoError(t, err)
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"second", "third"})
+		op, err := operationStorage.GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, op.State)
+		assert.Empty(t, op.LastError.Error())
+		assert.NotNil(t, op.ResumedAt)
+	})
+
+	t.Run("should resume failed operation from the given step", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = domain.Failed
+		operation.FinishedStages = []string{"stage-1", "stage-2"}
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "third", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		err := mgr.ResumeOperation(&operation, "third")
+		require.NoError(t, err)
+		_, err = operationStorage.UpdateOperation(operation)
+		require.NoError(t, err)
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"third"})
+		op, err := operationStorage.GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, op.State)
+		assert.Empty(t, op.ResumeFromStep)
+	})
+
+	t.Run("should reject not defined step", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		operation.State = domain.Failed
+		mgr, _, eventCollector := SetupStagedManager(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		err := mgr.ResumeOperation(&operation, "not-defined")
+
+		// then
+		assert.EqualError(t, err, "step not-defined not defined")
+		assert.Equal(t, domain.Failed, operation.State)
+	})
+}
+
+func TestStepPolicy(t *testing.T) {
+	t.Run("should fail the operation when the step exhausts its retry policy", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 10, eventPublisher: eventCollector}, nil, process.StepPolicy{MaxAttempts: 3}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first", "first", "first"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Equal(t, "first", op.LastError.GetStep())
+	})
+
+	t.Run("should keep retrying the step when the policy allows retrying forever", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		require.NoError(t, mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 3, eventPublisher: eventCollector}, nil, process.StepPolicy{MaxAttempts: 1, RetryForever: true}))
+		require.NoError(t, mgr.AddStep("stage-2", &testingStep{name: "second", eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+
+		// when
+		retry, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		eventCollector.AssertProcessedSteps(t, []string{"first", "first", "first", "first", "second"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Succeeded, op.State)
+	})
+
+	t.Run("should apply the policy overridden by the configuration", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, operationStorage, eventCollector := SetupStagedManager(t, operation)
+		mgr.UseStepPolicies(process.StepPolicies{"first": {MaxAttempts: 1}})
+		require.NoError(t, mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 3, eventPublisher: eventCollector}, nil, process.StepPolicy{MaxAttempts: 10}))
+
+		// when
+		_, err := mgr.Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		eventCollector.AssertProcessedSteps(t, []string{"first"})
+		op, _ := operationStorage.GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Failed, op.State)
+	})
+
+	t.Run("should count retries of the step run by another manager", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		memoryStorage := storage.NewMemoryStorage()
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+		eventCollector := &CollectingEventHandler{}
+		step := &retryingStep{name: "first", retries: 10, eventPublisher: eventCollector}
+		newManager := func() *process.StagedManager {
+			mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, time.Hour, process.StagedManagerConfiguration{}, slog.New(slog.DiscardHandler))
+			mgr.DefineStages([]string{"stage-1"})
+			require.NoError(t, mgr.AddStep("stage-1", step, nil, process.StepPolicy{MaxAttempts: 3}))
+			return mgr
+		}
+
+		// when
+		for range 2 {
+			retry, err := newManager().Execute(operation.ID)
+			require.NoError(t, err)
+			require.NotZero(t, retry)
+		}
+		retry, err := newManager().Execute(operation.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		op, _ := memoryStorage.Operations().GetOperationByID(operation.ID)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Equal(t, "first", op.LastError.GetStep())
+	})
+
+	t.Run("should reject invalid policy", func(t *testing.T) {
+		// given
+		operation := FixOperation("op-0001234")
+		mgr, _, eventCollector := SetupStagedManager(t, operation)
+
+		// when
+		err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil, process.StepPolicy{Backoff: "linear"})
+
+		// then
+		assert.EqualError(t, err, "invalid policy of step first: unknown backoff type linear")
+	})
+}
+
+func TestRecordSteps(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, brokerStorage, eventCollector := setupStagedManagerWithActions(t, operation)
+	mgr.RecordSteps(brokerStorage.OperationSteps())
+	require.NoError(t, mgr.AddStep("stage-1", &retryingStep{name: "first", retries: 2, eventPublisher: eventCollector}, nil, process.StepPolicy{}))
+	require.NoError(t, mgr.AddStep("stage-2", &retryingStep{name: "second", retries: 10, eventPublisher: eventCollector}, nil, process.StepPolicy{MaxAttempts: 2}))
+
+	// when
+	_, err := mgr.Execute(operation.ID)
+
+	// then
+	require.NoError(t, err)
+	steps, err := brokerStorage.OperationSteps().ListByOperationID(operation.ID)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+
+	assert.Equal(t, "stage-1", steps[0].Stage)
+	assert.Equal(t, "first", steps[0].StepName)
+	assert.Equal(t, internal.OperationStepStateSucceeded, steps[0].State)
+	assert.Equal(t, 3, steps[0].Attempts)
+	assert.NotNil(t, steps[0].FinishedAt)
+
+	assert.Equal(t, "stage-2", steps[1].Stage)
+	assert.Equal(t, "second", steps[1].StepName)
+	assert.Equal(t, internal.OperationStepStateFailed, steps[1].State)
+	assert.Equal(t, 2, steps[1].Attempts)
+	assert.NotEmpty(t, steps[1].LastError)
+
+	steps, err = brokerStorage.OperationSteps().ListByInstanceID(operation.InstanceID)
+	require.NoError(t, err)
+	assert.Len(t, steps, 2)
+}
+
+func TestRecordRetryingStep(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, brokerStorage, _ := setupStagedManagerWithActions(t, operation)
+	mgr.RecordSteps(brokerStorage.OperationSteps())
+	om := process.NewOperationManager(brokerStorage.Operations(), "first", kebError.KEBDependency)
+	require.NoError(t, mgr.AddStep("stage-1", &causeRetryingStep{name: "first", operationManager: om}, nil, process.StepPolicy{}))
+
+	// when
+	when, err := mgr.Execute(operation.ID)
+
+	// then
+	require.NoError(t, err)
+	assert.NotZero(t, when)
+	steps, err := brokerStorage.OperationSteps().ListByOperationID(operation.ID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+
+	assert.Equal(t, internal.OperationStepStateRetrying, steps[0].State)
+	assert.Nil(t, steps[0].FinishedAt)
+	assert.Equal(t, "waiting for the resource: resource is not ready", steps[0].LastError)
+}
+
+func setupStagedManagerWithActions(t *testing.T, op internal.Operation) (*process.StagedManager, storage.BrokerStorage, *CollectingEventHandler) {
+	memoryStorage := storage.NewMemoryStorage()
+	err := memoryStorage.Operations().InsertOperation(op)
+	require.NoError(t, err)
+
+	eventCollector := &CollectingEventHandler{}
+	l := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, 3*time.Second, process.StagedManagerConfiguration{MaxStepProcessingTime: time.Second}, l)
+	mgr.SpeedUp(100000)
+	mgr.DefineStages([]string{"stage-1", "stage-2"})
+	mgr.RecordActions(memoryStorage.Actions())
+
+	return mgr, memoryStorage, eventCollector
+}
+
 func SetupStagedManager(t *testing.T, op internal.Operation) (*process.StagedManager, storage.Operations, *CollectingEventHandler) {
 	memoryStorage := storage.NewMemoryStorage()
 	err := memoryStorage.Operations().InsertOperation(op)
@@ -194,6 +613,40 @@ func (s *testingStep) Run(operation internal.Operation, logger *slog.Logger) (in
 	return operation, 0, nil
 }
 
+// cancelingStep simulates the cancellation requested while the step is processed
+type cancelingStep struct {
+	name           string
+	operations     storage.Operations
+	eventPublisher event.Publisher
+}
+
+func (s *cancelingStep) Name() string {
+	return s.name
+}
+func (s *cancelingStep) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	stored, err := s.operations.GetOperationByID(operation.ID)
+	if err != nil {
+		return operation, 0, err
+	}
+	stored.State = internal.OperationStateCanceling
+	_, err = s.operations.UpdateOperation(*stored)
+	return operation, 0, err
+}
+
+type failingStep struct {
+	name           string
+	eventPublisher event.Publisher
+}
+
+func (s *failingStep) Name() string {
+	return s.name
+}
+func (s *failingStep) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	return operation, 0, fmt.Errorf("unable to revert the step")
+}
+
 type onceRetryingStep struct {
 	name           string
 	processed      bool
@@ -213,6 +666,38 @@ func (s *onceRetryingStep) Run(operation internal.Operation, logger *slog.Logger
 	return operation, 0, nil
 }
 
+// retryingStep needs the given number of retries before it succeeds
+type retryingStep struct {
+	name           string
+	retries        int
+	eventPublisher event.Publisher
+}
+
+func (s *retryingStep) Name() string {
+	return s.name
+}
+func (s *retryingStep) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	if s.retries > 0 {
+		s.retries--
+		return operation, time.Millisecond, nil
+	}
+	return operation, 0, nil
+}
+
+type causeRetryingStep struct {
+	name             string
+	operationManager *process.OperationManager
+}
+
+func (s *causeRetryingStep) Name() string {
+	return s.name
+}
+
+func (s *causeRetryingStep) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
+	return s.operationManager.RetryStep(operation, "waiting for the resource", fmt.Errorf("resource is not ready"), logger)
+}
+
 type panicStep struct {
 	name           string
 	processed      bool
@@ -354,7 +839,7 @@ func TestOperationSucceededEvent(t *testing.T) {
 	const opID = "op-0001234"
 	operation := FixOperation("op-0001234")
 	mgr, _, pubSub := SetupStagedManager2(t, operation)
-	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: pubSub}, nil)
+	err := mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: pubSub}, nil, process.StepPolicy{})
 	assert.NoError(t, err)
 
 	rc := &resultCollector{}
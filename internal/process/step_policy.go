@@ -0,0 +1,122 @@
+package process
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal/utils"
+)
+
+type BackoffType string
+
+const (
+	// BackoffConstant retries the step in intervals returned by the step
+	BackoffConstant BackoffType = "constant"
+	// BackoffExponential doubles the interval returned by the step with every retry, up to MaxInterval
+	BackoffExponential BackoffType = "exponential"
+
+	// maxExponentialInterval limits the exponential backoff when MaxInterval is not set
+	maxExponentialInterval = 24 * time.Hour
+)
+
+// StepPolicy declares how the staged manager retries a step on top of the retry interval returned by the step.
+// The zero value keeps the step in charge of its retries.
+type StepPolicy struct {
+	// Interval replaces the retry interval returned by the step, 0 keeps the interval returned by the step
+	Interval time.Duration `yaml:"interval"`
+	// MaxAttempts is the number of step runs after which the retry policy is exhausted, 0 means no limit
+	MaxAttempts int `yaml:"maxAttempts"`
+	// Backoff defines how the retry interval grows, constant if empty
+	Backoff BackoffType `yaml:"backoff"`
+	// MaxInterval limits the retry interval of the exponential backoff, 0 means no limit
+	MaxInterval time.Duration `yaml:"maxInterval"`
+	// Jitter is the fraction of the retry interval randomly added to the interval, from 0 to 1
+	Jitter float64 `yaml:"jitter"`
+	// Timeout is the time since the first step run after which the retry policy is exhausted, 0 means no limit
+	Timeout time.Duration `yaml:"timeout"`
+	// RetryForever classifies an exhausted retry policy as a warning instead of the operation failure,
+	// the operation is still limited by the operation timeout
+	RetryForever bool `yaml:"retryForever"`
+}
+
+func (p StepPolicy) String() string {
+	return fmt.Sprintf("(Interval=%s; MaxAttempts=%d; Backoff=%s; MaxInterval=%s; Jitter=%g; Timeout=%s; RetryForever=%t)",
+		p.Interval, p.MaxAttempts, p.EffectiveBackoff(), p.MaxInterval, p.Jitter, p.Timeout, p.RetryForever)
+}
+
+// StepPolicies are policies overriding the ones declared in the code, by step name
+type StepPolicies map[string]StepPolicy
+
+func ReadStepPoliciesFromFile(filename string) (StepPolicies, error) {
+	var policies StepPolicies
+	err := utils.UnmarshalYamlFile(filename, &policies)
+	if err != nil {
+		return nil, fmt.Errorf("while unmarshalling a file with step policies: %w", err)
+	}
+	for stepName, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("while validating policy of step %s: %w", stepName, err)
+		}
+	}
+	return policies, nil
+}
+
+func (p StepPolicy) Validate() error {
+	switch p.Backoff {
+	case "", BackoffConstant, BackoffExponential:
+	default:
+		return fmt.Errorf("unknown backoff type %s", p.Backoff)
+	}
+	if p.Interval < 0 || p.MaxAttempts < 0 || p.MaxInterval < 0 || p.Timeout < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// EffectiveBackoff returns the backoff type, constant if not set
+func (p StepPolicy) EffectiveBackoff() BackoffType {
+	if p.Backoff == "" {
+		return BackoffConstant
+	}
+	return p.Backoff
+}
+
+// countsRetries checks if the policy depends on the number of step runs or the time of the first one
+func (p StepPolicy) countsRetries() bool {
+	return p.MaxAttempts > 0 || p.Timeout > 0 || p.EffectiveBackoff() == BackoffExponential
+}
+
+// exhausted checks if the step run given times since the given time must not be retried anymore
+func (p StepPolicy) exhausted(attempts int, since time.Time) bool {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return true
+	}
+	return p.Timeout > 0 && time.Since(since) >= p.Timeout
+}
+
+// interval returns the retry interval after the given number of step runs, based on the interval of the policy or,
+// if not declared, the interval returned by the step
+func (p StepPolicy) interval(stepInterval time.Duration, attempts int) time.Duration {
+	interval := stepInterval
+	if p.Interval > 0 {
+		interval = p.Interval
+	}
+	if p.EffectiveBackoff() == BackoffExponential {
+		maxInterval := maxExponentialInterval
+		if p.MaxInterval > 0 {
+			maxInterval = p.MaxInterval
+		}
+		for i := 1; i < attempts && interval < maxInterval; i++ {
+			interval *= 2
+		}
+		interval = min(interval, maxInterval)
+	}
+	if p.Jitter > 0 {
+		interval += time.Duration(rand.Float64() * p.Jitter * float64(interval))
+	}
+	return interval
+}
@@ -0,0 +1,140 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepPolicy_Interval(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy   StepPolicy
+		attempts int
+		expected time.Duration
+	}{
+		"zero policy keeps the step interval": {
+			policy:   StepPolicy{},
+			attempts: 5,
+			expected: 10 * time.Second,
+		},
+		"constant backoff keeps the step interval": {
+			policy:   StepPolicy{Backoff: BackoffConstant},
+			attempts: 5,
+			expected: 10 * time.Second,
+		},
+		"exponential backoff doubles the interval": {
+			policy:   StepPolicy{Backoff: BackoffExponential},
+			attempts: 3,
+			expected: 40 * time.Second,
+		},
+		"exponential backoff is limited by max interval": {
+			policy:   StepPolicy{Backoff: BackoffExponential, MaxInterval: time.Minute},
+			attempts: 10,
+			expected: time.Minute,
+		},
+		"exponential backoff is limited without max interval": {
+			policy:   StepPolicy{Backoff: BackoffExponential},
+			attempts: 1000,
+			expected: maxExponentialInterval,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			interval := tc.policy.interval(10*time.Second, tc.attempts)
+
+			// then
+			assert.Equal(t, tc.expected, interval)
+		})
+	}
+
+	t.Run("jitter extends the interval by the given fraction at most", func(t *testing.T) {
+		// given
+		policy := StepPolicy{Jitter: 0.5}
+
+		for i := 0; i < 100; i++ {
+			// when
+			interval := policy.interval(10*time.Second, 1)
+
+			// then
+			assert.GreaterOrEqual(t, interval, 10*time.Second)
+			assert.LessOrEqual(t, interval, 15*time.Second)
+		}
+	})
+}
+
+func TestStepPolicy_Exhausted(t *testing.T) {
+	// given
+	policy := StepPolicy{MaxAttempts: 3, Timeout: time.Hour}
+
+	// then
+	assert.False(t, policy.exhausted(2, time.Now()))
+	assert.True(t, policy.exhausted(3, time.Now()))
+	assert.True(t, policy.exhausted(1, time.Now().Add(-2*time.Hour)))
+	assert.False(t, StepPolicy{}.exhausted(1000, time.Now().Add(-1000*time.Hour)))
+}
+
+func TestReadStepPoliciesFromFile(t *testing.T) {
+	t.Run("should read step policies", func(t *testing.T) {
+		// given
+		path := writeStepPoliciesFile(t, `
+Check_RuntimeResource_Provisioning:
+  backoff: exponential
+  maxInterval: 1m
+  jitter: 0.2
+  timeout: 90m
+Discover_Available_Zones:
+  maxAttempts: 5
+  retryForever: true
+`)
+
+		// when
+		policies, err := ReadStepPoliciesFromFile(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, StepPolicies{
+			"Check_RuntimeResource_Provisioning": {Backoff: BackoffExponential, MaxInterval: time.Minute, Jitter: 0.2, Timeout: 90 * time.Minute},
+			"Discover_Available_Zones":           {MaxAttempts: 5, RetryForever: true},
+		}, policies)
+	})
+
+	t.Run("should read empty step policies", func(t *testing.T) {
+		// given
+		path := writeStepPoliciesFile(t, "")
+
+		// when
+		policies, err := ReadStepPoliciesFromFile(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, policies)
+	})
+
+	for name, content := range map[string]string{
+		"unknown backoff":       "step: {backoff: linear}",
+		"negative attempts":     "step: {maxAttempts: -1}",
+		"negative timeout":      "step: {timeout: -1m}",
+		"jitter greater than 1": "step: {jitter: 1.5}",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// given
+			path := writeStepPoliciesFile(t, content)
+
+			// when
+			_, err := ReadStepPoliciesFromFile(path)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func writeStepPoliciesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "step_policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	kebError "github.com/kyma-project/kyma-environment-broker/internal/error"
-	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers/aws"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 	"github.com/kyma-project/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
@@ -19,21 +19,21 @@ import (
 )
 
 type DiscoverAvailableZonesStep struct {
-	operationManager *process.OperationManager
-	operationStorage storage.Operations
-	instanceStorage  storage.Instances
-	providerSpec     *configuration.ProviderSpec
-	gardenerClient   *gardener.Client
-	awsClientFactory aws.ClientFactory
+	operationManager   *process.OperationManager
+	operationStorage   storage.Operations
+	instanceStorage    storage.Instances
+	providerSpec       *configuration.ProviderSpec
+	gardenerClient     *gardener.Client
+	zonesClientFactory hyperscalers.ZonesClientFactory
 }
 
-func NewDiscoverAvailableZonesStep(db storage.BrokerStorage, providerSpec *configuration.ProviderSpec, gardenerClient *gardener.Client, awsClientFactory aws.ClientFactory) *DiscoverAvailableZonesStep {
+func NewDiscoverAvailableZonesStep(db storage.BrokerStorage, providerSpec *configuration.ProviderSpec, gardenerClient *gardener.Client, zonesClientFactory hyperscalers.ZonesClientFactory) *DiscoverAvailableZonesStep {
 	step := &DiscoverAvailableZonesStep{
-		operationStorage: db.Operations(),
-		instanceStorage:  db.Instances(),
-		providerSpec:     providerSpec,
-		gardenerClient:   gardenerClient,
-		awsClientFactory: awsClientFactory,
+		operationStorage:   db.Operations(),
+		instanceStorage:    db.Instances(),
+		providerSpec:       providerSpec,
+		gardenerClient:     gardenerClient,
+		zonesClientFactory: zonesClientFactory,
 	}
 	step.operationManager = process.NewOperationManager(db.Operations(), step.Name(), kebError.KEBDependency)
 	return step
@@ -44,8 +44,9 @@ func (s *DiscoverAvailableZonesStep) Name() string {
 }
 
 func (s *DiscoverAvailableZonesStep) Run(operation internal.Operation, log *slog.Logger) (internal.Operation, time.Duration, error) {
-	if !s.providerSpec.ZonesDiscovery(runtime.CloudProviderFromString(operation.ProviderValues.ProviderType)) {
-		log.Info(fmt.Sprintf("Zones discovery disabled for provider %s, skipping", runtime.CloudProviderFromString(operation.ProviderValues.ProviderType)))
+	provider := runtime.CloudProviderFromString(operation.ProviderValues.ProviderType)
+	if !s.providerSpec.ZonesDiscovery(provider) {
+		log.Info(fmt.Sprintf("Zones discovery disabled for provider %s, skipping", provider))
 		return operation, 0, nil
 	}
 	if len(operation.DiscoveredZones) > 0 {
@@ -58,7 +59,7 @@ func (s *DiscoverAvailableZonesStep) Run(operation internal.Operation, log *slog
 		if dberr.IsNotFound(err) {
 			return s.operationManager.OperationFailed(operation, fmt.Sprintf("instance %s does not exists", operation.InstanceID), err, log)
 		}
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to get instance %s", operation.InstanceID), err, 10*time.Second, time.Minute, log)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to get instance %s", operation.InstanceID), err, log)
 	}
 
 	subscriptionSecretName := instance.SubscriptionSecretName
@@ -71,21 +72,19 @@ func (s *DiscoverAvailableZonesStep) Run(operation internal.Operation, log *slog
 
 	secretBinding, err := s.gardenerClient.GetSecretBinding(subscriptionSecretName)
 	if err != nil {
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to get secret binding %s", subscriptionSecretName), err, 10*time.Second, time.Minute, log)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to get secret binding %s", subscriptionSecretName), err, log)
 	}
 
 	secret, err := s.gardenerClient.GetSecret(secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName())
 	if err != nil {
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to get secret %s/%s", secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName()), err, 10*time.Second, time.Minute, log)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to get secret %s/%s", secretBinding.GetSecretRefNamespace(), secretBinding.GetSecretRefName()), err, log)
 	}
-	accessKeyID, secretAccessKey, err := aws.ExtractCredentials(secret)
-	if err != nil {
-		return s.operationManager.OperationFailed(operation, "failed to extract AWS credentials", err, log)
+	client, err := s.zonesClientFactory.New(context.Background(), provider, secret, operation.ProviderValues.Region)
+	if hyperscalers.IsCredentialsError(err) {
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("failed to extract %s credentials", provider), err, log)
 	}
-
-	client, err := s.awsClientFactory.New(context.Background(), accessKeyID, secretAccessKey, operation.ProviderValues.Region)
 	if err != nil {
-		return s.operationManager.RetryOperation(operation, "unable to create AWS client", err, 10*time.Second, time.Minute, log)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to create %s client", provider), err, log)
 	}
 
 	discoveredZones := make(map[string][]string)
@@ -104,7 +103,7 @@ func (s *DiscoverAvailableZonesStep) Run(operation internal.Operation, log *slog
 	for machineType := range discoveredZones {
 		zones, err := client.AvailableZones(context.Background(), machineType)
 		if err != nil {
-			return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to get available zones for machine type %s", machineType), err, 10*time.Second, time.Minute, log)
+			return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to get available zones for machine type %s", machineType), err, log)
 		}
 		rand.Shuffle(len(zones), func(i, j int) { zones[i], zones[j] = zones[j], zones[i] })
 		log.Info(fmt.Sprintf("Available zones for machine type %s: %v", machineType, zones))
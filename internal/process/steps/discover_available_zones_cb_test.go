@@ -52,7 +52,7 @@ func TestDiscoverAvailableZonesCBStep_ZonesDiscoveryDisabled(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, false),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -90,7 +90,7 @@ func TestDiscoverAvailableZonesCBStep_FailWhenNoSubscriptionSecretName(t *testin
 	assert.NoError(t, err)
 
 	step := NewDiscoverAvailableZonesCBStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true), fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{}, nil))
+		fixture.NewFakeZonesClientFactory(map[string][]string{}, nil))
 
 	// when
 	operation, repeat, err := step.Run(operation, fixLogger())
@@ -139,7 +139,7 @@ func TestDiscoverAvailableZonesCBStep_SubscriptionSecretNameFromOperation(t *tes
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -196,7 +196,7 @@ func TestDiscoverAvailableZonesCBStep_RegionFromProviderValues(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -235,7 +235,7 @@ func TestDiscoverAvailableZonesCBStep_MachineTypeFromProviderValues(t *testing.T
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m5.large": {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 		}, nil),
 	)
@@ -273,7 +273,7 @@ func TestDiscoverAvailableZonesCBStep_RepeatWhenAWSError(t *testing.T) {
 	assert.NoError(t, err)
 
 	step := NewDiscoverAvailableZonesCBStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true),
-		fixture.CreateGardenerClientWithCredentialsBindings(), fixture.NewFakeAWSClientFactory(map[string][]string{}, fmt.Errorf("AWS error")))
+		fixture.CreateGardenerClientWithCredentialsBindings(), fixture.NewFakeZonesClientFactory(map[string][]string{}, fmt.Errorf("AWS error")))
 
 	// when
 	operation, repeat, err := step.Run(operation, fixLogger())
@@ -320,7 +320,7 @@ func TestDiscoverAvailableZonesCBStep_ProvisioningHappyPath(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -374,7 +374,7 @@ func TestDiscoverAvailableZonesCBStep_UpdateHappyPath(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClientWithCredentialsBindings(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
 		}, nil),
@@ -43,7 +43,7 @@ func TestDiscoverAvailableZonesStep_ZonesDiscoveryDisabled(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, false),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -80,7 +80,7 @@ func TestDiscoverAvailableZonesStep_FailWhenNoSubscriptionSecretName(t *testing.
 	err = memoryStorage.Operations().InsertOperation(operation)
 	assert.NoError(t, err)
 
-	step := NewDiscoverAvailableZonesStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true), fixture.CreateGardenerClient(), fixture.NewFakeAWSClientFactory(map[string][]string{}, nil))
+	step := NewDiscoverAvailableZonesStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true), fixture.CreateGardenerClient(), fixture.NewFakeZonesClientFactory(map[string][]string{}, nil))
 
 	// when
 	operation, repeat, err := step.Run(operation, fixLogger())
@@ -129,7 +129,7 @@ func TestDiscoverAvailableZonesStep_SubscriptionSecretNameFromOperation(t *testi
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -186,7 +186,7 @@ func TestDiscoverAvailableZonesStep_RegionFromProviderValues(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -225,7 +225,7 @@ func TestDiscoverAvailableZonesStep_MachineTypeFromProviderValues(t *testing.T)
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m5.large": {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 		}, nil),
 	)
@@ -262,7 +262,7 @@ func TestDiscoverAvailableZonesStep_RepeatWhenAWSError(t *testing.T) {
 	err = memoryStorage.Operations().InsertOperation(operation)
 	assert.NoError(t, err)
 
-	step := NewDiscoverAvailableZonesStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true), fixture.CreateGardenerClient(), fixture.NewFakeAWSClientFactory(map[string][]string{}, fmt.Errorf("AWS error")))
+	step := NewDiscoverAvailableZonesStep(memoryStorage, fixture.NewProviderSpecWithZonesDiscovery(t, true), fixture.CreateGardenerClient(), fixture.NewFakeZonesClientFactory(map[string][]string{}, fmt.Errorf("AWS error")))
 
 	// when
 	operation, repeat, err := step.Run(operation, fixLogger())
@@ -309,7 +309,7 @@ func TestDiscoverAvailableZonesStep_ProvisioningHappyPath(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"m6i.large":   {"ap-southeast-2a", "ap-southeast-2b", "ap-southeast-2c"},
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
@@ -363,7 +363,7 @@ func TestDiscoverAvailableZonesStep_UpdateHappyPath(t *testing.T) {
 		memoryStorage,
 		fixture.NewProviderSpecWithZonesDiscovery(t, true),
 		fixture.CreateGardenerClient(),
-		fixture.NewFakeAWSClientFactory(map[string][]string{
+		fixture.NewFakeZonesClientFactory(map[string][]string{
 			"g6.xlarge":   {"ap-southeast-2a", "ap-southeast-2c"},
 			"g4dn.xlarge": {"ap-southeast-2b"},
 		}, nil),
@@ -36,31 +36,67 @@ func (s *InitKymaTemplate) Name() string {
 }
 
 func (s *InitKymaTemplate) Run(operation internal.Operation, logger *slog.Logger) (internal.Operation, time.Duration, error) {
-	planName, found := broker.AvailablePlans.GetPlanNameByID(broker.PlanIDType(operation.ProvisioningParameters.PlanID))
-	if !found {
-		return s.operationManager.OperationFailed(operation, fmt.Sprintf("PlanID %s not found in PlanNamesMapping", operation.ProvisioningParameters.PlanID), nil, logger)
+	planName, err := kymaTemplatePlanName(operation)
+	if err != nil {
+		return s.operationManager.OperationFailed(operation, err.Error(), nil, logger)
 	}
-	cfg := &internal.ConfigForPlan{}
-	err := s.configProvider.Provide(planName, cfg)
+	cfg, err := s.configForPlan(planName)
 	if err != nil {
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to provide configuration for plan %s", planName), err, 10*time.Second, 30*time.Second, logger)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to provide configuration for plan %s", planName), err, logger)
 	}
-	obj, err := DecodeKymaTemplate(cfg.KymaTemplate)
+	kymaTemplate, namespace, err := s.render(cfg.KymaTemplate, operation, planName, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Unable to create kyma template: %s", err.Error()))
 		return s.operationManager.OperationFailed(operation, "unable to create a kyma template", err, logger)
 	}
 
-	kymaTemplate, err := s.applyChannelOverride(cfg.KymaTemplate, obj, operation, planName, logger)
+	return s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
+		op.KymaResourceNamespace = namespace
+		op.KymaTemplate = kymaTemplate
+	}, logger)
+}
+
+// Resolve returns the Kyma template and its namespace for the operation without storing them
+func (s *InitKymaTemplate) Resolve(operation internal.Operation, logger *slog.Logger) (string, string, error) {
+	planName, err := kymaTemplatePlanName(operation)
+	if err != nil {
+		return "", "", err
+	}
+	cfg, err := s.configForPlan(planName)
 	if err != nil {
-		return s.operationManager.OperationFailed(operation, "unable to apply channel override", err, logger)
+		return "", "", fmt.Errorf("unable to provide configuration for plan %s: %w", planName, err)
+	}
+	return s.render(cfg.KymaTemplate, operation, planName, logger)
+}
+
+func kymaTemplatePlanName(operation internal.Operation) (string, error) {
+	planName, found := broker.AvailablePlans.GetPlanNameByID(broker.PlanIDType(operation.ProvisioningParameters.PlanID))
+	if !found {
+		return "", fmt.Errorf("PlanID %s not found in PlanNamesMapping", operation.ProvisioningParameters.PlanID)
 	}
+	return planName, nil
+}
+
+func (s *InitKymaTemplate) configForPlan(planName string) (*internal.ConfigForPlan, error) {
+	cfg := &internal.ConfigForPlan{}
+	if err := s.configProvider.Provide(planName, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
 
+// render decodes the Kyma template of the plan and applies the channel requested in the operation, it returns the template and its namespace
+func (s *InitKymaTemplate) render(defaultTemplate string, operation internal.Operation, planName string, logger *slog.Logger) (string, string, error) {
+	obj, err := DecodeKymaTemplate(defaultTemplate)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to decode the kyma template: %w", err)
+	}
+	kymaTemplate, err := s.applyChannelOverride(defaultTemplate, obj, operation, planName, logger)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to apply channel override: %w", err)
+	}
 	logger.Info(fmt.Sprintf("Decoded kyma template: %v", obj))
-	return s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
-		op.KymaResourceNamespace = obj.GetNamespace()
-		op.KymaTemplate = kymaTemplate
-	}, logger)
+	return kymaTemplate, obj.GetNamespace(), nil
 }
 
 func (s *InitKymaTemplate) applyChannelOverride(defaultTemplate string, obj *unstructured.Unstructured, operation internal.Operation, planName string, logger *slog.Logger) (string, error) {
@@ -18,10 +18,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewCheckRuntimeResourceStep(os storage.Operations, k8sClient client.Client, runtimeResourceStateRetry internal.RetryTuple) *checkRuntimeResource {
+// NewCheckRuntimeResourceStep returns the step waiting for the updated Runtime resource, the retry interval and the timeout
+// are declared in the step policy
+func NewCheckRuntimeResourceStep(os storage.Operations, k8sClient client.Client) *checkRuntimeResource {
 	step := &checkRuntimeResource{
-		k8sClient:                 k8sClient,
-		runtimeResourceStateRetry: runtimeResourceStateRetry,
+		k8sClient: k8sClient,
 	}
 	step.operationManager = process.NewOperationManager(os, step.Name(), kebError.InfrastructureManagerDependency)
 	return step
@@ -38,9 +39,8 @@ func NewCheckRuntimeResourceProvisioningStep(os storage.Operations, k8sClient cl
 }
 
 type checkRuntimeResource struct {
-	k8sClient                 client.Client
-	operationManager          *process.OperationManager
-	runtimeResourceStateRetry internal.RetryTuple
+	k8sClient        client.Client
+	operationManager *process.OperationManager
 }
 
 type checkRuntimeResourceProvisioning struct {
@@ -63,7 +63,7 @@ func (s *checkRuntimeResource) Run(operation internal.Operation, log *slog.Logge
 	runtime, err := s.GetRuntimeResource(operation.RuntimeID, operation.KymaResourceNamespace)
 	if err != nil {
 		log.Error(fmt.Sprintf("unable to get Runtime resource %s/%s", operation.KymaResourceNamespace, operation.RuntimeID))
-		return s.operationManager.RetryOperation(operation, "unable to get Runtime resource", err, kcpRetryInterval, kcpRetryTimeout, log)
+		return s.operationManager.RetryStep(operation, "unable to get Runtime resource", err, log)
 	}
 
 	// check status
@@ -76,8 +76,8 @@ func (s *checkRuntimeResource) Run(operation internal.Operation, log *slog.Logge
 		log.Info(fmt.Sprintf("Runtime resource status: %v; failing operation", runtime.Status))
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("Runtime resource in %s state", imv1.RuntimeStateFailed), nil, log)
 	default:
-		log.Info(fmt.Sprintf("Runtime resource status: %v; retrying in %v steps for: %v", runtime.Status, s.runtimeResourceStateRetry.Interval, s.runtimeResourceStateRetry.Timeout))
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("Runtime resource not in %s state", imv1.RuntimeStateReady), nil, s.runtimeResourceStateRetry.Interval, s.runtimeResourceStateRetry.Timeout, log)
+		log.Info(fmt.Sprintf("Runtime resource status: %v; retrying", runtime.Status))
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("Runtime resource not in %s state", imv1.RuntimeStateReady), nil, log)
 	}
 }
 
@@ -33,7 +33,7 @@ func TestCheckRuntimeResourceStep(t *testing.T) {
 		existingRuntime := createRuntime(imv1.RuntimeStateReady)
 		k8sClient := fake.NewClientBuilder().WithRuntimeObjects(&existingRuntime).Build()
 
-		step := NewCheckRuntimeResourceStep(os, k8sClient, internal.RetryTuple{Timeout: 2 * time.Second, Interval: time.Second})
+		step := NewCheckRuntimeResourceStep(os, k8sClient)
 
 		// when
 		_, backoff, err := step.Run(operation, fixLogger())
@@ -43,29 +43,7 @@ func TestCheckRuntimeResourceStep(t *testing.T) {
 		assert.Zero(t, backoff)
 	})
 
-	t.Run("fail operation when not ready and timeout", func(t *testing.T) {
-		// given
-		operation := createFakeProvisioningOp("2")
-		operation.CreatedAt = time.Now().Add(-1 * time.Hour)
-		err = os.InsertOperation(operation)
-		assert.NoError(t, err)
-
-		existingRuntime := createRuntime("In Progress")
-		k8sClient := fake.NewClientBuilder().WithRuntimeObjects(&existingRuntime).Build()
-
-		// force immediate timeout
-		step := NewCheckRuntimeResourceStep(os, k8sClient, internal.RetryTuple{Timeout: -1 * time.Second, Interval: 2 * time.Second})
-
-		// when
-		op, backoff, err := step.Run(operation, fixLogger())
-
-		// then
-		assert.Error(t, err)
-		assert.Zero(t, backoff)
-		assert.Equal(t, domain.Failed, op.State)
-	})
-
-	t.Run("retry operation when not ready and not timeout", func(t *testing.T) {
+	t.Run("retry operation when not ready", func(t *testing.T) {
 		// given
 		operation := createFakeProvisioningOp("3")
 		err = os.InsertOperation(operation)
@@ -74,7 +52,7 @@ func TestCheckRuntimeResourceStep(t *testing.T) {
 		existingRuntime := createRuntime("In Progress")
 		k8sClient := fake.NewClientBuilder().WithRuntimeObjects(&existingRuntime).Build()
 
-		step := NewCheckRuntimeResourceStep(os, k8sClient, internal.RetryTuple{Timeout: 2 * time.Second, Interval: time.Second})
+		step := NewCheckRuntimeResourceStep(os, k8sClient)
 
 		// when
 		_, backoff, err := step.Run(operation, fixLogger())
@@ -93,7 +71,7 @@ func TestCheckRuntimeResourceStep(t *testing.T) {
 		existingRuntime := createRuntime(imv1.RuntimeStateFailed)
 		k8sClient := fake.NewClientBuilder().WithRuntimeObjects(&existingRuntime).Build()
 
-		step := NewCheckRuntimeResourceStep(os, k8sClient, internal.RetryTuple{Timeout: 2 * time.Second, Interval: time.Second})
+		step := NewCheckRuntimeResourceStep(os, k8sClient)
 
 		// when
 		op, backoff, err := step.Run(operation, fixLogger())
@@ -155,7 +133,7 @@ func TestCheckRuntimeResourceProvisioningStep(t *testing.T) {
 		assert.ErrorContains(t, err, "not found")
 	})
 
-	t.Run("retry operation when not ready and not timeout", func(t *testing.T) {
+	t.Run("retry operation when not ready", func(t *testing.T) {
 		// given
 		operation := createFakeProvisioningOp("3")
 		operation.Description = "Operation created"
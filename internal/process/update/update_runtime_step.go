@@ -64,11 +64,39 @@ func (s *UpdateRuntimeStep) Run(operation internal.Operation, log *slog.Logger)
 		if errors.IsNotFound(err) {
 			return s.operationManager.OperationFailed(operation, fmt.Sprintf("Runtime Resource  %s not found", operation.GetRuntimeResourceName()), err, log)
 		}
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to get Runtime Resource %s", operation.GetRuntimeResourceName()), err, 10*time.Second, 1*time.Minute, log)
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to get Runtime Resource %s", operation.GetRuntimeResourceName()), err, log)
 	}
 
-	// Update the runtime
+	if err := s.updateRuntime(&runtime, operation, log); err != nil {
+		return s.operationManager.OperationFailed(operation, err.Error(), err, log)
+	}
+
+	err = s.k8sClient.Update(context.Background(), &runtime)
+	if err != nil {
+		return s.operationManager.RetryStep(operation, fmt.Sprintf("unable to update Runtime Resource %s", operation.GetRuntimeResourceName()), err, log)
+	}
+
+	// this sleep is needed to wait for the runtime to be updated by the infrastructure manager with state PENDING,
+	// then we can wait for the state READY in the next step
+	time.Sleep(s.delay)
+
+	return operation, 0, nil
+}
+
+// Preview renders the Runtime resource updated for the operation without applying it
+func (s *UpdateRuntimeStep) Preview(operation internal.Operation, log *slog.Logger) (*imv1.Runtime, error) {
+	var runtime = imv1.Runtime{}
+	err := s.k8sClient.Get(context.Background(), client.ObjectKey{Name: operation.GetRuntimeResourceName(), Namespace: operation.GetRuntimeResourceNamespace()}, &runtime)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get Runtime Resource %s: %w", operation.GetRuntimeResourceName(), err)
+	}
+	if err := s.updateRuntime(&runtime, operation, log); err != nil {
+		return nil, err
+	}
+	return &runtime, nil
+}
 
+func (s *UpdateRuntimeStep) updateRuntime(runtime *imv1.Runtime, operation internal.Operation, log *slog.Logger) error {
 	runtime.Spec.Shoot.Provider.Workers[0].Machine.Type = provisioning.DefaultIfParamNotSet(runtime.Spec.Shoot.Provider.Workers[0].Machine.Type, operation.UpdatingParameters.MachineType)
 	runtime.Spec.Shoot.Provider.Workers[0].Minimum = int32(provisioning.DefaultIfParamNotSet(int(runtime.Spec.Shoot.Provider.Workers[0].Minimum), operation.UpdatingParameters.AutoScalerMin))
 	runtime.Spec.Shoot.Provider.Workers[0].Maximum = int32(provisioning.DefaultIfParamNotSet(int(runtime.Spec.Shoot.Provider.Workers[0].Maximum), operation.UpdatingParameters.AutoScalerMax))
@@ -81,7 +109,7 @@ func (s *UpdateRuntimeStep) Run(operation internal.Operation, log *slog.Logger)
 	if operation.UpdatingParameters.AdditionalWorkerNodePools != nil {
 		values, err := s.valuesProvider.ValuesForPlanAndParameters(operation.ProvisioningParameters)
 		if err != nil {
-			return s.operationManager.OperationFailed(operation, fmt.Sprintf("while calculating plan specific values: %s", err), err, log)
+			return fmt.Errorf("while calculating plan specific values: %w", err)
 		}
 
 		currentAdditionalWorkers := make(map[string]gardener.Worker)
@@ -94,7 +122,7 @@ func (s *UpdateRuntimeStep) Run(operation internal.Operation, log *slog.Logger)
 		additionalWorkers, err := s.workersProvider.CreateAdditionalWorkers(values, currentAdditionalWorkers, operation.UpdatingParameters.AdditionalWorkerNodePools,
 			runtime.Spec.Shoot.Provider.Workers[0].Zones, operation.ProvisioningParameters.PlanID, operation.DiscoveredZones, log)
 		if err != nil {
-			return s.operationManager.OperationFailed(operation, fmt.Sprintf("while creating additional workers: %s", err), err, log)
+			return fmt.Errorf("while creating additional workers: %w", err)
 		}
 		runtime.Spec.Shoot.Provider.AdditionalWorkers = &additionalWorkers
 	}
@@ -203,14 +231,5 @@ func (s *UpdateRuntimeStep) Run(operation internal.Operation, log *slog.Logger)
 		runtime.SetLabels(steps.UpdatePlanLabels(runtime.GetLabels(), operation.UpdatedPlanID))
 	}
 
-	err = s.k8sClient.Update(context.Background(), &runtime)
-	if err != nil {
-		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to update Runtime Resource %s", operation.GetRuntimeResourceName()), err, 10*time.Second, 1*time.Minute, log)
-	}
-
-	// this sleep is needed to wait for the runtime to be updated by the infrastructure manager with state PENDING,
-	// then we can wait for the state READY in the next step
-	time.Sleep(s.delay)
-
-	return operation, 0, nil
+	return nil
 }
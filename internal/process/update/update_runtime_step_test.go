@@ -87,6 +87,32 @@ func TestUpdateRuntimeStep_RunUpdateMachineType(t *testing.T) {
 
 }
 
+func TestUpdateRuntimeStep_PreviewDoesNotUpdateRuntime(t *testing.T) {
+	// given
+	err := imv1.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	kcpClient := fake.NewClientBuilder().WithRuntimeObjects(fixRuntimeResource(runtimeResourceName)).Build()
+	step := NewUpdateRuntimeStep(memoryStorage, kcpClient, 0, broker.InfrastructureManager{}, nil, &workers.Provider{}, fixValuesProvider())
+	operation := fixture.FixUpdatingOperation("op-id", "inst-id").Operation
+	operation.RuntimeResourceName = runtimeResourceName
+	operation.KymaResourceNamespace = kcpSystemNamespace
+	operation.UpdatingParameters = internal.UpdatingParametersDTO{
+		MachineType: ptr.String("new-machine-type"),
+	}
+
+	// when
+	preview, err := step.Preview(operation, fixLogger())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "new-machine-type", preview.Spec.Shoot.Provider.Workers[0].Machine.Type)
+
+	var gotRuntime imv1.Runtime
+	err = kcpClient.Get(context.Background(), client.ObjectKey{Name: operation.RuntimeResourceName, Namespace: kcpSystemNamespace}, &gotRuntime)
+	require.NoError(t, err)
+	assert.NotEqual(t, "new-machine-type", gotRuntime.Spec.Shoot.Provider.Workers[0].Machine.Type)
+}
+
 func TestUpdateRuntimeStep_RunUpdateEmptyOIDCConfigWithOIDCObject(t *testing.T) {
 	// given
 	err := imv1.AddToScheme(scheme.Scheme)
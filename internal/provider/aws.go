@@ -4,13 +4,12 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
-	"github.com/kyma-project/kyma-environment-broker/internal/euaccess"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 )
 
 const (
 	DefaultAWSRegion              = "eu-central-1"
 	DefaultAWSTrialRegion         = "eu-west-1"
-	DefaultEuAccessAWSRegion      = "eu-central-1"
 	DefaultAWSMultiZoneCount      = 3
 	DefaultAWSMachineType         = "m6i.large"
 	DefaultOldAWSTrialMachineType = "m5.xlarge"
@@ -39,11 +38,13 @@ type (
 		UseSmallerMachineTypes bool
 		ProvisioningParameters internal.ProvisioningParameters
 		ZonesProvider          ZonesProvider
+		RegionPolicies         regionpolicy.Policies
 	}
 	AWSFreemiumInputProvider struct {
 		UseSmallerMachineTypes bool
 		ProvisioningParameters internal.ProvisioningParameters
 		ZonesProvider          ZonesProvider
+		RegionPolicies         regionpolicy.Policies
 	}
 )
 
@@ -115,8 +116,8 @@ func (p *AWSTrialInputProvider) Provide() internal.ProviderValues {
 }
 
 func (p *AWSTrialInputProvider) region() string {
-	if euaccess.IsEURestrictedAccess(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultEuAccessAWSRegion
+	if region, restricted := p.RegionPolicies.DefaultHyperscalerRegion(p.ProvisioningParameters.PlatformRegion, pkg.AWS); restricted {
+		return region
 	}
 	if p.ProvisioningParameters.PlatformRegion != "" {
 		abstractRegion, found := p.PlatformRegionMapping[p.ProvisioningParameters.PlatformRegion]
@@ -158,8 +159,8 @@ func (p *AWSFreemiumInputProvider) Provide() internal.ProviderValues {
 }
 
 func (p *AWSFreemiumInputProvider) region() string {
-	if euaccess.IsEURestrictedAccess(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultEuAccessAWSRegion
+	if region, restricted := p.RegionPolicies.DefaultHyperscalerRegion(p.ProvisioningParameters.PlatformRegion, pkg.AWS); restricted {
+		return region
 	}
 	return DefaultAWSRegion
 }
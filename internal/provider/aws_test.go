@@ -6,6 +6,7 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,7 +90,8 @@ func TestAWSTrialDefaults(t *testing.T) {
 			Parameters:     pkg.ProvisioningParametersDTO{Region: nil},
 			PlatformRegion: "cf-eu11",
 		},
-		ZonesProvider: FakeZonesProvider([]string{"a", "b", "c"}),
+		ZonesProvider:  FakeZonesProvider([]string{"a", "b", "c"}),
+		RegionPolicies: regionpolicy.DefaultPolicies(),
 	}
 
 	// when
@@ -112,6 +114,34 @@ func TestAWSTrialDefaults(t *testing.T) {
 	}, values)
 }
 
+func TestAWSTrialAndFreemiumEUAccessRegions(t *testing.T) {
+	for _, platformRegion := range []string{"cf-eu11", "cf-ch20", "cf-eu01", "cf-eu02"} {
+		t.Run(platformRegion, func(t *testing.T) {
+			// given
+			parameters := internal.ProvisioningParameters{PlatformRegion: platformRegion}
+			trial := AWSTrialInputProvider{
+				PlatformRegionMapping:  TestTrialPlatformRegionMapping,
+				ProvisioningParameters: parameters,
+				ZonesProvider:          FakeZonesProvider([]string{"a", "b", "c"}),
+				RegionPolicies:         regionpolicy.DefaultPolicies(),
+			}
+			freemium := AWSFreemiumInputProvider{
+				ProvisioningParameters: parameters,
+				ZonesProvider:          FakeZonesProvider([]string{"a", "b", "c"}),
+				RegionPolicies:         regionpolicy.DefaultPolicies(),
+			}
+
+			// when
+			trialValues := trial.Provide()
+			freemiumValues := freemium.Provide()
+
+			// then
+			assert.Equal(t, "eu-central-1", trialValues.Region)
+			assert.Equal(t, "eu-central-1", freemiumValues.Region)
+		})
+	}
+}
+
 func TestAWSTrialSpecific(t *testing.T) {
 
 	// given
@@ -4,12 +4,11 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
-	"github.com/kyma-project/kyma-environment-broker/internal/euaccess"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 )
 
 const (
 	DefaultAzureRegion              = "eastus"
-	DefaultEuAccessAzureRegion      = "switzerlandnorth"
 	DefaultAzureMultiZoneCount      = 3
 	DefaultAzureMachineType         = "Standard_D2s_v5"
 	DefaultOldAzureTrialMachineType = "Standard_D4s_v5"
@@ -40,6 +39,7 @@ type (
 		UseSmallerMachineTypes bool
 		ProvisioningParameters internal.ProvisioningParameters
 		ZonesProvider          ZonesProvider
+		RegionPolicies         regionpolicy.Policies
 	}
 	AzureLiteInputProvider struct {
 		Purpose                string
@@ -115,8 +115,8 @@ func (p *AzureTrialInputProvider) Provide() internal.ProviderValues {
 }
 
 func (p *AzureTrialInputProvider) region() string {
-	if euaccess.IsEURestrictedAccess(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultEuAccessAzureRegion
+	if region, restricted := p.RegionPolicies.DefaultHyperscalerRegion(p.ProvisioningParameters.PlatformRegion, pkg.Azure); restricted {
+		return region
 	}
 	if p.ProvisioningParameters.PlatformRegion != "" {
 		abstractRegion, found := p.PlatformRegionMapping[p.ProvisioningParameters.PlatformRegion]
@@ -154,13 +154,6 @@ func (p *AzureLiteInputProvider) Provide() internal.ProviderValues {
 	}
 }
 
-func (p *AzureLiteInputProvider) region() string {
-	if euaccess.IsEURestrictedAccess(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultEuAccessAzureRegion
-	}
-	return DefaultAzureRegion
-}
-
 func (p *AzureFreemiumInputProvider) Provide() internal.ProviderValues {
 	machineType := DefaultOldAzureTrialMachineType
 	if p.UseSmallerMachineTypes {
@@ -6,6 +6,8 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
+	"github.com/stretchr/testify/assert"
 )
 
 var AzureTrialPlatformRegionMapping = map[string]string{"cf-eu11": "europe", "cf-ap21": "asia"}
@@ -52,7 +54,8 @@ func TestAzureTrialDefaults(t *testing.T) {
 			Parameters:     pkg.ProvisioningParametersDTO{Region: ptr.String("eastus")},
 			PlatformRegion: "cf-eu11",
 		},
-		ZonesProvider: FakeZonesProvider([]string{"1", "2", "3"}),
+		ZonesProvider:  FakeZonesProvider([]string{"1", "2", "3"}),
+		RegionPolicies: regionpolicy.DefaultPolicies(),
 	}
 
 	// when
@@ -75,6 +78,26 @@ func TestAzureTrialDefaults(t *testing.T) {
 	}, values)
 }
 
+func TestAzureTrialEUAccessRegions(t *testing.T) {
+	for _, platformRegion := range []string{"cf-eu11", "cf-ch20", "cf-eu01", "cf-eu02"} {
+		t.Run(platformRegion, func(t *testing.T) {
+			// given
+			azure := AzureTrialInputProvider{
+				PlatformRegionMapping:  AzureTrialPlatformRegionMapping,
+				ProvisioningParameters: internal.ProvisioningParameters{PlatformRegion: platformRegion},
+				ZonesProvider:          FakeZonesProvider([]string{"1", "2", "3"}),
+				RegionPolicies:         regionpolicy.DefaultPolicies(),
+			}
+
+			// when
+			values := azure.Provide()
+
+			// then
+			assert.Equal(t, "switzerlandnorth", values.Region)
+		})
+	}
+}
+
 func TestAzureLiteDefaults(t *testing.T) {
 
 	// given
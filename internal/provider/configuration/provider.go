@@ -11,6 +11,7 @@ import (
 
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/hyperscalers"
 
 	"gopkg.in/yaml.v3"
 )
@@ -187,7 +188,7 @@ func (p *ProviderSpec) RegionSupportingMachine(providerType string) (internal.Re
 func (p *ProviderSpec) ValidateZonesDiscovery() error {
 	for provider, providerDTO := range p.data {
 		if providerDTO.ZonesDiscovery {
-			if provider != "aws" {
+			if !hyperscalers.SupportsZonesDiscovery(runtime.CloudProviderFromString(string(provider))) {
 				return fmt.Errorf("zone discovery is not yet supported for the %s provider", provider)
 			}
 
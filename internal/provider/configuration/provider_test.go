@@ -92,17 +92,33 @@ func TestProviderSpec_Validation(t *testing.T) {
 }
 
 func TestProviderSpec_ValidateZonesDiscovery(t *testing.T) {
-	t.Run("should fail when zonesDiscovery enabled on nonAWS provider", func(t *testing.T) {
+	t.Run("should fail when zonesDiscovery enabled on provider without zones discovery", func(t *testing.T) {
 		// given
 		providerSpec, err := NewProviderSpec(strings.NewReader(`
-gcp:
+sap-converged-cloud:
   zonesDiscovery: true
 `))
 		require.NoError(t, err)
 
 		// when / then
 		err = providerSpec.ValidateZonesDiscovery()
-		assert.EqualError(t, err, "zone discovery is not yet supported for the gcp provider")
+		assert.EqualError(t, err, "zone discovery is not yet supported for the sap-converged-cloud provider")
+	})
+
+	t.Run("should pass when zonesDiscovery enabled on Azure, GCP and Alicloud providers", func(t *testing.T) {
+		// given
+		providerSpec, err := NewProviderSpec(strings.NewReader(`
+azure:
+  zonesDiscovery: true
+gcp:
+  zonesDiscovery: true
+alicloud:
+  zonesDiscovery: true
+`))
+		require.NoError(t, err)
+
+		// when / then
+		assert.NoError(t, providerSpec.ValidateZonesDiscovery())
 	})
 
 	t.Run("should pass when zonesDiscovery enabled on AWS provider", func(t *testing.T) {
@@ -1,18 +1,19 @@
 package provider
 
 import (
+	"slices"
+
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
-	"github.com/kyma-project/kyma-environment-broker/internal/assuredworkloads"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 )
 
 const (
-	DefaultGCPRegion                 = "europe-west3"
-	DefaultGCPAssuredWorkloadsRegion = "me-central2"
-	DefaultGCPMachineType            = "n2-standard-2"
-	DefaultGCPTrialMachineType       = "n2-standard-4"
-	DefaultGCPMultiZoneCount         = 3
+	DefaultGCPRegion           = "europe-west3"
+	DefaultGCPMachineType      = "n2-standard-2"
+	DefaultGCPTrialMachineType = "n2-standard-4"
+	DefaultGCPMultiZoneCount   = 3
 )
 
 var europeGcp = "europe-west3"
@@ -32,6 +33,7 @@ type (
 		ProvisioningParameters internal.ProvisioningParameters
 		FailureTolerance       string
 		ZonesProvider          ZonesProvider
+		RegionPolicies         regionpolicy.Policies
 	}
 
 	GCPTrialInputProvider struct {
@@ -39,6 +41,7 @@ type (
 		PlatformRegionMapping  map[string]string
 		ProvisioningParameters internal.ProvisioningParameters
 		ZonesProvider          ZonesProvider
+		RegionPolicies         regionpolicy.Policies
 	}
 )
 
@@ -69,12 +72,20 @@ func (p *GCPInputProvider) zonesCount() int {
 }
 
 func (p *GCPInputProvider) region() string {
-	if assuredworkloads.IsKSA(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultGCPAssuredWorkloadsRegion
+	var region string
+	if p.ProvisioningParameters.Parameters.Region != nil {
+		region = *p.ProvisioningParameters.Parameters.Region
 	}
 
-	if p.ProvisioningParameters.Parameters.Region != nil && *p.ProvisioningParameters.Parameters.Region != "" {
-		return *p.ProvisioningParameters.Parameters.Region
+	if allowed, restricted := p.RegionPolicies.HyperscalerRegions(p.ProvisioningParameters.PlatformRegion, pkg.GCP); restricted {
+		if slices.Contains(allowed, region) {
+			return region
+		}
+		return allowed[0]
+	}
+
+	if region != "" {
+		return region
 	}
 
 	return DefaultGCPRegion
@@ -99,8 +110,8 @@ func (p *GCPTrialInputProvider) Provide() internal.ProviderValues {
 }
 
 func (p *GCPTrialInputProvider) region() string {
-	if assuredworkloads.IsKSA(p.ProvisioningParameters.PlatformRegion) {
-		return DefaultGCPAssuredWorkloadsRegion
+	if region, restricted := p.RegionPolicies.DefaultHyperscalerRegion(p.ProvisioningParameters.PlatformRegion, pkg.GCP); restricted {
+		return region
 	}
 	if p.ProvisioningParameters.PlatformRegion != "" {
 		abstractRegion, found := p.PlatformRegionMapping[p.ProvisioningParameters.PlatformRegion]
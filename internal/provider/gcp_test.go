@@ -6,6 +6,8 @@ import (
 	pkg "github.com/kyma-project/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGCPDefaults(t *testing.T) {
@@ -305,7 +307,8 @@ func TestGCPTrial_KSA(t *testing.T) {
 			},
 			PlatformRegion: "cf-sa30",
 		},
-		ZonesProvider: FakeZonesProvider([]string{"a", "b", "c"}),
+		ZonesProvider:  FakeZonesProvider([]string{"a", "b", "c"}),
+		RegionPolicies: regionpolicy.DefaultPolicies(),
 	}
 
 	// when
@@ -327,3 +330,45 @@ func TestGCPTrial_KSA(t *testing.T) {
 		DiskType:             "pd-standard",
 	}, values)
 }
+
+func TestGCP_RestrictedRegions(t *testing.T) {
+	policies := regionpolicy.Policies{
+		"cf-sa30": {Provider: "gcp", DataResidency: regionpolicy.DataResidencyAssuredWorkloads, HyperscalerRegions: map[string][]string{"gcp": {"me-central2", "me-west1"}}},
+	}
+
+	t.Run("should keep the allowed region", func(t *testing.T) {
+		// given
+		provider := GCPInputProvider{
+			ProvisioningParameters: internal.ProvisioningParameters{
+				Parameters:     pkg.ProvisioningParametersDTO{Region: ptr.String("me-west1")},
+				PlatformRegion: "cf-sa30",
+			},
+			ZonesProvider:  FakeZonesProvider([]string{"a", "b", "c"}),
+			RegionPolicies: policies,
+		}
+
+		// when
+		values := provider.Provide()
+
+		// then
+		assert.Equal(t, "me-west1", values.Region)
+	})
+
+	t.Run("should use the default allowed region when the region is not allowed", func(t *testing.T) {
+		// given
+		provider := GCPInputProvider{
+			ProvisioningParameters: internal.ProvisioningParameters{
+				Parameters:     pkg.ProvisioningParametersDTO{Region: ptr.String("europe-west3")},
+				PlatformRegion: "cf-sa30",
+			},
+			ZonesProvider:  FakeZonesProvider([]string{"a", "b", "c"}),
+			RegionPolicies: policies,
+		}
+
+		// when
+		values := provider.Provide()
+
+		// then
+		assert.Equal(t, "me-central2", values.Region)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/kyma-environment-broker/internal/provider/configuration"
+	"github.com/kyma-project/kyma-environment-broker/internal/regionpolicy"
 )
 
 type Provider interface {
@@ -32,8 +33,9 @@ type PlanSpecificValuesProvider struct {
 	defaultPurpose             string
 	commercialFailureTolerance string
 
-	zonesProvider ZonesProvider
-	planSpec      PlanConfigProvider
+	zonesProvider  ZonesProvider
+	planSpec       PlanConfigProvider
+	regionPolicies regionpolicy.Policies
 }
 
 func NewPlanSpecificValuesProvider(cfg broker.InfrastructureManager,
@@ -48,9 +50,15 @@ func NewPlanSpecificValuesProvider(cfg broker.InfrastructureManager,
 		commercialFailureTolerance: cfg.ControlPlaneFailureTolerance,
 		zonesProvider:              zonesProvider,
 		planSpec:                   planSpec,
+		regionPolicies:             regionpolicy.DefaultPolicies(),
 	}
 }
 
+// UseRegionPolicies replaces the default region policies, which restrict hyperscaler regions of runtimes in platform regions
+func (s *PlanSpecificValuesProvider) UseRegionPolicies(policies regionpolicy.Policies) {
+	s.regionPolicies = policies
+}
+
 func NewFakePlanSpecFromFile() (*configuration.PlanSpecifications, error) {
 	_, filename, _, _ := runtime.Caller(0)
 	dir := filepath.Dir(filename)
@@ -98,6 +106,7 @@ func (s *PlanSpecificValuesProvider) ValuesForPlanAndParameters(provisioningPara
 			ProvisioningParameters: provisioningParameters,
 			FailureTolerance:       s.commercialFailureTolerance,
 			ZonesProvider:          s.zonesProvider,
+			RegionPolicies:         s.regionPolicies,
 		}
 	case broker.FreemiumPlanID:
 		switch provisioningParameters.PlatformProvider {
@@ -106,6 +115,7 @@ func (s *PlanSpecificValuesProvider) ValuesForPlanAndParameters(provisioningPara
 				UseSmallerMachineTypes: s.useSmallerMachineTypes,
 				ProvisioningParameters: provisioningParameters,
 				ZonesProvider:          s.zonesProvider,
+				RegionPolicies:         s.regionPolicies,
 			}
 		case pkg.Azure:
 			p = &AzureFreemiumInputProvider{
@@ -146,12 +156,14 @@ func (s *PlanSpecificValuesProvider) ValuesForPlanAndParameters(provisioningPara
 				UseSmallerMachineTypes: s.useSmallerMachineTypes,
 				ProvisioningParameters: provisioningParameters,
 				ZonesProvider:          s.zonesProvider,
+				RegionPolicies:         s.regionPolicies,
 			}
 		case pkg.GCP:
 			p = &GCPTrialInputProvider{
 				PlatformRegionMapping:  s.trialPlatformRegionMapping,
 				ProvisioningParameters: provisioningParameters,
 				ZonesProvider:          s.zonesProvider,
+				RegionPolicies:         s.regionPolicies,
 			}
 		case pkg.Azure:
 			p = &AzureTrialInputProvider{
@@ -159,6 +171,7 @@ func (s *PlanSpecificValuesProvider) ValuesForPlanAndParameters(provisioningPara
 				UseSmallerMachineTypes: s.useSmallerMachineTypes,
 				ProvisioningParameters: provisioningParameters,
 				ZonesProvider:          s.zonesProvider,
+				RegionPolicies:         s.regionPolicies,
 			}
 		default:
 			return internal.ProviderValues{}, fmt.Errorf("trial provider for %s not yet implemented", trialProvider)
@@ -0,0 +1,319 @@
+package regionpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/kyma-environment-broker/internal/utils"
+)
+
+// DataResidency classifies the restrictions of runtimes created in a platform region
+type DataResidency string
+
+const (
+	// DataResidencyStandard does not restrict runtimes
+	DataResidencyStandard DataResidency = "standard"
+	// DataResidencyEUAccess restricts runtimes to hyperscaler regions and accounts operated under EU access
+	DataResidencyEUAccess DataResidency = "euAccess"
+	// DataResidencyAssuredWorkloads restricts runtimes to hyperscaler regions and accounts of the assured workloads, for example in KSA
+	DataResidencyAssuredWorkloads DataResidency = "assuredWorkloads"
+)
+
+// Policy defines how runtimes created in a platform region are handled
+type Policy struct {
+	// Provider is the provider which hosts the platform region
+	Provider string `yaml:"provider"`
+	// DataResidency is the data residency class of the platform region, standard if empty
+	DataResidency DataResidency `yaml:"dataResidency"`
+	// HyperscalerRegions are hyperscaler regions allowed in the platform region by provider, the first region is the default one.
+	// Regions of providers which are not listed are not restricted.
+	HyperscalerRegions map[string][]string `yaml:"hyperscalerRegions"`
+	// RequiredHAPFlags are output attributes of the HAP rule, which must be set for the rule matching the platform region
+	RequiredHAPFlags []string `yaml:"requiredHAPFlags"`
+}
+
+// Policies are policies of platform regions, by platform region
+type Policies map[string]Policy
+
+// providerPlans are plans named after the provider, used to match HAP rules of the provider
+var providerPlans = map[runtime.CloudProvider]string{
+	runtime.AWS:               "aws",
+	runtime.Azure:             "azure",
+	runtime.GCP:               "gcp",
+	runtime.SapConvergedCloud: "sap-converged-cloud",
+	runtime.Alicloud:          "alicloud",
+}
+
+var platformRegionProviderRE = regexp.MustCompile("[0-9]")
+
+// DefaultPolicies returns policies used when no policies file is configured
+func DefaultPolicies() Policies {
+	return Policies{
+		"cf-eu11": {
+			Provider:           "aws",
+			DataResidency:      DataResidencyEUAccess,
+			HyperscalerRegions: euAccessHyperscalerRegions(),
+			RequiredHAPFlags:   []string{rules.EUAccessAttributeName},
+		},
+		"cf-ch20": {
+			Provider:           "azure",
+			DataResidency:      DataResidencyEUAccess,
+			HyperscalerRegions: euAccessHyperscalerRegions(),
+			RequiredHAPFlags:   []string{rules.EUAccessAttributeName},
+		},
+		"cf-eu01": {
+			Provider:           "sap-converged-cloud",
+			DataResidency:      DataResidencyEUAccess,
+			HyperscalerRegions: euAccessHyperscalerRegions(),
+		},
+		"cf-eu02": {
+			Provider:           "sap-converged-cloud",
+			DataResidency:      DataResidencyEUAccess,
+			HyperscalerRegions: euAccessHyperscalerRegions(),
+		},
+		"cf-sa30": {
+			Provider:           "gcp",
+			DataResidency:      DataResidencyAssuredWorkloads,
+			HyperscalerRegions: map[string][]string{"gcp": {"me-central2"}},
+			RequiredHAPFlags:   []string{rules.PlatformRegionSuffix},
+		},
+	}
+}
+
+// euAccessHyperscalerRegions returns the hyperscaler regions of EU access platform regions, trial and freemium runtimes
+// are also created in them when the platform region is hosted by another provider
+func euAccessHyperscalerRegions() map[string][]string {
+	return map[string][]string{"aws": {"eu-central-1"}, "azure": {"switzerlandnorth"}}
+}
+
+// ReadPoliciesFromFile reads and validates policies from the file, the default policies are returned if the filename is empty
+func ReadPoliciesFromFile(filename string) (Policies, error) {
+	if filename == "" {
+		return DefaultPolicies(), nil
+	}
+	var policies Policies
+	err := utils.UnmarshalYamlFile(filename, &policies)
+	if err != nil {
+		return nil, fmt.Errorf("while unmarshalling a file with region policies: %w", err)
+	}
+	if policies == nil {
+		policies = Policies{}
+	}
+	for platformRegion, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("while validating policy of platform region %s: %w", platformRegion, err)
+		}
+	}
+	return policies, nil
+}
+
+func (p Policy) Validate() error {
+	if !isKnownProvider(p.Provider) {
+		return fmt.Errorf("unknown provider %q", p.Provider)
+	}
+	switch p.DataResidency {
+	case "", DataResidencyStandard, DataResidencyEUAccess, DataResidencyAssuredWorkloads:
+	default:
+		return fmt.Errorf("unknown data residency %q, allowed values: %s, %s, %s", p.DataResidency, DataResidencyStandard, DataResidencyEUAccess, DataResidencyAssuredWorkloads)
+	}
+	for provider, regions := range p.HyperscalerRegions {
+		if !isKnownProvider(provider) {
+			return fmt.Errorf("unknown provider %q of hyperscaler regions", provider)
+		}
+		if len(regions) == 0 {
+			return fmt.Errorf("hyperscaler regions of the %s provider must not be empty", provider)
+		}
+	}
+	for _, flag := range p.RequiredHAPFlags {
+		switch flag {
+		case rules.EUAccessAttributeName, rules.PlatformRegionSuffix, rules.SharedAttributeName:
+		default:
+			return fmt.Errorf("unsupported HAP flag %q, allowed values: %s, %s, %s", flag, rules.EUAccessAttributeName, rules.PlatformRegionSuffix, rules.SharedAttributeName)
+		}
+	}
+	return nil
+}
+
+func (p Policy) String() string {
+	return fmt.Sprintf("(Provider=%s; DataResidency=%s; HyperscalerRegions=%v; RequiredHAPFlags=%v)",
+		p.Provider, p.effectiveDataResidency(), p.HyperscalerRegions, p.RequiredHAPFlags)
+}
+
+func (p Policy) effectiveDataResidency() DataResidency {
+	if p.DataResidency == "" {
+		return DataResidencyStandard
+	}
+	return p.DataResidency
+}
+
+// Provider returns the provider hosting the platform region. Platform regions without a policy follow the naming convention,
+// where the first digit of the region name identifies the provider.
+func (p Policies) Provider(platformRegion string) runtime.CloudProvider {
+	if policy, found := p[platformRegion]; found {
+		return runtime.CloudProviderFromString(policy.Provider)
+	}
+	if platformRegion == "" {
+		return runtime.UnknownProvider
+	}
+	switch platformRegionProviderRE.FindString(platformRegion) {
+	case "0":
+		return runtime.SapConvergedCloud
+	case "1":
+		return runtime.AWS
+	case "2":
+		return runtime.Azure
+	case "3":
+		return runtime.GCP
+	case "4":
+		return runtime.Alicloud
+	default:
+		return runtime.UnknownProvider
+	}
+}
+
+func (p Policies) DataResidency(platformRegion string) DataResidency {
+	return p[platformRegion].effectiveDataResidency()
+}
+
+func (p Policies) IsEURestrictedAccess(platformRegion string) bool {
+	return p.DataResidency(platformRegion) == DataResidencyEUAccess
+}
+
+func (p Policies) IsAssuredWorkloads(platformRegion string) bool {
+	return p.DataResidency(platformRegion) == DataResidencyAssuredWorkloads
+}
+
+// HyperscalerRegions returns hyperscaler regions of the provider allowed in the platform region,
+// restricted is false when the platform region does not restrict regions of the provider
+func (p Policies) HyperscalerRegions(platformRegion string, provider runtime.CloudProvider) (regions []string, restricted bool) {
+	policy, found := p[platformRegion]
+	if !found {
+		return nil, false
+	}
+	for name, regions := range policy.HyperscalerRegions {
+		if runtime.CloudProviderFromString(name) == provider {
+			return regions, true
+		}
+	}
+	return nil, false
+}
+
+// DefaultHyperscalerRegion returns the first hyperscaler region of the provider allowed in the platform region
+func (p Policies) DefaultHyperscalerRegion(platformRegion string, provider runtime.CloudProvider) (string, bool) {
+	regions, restricted := p.HyperscalerRegions(platformRegion, provider)
+	if !restricted {
+		return "", false
+	}
+	return regions[0], true
+}
+
+// FilterHyperscalerRegions removes regions which are not allowed in the platform region, keeping the order of regions
+func (p Policies) FilterHyperscalerRegions(platformRegion string, provider runtime.CloudProvider, regions []string) []string {
+	allowed, restricted := p.HyperscalerRegions(platformRegion, provider)
+	if !restricted {
+		return regions
+	}
+	filtered := make([]string, 0, len(regions))
+	for _, region := range regions {
+		if slices.Contains(allowed, region) {
+			filtered = append(filtered, region)
+		}
+	}
+	return filtered
+}
+
+// RegionValidator validates hyperscaler regions of providers, implemented by the providers configuration
+type RegionValidator interface {
+	Regions(provider runtime.CloudProvider) []string
+	Validate(provider runtime.CloudProvider, region string) error
+}
+
+// ValidateHyperscalerRegions checks that all allowed hyperscaler regions are configured for their providers,
+// regions of providers without any configured region are not validated
+func (p Policies) ValidateHyperscalerRegions(validator RegionValidator) error {
+	for _, platformRegion := range p.platformRegions() {
+		for name, regions := range p[platformRegion].HyperscalerRegions {
+			provider := runtime.CloudProviderFromString(name)
+			if len(validator.Regions(provider)) == 0 {
+				continue
+			}
+			for _, region := range regions {
+				if err := validator.Validate(provider, region); err != nil {
+					return fmt.Errorf("invalid hyperscaler region of platform region %s: %w", platformRegion, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateHAPRules checks that HAP rules of the provider selected in the platform region set all required HAP flags, it
+// checks the rule matching the default hyperscaler region and all rules restricted to the platform region
+func (p Policies) ValidateHAPRules(rulesService *rules.RulesService) error {
+	var errs []string
+	for _, platformRegion := range p.platformRegions() {
+		policy := p[platformRegion]
+		if len(policy.RequiredHAPFlags) == 0 {
+			continue
+		}
+		provider := runtime.CloudProviderFromString(policy.Provider)
+		hyperscalerRegion, _ := p.DefaultHyperscalerRegion(platformRegion, provider)
+		// the validation is not done for an instance, rules restricted to global accounts, subaccount labels or machine
+		// families in the platform region are checked below
+		attributes := rules.NewProvisioningAttributes(providerPlans[provider], platformRegion, hyperscalerRegion, providerPlans[provider], "", nil, "")
+		result, found := rulesService.MatchProvisioningAttributesWithValidRuleset(attributes)
+		if !found {
+			errs = append(errs, fmt.Sprintf("platform region %s: no HAP rule matches the %s plan", platformRegion, attributes.Plan))
+			continue
+		}
+		results := append([]rules.Result{result}, rulesService.MatchPlatformRegionRules(attributes)...)
+		checked := map[string]bool{}
+		for _, result := range results {
+			if checked[result.Rule()] {
+				continue
+			}
+			checked[result.Rule()] = true
+			for _, flag := range policy.RequiredHAPFlags {
+				if !hasFlag(result, flag, platformRegion) {
+					errs = append(errs, fmt.Sprintf("platform region %s: HAP rule %q does not set the required %s flag", platformRegion, result.Rule(), flag))
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("HAP rules do not comply with region policies: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func hasFlag(result rules.Result, flag, platformRegion string) bool {
+	switch flag {
+	case rules.EUAccessAttributeName:
+		return result.IsEUAccess()
+	case rules.SharedAttributeName:
+		return result.IsShared()
+	case rules.PlatformRegionSuffix:
+		return strings.HasSuffix(result.Hyperscaler(), "_"+platformRegion)
+	default:
+		return false
+	}
+}
+
+func (p Policies) platformRegions() []string {
+	platformRegions := make([]string, 0, len(p))
+	for platformRegion := range p {
+		platformRegions = append(platformRegions, platformRegion)
+	}
+	sort.Strings(platformRegions)
+	return platformRegions
+}
+
+func isKnownProvider(provider string) bool {
+	_, found := providerPlans[runtime.CloudProviderFromString(provider)]
+	return found
+}
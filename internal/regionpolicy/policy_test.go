@@ -0,0 +1,250 @@
+package regionpolicy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/common/hyperscaler/rules"
+	"github.com/kyma-project/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestReadPoliciesFromFile(t *testing.T) {
+	t.Run("should read region policies", func(t *testing.T) {
+		// given
+		path := writePoliciesFile(t, `
+cf-eu11:
+  provider: aws
+  dataResidency: euAccess
+  hyperscalerRegions:
+    aws: [eu-central-1]
+  requiredHAPFlags: [EU]
+cf-us10:
+  provider: aws
+`)
+
+		// when
+		policies, err := ReadPoliciesFromFile(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Policies{
+			"cf-eu11": {Provider: "aws", DataResidency: DataResidencyEUAccess, HyperscalerRegions: map[string][]string{"aws": {"eu-central-1"}}, RequiredHAPFlags: []string{"EU"}},
+			"cf-us10": {Provider: "aws"},
+		}, policies)
+	})
+
+	t.Run("should read empty region policies", func(t *testing.T) {
+		// given
+		path := writePoliciesFile(t, "")
+
+		// when
+		policies, err := ReadPoliciesFromFile(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, policies)
+	})
+
+	t.Run("should return default policies when no file is configured", func(t *testing.T) {
+		// when
+		policies, err := ReadPoliciesFromFile("")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, DefaultPolicies(), policies)
+	})
+
+	for name, content := range map[string]string{
+		"unknown provider":                 "cf-eu11: {provider: ibm}",
+		"unknown data residency":           "cf-eu11: {provider: aws, dataResidency: local}",
+		"unknown hyperscaler region owner": "cf-eu11: {provider: aws, hyperscalerRegions: {ibm: [eu-de]}}",
+		"empty hyperscaler regions":        "cf-eu11: {provider: aws, hyperscalerRegions: {aws: []}}",
+		"unsupported HAP flag":             "cf-eu11: {provider: aws, requiredHAPFlags: [P]}",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// given
+			path := writePoliciesFile(t, content)
+
+			// when
+			_, err := ReadPoliciesFromFile(path)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicies_Provider(t *testing.T) {
+	// given
+	policies := Policies{"cf-eu30": {Provider: "azure"}}
+
+	for platformRegion, expected := range map[string]runtime.CloudProvider{
+		"cf-eu30": runtime.Azure,
+		"cf-eu01": runtime.SapConvergedCloud,
+		"cf-eu10": runtime.AWS,
+		"cf-eu20": runtime.Azure,
+		"cf-sa30": runtime.GCP,
+		"cf-cn40": runtime.Alicloud,
+		"cf-eu":   runtime.UnknownProvider,
+		"":        runtime.UnknownProvider,
+	} {
+		t.Run(platformRegion, func(t *testing.T) {
+			// when
+			provider := policies.Provider(platformRegion)
+
+			// then
+			assert.Equal(t, expected, provider)
+		})
+	}
+}
+
+func TestPolicies_DataResidency(t *testing.T) {
+	// given
+	policies := DefaultPolicies()
+
+	// then
+	assert.True(t, policies.IsEURestrictedAccess("cf-eu11"))
+	assert.True(t, policies.IsEURestrictedAccess("cf-ch20"))
+	assert.True(t, policies.IsEURestrictedAccess("cf-eu01"))
+	assert.False(t, policies.IsEURestrictedAccess("cf-eu10"))
+	assert.True(t, policies.IsAssuredWorkloads("cf-sa30"))
+	assert.False(t, policies.IsAssuredWorkloads("cf-eu11"))
+	assert.Equal(t, DataResidencyStandard, policies.DataResidency("cf-us10"))
+}
+
+func TestPolicies_HyperscalerRegions(t *testing.T) {
+	// given
+	policies := Policies{
+		"cf-eu11": {Provider: "aws", HyperscalerRegions: map[string][]string{"aws": {"eu-central-1", "eu-west-1"}}},
+	}
+
+	t.Run("should return the default region", func(t *testing.T) {
+		// when
+		region, restricted := policies.DefaultHyperscalerRegion("cf-eu11", runtime.AWS)
+
+		// then
+		assert.True(t, restricted)
+		assert.Equal(t, "eu-central-1", region)
+	})
+
+	t.Run("should not restrict providers without hyperscaler regions", func(t *testing.T) {
+		// when
+		_, restricted := policies.DefaultHyperscalerRegion("cf-eu11", runtime.GCP)
+
+		// then
+		assert.False(t, restricted)
+	})
+
+	t.Run("should filter regions", func(t *testing.T) {
+		// when
+		regions := policies.FilterHyperscalerRegions("cf-eu11", runtime.AWS, []string{"eu-west-1", "us-east-1", "eu-central-1"})
+
+		// then
+		assert.Equal(t, []string{"eu-west-1", "eu-central-1"}, regions)
+	})
+
+	t.Run("should not filter regions of platform regions without policy", func(t *testing.T) {
+		// when
+		regions := policies.FilterHyperscalerRegions("cf-us10", runtime.AWS, []string{"us-east-1"})
+
+		// then
+		assert.Equal(t, []string{"us-east-1"}, regions)
+	})
+}
+
+func TestPolicies_ValidateHAPRules(t *testing.T) {
+	plans := sets.New("aws", "azure", "gcp", "sap-converged-cloud")
+
+	t.Run("should accept rules of the default policies", func(t *testing.T) {
+		// given
+		rulesService, err := rules.NewRulesServiceFromSlice([]string{
+			"aws", "aws(PR=cf-eu11) -> EU", "azure", "azure(PR=cf-ch20) -> EU", "gcp", "gcp(PR=cf-sa30) -> PR", "sap-converged-cloud(HR=*) -> S",
+		}, plans, plans)
+		require.NoError(t, err)
+
+		// when
+		err = DefaultPolicies().ValidateHAPRules(rulesService)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject rules which do not set required flags", func(t *testing.T) {
+		// given
+		rulesService, err := rules.NewRulesServiceFromSlice([]string{"aws", "azure(PR=cf-ch20) -> EU", "gcp"}, plans, sets.New[string]())
+		require.NoError(t, err)
+
+		// when
+		err = DefaultPolicies().ValidateHAPRules(rulesService)
+
+		// then
+		assert.EqualError(t, err, `HAP rules do not comply with region policies: `+
+			`platform region cf-eu11: HAP rule "aws" does not set the required EU flag; `+
+			`platform region cf-sa30: HAP rule "gcp" does not set the required PR flag`)
+	})
+
+	t.Run("should reject rules restricted to the platform region which do not set required flags", func(t *testing.T) {
+		// given
+		rulesService, err := rules.NewRulesServiceFromSlice([]string{
+			"aws", "aws(PR=cf-eu11) -> EU", "aws(PR=cf-eu11, MF=g6)", "aws(PR=cf-eu11, MF=m6i) -> EU",
+			"azure", "azure(PR=cf-ch20) -> EU", "gcp", "gcp(PR=cf-sa30) -> PR",
+		}, plans, sets.New[string]())
+		require.NoError(t, err)
+
+		// when
+		err = DefaultPolicies().ValidateHAPRules(rulesService)
+
+		// then
+		assert.EqualError(t, err, `HAP rules do not comply with region policies: `+
+			`platform region cf-eu11: HAP rule "aws(PR=cf-eu11, MF=g6)" does not set the required EU flag`)
+	})
+}
+
+func TestPolicies_ValidateHyperscalerRegions(t *testing.T) {
+	// given
+	policies := Policies{
+		"cf-eu11": {Provider: "aws", HyperscalerRegions: map[string][]string{"aws": {"eu-central-1"}, "azure": {"switzerlandnorth"}}},
+	}
+
+	t.Run("should accept configured regions", func(t *testing.T) {
+		// when
+		err := policies.ValidateHyperscalerRegions(fakeRegionValidator{runtime.AWS: {"eu-central-1"}})
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject regions missing in the providers configuration", func(t *testing.T) {
+		// when
+		err := policies.ValidateHyperscalerRegions(fakeRegionValidator{runtime.AWS: {"eu-west-1"}})
+
+		// then
+		assert.EqualError(t, err, "invalid hyperscaler region of platform region cf-eu11: region eu-central-1 not found for provider AWS")
+	})
+}
+
+type fakeRegionValidator map[runtime.CloudProvider][]string
+
+func (f fakeRegionValidator) Regions(provider runtime.CloudProvider) []string {
+	return f[provider]
+}
+
+func (f fakeRegionValidator) Validate(provider runtime.CloudProvider, region string) error {
+	for _, r := range f[provider] {
+		if r == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("region %s not found for provider %s", region, provider)
+}
+
+func writePoliciesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "region_policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
@@ -0,0 +1,154 @@
+package resourcewatch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/customresources"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const kcpNamespace = "kcp-system"
+
+var (
+	RuntimeGVR = schema.GroupVersionResource{Group: "infrastructuremanager.kyma-project.io", Version: "v1", Resource: "runtimes"}
+	KymaGVR    = schema.GroupVersionResource{Group: "operator.kyma-project.io", Version: "v1beta2", Resource: "kymas"}
+)
+
+var notificationsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "kcp",
+	Subsystem: "keb_v2",
+	Name:      "resource_notifications_total",
+	Help:      "Number of operations woken up by changes of Runtime and Kyma resources",
+}, []string{"resource", "operation_type"})
+
+type Config struct {
+	Enabled      bool          `envconfig:"default=false"`
+	ResyncPeriod time.Duration `envconfig:"default=10m"`
+	// PollInterval is the interval at which steps waiting for watched resources check them when the watch is enabled
+	PollInterval time.Duration `envconfig:"default=1m"`
+}
+
+// Queue processes a scheduled operation as soon as possible. It does not schedule operations which are not in the queue
+// and does not change operations processed by a replica, so every replica watching the resources can notify the queue.
+type Queue interface {
+	Wake(operationID string)
+}
+
+// Notifier watches Runtime and Kyma resources and wakes the operation in progress of the instance in its queue when the
+// resource status changes or the resource is deleted. Steps keep polling with a longer interval, so a missed notification
+// only delays the operation.
+type Notifier struct {
+	operations storage.Operations
+	queues     map[internal.OperationType]Queue
+	log        *slog.Logger
+}
+
+func NewNotifier(operations storage.Operations, queues map[internal.OperationType]Queue, log *slog.Logger) *Notifier {
+	return &Notifier{
+		operations: operations,
+		queues:     queues,
+		log:        log.With("service", "ResourceNotifier"),
+	}
+}
+
+// Run starts informers for Runtime and Kyma resources in the KCP namespace without waiting for their caches,
+// informers are stopped when the context is done
+func (n *Notifier) Run(ctx context.Context, client dynamic.Interface, resyncPeriod time.Duration) error {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, resyncPeriod, kcpNamespace, nil)
+	for _, gvr := range []schema.GroupVersionResource{RuntimeGVR, KymaGVR} {
+		_, err := factory.ForResource(gvr).Informer().AddEventHandler(n.eventHandler(gvr.Resource))
+		if err != nil {
+			return fmt.Errorf("while adding an event handler for %s: %w", gvr.Resource, err)
+		}
+	}
+	factory.Start(ctx.Done())
+	go func() {
+		for gvr, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if synced {
+				n.log.Info(fmt.Sprintf("%s informer synced", gvr.Resource))
+			}
+		}
+	}()
+	return nil
+}
+
+func (n *Notifier) eventHandler(resource string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldResource, ok := oldObj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			newResource, ok := newObj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			if !changed(oldResource, newResource) {
+				return
+			}
+			n.notify(resource, newResource)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			deleted, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			n.notify(resource, deleted)
+		},
+	}
+}
+
+// changed checks if the status or the deletion timestamp of the resource changed, periodic resyncs are ignored
+func changed(oldResource, newResource *unstructured.Unstructured) bool {
+	if oldResource.GetResourceVersion() == newResource.GetResourceVersion() {
+		return false
+	}
+	if !reflect.DeepEqual(oldResource.GetDeletionTimestamp(), newResource.GetDeletionTimestamp()) {
+		return true
+	}
+	return !reflect.DeepEqual(oldResource.Object["status"], newResource.Object["status"])
+}
+
+func (n *Notifier) notify(resource string, obj *unstructured.Unstructured) {
+	instanceID := obj.GetLabels()[customresources.InstanceIdLabel]
+	if instanceID == "" {
+		return
+	}
+	log := n.log.With("instanceID", instanceID, "resource", resource, "name", obj.GetName())
+
+	operation, err := n.operations.GetLastOperation(instanceID)
+	if dberr.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		log.Warn(fmt.Sprintf("unable to get the last operation: %s", err))
+		return
+	}
+	if operation.State != domain.InProgress {
+		return
+	}
+	queue, found := n.queues[operation.Type]
+	if !found {
+		return
+	}
+	log.Info(fmt.Sprintf("%s resource changed, waking %s operation %s", resource, operation.Type, operation.ID))
+	notificationsMetric.WithLabelValues(resource, string(operation.Type)).Inc()
+	queue.Wake(operation.ID)
+}
@@ -0,0 +1,140 @@
+package resourcewatch
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/customresources"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+type fakeQueue struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (q *fakeQueue) Wake(operationID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.ids = append(q.ids, operationID)
+}
+
+func (q *fakeQueue) added() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]string{}, q.ids...)
+}
+
+func TestNotifier(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := storage.NewMemoryStorage()
+	provisioning := fixture.FixProvisioningOperation("provisioning-id", "instance-id")
+	provisioning.State = domain.InProgress
+	require.NoError(t, db.Operations().InsertOperation(provisioning))
+	finished := fixture.FixProvisioningOperation("finished-id", "finished-instance-id")
+	finished.State = domain.Succeeded
+	require.NoError(t, db.Operations().InsertOperation(finished))
+
+	runtimeResource := fixResource("Runtime", "runtime-id", "instance-id")
+	finishedResource := fixResource("Runtime", "finished-runtime-id", "finished-instance-id")
+	kymaResource := fixResource("Kyma", "runtime-id", "instance-id")
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		RuntimeGVR: "RuntimeList",
+		KymaGVR:    "KymaList",
+	}, runtimeResource, finishedResource, kymaResource)
+
+	queue := &fakeQueue{}
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	notifier := NewNotifier(db.Operations(), map[internal.OperationType]Queue{internal.OperationTypeProvision: queue}, log)
+	require.NoError(t, notifier.Run(ctx, client, time.Minute))
+	require.Eventually(t, func() bool {
+		list, err := client.Resource(RuntimeGVR).Namespace(kcpNamespace).List(ctx, mv1.ListOptions{})
+		return err == nil && len(list.Items) == 2
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	t.Run("should ignore changes without status change", func(t *testing.T) {
+		// when
+		updateResource(t, ctx, client, RuntimeGVR, runtimeResource, func(u *unstructured.Unstructured) {
+			u.SetAnnotations(map[string]string{"changed": "true"})
+		})
+
+		// then
+		time.Sleep(100 * time.Millisecond)
+		assert.Empty(t, queue.added())
+	})
+
+	t.Run("should ignore resources of instances without operation in progress", func(t *testing.T) {
+		// when
+		updateResource(t, ctx, client, RuntimeGVR, finishedResource, func(u *unstructured.Unstructured) {
+			require.NoError(t, unstructured.SetNestedField(u.Object, "Ready", "status", "state"))
+		})
+
+		// then
+		time.Sleep(100 * time.Millisecond)
+		assert.Empty(t, queue.added())
+	})
+
+	t.Run("should wake the operation in progress when the Runtime status changes", func(t *testing.T) {
+		// when
+		updateResource(t, ctx, client, RuntimeGVR, runtimeResource, func(u *unstructured.Unstructured) {
+			require.NoError(t, unstructured.SetNestedField(u.Object, "Ready", "status", "state"))
+		})
+
+		// then
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]string{"provisioning-id"}, queue.added())
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should wake the operation in progress when the Kyma resource is deleted", func(t *testing.T) {
+		// when
+		require.NoError(t, client.Resource(KymaGVR).Namespace(kcpNamespace).Delete(ctx, "runtime-id", mv1.DeleteOptions{}))
+
+		// then
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]string{"provisioning-id", "provisioning-id"}, queue.added())
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func fixResource(kind, name, instanceID string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetKind(kind)
+	if kind == "Kyma" {
+		u.SetAPIVersion(KymaGVR.GroupVersion().String())
+	} else {
+		u.SetAPIVersion(RuntimeGVR.GroupVersion().String())
+	}
+	u.SetName(name)
+	u.SetNamespace(kcpNamespace)
+	u.SetResourceVersion("1")
+	u.SetLabels(map[string]string{customresources.InstanceIdLabel: instanceID})
+	return u
+}
+
+func updateResource(t *testing.T, ctx context.Context, client *fake.FakeDynamicClient, gvr schema.GroupVersionResource, resource *unstructured.Unstructured, modify func(u *unstructured.Unstructured)) {
+	current, err := client.Resource(gvr).Namespace(kcpNamespace).Get(ctx, resource.GetName(), mv1.GetOptions{})
+	require.NoError(t, err)
+	modify(current)
+	current.SetResourceVersion(current.GetResourceVersion() + "1")
+	_, err = client.Resource(gvr).Namespace(kcpNamespace).Update(ctx, current, mv1.UpdateOptions{})
+	require.NoError(t, err)
+}
@@ -105,10 +105,11 @@ func (c *converter) NewDTO(instance internal.Instance) (pkg.RuntimeDTO, error) {
 			ModifiedAt: instance.UpdatedAt,
 			ExpiredAt:  instance.ExpiredAt,
 		},
-		Parameters:      instance.Parameters.Parameters,
-		LicenseType:     instance.Parameters.ErsContext.LicenseType,
-		CommercialModel: instance.Parameters.ErsContext.CommercialModel,
-		EmptyUpdates:    instance.EmptyUpdates,
+		Parameters:       instance.Parameters.Parameters,
+		LicenseType:      instance.Parameters.ErsContext.LicenseType,
+		CommercialModel:  instance.Parameters.ErsContext.CommercialModel,
+		EmptyUpdates:     instance.EmptyUpdates,
+		SubaccountLabels: instance.Parameters.ErsContext.Labels,
 	}
 
 	toReturn.SubscriptionSecretName = &instance.SubscriptionSecretName
@@ -198,6 +199,28 @@ func (c *converter) ApplyUpdateOperations(dto *pkg.RuntimeDTO, oprs []internal.U
 	c.adjustRuntimeState(dto)
 }
 
+// NewOperationStepDTOs converts recorded steps of operations, the duration of a step which is still retried lasts until its last run
+func NewOperationStepDTOs(steps []internal.OperationStep) []pkg.OperationStep {
+	dtos := make([]pkg.OperationStep, 0, len(steps))
+	for _, step := range steps {
+		end := step.UpdatedAt
+		if step.FinishedAt != nil {
+			end = *step.FinishedAt
+		}
+		dtos = append(dtos, pkg.OperationStep{
+			Stage:           step.Stage,
+			StepName:        step.StepName,
+			State:           string(step.State),
+			Attempts:        step.Attempts,
+			StartedAt:       step.StartedAt,
+			FinishedAt:      step.FinishedAt,
+			DurationSeconds: end.Sub(step.StartedAt).Seconds(),
+			LastError:       step.LastError,
+		})
+	}
+	return dtos
+}
+
 func (c *converter) adjustRuntimeState(dto *pkg.RuntimeDTO) {
 	lastOp := dto.LastOperation()
 	switch lastOp.State {
@@ -41,6 +41,7 @@ type Handler struct {
 	instancesArchivedDb storage.InstancesArchived
 	subaccountStatesDb  storage.SubaccountStates
 	actionsDb           storage.Actions
+	operationStepsDb    storage.OperationSteps
 	converter           Converter
 	defaultMaxPage      int
 	k8sClient           client.Client
@@ -56,6 +57,7 @@ func NewHandler(storage storage.BrokerStorage, defaultMaxPage int, defaultReques
 		instancesArchivedDb: storage.InstancesArchived(),
 		subaccountStatesDb:  storage.SubaccountStates(),
 		actionsDb:           storage.Actions(),
+		operationStepsDb:    storage.OperationSteps(),
 		converter:           NewConverter(defaultRequestRegion),
 		defaultMaxPage:      defaultMaxPage,
 		k8sClient:           k8sClient,
@@ -357,6 +359,39 @@ func (h *Handler) setRuntimeAllOperations(dto *pkg.RuntimeDTO) error {
 	}
 	h.converter.ApplyUpdateOperations(dto, uOprs, totalCount)
 
+	return h.addOperationSteps(dto)
+}
+
+func (h *Handler) addOperationSteps(dto *pkg.RuntimeDTO) error {
+	steps, err := h.operationStepsDb.ListByInstanceID(dto.InstanceID)
+	if err != nil {
+		return fmt.Errorf("while fetching operation steps for instance %s: %w", dto.InstanceID, err)
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+	stepsByOperation := make(map[string][]internal.OperationStep)
+	for _, step := range steps {
+		stepsByOperation[step.OperationID] = append(stepsByOperation[step.OperationID], step)
+	}
+
+	operations := []*pkg.Operation{dto.Status.Provisioning, dto.Status.Deprovisioning}
+	for _, data := range []*pkg.OperationsData{dto.Status.UpgradingCluster, dto.Status.Update, dto.Status.Suspension, dto.Status.Unsuspension} {
+		if data == nil {
+			continue
+		}
+		for i := range data.Data {
+			operations = append(operations, &data.Data[i])
+		}
+	}
+	for _, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		if operationSteps, found := stepsByOperation[operation.OperationID]; found {
+			operation.Steps = NewOperationStepDTOs(operationSteps)
+		}
+	}
 	return nil
 }
 
@@ -581,6 +581,58 @@ func TestRuntimeHandler(t *testing.T) {
 		assert.Equal(t, pkg.StateSucceeded, out.Data[0].Status.State)
 	})
 
+	t.Run("test recorded steps of all operations", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		testTime := time.Now()
+		err := db.Instances().Insert(fixInstance(testID1, testTime))
+		require.NoError(t, err)
+
+		provOp := fixture.FixProvisioningOperation(fixRandomID(), testID1)
+		err = db.Operations().InsertOperation(provOp)
+		require.NoError(t, err)
+		unsuspensionOp := fixture.FixProvisioningOperation(fixRandomID(), testID1)
+		unsuspensionOp.CreatedAt = unsuspensionOp.CreatedAt.Add(time.Minute)
+		err = db.Operations().InsertOperation(unsuspensionOp)
+		require.NoError(t, err)
+
+		for _, step := range []internal.OperationStep{
+			{OperationID: provOp.ID, InstanceID: testID1, Stage: "start", StepName: "Start", State: internal.OperationStepStateSucceeded, StartedAt: testTime, UpdatedAt: testTime},
+			{OperationID: unsuspensionOp.ID, InstanceID: testID1, Stage: "create_runtime", StepName: "Check_RuntimeResource_Provisioning", State: internal.OperationStepStateRetrying, StartedAt: testTime, UpdatedAt: testTime},
+		} {
+			err = db.OperationSteps().Upsert(step)
+			require.NoError(t, err)
+		}
+
+		runtimeHandler := runtime.NewHandler(db, 2, "", k8sClient, log)
+
+		rr := httptest.NewRecorder()
+		router := httputil.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		// when
+		req, err := http.NewRequest("GET", fmt.Sprintf("/runtimes?op_detail=%s", pkg.AllOperation), nil)
+		require.NoError(t, err)
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.RuntimesPage
+		err = json.Unmarshal(rr.Body.Bytes(), &out)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, out.Count)
+		require.NotNil(t, out.Data[0].Status.Provisioning)
+		require.Len(t, out.Data[0].Status.Provisioning.Steps, 1)
+		assert.Equal(t, "Start", out.Data[0].Status.Provisioning.Steps[0].StepName)
+		require.NotNil(t, out.Data[0].Status.Unsuspension)
+		require.Len(t, out.Data[0].Status.Unsuspension.Data, 1)
+		require.Len(t, out.Data[0].Status.Unsuspension.Data[0].Steps, 1)
+		assert.Equal(t, "Check_RuntimeResource_Provisioning", out.Data[0].Status.Unsuspension.Data[0].Steps[0].StepName)
+		assert.Equal(t, string(internal.OperationStepStateRetrying), out.Data[0].Status.Unsuspension.Data[0].Steps[0].State)
+	})
+
 }
 
 func TestRuntimeHandler_WithKimOnlyDrivenInstances(t *testing.T) {
@@ -19,6 +19,10 @@ type Config struct {
 	SSLRootCert string `envconfig:"optional"`
 
 	SecretKey string `envconfig:"optional"`
+	// SecretKeyID identifies SecretKey in encrypted data, empty ID keeps the data format used before key rotation was introduced
+	SecretKeyID string `envconfig:"optional"`
+	// PreviousSecretKeys are used only for decryption, every entry has the format <id>:<key>, ":<key>" is the key without ID
+	PreviousSecretKeys []string `envconfig:"optional"`
 
 	MaxOpenConns    int           `envconfig:"default=8"`
 	MaxIdleConns    int           `envconfig:"default=2"`
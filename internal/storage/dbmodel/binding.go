@@ -14,6 +14,22 @@ type BindingDTO struct {
 	Kubeconfig        string
 	ExpirationSeconds int64
 	CreatedBy         string
+	// Scope is the JSON encoded internal.BindingScope
+	Scope          string
+	CredentialType string
+
+	State            string
+	StateDescription string
+	Async            bool
+}
+
+// BindingFilter holds the filters when listing bindings of all instances
+type BindingFilter struct {
+	InstanceIDs []string
+	CreatedBy   []string
+	Expired     *bool
+	Page        int
+	PageSize    int
 }
 
 type BindingStatsDTO struct {
@@ -0,0 +1,31 @@
+package dbmodel
+
+// EncryptedDataDTO contains a stored value with encrypted fields, the Data is provisioning parameters for instances and
+// operations and a kubeconfig for bindings
+type EncryptedDataDTO struct {
+	ID         string
+	InstanceID string
+	Data       string
+}
+
+// KeyRotationCursor points to the last record processed by the key rotation. Instances are ordered by InstanceID,
+// operations by ID and bindings by InstanceID and ID.
+type KeyRotationCursor struct {
+	InstanceID string
+	ID         string
+}
+
+// KeyRotationBatch is the result of rotating the encryption key of a batch of records
+type KeyRotationBatch struct {
+	Cursor  KeyRotationCursor
+	Scanned int
+	Rotated int
+	// Conflicts contains records modified while the batch was processed, they are encrypted with the current key by the writer
+	Conflicts []string
+	Failed    []KeyRotationFailure
+}
+
+type KeyRotationFailure struct {
+	ID     string
+	Reason string
+}
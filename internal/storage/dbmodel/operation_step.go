@@ -0,0 +1,21 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OperationStepDTO struct {
+	OperationID string
+	InstanceID  string
+	Stage       string
+	StepName    string
+
+	State     string
+	Attempts  int
+	LastError string
+
+	StartedAt  time.Time
+	FinishedAt sql.NullTime
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,19 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+type QueueItemDTO struct {
+	QueueName   string
+	OperationID string
+
+	NextRunAt      time.Time
+	LeaseOwner     sql.NullString
+	LeaseExpiresAt sql.NullTime
+	Dirty          bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
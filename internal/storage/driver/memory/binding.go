@@ -2,21 +2,29 @@ package memory
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/kyma-project/kyma-environment-broker/common/pagination"
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
 )
 
 type Binding struct {
 	mu   sync.Mutex
 	data map[string]internal.Binding
+	// reportedExpiresAt keeps the reported expiration by binding ID
+	reportedExpiresAt map[string]time.Time
 }
 
 func NewBinding() *Binding {
 	return &Binding{
-		data: make(map[string]internal.Binding),
+		data:              make(map[string]internal.Binding),
+		reportedExpiresAt: make(map[string]time.Time),
 	}
 }
 
@@ -59,6 +67,7 @@ func (s *Binding) Delete(instanceID, bindingID string) error {
 	defer s.mu.Unlock()
 
 	delete(s.data, bindingID)
+	delete(s.reportedExpiresAt, bindingID)
 	return nil
 }
 
@@ -105,6 +114,86 @@ func (s *Binding) ListExpired() ([]internal.Binding, error) {
 	return bindings, nil
 }
 
+func (s *Binding) ListUnreportedExpired() ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bindings []internal.Binding
+	for _, binding := range s.data {
+		if s.expirationUnreported(binding) && (binding.State == domain.Succeeded || binding.State == "") {
+			bindings = append(bindings, binding)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].ExpiresAt.Before(bindings[j].ExpiresAt)
+	})
+
+	return bindings, nil
+}
+
+func (s *Binding) MarkExpirationReported(instanceID, bindingID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	binding, found := s.data[bindingID]
+	if !found || binding.InstanceID != instanceID || !s.expirationUnreported(binding) {
+		return false, nil
+	}
+	s.reportedExpiresAt[bindingID] = binding.ExpiresAt
+
+	return true, nil
+}
+
+func (s *Binding) expirationUnreported(binding internal.Binding) bool {
+	reported, found := s.reportedExpiresAt[binding.ID]
+	return !binding.ExpiresAt.After(time.Now()) && (!found || !reported.Equal(binding.ExpiresAt))
+}
+
+func (s *Binding) ListInProgress() ([]internal.Binding, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bindings []internal.Binding
+	for _, binding := range s.data {
+		if binding.State == domain.InProgress {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	return bindings, nil
+}
+
+func (s *Binding) List(filter dbmodel.BindingFilter) ([]internal.Binding, int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bindings []internal.Binding
+	for _, binding := range s.data {
+		if len(filter.InstanceIDs) > 0 && !slices.Contains(filter.InstanceIDs, binding.InstanceID) {
+			continue
+		}
+		if len(filter.CreatedBy) > 0 && !slices.Contains(filter.CreatedBy, binding.CreatedBy) {
+			continue
+		}
+		if filter.Expired != nil && *filter.Expired != binding.ExpiresAt.Before(time.Now()) {
+			continue
+		}
+		binding.Kubeconfig = ""
+		bindings = append(bindings, binding)
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].CreatedAt.After(bindings[j].CreatedAt)
+	})
+
+	offset := pagination.ConvertPageAndPageSizeToOffset(filter.PageSize, filter.Page)
+	toReturn := make([]internal.Binding, 0)
+	for i := offset; (filter.PageSize < 1 || i < offset+filter.PageSize) && i < len(bindings); i++ {
+		toReturn = append(toReturn, bindings[i])
+	}
+
+	return toReturn, len(toReturn), len(bindings), nil
+}
+
 func (s *Binding) GetStatistics() (internal.BindingStats, error) {
 	return internal.BindingStats{}, fmt.Errorf("not implemented")
 }
@@ -20,6 +20,12 @@ type operations struct {
 	operations               map[string]internal.Operation
 	upgradeClusterOperations map[string]internal.UpgradeClusterOperation
 	updateOperations         map[string]internal.UpdatingOperation
+	leases                   map[string]operationLease
+}
+
+type operationLease struct {
+	owner     string
+	expiresAt time.Time
 }
 
 // NewOperation creates in-memory storage for OSB operations.
@@ -28,6 +34,7 @@ func NewOperation() *operations {
 		operations:               make(map[string]internal.Operation, 0),
 		upgradeClusterOperations: make(map[string]internal.UpgradeClusterOperation, 0),
 		updateOperations:         make(map[string]internal.UpdatingOperation, 0),
+		leases:                   make(map[string]operationLease, 0),
 	}
 }
 
@@ -36,6 +43,7 @@ func (s *operations) DeleteByID(operationID string) error {
 	defer s.mu.Unlock()
 
 	delete(s.operations, operationID)
+	delete(s.leases, operationID)
 	return nil
 }
 
@@ -468,7 +476,7 @@ func (s *operations) GetNotFinishedOperationsByType(opType internal.OperationTyp
 	switch opType {
 	case internal.OperationTypeProvision:
 		for _, op := range s.operations {
-			if op.State == domain.InProgress {
+			if op.State == domain.InProgress || op.State == internal.OperationStateCanceling {
 				ops = append(ops, op)
 			}
 		}
@@ -776,3 +784,37 @@ func (s *operations) GetAllOperations() ([]internal.Operation, error) {
 	}
 	return ops, nil
 }
+
+func (s *operations) AcquireOperationLease(operationID, owner string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.exists(operationID) {
+		return dberr.NotFound("operation with id %s not exist", operationID)
+	}
+	now := time.Now()
+	if lease, found := s.leases[operationID]; found && lease.owner != owner && !lease.expiresAt.Before(now) {
+		return dberr.Conflict("operation with id %s is leased by %s", operationID, lease.owner)
+	}
+	s.leases[operationID] = operationLease{owner: owner, expiresAt: now.Add(duration)}
+
+	return nil
+}
+
+func (s *operations) ReleaseOperationLease(operationID, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lease, found := s.leases[operationID]; found && lease.owner == owner {
+		delete(s.leases, operationID)
+	}
+
+	return nil
+}
+
+func (s *operations) exists(operationID string) bool {
+	_, provisioningExists := s.operations[operationID]
+	_, updateExists := s.updateOperations[operationID]
+	_, upgradeClusterExists := s.upgradeClusterOperations[operationID]
+	return provisioningExists || updateExists || upgradeClusterExists
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+)
+
+type operationStepKey struct {
+	operationID string
+	stepName    string
+}
+
+type OperationSteps struct {
+	mu    sync.Mutex
+	steps map[operationStepKey]internal.OperationStep
+}
+
+func NewOperationSteps() *OperationSteps {
+	return &OperationSteps{
+		steps: make(map[operationStepKey]internal.OperationStep),
+	}
+}
+
+func (s *OperationSteps) Upsert(step internal.OperationStep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := operationStepKey{operationID: step.OperationID, stepName: step.StepName}
+	step.Attempts = 1
+	if existing, found := s.steps[key]; found {
+		step.Attempts = existing.Attempts + 1
+		step.StartedAt = existing.StartedAt
+	}
+	s.steps[key] = step
+
+	return nil
+}
+
+func (s *OperationSteps) ListByOperationID(operationID string) ([]internal.OperationStep, error) {
+	return s.list(func(step internal.OperationStep) bool {
+		return step.OperationID == operationID
+	}), nil
+}
+
+func (s *OperationSteps) ListByInstanceID(instanceID string) ([]internal.OperationStep, error) {
+	return s.list(func(step internal.OperationStep) bool {
+		return step.InstanceID == instanceID
+	}), nil
+}
+
+func (s *OperationSteps) list(filter func(step internal.OperationStep) bool) []internal.OperationStep {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	steps := make([]internal.OperationStep, 0)
+	for _, step := range s.steps {
+		if filter(step) {
+			steps = append(steps, step)
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].StartedAt.Before(steps[j].StartedAt)
+	})
+
+	return steps
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+)
+
+type queueItemKey struct {
+	queueName   string
+	operationID string
+}
+
+type QueueItems struct {
+	mu    sync.Mutex
+	items map[queueItemKey]internal.QueueItem
+}
+
+func NewQueueItems() *QueueItems {
+	return &QueueItems{
+		items: make(map[queueItemKey]internal.QueueItem),
+	}
+}
+
+func (q *QueueItems) Insert(item internal.QueueItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := queueItemKey{queueName: item.QueueName, operationID: item.OperationID}
+	if _, found := q.items[key]; found {
+		return dberr.AlreadyExists("operation %s is already scheduled in the queue %s", item.OperationID, item.QueueName)
+	}
+	q.items[key] = q.scheduled(item, time.Now())
+
+	return nil
+}
+
+func (q *QueueItems) Upsert(item internal.QueueItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := queueItemKey{queueName: item.QueueName, operationID: item.OperationID}
+	existing, found := q.items[key]
+	if !found {
+		q.items[key] = q.scheduled(item, time.Now())
+		return nil
+	}
+	existing.NextRunAt = item.NextRunAt
+	existing.UpdatedAt = time.Now()
+	existing.Dirty = existing.LeaseOwner != ""
+	q.items[key] = existing
+
+	return nil
+}
+
+func (q *QueueItems) Claim(queueName, owner string, leaseDuration time.Duration) (*internal.QueueItem, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	var candidates []internal.QueueItem
+	for _, item := range q.items {
+		if item.QueueName != queueName || item.NextRunAt.After(now) {
+			continue
+		}
+		if item.LeaseExpiresAt != nil && !item.LeaseExpiresAt.Before(now) {
+			continue
+		}
+		candidates = append(candidates, item)
+	}
+	if len(candidates) == 0 {
+		return nil, dberr.NotFound("no items ready to be processed in the queue %s", queueName)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].NextRunAt.Before(candidates[j].NextRunAt)
+	})
+
+	claimed := candidates[0]
+	leaseExpiresAt := now.Add(leaseDuration)
+	claimed.LeaseOwner = owner
+	claimed.LeaseExpiresAt = &leaseExpiresAt
+	claimed.Dirty = false
+	claimed.UpdatedAt = now
+	q.items[queueItemKey{queueName: claimed.QueueName, operationID: claimed.OperationID}] = claimed
+
+	return &claimed, nil
+}
+
+func (q *QueueItems) Renew(queueName, operationID, owner string, leaseDuration time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := queueItemKey{queueName: queueName, operationID: operationID}
+	if item, found := q.items[key]; found && item.LeaseOwner == owner {
+		leaseExpiresAt := time.Now().Add(leaseDuration)
+		item.LeaseExpiresAt = &leaseExpiresAt
+		q.items[key] = item
+	}
+
+	return nil
+}
+
+func (q *QueueItems) Release(queueName, operationID, owner string, nextRunAt time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.release(queueItemKey{queueName: queueName, operationID: operationID}, owner, nextRunAt)
+
+	return nil
+}
+
+func (q *QueueItems) Wake(queueName, operationID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	key := queueItemKey{queueName: queueName, operationID: operationID}
+	item, found := q.items[key]
+	if !found || !item.NextRunAt.After(now) {
+		return nil
+	}
+	if item.LeaseExpiresAt != nil && !item.LeaseExpiresAt.Before(now) {
+		return nil
+	}
+	item.NextRunAt = now
+	item.UpdatedAt = now
+	q.items[key] = item
+
+	return nil
+}
+
+func (q *QueueItems) Delete(queueName, operationID, owner string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := queueItemKey{queueName: queueName, operationID: operationID}
+	item, found := q.items[key]
+	switch {
+	case !found || item.LeaseOwner != owner:
+	case item.Dirty:
+		q.release(key, owner, time.Now())
+	default:
+		delete(q.items, key)
+	}
+
+	return nil
+}
+
+func (q *QueueItems) release(key queueItemKey, owner string, nextRunAt time.Time) {
+	item, found := q.items[key]
+	if !found || item.LeaseOwner != owner {
+		return
+	}
+	if !item.Dirty || nextRunAt.Before(item.NextRunAt) {
+		item.NextRunAt = nextRunAt
+	}
+	item.LeaseOwner = ""
+	item.LeaseExpiresAt = nil
+	item.Dirty = false
+	item.UpdatedAt = time.Now()
+	q.items[key] = item
+}
+
+func (q *QueueItems) Count(queueName string) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count := 0
+	for key := range q.items {
+		if key.queueName == queueName {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (q *QueueItems) scheduled(item internal.QueueItem, now time.Time) internal.QueueItem {
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	}
+	item.UpdatedAt = now
+	item.LeaseOwner = ""
+	item.LeaseExpiresAt = nil
+
+	return item
+}
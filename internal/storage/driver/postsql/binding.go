@@ -1,12 +1,14 @@
 package postsql
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
 )
 
 type Binding struct {
@@ -114,6 +116,65 @@ func (s *Binding) ListExpired() ([]internal.Binding, error) {
 	return bindings, err
 }
 
+func (s *Binding) ListInProgress() ([]internal.Binding, error) {
+	dtos, err := s.Factory.NewReadSession().ListBindingsInProgress()
+	if err != nil {
+		return []internal.Binding{}, err
+	}
+	var bindings []internal.Binding
+	for _, dto := range dtos {
+		binding, err := s.toBinding(dto)
+		if err != nil {
+			return []internal.Binding{}, err
+		}
+
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+func (s *Binding) ListUnreportedExpired() ([]internal.Binding, error) {
+	dtos, err := s.Factory.NewReadSession().ListUnreportedExpiredBindings()
+	if err != nil {
+		return []internal.Binding{}, err
+	}
+	bindings := make([]internal.Binding, 0, len(dtos))
+	for _, dto := range dtos {
+		bindings = append(bindings, internal.Binding{
+			ID:         dto.ID,
+			InstanceID: dto.InstanceID,
+			ExpiresAt:  dto.ExpiresAt,
+			CreatedBy:  dto.CreatedBy,
+		})
+	}
+	return bindings, nil
+}
+
+func (s *Binding) MarkExpirationReported(instanceID, bindingID string) (bool, error) {
+	sess := s.Factory.NewWriteSession()
+	marked, err := sess.MarkBindingExpirationReported(instanceID, bindingID)
+	if err != nil {
+		return false, fmt.Errorf("while marking expiration of binding %s as reported: %w", bindingID, err)
+	}
+	return marked, nil
+}
+
+func (s *Binding) List(filter dbmodel.BindingFilter) ([]internal.Binding, int, int, error) {
+	dtos, totalCount, err := s.Factory.NewReadSession().ListBindingsByFilter(filter)
+	if err != nil {
+		return []internal.Binding{}, 0, 0, err
+	}
+	bindings := make([]internal.Binding, 0, len(dtos))
+	for _, dto := range dtos {
+		binding, err := s.toBindingWithoutKubeconfig(dto)
+		if err != nil {
+			return []internal.Binding{}, 0, 0, err
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, len(bindings), totalCount, nil
+}
+
 func (s *Binding) GetStatistics() (internal.BindingStats, error) {
 	sess := s.Factory.NewReadSession()
 	dto, err := sess.GetBindingsStatistics()
@@ -128,6 +189,10 @@ func (s *Binding) toBindingDTO(binding *internal.Binding) (dbmodel.BindingDTO, e
 	if err != nil {
 		return dbmodel.BindingDTO{}, fmt.Errorf("while encrypting kubeconfig: %w", err)
 	}
+	scope, err := json.Marshal(binding.Scope)
+	if err != nil {
+		return dbmodel.BindingDTO{}, fmt.Errorf("while marshaling scope: %w", err)
+	}
 
 	return dbmodel.BindingDTO{
 		Kubeconfig:        string(encrypted),
@@ -137,6 +202,11 @@ func (s *Binding) toBindingDTO(binding *internal.Binding) (dbmodel.BindingDTO, e
 		ExpirationSeconds: binding.ExpirationSeconds,
 		CreatedBy:         binding.CreatedBy,
 		ExpiresAt:         binding.ExpiresAt,
+		Scope:             string(scope),
+		State:             string(binding.State),
+		StateDescription:  binding.StateDescription,
+		CredentialType:    binding.CredentialType,
+		Async:             binding.Async,
 	}, nil
 }
 
@@ -145,15 +215,35 @@ func (s *Binding) toBinding(dto dbmodel.BindingDTO) (internal.Binding, error) {
 	if err != nil {
 		return internal.Binding{}, fmt.Errorf("while decrypting kubeconfig: %w", err)
 	}
+	binding, err := s.toBindingWithoutKubeconfig(dto)
+	if err != nil {
+		return internal.Binding{}, err
+	}
+	binding.Kubeconfig = string(decrypted)
+
+	return binding, nil
+}
+
+func (s *Binding) toBindingWithoutKubeconfig(dto dbmodel.BindingDTO) (internal.Binding, error) {
+	var scope internal.BindingScope
+	if dto.Scope != "" {
+		if err := json.Unmarshal([]byte(dto.Scope), &scope); err != nil {
+			return internal.Binding{}, fmt.Errorf("while unmarshaling scope: %w", err)
+		}
+	}
 
 	return internal.Binding{
-		Kubeconfig:        string(decrypted),
 		ID:                dto.ID,
 		InstanceID:        dto.InstanceID,
 		CreatedAt:         dto.CreatedAt,
 		ExpirationSeconds: dto.ExpirationSeconds,
 		CreatedBy:         dto.CreatedBy,
 		ExpiresAt:         dto.ExpiresAt,
+		Scope:             scope,
+		State:             domain.LastOperationState(dto.State),
+		StateDescription:  dto.StateDescription,
+		CredentialType:    dto.CredentialType,
+		Async:             dto.Async,
 	}, nil
 }
 
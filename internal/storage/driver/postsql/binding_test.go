@@ -6,7 +6,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kyma-project/kyma-environment-broker/internal"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v12/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -77,6 +81,158 @@ func TestBinding(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("should store binding scope", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		fixedBinding := fixture.FixBinding(testBindingId)
+		fixedBinding.Scope = internal.BindingScope{Role: internal.BindingRoleView, Namespaces: []string{"default", "app"}}
+		fixedBinding.CredentialType = internal.BindingCredentialTypeClientCertificate
+
+		err = brokerStorage.Bindings().Insert(&fixedBinding)
+		require.NoError(t, err)
+
+		// when
+		createdBinding, err := brokerStorage.Bindings().Get(fixedBinding.InstanceID, fixedBinding.ID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, fixedBinding.Scope, createdBinding.Scope)
+		assert.Equal(t, internal.BindingCredentialTypeClientCertificate, createdBinding.CredentialType)
+	})
+
+	t.Run("should store binding state and list bindings in progress", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		inProgress := fixture.FixBinding("binding-in-progress")
+		inProgress.Kubeconfig = ""
+		inProgress.State = domain.InProgress
+		err = brokerStorage.Bindings().Insert(&inProgress)
+		require.NoError(t, err)
+		failed := fixture.FixBinding("binding-failed")
+		failed.Kubeconfig = ""
+		failed.State = domain.Failed
+		failed.StateDescription = "runtime not reachable"
+		err = brokerStorage.Bindings().Insert(&failed)
+		require.NoError(t, err)
+
+		// when
+		bindings, err := brokerStorage.Bindings().ListInProgress()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, bindings, 1)
+		assert.Equal(t, inProgress.ID, bindings[0].ID)
+
+		// when
+		inProgress.State = domain.Succeeded
+		inProgress.Kubeconfig = "kubeconfig"
+		err = brokerStorage.Bindings().Update(&inProgress)
+		require.NoError(t, err)
+
+		// then
+		updated, err := brokerStorage.Bindings().Get(inProgress.InstanceID, inProgress.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, updated.State)
+		stored, err := brokerStorage.Bindings().Get(failed.InstanceID, failed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, stored.State)
+		assert.Equal(t, "runtime not reachable", stored.StateDescription)
+	})
+
+	t.Run("should mark the expiration as reported once", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		expired := fixture.FixBinding("binding-expired")
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		require.NoError(t, brokerStorage.Bindings().Insert(&expired))
+		active := fixture.FixBinding("binding-active")
+		require.NoError(t, brokerStorage.Bindings().Insert(&active))
+
+		// when
+		bindings, err := brokerStorage.Bindings().ListUnreportedExpired()
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, bindings, 1)
+		assert.Equal(t, expired.ID, bindings[0].ID)
+		assert.Equal(t, expired.CreatedBy, bindings[0].CreatedBy)
+
+		// when
+		marked, err := brokerStorage.Bindings().MarkExpirationReported(expired.InstanceID, expired.ID)
+		require.NoError(t, err)
+		markedAgain, err := brokerStorage.Bindings().MarkExpirationReported(expired.InstanceID, expired.ID)
+		require.NoError(t, err)
+		markedActive, err := brokerStorage.Bindings().MarkExpirationReported(active.InstanceID, active.ID)
+		require.NoError(t, err)
+
+		// then
+		assert.True(t, marked)
+		assert.False(t, markedAgain)
+		assert.False(t, markedActive)
+		bindings, err = brokerStorage.Bindings().ListUnreportedExpired()
+		require.NoError(t, err)
+		assert.Empty(t, bindings)
+	})
+
+	t.Run("should list bindings by filter without kubeconfigs", func(t *testing.T) {
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+
+		// given
+		expired := fixture.FixBinding("binding-expired")
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		require.NoError(t, brokerStorage.Bindings().Insert(&expired))
+		active := fixture.FixBinding("binding-active")
+		active.CreatedBy = "jane.doe@email.com"
+		require.NoError(t, brokerStorage.Bindings().Insert(&active))
+
+		// when
+		bindings, count, totalCount, err := brokerStorage.Bindings().List(dbmodel.BindingFilter{CreatedBy: []string{"jane.doe@email.com"}})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, bindings, 1)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, totalCount)
+		assert.Equal(t, active.ID, bindings[0].ID)
+		assert.Empty(t, bindings[0].Kubeconfig)
+
+		// when
+		bindings, _, totalCount, err = brokerStorage.Bindings().List(dbmodel.BindingFilter{Expired: ptr.Bool(true), Page: 1, PageSize: 10})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, bindings, 1)
+		assert.Equal(t, 1, totalCount)
+		assert.Equal(t, expired.ID, bindings[0].ID)
+	})
+
 	t.Run("should succeed when the same object is deleted twice", func(t *testing.T) {
 		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
 		require.NoError(t, err)
@@ -13,4 +13,7 @@ type Cipher interface {
 	// methods used to encrypt/decrypt kubeconfig
 	EncryptKubeconfig(pp *internal.ProvisioningParameters) error
 	DecryptKubeconfigUsingMode(pp *internal.ProvisioningParameters) error
+
+	// UsesCurrentKey is used by the key rotation to find data encrypted with previous keys
+	UsesCurrentKey(data []byte) bool
 }
@@ -0,0 +1,148 @@
+package postsql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+)
+
+type KeyRotation struct {
+	postsql.Factory
+	cipher Cipher
+}
+
+func NewKeyRotation(sess postsql.Factory, cipher Cipher) *KeyRotation {
+	return &KeyRotation{
+		Factory: sess,
+		cipher:  cipher,
+	}
+}
+
+func (s *KeyRotation) RotateInstances(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	records, err := s.NewReadSession().ListInstancesEncryptedData(cursor.InstanceID, limit)
+	if err != nil {
+		return dbmodel.KeyRotationBatch{Cursor: cursor}, err
+	}
+	return s.rotate(records, cursor, dryRun, s.reencryptProvisioningParameters, func(record dbmodel.EncryptedDataDTO, newData string) dberr.Error {
+		return s.NewWriteSession().UpdateInstanceEncryptedData(record.InstanceID, record.Data, newData)
+	}), nil
+}
+
+func (s *KeyRotation) RotateOperations(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	records, err := s.NewReadSession().ListOperationsEncryptedData(cursor.ID, limit)
+	if err != nil {
+		return dbmodel.KeyRotationBatch{Cursor: cursor}, err
+	}
+	return s.rotate(records, cursor, dryRun, s.reencryptProvisioningParameters, func(record dbmodel.EncryptedDataDTO, newData string) dberr.Error {
+		return s.NewWriteSession().UpdateOperationEncryptedData(record.ID, record.Data, newData)
+	}), nil
+}
+
+func (s *KeyRotation) RotateBindings(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error) {
+	records, err := s.NewReadSession().ListBindingsEncryptedData(cursor.InstanceID, cursor.ID, limit)
+	if err != nil {
+		return dbmodel.KeyRotationBatch{Cursor: cursor}, err
+	}
+	return s.rotate(records, cursor, dryRun, s.reencryptKubeconfig, func(record dbmodel.EncryptedDataDTO, newData string) dberr.Error {
+		return s.NewWriteSession().UpdateBindingEncryptedData(record.InstanceID, record.ID, record.Data, newData)
+	}), nil
+}
+
+// reencryptFunc returns the data encrypted with the current key or false if the data does not need to be rotated
+type reencryptFunc func(data string) (string, bool, error)
+
+func (s *KeyRotation) rotate(records []dbmodel.EncryptedDataDTO, cursor dbmodel.KeyRotationCursor, dryRun bool, reencrypt reencryptFunc, update func(dbmodel.EncryptedDataDTO, string) dberr.Error) dbmodel.KeyRotationBatch {
+	batch := dbmodel.KeyRotationBatch{Cursor: cursor, Scanned: len(records)}
+	for _, record := range records {
+		batch.Cursor = dbmodel.KeyRotationCursor{InstanceID: record.InstanceID, ID: record.ID}
+		if record.Data == "" {
+			continue
+		}
+
+		newData, rotate, err := reencrypt(record.Data)
+		if err != nil {
+			batch.Failed = append(batch.Failed, dbmodel.KeyRotationFailure{ID: record.ID, Reason: err.Error()})
+			continue
+		}
+		if !rotate {
+			continue
+		}
+		if dryRun {
+			batch.Rotated++
+			continue
+		}
+		switch err := update(record, newData); {
+		case dberr.IsConflict(err):
+			batch.Conflicts = append(batch.Conflicts, record.ID)
+		case err != nil:
+			batch.Failed = append(batch.Failed, dbmodel.KeyRotationFailure{ID: record.ID, Reason: err.Error()})
+		default:
+			batch.Rotated++
+		}
+	}
+	return batch
+}
+
+func (s *KeyRotation) reencryptProvisioningParameters(data string) (string, bool, error) {
+	params := internal.ProvisioningParameters{}
+	if err := json.Unmarshal([]byte(data), &params); err != nil {
+		return "", false, fmt.Errorf("while unmarshaling provisioning parameters: %w", err)
+	}
+
+	var encrypted []string
+	if creds := params.ErsContext.SMOperatorCredentials; creds != nil {
+		encrypted = append(encrypted, creds.ClientID, creds.ClientSecret)
+	}
+	encrypted = append(encrypted, params.Parameters.Kubeconfig)
+	if s.usesCurrentKey(encrypted...) {
+		return "", false, nil
+	}
+
+	if err := s.cipher.DecryptSMCredentialsUsingMode(&params); err != nil {
+		return "", false, err
+	}
+	if err := s.cipher.DecryptKubeconfigUsingMode(&params); err != nil {
+		return "", false, err
+	}
+	if err := s.cipher.EncryptSMCredentials(&params); err != nil {
+		return "", false, err
+	}
+	if err := s.cipher.EncryptKubeconfig(&params); err != nil {
+		return "", false, err
+	}
+
+	rotated, err := json.Marshal(params)
+	if err != nil {
+		return "", false, fmt.Errorf("while marshaling provisioning parameters: %w", err)
+	}
+	return string(rotated), true, nil
+}
+
+func (s *KeyRotation) reencryptKubeconfig(data string) (string, bool, error) {
+	if s.usesCurrentKey(data) {
+		return "", false, nil
+	}
+	decrypted, err := s.cipher.DecryptUsingMode([]byte(data))
+	if err != nil {
+		return "", false, fmt.Errorf("while decrypting kubeconfig: %w", err)
+	}
+	encrypted, err := s.cipher.Encrypt(decrypted)
+	if err != nil {
+		return "", false, fmt.Errorf("while encrypting kubeconfig: %w", err)
+	}
+	return string(encrypted), true, nil
+}
+
+// usesCurrentKey returns true if all non-empty values are encrypted with the current key
+func (s *KeyRotation) usesCurrentKey(values ...string) bool {
+	for _, value := range values {
+		if value != "" && !s.cipher.UsesCurrentKey([]byte(value)) {
+			return false
+		}
+	}
+	return true
+}
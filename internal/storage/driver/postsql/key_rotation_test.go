@@ -0,0 +1,95 @@
+package postsql_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRotation(t *testing.T) {
+	storageCleanup, legacyStorage, err := GetStorageForDatabaseTests()
+	require.NoError(t, err)
+	defer func() {
+		err := storageCleanup()
+		assert.NoError(t, err)
+	}()
+
+	// given
+	config := brokerStorageDatabaseTestConfig()
+	instance := fixture.FixInstance("instance-1")
+	instance.Parameters.ErsContext.SMOperatorCredentials = &internal.ServiceManagerOperatorCredentials{ClientID: "client-id", ClientSecret: "client-secret"}
+	instance.Parameters.Parameters.Kubeconfig = "instance-kubeconfig"
+	require.NoError(t, legacyStorage.Instances().Insert(instance))
+	operation := fixture.FixProvisioningOperation("operation-1", instance.InstanceID)
+	operation.ProvisioningParameters = instance.Parameters
+	require.NoError(t, legacyStorage.Operations().InsertOperation(operation))
+	binding := fixture.FixBinding("binding-1")
+	require.NoError(t, legacyStorage.Bindings().Insert(&binding))
+
+	config.SecretKeyID = "v2"
+	config.SecretKey = "0123456789abcdef0123456789abcdef"
+	config.PreviousSecretKeys = []string{":" + brokerStorageDatabaseTestConfig().SecretKey}
+	encrypter, err := storage.NewEncrypterFromConfig(config)
+	require.NoError(t, err)
+	rotatedStorage, connection, err := storage.NewFromConfig(config, events.Config{}, encrypter)
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+
+	t.Run("should only count records to rotate in dry run", func(t *testing.T) {
+		// when
+		batch, err := rotatedStorage.KeyRotation().RotateInstances(dbmodel.KeyRotationCursor{}, 10, true)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, batch.Scanned)
+		assert.Equal(t, 1, batch.Rotated)
+
+		_, err = legacyStorage.Instances().GetByID(instance.InstanceID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should rotate instances, operations and bindings", func(t *testing.T) {
+		// when
+		instances, err := rotatedStorage.KeyRotation().RotateInstances(dbmodel.KeyRotationCursor{}, 10, false)
+		require.NoError(t, err)
+		operations, err := rotatedStorage.KeyRotation().RotateOperations(dbmodel.KeyRotationCursor{}, 10, false)
+		require.NoError(t, err)
+		bindings, err := rotatedStorage.KeyRotation().RotateBindings(dbmodel.KeyRotationCursor{}, 10, false)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, dbmodel.KeyRotationBatch{Cursor: dbmodel.KeyRotationCursor{InstanceID: instance.InstanceID, ID: instance.InstanceID}, Scanned: 1, Rotated: 1}, instances)
+		assert.Equal(t, dbmodel.KeyRotationBatch{Cursor: dbmodel.KeyRotationCursor{InstanceID: instance.InstanceID, ID: operation.ID}, Scanned: 1, Rotated: 1}, operations)
+		assert.Equal(t, dbmodel.KeyRotationBatch{Cursor: dbmodel.KeyRotationCursor{InstanceID: binding.InstanceID, ID: binding.ID}, Scanned: 1, Rotated: 1}, bindings)
+
+		_, err = legacyStorage.Instances().GetByID(instance.InstanceID)
+		assert.Error(t, err)
+
+		gotInstance, err := rotatedStorage.Instances().GetByID(instance.InstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, "client-secret", gotInstance.Parameters.ErsContext.SMOperatorCredentials.ClientSecret)
+		assert.Equal(t, "instance-kubeconfig", gotInstance.Parameters.Parameters.Kubeconfig)
+		gotOperation, err := rotatedStorage.Operations().GetOperationByID(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "client-id", gotOperation.ProvisioningParameters.ErsContext.SMOperatorCredentials.ClientID)
+		gotBinding, err := rotatedStorage.Bindings().Get(binding.InstanceID, binding.ID)
+		require.NoError(t, err)
+		assert.Equal(t, binding.Kubeconfig, gotBinding.Kubeconfig)
+	})
+
+	t.Run("should skip records encrypted with the current key", func(t *testing.T) {
+		// when
+		batch, err := rotatedStorage.KeyRotation().RotateBindings(dbmodel.KeyRotationCursor{}, 10, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, batch.Scanned)
+		assert.Equal(t, 0, batch.Rotated)
+	})
+}
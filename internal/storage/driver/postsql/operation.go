@@ -613,6 +613,23 @@ func (s *operations) DeleteByID(operationID string) error {
 	return s.Factory.NewWriteSession().DeleteOperationByID(operationID)
 }
 
+func (s *operations) AcquireOperationLease(operationID, owner string, duration time.Duration) error {
+	now := time.Now()
+	err := s.Factory.NewWriteSession().AcquireOperationLease(operationID, owner, now, now.Add(duration))
+	if err == nil {
+		return nil
+	}
+	// the lease is not acquired also when the operation does not exist, e.g. it was removed at the end of a deprovisioning
+	if _, getErr := s.Factory.NewReadSession().GetOperationByID(operationID); dberr.IsNotFound(getErr) {
+		return dberr.NotFound("Operation with id %s not exist", operationID)
+	}
+	return err
+}
+
+func (s *operations) ReleaseOperationLease(operationID, owner string) error {
+	return s.Factory.NewWriteSession().ReleaseOperationLease(operationID, owner)
+}
+
 func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO, error) {
 	err := s.cipher.EncryptSMCredentials(&op.ProvisioningParameters)
 	if err != nil {
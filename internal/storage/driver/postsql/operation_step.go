@@ -0,0 +1,95 @@
+package postsql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+)
+
+type OperationSteps struct {
+	postsql.Factory
+}
+
+func NewOperationSteps(sess postsql.Factory) *OperationSteps {
+	return &OperationSteps{
+		Factory: sess,
+	}
+}
+
+// Upsert records a run of the step, returns the not found error if the operation does not exist
+func (s *OperationSteps) Upsert(step internal.OperationStep) error {
+	err := s.Factory.NewWriteSession().UpsertOperationStep(s.toOperationStepDTO(step))
+
+	switch {
+	case dberr.IsNotFound(err):
+		return err
+	case err != nil:
+		return fmt.Errorf("while recording step %s of operation %s: %w", step.StepName, step.OperationID, err)
+	}
+
+	return nil
+}
+
+func (s *OperationSteps) ListByOperationID(operationID string) ([]internal.OperationStep, error) {
+	dtos, err := s.Factory.NewReadSession().ListOperationSteps(operationID)
+	if err != nil {
+		return nil, fmt.Errorf("while listing steps of operation %s: %w", operationID, err)
+	}
+
+	return s.toOperationSteps(dtos), nil
+}
+
+func (s *OperationSteps) ListByInstanceID(instanceID string) ([]internal.OperationStep, error) {
+	dtos, err := s.Factory.NewReadSession().ListOperationStepsByInstanceID(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("while listing operation steps of instance %s: %w", instanceID, err)
+	}
+
+	return s.toOperationSteps(dtos), nil
+}
+
+func (s *OperationSteps) toOperationStepDTO(step internal.OperationStep) dbmodel.OperationStepDTO {
+	dto := dbmodel.OperationStepDTO{
+		OperationID: step.OperationID,
+		InstanceID:  step.InstanceID,
+		Stage:       step.Stage,
+		StepName:    step.StepName,
+		State:       string(step.State),
+		Attempts:    step.Attempts,
+		LastError:   step.LastError,
+		StartedAt:   step.StartedAt,
+		UpdatedAt:   step.UpdatedAt,
+	}
+	if step.FinishedAt != nil {
+		dto.FinishedAt = sql.NullTime{Time: *step.FinishedAt, Valid: true}
+	}
+
+	return dto
+}
+
+func (s *OperationSteps) toOperationSteps(dtos []dbmodel.OperationStepDTO) []internal.OperationStep {
+	steps := make([]internal.OperationStep, 0, len(dtos))
+	for _, dto := range dtos {
+		step := internal.OperationStep{
+			OperationID: dto.OperationID,
+			InstanceID:  dto.InstanceID,
+			Stage:       dto.Stage,
+			StepName:    dto.StepName,
+			State:       internal.OperationStepState(dto.State),
+			Attempts:    dto.Attempts,
+			LastError:   dto.LastError,
+			StartedAt:   dto.StartedAt,
+			UpdatedAt:   dto.UpdatedAt,
+		}
+		if dto.FinishedAt.Valid {
+			step.FinishedAt = &dto.FinishedAt.Time
+		}
+		steps = append(steps, step)
+	}
+
+	return steps
+}
@@ -0,0 +1,96 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationSteps(t *testing.T) {
+	storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+	require.NoError(t, err)
+	require.NotNil(t, brokerStorage)
+	defer func() {
+		err := storageCleanup()
+		assert.NoError(t, err)
+	}()
+	operation := fixture.FixProvisioningOperation("operation-id", "instance-id")
+	err = brokerStorage.Operations().InsertOperation(operation)
+	require.NoError(t, err)
+
+	startedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Millisecond)
+	finishedAt := startedAt.Add(time.Minute)
+
+	// when
+	err = brokerStorage.OperationSteps().Upsert(internal.OperationStep{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		Stage:       "create_runtime",
+		StepName:    "Check_RuntimeResource_Provisioning",
+		State:       internal.OperationStepStateRetrying,
+		StartedAt:   startedAt.Add(time.Second),
+		UpdatedAt:   startedAt.Add(time.Second),
+	})
+	require.NoError(t, err)
+	err = brokerStorage.OperationSteps().Upsert(internal.OperationStep{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		Stage:       "create_runtime",
+		StepName:    "Check_RuntimeResource_Provisioning",
+		State:       internal.OperationStepStateSucceeded,
+		StartedAt:   finishedAt,
+		FinishedAt:  &finishedAt,
+		UpdatedAt:   finishedAt,
+	})
+	require.NoError(t, err)
+	err = brokerStorage.OperationSteps().Upsert(internal.OperationStep{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		Stage:       "start",
+		StepName:    "Start",
+		State:       internal.OperationStepStateSucceeded,
+		StartedAt:   startedAt,
+		FinishedAt:  &startedAt,
+		UpdatedAt:   startedAt,
+	})
+	require.NoError(t, err)
+
+	// then
+	steps, err := brokerStorage.OperationSteps().ListByOperationID(operation.ID)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+
+	assert.Equal(t, "Start", steps[0].StepName)
+	assert.Equal(t, 1, steps[0].Attempts)
+
+	assert.Equal(t, "Check_RuntimeResource_Provisioning", steps[1].StepName)
+	assert.Equal(t, internal.OperationStepStateSucceeded, steps[1].State)
+	assert.Equal(t, 2, steps[1].Attempts)
+	assert.True(t, startedAt.Add(time.Second).Equal(steps[1].StartedAt))
+	require.NotNil(t, steps[1].FinishedAt)
+	assert.True(t, finishedAt.Equal(*steps[1].FinishedAt))
+
+	steps, err = brokerStorage.OperationSteps().ListByInstanceID(operation.InstanceID)
+	require.NoError(t, err)
+	assert.Len(t, steps, 2)
+
+	// when
+	err = brokerStorage.OperationSteps().Upsert(internal.OperationStep{
+		OperationID: "not-existing-operation-id",
+		InstanceID:  operation.InstanceID,
+		Stage:       "start",
+		StepName:    "Start",
+		State:       internal.OperationStepStateSucceeded,
+		StartedAt:   startedAt,
+		UpdatedAt:   startedAt,
+	})
+
+	// then
+	assert.True(t, dberr.IsNotFound(err))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/pivotal-cf/brokerapi/v12/domain"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,48 @@ func TestOperation(t *testing.T) {
 		assert.Equal(t, "op-to-keep", ops[0].ID)
 	})
 
+	t.Run("should lease operation", func(t *testing.T) {
+		// given
+		storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+		defer func() {
+			err := storageCleanup()
+			assert.NoError(t, err)
+		}()
+		op := fixture.FixProvisioningOperation("op-leased", "inst1")
+		err = brokerStorage.Operations().InsertOperation(op)
+		require.NoError(t, err)
+
+		// when
+		err = brokerStorage.Operations().AcquireOperationLease(op.ID, "replica-1", time.Minute)
+		require.NoError(t, err)
+
+		// then
+		err = brokerStorage.Operations().AcquireOperationLease(op.ID, "replica-1", time.Minute)
+		assert.NoError(t, err)
+		err = brokerStorage.Operations().AcquireOperationLease(op.ID, "replica-2", time.Minute)
+		assert.True(t, dberr.IsConflict(err))
+		err = brokerStorage.Operations().AcquireOperationLease("not-existing", "replica-2", time.Minute)
+		assert.True(t, dberr.IsNotFound(err))
+
+		// the lease does not change the operation version
+		stored, err := brokerStorage.Operations().GetOperationByID(op.ID)
+		require.NoError(t, err)
+		_, err = brokerStorage.Operations().UpdateOperation(*stored)
+		assert.NoError(t, err)
+
+		// when
+		err = brokerStorage.Operations().ReleaseOperationLease(op.ID, "replica-1")
+		require.NoError(t, err)
+
+		// then
+		err = brokerStorage.Operations().AcquireOperationLease(op.ID, "replica-2", -time.Second)
+		assert.NoError(t, err)
+		err = brokerStorage.Operations().AcquireOperationLease(op.ID, "replica-1", time.Minute)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Provisioning in Shanghai", func(t *testing.T) {
 		storageCleanup, brokerStorage, err := storage.GetStorageForTests(cfg,
 			storage.WithConnectionURL(fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s timezone=%s", cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode, "'Asia/Shanghai'")))
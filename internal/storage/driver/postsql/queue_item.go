@@ -0,0 +1,117 @@
+package postsql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/postsql"
+)
+
+type QueueItems struct {
+	postsql.Factory
+}
+
+func NewQueueItems(sess postsql.Factory) *QueueItems {
+	return &QueueItems{
+		Factory: sess,
+	}
+}
+
+// Insert schedules the item only if it is not already present in the queue
+func (q *QueueItems) Insert(item internal.QueueItem) error {
+	err := q.Factory.NewWriteSession().InsertQueueItem(q.toQueueItemDTO(item))
+
+	switch {
+	case dberr.IsAlreadyExists(err):
+		return err
+	case err != nil:
+		return fmt.Errorf("while inserting operation %s to the queue %s: %w", item.OperationID, item.QueueName, err)
+	}
+
+	return nil
+}
+
+// Upsert schedules the item, overriding the run time of an existing one. The lease of an existing item is kept and the item is
+// marked dirty, so it is kept in the queue when the lease owner releases it.
+func (q *QueueItems) Upsert(item internal.QueueItem) error {
+	err := q.Factory.NewWriteSession().UpsertQueueItem(q.toQueueItemDTO(item))
+	if err != nil {
+		return fmt.Errorf("while scheduling operation %s in the queue %s: %w", item.OperationID, item.QueueName, err)
+	}
+
+	return nil
+}
+
+func (q *QueueItems) Claim(queueName, owner string, leaseDuration time.Duration) (*internal.QueueItem, error) {
+	now := time.Now()
+	dto, err := q.Factory.NewWriteSession().ClaimQueueItem(queueName, owner, now, now.Add(leaseDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	item := q.toQueueItem(dto)
+	return &item, nil
+}
+
+func (q *QueueItems) Renew(queueName, operationID, owner string, leaseDuration time.Duration) error {
+	return q.Factory.NewWriteSession().RenewQueueItemLease(queueName, operationID, owner, time.Now().Add(leaseDuration))
+}
+
+func (q *QueueItems) Release(queueName, operationID, owner string, nextRunAt time.Time) error {
+	return q.Factory.NewWriteSession().ReleaseQueueItem(queueName, operationID, owner, nextRunAt, time.Now())
+}
+
+// Wake schedules an existing item which is not leased for immediate processing
+func (q *QueueItems) Wake(queueName, operationID string) error {
+	return q.Factory.NewWriteSession().WakeQueueItem(queueName, operationID, time.Now())
+}
+
+// Delete removes the item leased by the owner. An item marked dirty is released instead, so it is processed again.
+func (q *QueueItems) Delete(queueName, operationID, owner string) error {
+	sess := q.Factory.NewWriteSession()
+	if err := sess.DeleteQueueItem(queueName, operationID, owner); err != nil {
+		return err
+	}
+	now := time.Now()
+	return sess.ReleaseQueueItem(queueName, operationID, owner, now, now)
+}
+
+func (q *QueueItems) Count(queueName string) (int, error) {
+	return q.Factory.NewReadSession().CountQueueItems(queueName)
+}
+
+func (q *QueueItems) toQueueItemDTO(item internal.QueueItem) dbmodel.QueueItemDTO {
+	now := time.Now()
+	createdAt := item.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	return dbmodel.QueueItemDTO{
+		QueueName:   item.QueueName,
+		OperationID: item.OperationID,
+		NextRunAt:   item.NextRunAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   now,
+	}
+}
+
+func (q *QueueItems) toQueueItem(dto dbmodel.QueueItemDTO) internal.QueueItem {
+	item := internal.QueueItem{
+		QueueName:   dto.QueueName,
+		OperationID: dto.OperationID,
+		NextRunAt:   dto.NextRunAt,
+		LeaseOwner:  dto.LeaseOwner.String,
+		Dirty:       dto.Dirty,
+		CreatedAt:   dto.CreatedAt,
+		UpdatedAt:   dto.UpdatedAt,
+	}
+	if dto.LeaseExpiresAt.Valid {
+		item.LeaseExpiresAt = &dto.LeaseExpiresAt.Time
+	}
+
+	return item
+}
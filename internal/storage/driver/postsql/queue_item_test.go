@@ -0,0 +1,105 @@
+package postsql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma-environment-broker/internal"
+	"github.com/kyma-project/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueItems(t *testing.T) {
+	storageCleanup, brokerStorage, err := GetStorageForDatabaseTests()
+	require.NoError(t, err)
+	require.NotNil(t, brokerStorage)
+	defer func() {
+		err := storageCleanup()
+		assert.NoError(t, err)
+	}()
+	items := brokerStorage.QueueItems()
+
+	// given
+	err = items.Upsert(internal.QueueItem{QueueName: "provisioning", OperationID: "op-1", NextRunAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	err = items.Upsert(internal.QueueItem{QueueName: "provisioning", OperationID: "op-2", NextRunAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	err = items.Upsert(internal.QueueItem{QueueName: "update", OperationID: "op-3", NextRunAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+
+	err = items.Insert(internal.QueueItem{QueueName: "provisioning", OperationID: "op-1", NextRunAt: time.Now()})
+	assert.True(t, dberr.IsAlreadyExists(err))
+
+	count, err := items.Count("provisioning")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// when
+	claimed, err := items.Claim("provisioning", "replica-1", time.Minute)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", claimed.OperationID)
+	assert.Equal(t, "replica-1", claimed.LeaseOwner)
+	require.NotNil(t, claimed.LeaseExpiresAt)
+
+	_, err = items.Claim("provisioning", "replica-2", time.Minute)
+	assert.True(t, dberr.IsNotFound(err))
+
+	// when the item is deleted by a replica which does not hold the lease
+	err = items.Delete("provisioning", "op-1", "replica-2")
+	require.NoError(t, err)
+	count, err = items.Count("provisioning")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// when the item is scheduled again while it is leased the lease is kept
+	err = items.Upsert(internal.QueueItem{QueueName: "provisioning", OperationID: "op-1", NextRunAt: time.Now().Add(-time.Second)})
+	require.NoError(t, err)
+	err = items.Renew("provisioning", "op-1", "replica-1", time.Minute)
+	require.NoError(t, err)
+	_, err = items.Claim("provisioning", "replica-2", time.Minute)
+	assert.True(t, dberr.IsNotFound(err))
+
+	// when the lease owner deletes the dirty item it is released instead
+	err = items.Delete("provisioning", "op-1", "replica-1")
+	require.NoError(t, err)
+	claimed, err = items.Claim("provisioning", "replica-2", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", claimed.OperationID)
+	assert.Equal(t, "replica-2", claimed.LeaseOwner)
+	assert.False(t, claimed.Dirty)
+
+	err = items.Delete("provisioning", "op-1", "replica-2")
+	require.NoError(t, err)
+	count, err = items.Count("provisioning")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// when the item scheduled later is woken up it is claimed immediately, a leased item is not changed
+	err = items.Wake("provisioning", "op-2")
+	require.NoError(t, err)
+	claimed, err = items.Claim("provisioning", "replica-1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "op-2", claimed.OperationID)
+	err = items.Wake("provisioning", "op-2")
+	require.NoError(t, err)
+	_, err = items.Claim("provisioning", "replica-2", time.Minute)
+	assert.True(t, dberr.IsNotFound(err))
+
+	// when the lease expired another replica claims the item
+	claimed, err = items.Claim("update", "replica-1", -time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "op-3", claimed.OperationID)
+	claimed, err = items.Claim("update", "replica-2", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "replica-2", claimed.LeaseOwner)
+
+	// when the lease owner releases the item it is scheduled at the given time
+	err = items.Release("update", "op-3", "replica-2", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = items.Claim("update", "replica-1", time.Minute)
+	assert.True(t, dberr.IsNotFound(err))
+}
@@ -1,20 +1,74 @@
 package storage
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/kyma-project/kyma-environment-broker/internal"
 )
 
+// keyIDSeparator separates the key ID from the encrypted payload. It never occurs in base64 encoded data,
+// so payloads encrypted before key IDs were introduced are recognized as encrypted with the legacy key.
+const keyIDSeparator = ":"
+
+// NewEncrypter returns an Encrypter using a single key without a key ID.
 func NewEncrypter(secretKey string) *Encrypter {
-	return &Encrypter{key: []byte(secretKey)}
+	return &Encrypter{keys: map[string][]byte{"": []byte(secretKey)}}
+}
+
+// NewEncrypterFromConfig returns an Encrypter which encrypts data with the key identified by SecretKeyID
+// and decrypts data encrypted with the current key or any of the previous keys.
+func NewEncrypterFromConfig(cfg Config) (*Encrypter, error) {
+	if strings.Contains(cfg.SecretKeyID, keyIDSeparator) {
+		return nil, fmt.Errorf("secret key ID %q must not contain %q", cfg.SecretKeyID, keyIDSeparator)
+	}
+	e := &Encrypter{
+		keyID: cfg.SecretKeyID,
+		keys:  map[string][]byte{cfg.SecretKeyID: []byte(cfg.SecretKey)},
+	}
+	for _, entry := range cfg.PreviousSecretKeys {
+		id, key, found := strings.Cut(entry, keyIDSeparator)
+		if !found {
+			return nil, fmt.Errorf("previous secret key must have the format <id>:<key>")
+		}
+		if id == cfg.SecretKeyID {
+			return nil, fmt.Errorf("previous secret key ID %q is the same as the current secret key ID", id)
+		}
+		if _, exists := e.keys[id]; exists {
+			return nil, fmt.Errorf("previous secret key ID %q is duplicated", id)
+		}
+		e.keys[id] = []byte(key)
+	}
+	return e, nil
 }
 
 type Encrypter struct {
-	key []byte
+	// keyID identifies the key used for encryption, an empty ID means the payload is not prefixed
+	keyID string
+	keys  map[string][]byte
+}
+
+// KeyID returns the ID of the key used for encryption.
+func (e *Encrypter) KeyID() string {
+	return e.keyID
+}
+
+// UsesCurrentKey returns true if the data was encrypted with the key used for encryption.
+func (e *Encrypter) UsesCurrentKey(data []byte) bool {
+	keyID, _ := splitKeyID(data)
+	return keyID == e.keyID
+}
+
+func splitKeyID(data []byte) (string, []byte) {
+	keyID, payload, found := bytes.Cut(data, []byte(keyIDSeparator))
+	if !found {
+		return "", data
+	}
+	return string(keyID), payload
 }
 
 func (e *Encrypter) Encrypt(data []byte) ([]byte, error) {
@@ -68,7 +122,7 @@ func (e *Encrypter) EncryptKubeconfig(provisioningParameters *internal.Provision
 }
 
 func (e *Encrypter) encryptGCM(data []byte) ([]byte, error) {
-	aes, err := aes.NewCipher(e.key)
+	aes, err := aes.NewCipher(e.keys[e.keyID])
 	if err != nil {
 		return nil, err
 	}
@@ -77,18 +131,26 @@ func (e *Encrypter) encryptGCM(data []byte) ([]byte, error) {
 		return nil, err
 	}
 	encoded := gcm.Seal(nil, make([]byte, gcm.NonceSize()), data, nil)
-	return []byte(base64.StdEncoding.EncodeToString(encoded)), nil
+	if e.keyID == "" {
+		return []byte(base64.StdEncoding.EncodeToString(encoded)), nil
+	}
+	return []byte(e.keyID + keyIDSeparator + base64.StdEncoding.EncodeToString(encoded)), nil
 }
 
 // Decryption
 type DecryptFunc func(data []byte) ([]byte, error)
 
-func (e *Encrypter) decryptGCM(ciphertext []byte) ([]byte, error) {
-	ciphertext, err := base64.StdEncoding.DecodeString(string(ciphertext))
+func (e *Encrypter) decryptGCM(data []byte) ([]byte, error) {
+	keyID, payload := splitKeyID(data)
+	key, found := e.keys[keyID]
+	if !found {
+		return nil, fmt.Errorf("unknown secret key ID %q", keyID)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(string(payload))
 	if err != nil {
 		return nil, err
 	}
-	aes, err := aes.NewCipher(e.key)
+	aes, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/kyma-environment-broker/common/runtime"
@@ -143,3 +144,96 @@ func TestDecryptKubeconfigUsingModeWithEmptyKubeconfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "", params.Parameters.Kubeconfig)
 }
+
+func TestEncrypterWithKeyRotation(t *testing.T) {
+	legacyKey := rand.String(32)
+	previousKey := rand.String(32)
+	currentKey := rand.String(32)
+	data := []byte("data encrypted with rotated keys")
+
+	legacy := NewEncrypter(legacyKey)
+	previous, err := NewEncrypterFromConfig(Config{SecretKey: previousKey, SecretKeyID: "v1"})
+	require.NoError(t, err)
+	current, err := NewEncrypterFromConfig(Config{
+		SecretKey:          currentKey,
+		SecretKeyID:        "v2",
+		PreviousSecretKeys: []string{":" + legacyKey, "v1:" + previousKey},
+	})
+	require.NoError(t, err)
+
+	t.Run("should prefix encrypted data with the key ID", func(t *testing.T) {
+		// when
+		encrypted, err := current.Encrypt(data)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(encrypted), "v2:"))
+		assert.True(t, current.UsesCurrentKey(encrypted))
+
+		decrypted, err := current.DecryptUsingMode(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	})
+
+	t.Run("should decrypt data encrypted with previous keys", func(t *testing.T) {
+		for name, encrypter := range map[string]*Encrypter{"legacy": legacy, "previous": previous} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				encrypted, err := encrypter.Encrypt(data)
+				require.NoError(t, err)
+
+				// when
+				decrypted, err := current.DecryptUsingMode(encrypted)
+
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, data, decrypted)
+				assert.False(t, current.UsesCurrentKey(encrypted))
+			})
+		}
+	})
+
+	t.Run("should fail for unknown key ID", func(t *testing.T) {
+		// given
+		encrypted, err := current.Encrypt(data)
+		require.NoError(t, err)
+
+		// when
+		_, err = previous.DecryptUsingMode(encrypted)
+
+		// then
+		assert.EqualError(t, err, `unknown secret key ID "v2"`)
+	})
+}
+
+func TestNewEncrypterFromConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config        Config
+		expectedError string
+	}{
+		"key ID with separator": {
+			config:        Config{SecretKey: rand.String(32), SecretKeyID: "v:1"},
+			expectedError: `secret key ID "v:1" must not contain ":"`,
+		},
+		"previous key without ID": {
+			config:        Config{SecretKey: rand.String(32), SecretKeyID: "v2", PreviousSecretKeys: []string{rand.String(32)}},
+			expectedError: "previous secret key must have the format <id>:<key>",
+		},
+		"previous key with current ID": {
+			config:        Config{SecretKey: rand.String(32), SecretKeyID: "v2", PreviousSecretKeys: []string{"v2:" + rand.String(32)}},
+			expectedError: `previous secret key ID "v2" is the same as the current secret key ID`,
+		},
+		"duplicated previous key ID": {
+			config:        Config{SecretKey: rand.String(32), SecretKeyID: "v3", PreviousSecretKeys: []string{"v1:" + rand.String(32), "v1:" + rand.String(32)}},
+			expectedError: `previous secret key ID "v1" is duplicated`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := NewEncrypterFromConfig(tc.config)
+
+			// then
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
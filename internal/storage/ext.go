@@ -62,6 +62,9 @@ type Operations interface {
 
 	DeleteByID(operationID string) error
 	GetAllOperations() ([]internal.Operation, error)
+
+	AcquireOperationLease(operationID, owner string, duration time.Duration) error
+	ReleaseOperationLease(operationID, owner string) error
 }
 
 type Provisioning interface {
@@ -111,6 +114,13 @@ type Bindings interface {
 	Delete(instanceID, bindingID string) error
 	ListByInstanceID(instanceID string) ([]internal.Binding, error)
 	ListExpired() ([]internal.Binding, error)
+	ListInProgress() ([]internal.Binding, error)
+	// ListUnreportedExpired lists created bindings whose expiration was not reported yet
+	ListUnreportedExpired() ([]internal.Binding, error)
+	// MarkExpirationReported returns true only to the first caller after the binding expired
+	MarkExpirationReported(instanceID, bindingID string) (bool, error)
+	// List returns bindings of all instances matching the filter without kubeconfigs, the number of returned bindings and the total number of matching bindings
+	List(filter dbmodel.BindingFilter) ([]internal.Binding, int, int, error)
 	GetStatistics() (internal.BindingStats, error)
 }
 
@@ -119,6 +129,31 @@ type Actions interface {
 	ListActionsByInstanceID(instanceID string) ([]runtime.Action, error)
 }
 
+type QueueItems interface {
+	Insert(item internal.QueueItem) error
+	Upsert(item internal.QueueItem) error
+	Claim(queueName, owner string, leaseDuration time.Duration) (*internal.QueueItem, error)
+	Renew(queueName, operationID, owner string, leaseDuration time.Duration) error
+	Release(queueName, operationID, owner string, nextRunAt time.Time) error
+	Wake(queueName, operationID string) error
+	Delete(queueName, operationID, owner string) error
+	Count(queueName string) (int, error)
+}
+
+// KeyRotation re-encrypts stored data with the current encryption key. Every call processes at most limit records
+// following the cursor and returns the cursor of the last processed record.
+type KeyRotation interface {
+	RotateInstances(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error)
+	RotateOperations(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error)
+	RotateBindings(cursor dbmodel.KeyRotationCursor, limit int, dryRun bool) (dbmodel.KeyRotationBatch, error)
+}
+
+type OperationSteps interface {
+	Upsert(step internal.OperationStep) error
+	ListByOperationID(operationID string) ([]internal.OperationStep, error)
+	ListByInstanceID(instanceID string) ([]internal.OperationStep, error)
+}
+
 type TimeZones interface {
 	GetTimeZone() (string, error)
 }
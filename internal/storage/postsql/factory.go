@@ -56,9 +56,18 @@ type ReadSession interface {
 	GetBinding(instanceID string, bindingID string) (dbmodel.BindingDTO, dberr.Error)
 	ListBindings(instanceID string) ([]dbmodel.BindingDTO, error)
 	ListExpiredBindings() ([]dbmodel.BindingDTO, error)
+	ListBindingsInProgress() ([]dbmodel.BindingDTO, error)
+	ListUnreportedExpiredBindings() ([]dbmodel.BindingDTO, error)
+	ListBindingsByFilter(filter dbmodel.BindingFilter) ([]dbmodel.BindingDTO, int, error)
 	GetBindingsStatistics() (dbmodel.BindingStatsDTO, error)
 	ListActions(instanceID string) ([]runtime.Action, error)
 	GetTimeZone() (string, dberr.Error)
+	CountQueueItems(queueName string) (int, error)
+	ListOperationSteps(operationID string) ([]dbmodel.OperationStepDTO, error)
+	ListOperationStepsByInstanceID(instanceID string) ([]dbmodel.OperationStepDTO, error)
+	ListInstancesEncryptedData(afterInstanceID string, limit int) ([]dbmodel.EncryptedDataDTO, error)
+	ListOperationsEncryptedData(afterID string, limit int) ([]dbmodel.EncryptedDataDTO, error)
+	ListBindingsEncryptedData(afterInstanceID, afterID string, limit int) ([]dbmodel.EncryptedDataDTO, error)
 }
 
 //go:generate mockery --name=WriteSession
@@ -79,6 +88,20 @@ type WriteSession interface {
 	DeleteBinding(instanceID, bindingID string) dberr.Error
 	UpdateInstanceLastOperation(instanceID, operationID string) error
 	InsertAction(actionType runtime.ActionType, instanceID, message, oldValue, newValue string) dberr.Error
+	AcquireOperationLease(operationID, owner string, now, leaseExpiresAt time.Time) dberr.Error
+	ReleaseOperationLease(operationID, owner string) dberr.Error
+	InsertQueueItem(item dbmodel.QueueItemDTO) dberr.Error
+	UpsertQueueItem(item dbmodel.QueueItemDTO) dberr.Error
+	ClaimQueueItem(queueName, owner string, now, leaseExpiresAt time.Time) (dbmodel.QueueItemDTO, dberr.Error)
+	RenewQueueItemLease(queueName, operationID, owner string, leaseExpiresAt time.Time) dberr.Error
+	ReleaseQueueItem(queueName, operationID, owner string, nextRunAt, now time.Time) dberr.Error
+	WakeQueueItem(queueName, operationID string, nextRunAt time.Time) dberr.Error
+	DeleteQueueItem(queueName, operationID, owner string) dberr.Error
+	UpsertOperationStep(step dbmodel.OperationStepDTO) dberr.Error
+	UpdateInstanceEncryptedData(instanceID, oldData, newData string) dberr.Error
+	UpdateOperationEncryptedData(operationID, oldData, newData string) dberr.Error
+	UpdateBindingEncryptedData(instanceID, bindingID, oldData, newData string) dberr.Error
+	MarkBindingExpirationReported(instanceID, bindingID string) (bool, dberr.Error)
 }
 
 type Transaction interface {
@@ -20,6 +20,8 @@ const (
 	InstancesArchivedTableName = "instances_archived"
 	BindingsTableName          = "bindings"
 	ActionsTableName           = "actions"
+	QueueItemsTableName        = "queue_items"
+	OperationStepsTableName    = "operation_steps"
 )
 
 // InitializeDatabase opens database connection and initializes schema if it does not exist
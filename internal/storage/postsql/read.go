@@ -72,6 +72,87 @@ func (r readSession) ListBindings(instanceID string) ([]dbmodel.BindingDTO, erro
 	return bindings, err
 }
 
+// ListBindingsByFilter returns bindings matching the filter without kubeconfigs and the total number of matching bindings
+func (r readSession) ListBindingsByFilter(filter dbmodel.BindingFilter) ([]dbmodel.BindingDTO, int, error) {
+	var bindings []dbmodel.BindingDTO
+	stmt := r.session.
+		Select("id", "instance_id", "created_at", "expires_at", "expiration_seconds", "created_by", "scope", "state", "state_description", "credential_type").
+		From(BindingsTableName).
+		OrderDesc("created_at")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		stmt.Paginate(uint64(filter.Page), uint64(filter.PageSize))
+	}
+	addBindingFilter(stmt, filter)
+
+	_, err := stmt.Load(&bindings)
+	if err != nil {
+		return nil, -1, fmt.Errorf("while listing bindings: %w", err)
+	}
+
+	var res struct {
+		Total int
+	}
+	countStmt := r.session.Select("count(*) as total").From(BindingsTableName)
+	addBindingFilter(countStmt, filter)
+	err = countStmt.LoadOne(&res)
+	if err != nil {
+		return nil, -1, fmt.Errorf("while counting bindings: %w", err)
+	}
+
+	return bindings, res.Total, nil
+}
+
+func addBindingFilter(stmt *dbr.SelectStmt, filter dbmodel.BindingFilter) {
+	if len(filter.InstanceIDs) > 0 {
+		stmt.Where("instance_id IN ?", filter.InstanceIDs)
+	}
+	if len(filter.CreatedBy) > 0 {
+		stmt.Where("created_by IN ?", filter.CreatedBy)
+	}
+	if filter.Expired != nil {
+		if *filter.Expired {
+			stmt.Where(dbr.Lte("expires_at", time.Now().UTC()))
+		} else {
+			stmt.Where(dbr.Gt("expires_at", time.Now().UTC()))
+		}
+	}
+}
+
+func (r readSession) ListBindingsInProgress() ([]dbmodel.BindingDTO, error) {
+	var bindings []dbmodel.BindingDTO
+	_, err := r.session.
+		Select("*").
+		From(BindingsTableName).
+		Where(dbr.Eq("state", string(domain.InProgress))).
+		OrderBy("created_at").
+		Load(&bindings)
+
+	if err != nil {
+		return nil, fmt.Errorf("while getting bindings in progress: %w", err)
+	}
+
+	return bindings, nil
+}
+
+// ListUnreportedExpiredBindings lists created bindings which expired after their last reported expiration
+func (r readSession) ListUnreportedExpiredBindings() ([]dbmodel.BindingDTO, error) {
+	var bindings []dbmodel.BindingDTO
+	_, err := r.session.
+		Select("id", "instance_id", "expires_at", "created_by").
+		From(BindingsTableName).
+		Where(dbr.Lte("expires_at", time.Now().UTC())).
+		Where("reported_expires_at IS DISTINCT FROM expires_at").
+		Where(dbr.Or(dbr.Eq("state", string(domain.Succeeded)), dbr.Eq("state", ""))).
+		OrderBy("expires_at").
+		Load(&bindings)
+
+	if err != nil {
+		return nil, fmt.Errorf("while getting unreported expired bindings: %w", err)
+	}
+
+	return bindings, nil
+}
+
 func (r readSession) ListExpiredBindings() ([]dbmodel.BindingDTO, error) {
 	currentTime := time.Now().UTC()
 	var bindings []dbmodel.BindingDTO
@@ -305,7 +386,8 @@ func (r readSession) CountNotFinishedOperationsByInstanceID(instanceID string) (
 func (r readSession) GetNotFinishedOperationsByType(operationType internal.OperationType) ([]dbmodel.OperationDTO, dberr.Error) {
 	stateInProgress := dbr.Eq("state", domain.InProgress)
 	statePending := dbr.Eq("state", internal.OperationStatePending)
-	stateCondition := dbr.Or(statePending, stateInProgress)
+	stateCanceling := dbr.Eq("state", internal.OperationStateCanceling)
+	stateCondition := dbr.Or(statePending, stateInProgress, stateCanceling)
 	typeCondition := dbr.Eq("type", operationType)
 	var operations []dbmodel.OperationDTO
 
@@ -943,6 +1025,86 @@ func (r readSession) ListActions(instanceID string) ([]runtime.Action, error) {
 	return actions, err
 }
 
+func (r readSession) CountQueueItems(queueName string) (int, error) {
+	var res struct {
+		Total int
+	}
+	err := r.session.Select("count(*) as total").
+		From(QueueItemsTableName).
+		Where(dbr.Eq("queue_name", queueName)).
+		LoadOne(&res)
+
+	return res.Total, err
+}
+
+func (r readSession) ListOperationSteps(operationID string) ([]dbmodel.OperationStepDTO, error) {
+	var steps []dbmodel.OperationStepDTO
+	_, err := r.session.Select("*").
+		From(OperationStepsTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		OrderBy("started_at").
+		Load(&steps)
+
+	return steps, err
+}
+
+func (r readSession) ListOperationStepsByInstanceID(instanceID string) ([]dbmodel.OperationStepDTO, error) {
+	var steps []dbmodel.OperationStepDTO
+	_, err := r.session.Select("*").
+		From(OperationStepsTableName).
+		Where(dbr.Eq("instance_id", instanceID)).
+		OrderBy("started_at").
+		Load(&steps)
+
+	return steps, err
+}
+
+func (r readSession) ListInstancesEncryptedData(afterInstanceID string, limit int) ([]dbmodel.EncryptedDataDTO, error) {
+	var data []dbmodel.EncryptedDataDTO
+	_, err := r.session.
+		Select("instance_id as id", "instance_id", "provisioning_parameters as data").
+		From(InstancesTableName).
+		Where(dbr.Gt("instance_id", afterInstanceID)).
+		OrderBy("instance_id").
+		Limit(uint64(limit)).
+		Load(&data)
+	if err != nil {
+		return nil, fmt.Errorf("while listing instances encrypted data: %w", err)
+	}
+	return data, nil
+}
+
+func (r readSession) ListOperationsEncryptedData(afterID string, limit int) ([]dbmodel.EncryptedDataDTO, error) {
+	var data []dbmodel.EncryptedDataDTO
+	_, err := r.session.
+		Select("id", "instance_id", "COALESCE(provisioning_parameters::text, '') as data").
+		From(OperationTableName).
+		Where(dbr.Gt("id", afterID)).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&data)
+	if err != nil {
+		return nil, fmt.Errorf("while listing operations encrypted data: %w", err)
+	}
+	return data, nil
+}
+
+func (r readSession) ListBindingsEncryptedData(afterInstanceID, afterID string, limit int) ([]dbmodel.EncryptedDataDTO, error) {
+	var data []dbmodel.EncryptedDataDTO
+	_, err := r.session.
+		Select("id", "instance_id", "COALESCE(kubeconfig, '') as data").
+		From(BindingsTableName).
+		Where("(instance_id, id) > (?, ?)", afterInstanceID, afterID).
+		OrderBy("instance_id").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Load(&data)
+	if err != nil {
+		return nil, fmt.Errorf("while listing bindings encrypted data: %w", err)
+	}
+	return data, nil
+}
+
 func addInstanceArchivedFilter(stmt *dbr.SelectStmt, filter dbmodel.InstanceFilter) {
 	if len(filter.InstanceIDs) > 0 {
 		stmt.Where("instance_id IN ?", filter.InstanceIDs)
@@ -1,6 +1,8 @@
 package postsql
 
 import (
+	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/kyma-project/kyma-environment-broker/common/events"
@@ -14,7 +16,8 @@ import (
 )
 
 const (
-	UniqueViolationErrorCode = "23505"
+	UniqueViolationErrorCode     = "23505"
+	ForeignKeyViolationErrorCode = "23503"
 )
 
 type writeSession struct {
@@ -55,6 +58,11 @@ func (ws writeSession) InsertBinding(binding dbmodel.BindingDTO) dberr.Error {
 		Pair("kubeconfig", binding.Kubeconfig).
 		Pair("expiration_seconds", binding.ExpirationSeconds).
 		Pair("created_by", binding.CreatedBy).
+		Pair("scope", binding.Scope).
+		Pair("state", binding.State).
+		Pair("state_description", binding.StateDescription).
+		Pair("credential_type", binding.CredentialType).
+		Pair("async", binding.Async).
 		Exec()
 
 	if err != nil {
@@ -73,6 +81,8 @@ func (ws writeSession) UpdateBinding(binding dbmodel.BindingDTO) dberr.Error {
 	_, err := ws.update(BindingsTableName).
 		Set("kubeconfig", binding.Kubeconfig).
 		Set("expires_at", binding.ExpiresAt).
+		Set("state", binding.State).
+		Set("state_description", binding.StateDescription).
 		Where(dbr.Eq("id", binding.ID)).
 		Where(dbr.Eq("instance_id", binding.InstanceID)).
 		Exec()
@@ -425,6 +435,272 @@ func (ws writeSession) InsertAction(actionType runtime.ActionType, instanceID, m
 	return nil
 }
 
+// AcquireOperationLease sets the lease if the operation is not leased, the lease belongs to the same owner or has expired.
+// The operation version is not changed, so the lease does not interfere with the optimistic locking.
+func (ws writeSession) AcquireOperationLease(operationID, owner string, now, leaseExpiresAt time.Time) dberr.Error {
+	res, err := ws.update(OperationTableName).
+		Set("lease_owner", owner).
+		Set("lease_expires_at", leaseExpiresAt).
+		Where(dbr.Eq("id", operationID)).
+		Where("(lease_owner IS NULL OR lease_owner = ? OR lease_expires_at < ?)", owner, now).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to acquire lease for Operation with ID:'%s': %s", operationID, err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.Conflict("Operation with ID:'%s' is leased by another owner or does not exist", operationID)
+	}
+
+	return nil
+}
+
+func (ws writeSession) ReleaseOperationLease(operationID, owner string) dberr.Error {
+	_, err := ws.update(OperationTableName).
+		Set("lease_owner", nil).
+		Set("lease_expires_at", nil).
+		Where(dbr.Eq("id", operationID)).
+		Where(dbr.Eq("lease_owner", owner)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to release lease for Operation with ID:'%s': %s", operationID, err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertQueueItem(item dbmodel.QueueItemDTO) dberr.Error {
+	_, err := ws.insertInto(QueueItemsTableName).
+		Pair("queue_name", item.QueueName).
+		Pair("operation_id", item.OperationID).
+		Pair("next_run_at", item.NextRunAt).
+		Pair("created_at", item.CreatedAt).
+		Pair("updated_at", item.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("operation %s is already scheduled in the queue %s", item.OperationID, item.QueueName)
+			}
+		}
+		return dberr.Internal("Failed to insert record to QueueItems table: %s", err)
+	}
+
+	return nil
+}
+
+// UpsertQueueItem schedules the item at the given time. The lease held on an existing item is kept and the item is marked dirty,
+// so the lease owner keeps it in the queue when it releases the item.
+func (ws writeSession) UpsertQueueItem(item dbmodel.QueueItemDTO) dberr.Error {
+	_, err := ws.insertBySql(fmt.Sprintf(`INSERT INTO %s (queue_name, operation_id, next_run_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (queue_name, operation_id) DO UPDATE
+		SET next_run_at = EXCLUDED.next_run_at, updated_at = EXCLUDED.updated_at, dirty = %s.lease_owner IS NOT NULL`, QueueItemsTableName, QueueItemsTableName),
+		item.QueueName, item.OperationID, item.NextRunAt, item.CreatedAt, item.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to upsert record to QueueItems table: %s", err)
+	}
+
+	return nil
+}
+
+// UpsertOperationStep records a run of the step, the number of attempts is incremented and the start of the first run is kept
+func (ws writeSession) UpsertOperationStep(step dbmodel.OperationStepDTO) dberr.Error {
+	_, err := ws.insertBySql(fmt.Sprintf(`INSERT INTO %s (operation_id, instance_id, stage, step_name, state, attempts, last_error, started_at, finished_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, 1, ?, ?, ?, ?)
+		ON CONFLICT (operation_id, step_name) DO UPDATE
+		SET stage = EXCLUDED.stage, state = EXCLUDED.state, attempts = %s.attempts + 1, last_error = EXCLUDED.last_error,
+			finished_at = EXCLUDED.finished_at, updated_at = EXCLUDED.updated_at`, OperationStepsTableName, OperationStepsTableName),
+		step.OperationID, step.InstanceID, step.Stage, step.StepName, step.State, step.LastError, step.StartedAt, step.FinishedAt, step.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code == ForeignKeyViolationErrorCode {
+			return dberr.NotFound("operation %s not found", step.OperationID)
+		}
+		return dberr.Internal("Failed to upsert record to OperationSteps table: %s", err)
+	}
+
+	return nil
+}
+
+// ClaimQueueItem leases the earliest due item which is not leased or whose lease has expired.
+// Rows locked by another transaction are skipped, so concurrent replicas never claim the same item.
+func (ws writeSession) ClaimQueueItem(queueName, owner string, now, leaseExpiresAt time.Time) (dbmodel.QueueItemDTO, dberr.Error) {
+	var items []dbmodel.QueueItemDTO
+	err := ws.update(QueueItemsTableName).
+		Set("lease_owner", owner).
+		Set("lease_expires_at", leaseExpiresAt).
+		Set("dirty", false).
+		Set("updated_at", now).
+		Where(fmt.Sprintf(`(queue_name, operation_id) IN (
+			SELECT queue_name, operation_id FROM %s
+			WHERE queue_name = ? AND next_run_at <= ? AND (lease_expires_at IS NULL OR lease_expires_at < ?)
+			ORDER BY next_run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED)`, QueueItemsTableName), queueName, now, now).
+		Returning("queue_name", "operation_id", "next_run_at", "lease_owner", "lease_expires_at", "dirty", "created_at", "updated_at").
+		Load(&items)
+
+	if err != nil {
+		return dbmodel.QueueItemDTO{}, dberr.Internal("Failed to claim item from the queue %s: %s", queueName, err)
+	}
+	if len(items) == 0 {
+		return dbmodel.QueueItemDTO{}, dberr.NotFound("no items ready to be processed in the queue %s", queueName)
+	}
+
+	return items[0], nil
+}
+
+// RenewQueueItemLease extends the lease of the item if it is still leased by the given owner
+func (ws writeSession) RenewQueueItemLease(queueName, operationID, owner string, leaseExpiresAt time.Time) dberr.Error {
+	_, err := ws.update(QueueItemsTableName).
+		Set("lease_expires_at", leaseExpiresAt).
+		Where(dbr.Eq("queue_name", queueName)).
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("lease_owner", owner)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to renew lease of operation %s in the queue %s: %s", operationID, queueName, err)
+	}
+
+	return nil
+}
+
+// ReleaseQueueItem drops the lease of the given owner and schedules the item at the given time.
+// An item marked dirty keeps its run time if it is earlier.
+func (ws writeSession) ReleaseQueueItem(queueName, operationID, owner string, nextRunAt, now time.Time) dberr.Error {
+	_, err := ws.updateBySql(fmt.Sprintf(`UPDATE %s
+		SET next_run_at = CASE WHEN dirty AND next_run_at < ? THEN next_run_at ELSE ? END,
+			lease_owner = NULL, lease_expires_at = NULL, dirty = FALSE, updated_at = ?
+		WHERE queue_name = ? AND operation_id = ? AND lease_owner = ?`, QueueItemsTableName),
+		nextRunAt, nextRunAt, now, queueName, operationID, owner).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to release operation %s in the queue %s: %s", operationID, queueName, err)
+	}
+
+	return nil
+}
+
+// WakeQueueItem moves the run time of an existing item which is not leased to the given time if it is scheduled later.
+// Items leased by a replica are not changed.
+func (ws writeSession) WakeQueueItem(queueName, operationID string, nextRunAt time.Time) dberr.Error {
+	_, err := ws.update(QueueItemsTableName).
+		Set("next_run_at", nextRunAt).
+		Set("updated_at", nextRunAt).
+		Where(dbr.Eq("queue_name", queueName)).
+		Where(dbr.Eq("operation_id", operationID)).
+		Where("next_run_at > ?", nextRunAt).
+		Where("(lease_expires_at IS NULL OR lease_expires_at < ?)", nextRunAt).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to wake operation %s in the queue %s: %s", operationID, queueName, err)
+	}
+
+	return nil
+}
+
+// DeleteQueueItem removes the item only if it is still leased by the given owner and it was not scheduled again in the meantime.
+func (ws writeSession) DeleteQueueItem(queueName, operationID, owner string) dberr.Error {
+	_, err := ws.deleteFrom(QueueItemsTableName).
+		Where(dbr.Eq("queue_name", queueName)).
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("lease_owner", owner)).
+		Where(dbr.Eq("dirty", false)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete operation %s from the queue %s: %s", operationID, queueName, err)
+	}
+
+	return nil
+}
+
+// UpdateInstanceEncryptedData replaces provisioning parameters only if they were not modified since they were read.
+// The version is not incremented, so concurrent updates of the instance are not rejected.
+func (ws writeSession) UpdateInstanceEncryptedData(instanceID, oldData, newData string) dberr.Error {
+	res, err := ws.update(InstancesTableName).
+		Set("provisioning_parameters", newData).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Eq("provisioning_parameters", oldData)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update encrypted data of instance %s: %s", instanceID, err)
+	}
+	return ws.checkEncryptedDataUpdated(res, "instance %s", instanceID)
+}
+
+// UpdateOperationEncryptedData replaces provisioning parameters only if they were not modified since they were read.
+// The version is not incremented, so concurrent updates of the operation are not rejected.
+func (ws writeSession) UpdateOperationEncryptedData(operationID, oldData, newData string) dberr.Error {
+	res, err := ws.update(OperationTableName).
+		Set("provisioning_parameters", newData).
+		Where(dbr.Eq("id", operationID)).
+		Where("provisioning_parameters::text = ?", oldData).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update encrypted data of operation %s: %s", operationID, err)
+	}
+	return ws.checkEncryptedDataUpdated(res, "operation %s", operationID)
+}
+
+// UpdateBindingEncryptedData replaces the kubeconfig only if it was not modified since it was read.
+func (ws writeSession) UpdateBindingEncryptedData(instanceID, bindingID, oldData, newData string) dberr.Error {
+	res, err := ws.update(BindingsTableName).
+		Set("kubeconfig", newData).
+		Where(dbr.Eq("id", bindingID)).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Eq("kubeconfig", oldData)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("Failed to update encrypted data of binding %s: %s", bindingID, err)
+	}
+	return ws.checkEncryptedDataUpdated(res, "binding %s", bindingID)
+}
+
+// MarkBindingExpirationReported stores the expiration of the binding as reported, it returns false if the binding did not expire
+// or its expiration was already reported
+func (ws writeSession) MarkBindingExpirationReported(instanceID, bindingID string) (bool, dberr.Error) {
+	res, err := ws.update(BindingsTableName).
+		Set("reported_expires_at", dbr.Expr("expires_at")).
+		Where(dbr.Eq("id", bindingID)).
+		Where(dbr.Eq("instance_id", instanceID)).
+		Where(dbr.Lte("expires_at", time.Now().UTC())).
+		Where("reported_expires_at IS DISTINCT FROM expires_at").
+		Exec()
+	if err != nil {
+		return false, dberr.Internal("Failed to mark expiration of binding %s as reported: %s", bindingID, err)
+	}
+	rAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	return rAffected > 0, nil
+}
+
+func (ws writeSession) checkEncryptedDataUpdated(res sql.Result, format string, a ...interface{}) dberr.Error {
+	rAffected, err := res.RowsAffected()
+	if err != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.Conflict("encrypted data of "+format+" was modified or removed", a...)
+	}
+	return nil
+}
+
 func (ws writeSession) Commit() dberr.Error {
 	err := ws.transaction.Commit()
 	if err != nil {
@@ -446,6 +722,14 @@ func (ws writeSession) insertInto(table string) *dbr.InsertStmt {
 	return ws.session.InsertInto(table)
 }
 
+func (ws writeSession) insertBySql(query string, value ...interface{}) *dbr.InsertStmt {
+	if ws.transaction != nil {
+		return ws.transaction.InsertBySql(query, value...)
+	}
+
+	return ws.session.InsertBySql(query, value...)
+}
+
 func (ws writeSession) deleteFrom(table string) *dbr.DeleteStmt {
 	if ws.transaction != nil {
 		return ws.transaction.DeleteFrom(table)
@@ -461,3 +745,11 @@ func (ws writeSession) update(table string) *dbr.UpdateStmt {
 
 	return ws.session.Update(table)
 }
+
+func (ws writeSession) updateBySql(query string, value ...interface{}) *dbr.UpdateStmt {
+	if ws.transaction != nil {
+		return ws.transaction.UpdateBySql(query, value...)
+	}
+
+	return ws.session.UpdateBySql(query, value...)
+}
@@ -25,6 +25,9 @@ type BrokerStorage interface {
 	Bindings() Bindings
 	Actions() Actions
 	TimeZones() TimeZones
+	QueueItems() QueueItems
+	OperationSteps() OperationSteps
+	KeyRotation() KeyRotation
 }
 
 const (
@@ -60,6 +63,9 @@ func NewFromConfigAndConnectionURL(cfg Config, evcfg events.Config, cipher postg
 		bindings:          postgres.NewBinding(factory, cipher),
 		actions:           postgres.NewAction(factory),
 		timezones:         postgres.NewTimeZones(factory),
+		queueItems:        postgres.NewQueueItems(factory),
+		operationSteps:    postgres.NewOperationSteps(factory),
+		keyRotation:       postgres.NewKeyRotation(factory, cipher),
 	}, connection, nil
 }
 
@@ -74,6 +80,9 @@ func NewMemoryStorage() BrokerStorage {
 		instancesArchived: memory.NewInstanceArchivedInMemoryStorage(),
 		bindings:          memory.NewBinding(),
 		actions:           memory.NewAction(),
+		queueItems:        memory.NewQueueItems(),
+		operationSteps:    memory.NewOperationSteps(),
+		keyRotation:       memory.NewKeyRotation(),
 	}
 }
 
@@ -134,6 +143,9 @@ type storage struct {
 	bindings          Bindings
 	actions           Actions
 	timezones         TimeZones
+	queueItems        QueueItems
+	operationSteps    OperationSteps
+	keyRotation       KeyRotation
 }
 
 func (s storage) Instances() Instances {
@@ -173,3 +185,15 @@ func (s storage) Actions() Actions {
 }
 
 func (s storage) TimeZones() TimeZones { return s.timezones }
+
+func (s storage) QueueItems() QueueItems {
+	return s.queueItems
+}
+
+func (s storage) KeyRotation() KeyRotation {
+	return s.keyRotation
+}
+
+func (s storage) OperationSteps() OperationSteps {
+	return s.operationSteps
+}
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS queue_items (
+    queue_name          varchar(64) NOT NULL,
+    operation_id        varchar(255) NOT NULL,
+    next_run_at         timestamp with time zone NOT NULL,
+    lease_owner         varchar(255),
+    lease_expires_at    timestamp with time zone,
+    created_at          timestamp with time zone NOT NULL,
+    updated_at          timestamp with time zone NOT NULL,
+    PRIMARY KEY (queue_name, operation_id)
+);
+
+CREATE INDEX IF NOT EXISTS queue_items_queue_name_next_run_at ON queue_items USING btree (queue_name, next_run_at);
+
+COMMIT;
@@ -0,0 +1,2 @@
+ALTER TABLE operations DROP COLUMN IF EXISTS lease_owner;
+ALTER TABLE operations DROP COLUMN IF EXISTS lease_expires_at;
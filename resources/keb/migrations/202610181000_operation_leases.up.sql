@@ -0,0 +1,2 @@
+ALTER TABLE operations ADD COLUMN IF NOT EXISTS lease_owner varchar(255);
+ALTER TABLE operations ADD COLUMN IF NOT EXISTS lease_expires_at timestamp with time zone;
@@ -0,0 +1 @@
+ALTER TABLE bindings DROP COLUMN scope;
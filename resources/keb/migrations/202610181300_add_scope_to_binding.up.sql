@@ -0,0 +1,2 @@
+ALTER TABLE bindings
+    ADD COLUMN scope TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE bindings
+    DROP COLUMN state,
+    DROP COLUMN state_description;
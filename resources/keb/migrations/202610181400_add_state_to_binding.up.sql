@@ -0,0 +1,3 @@
+ALTER TABLE bindings
+    ADD COLUMN state VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN state_description TEXT NOT NULL DEFAULT '';
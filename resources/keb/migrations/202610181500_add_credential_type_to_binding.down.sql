@@ -0,0 +1,2 @@
+ALTER TABLE bindings
+    DROP COLUMN credential_type;
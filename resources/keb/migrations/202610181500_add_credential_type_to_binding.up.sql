@@ -0,0 +1,2 @@
+ALTER TABLE bindings
+    ADD COLUMN credential_type VARCHAR(32) NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS operation_steps (
+    operation_id    varchar(255) NOT NULL REFERENCES operations (id) ON DELETE CASCADE,
+    instance_id     varchar(255) NOT NULL,
+    stage           varchar(255) NOT NULL,
+    step_name       varchar(255) NOT NULL,
+    state           varchar(32) NOT NULL,
+    attempts        integer NOT NULL,
+    last_error      text NOT NULL,
+    started_at      timestamp with time zone NOT NULL,
+    finished_at     timestamp with time zone,
+    updated_at      timestamp with time zone NOT NULL,
+    PRIMARY KEY (operation_id, step_name)
+);
+
+CREATE INDEX IF NOT EXISTS operation_steps_instance_id ON operation_steps USING btree (instance_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE queue_items DROP COLUMN IF EXISTS dirty;
+
+COMMIT;
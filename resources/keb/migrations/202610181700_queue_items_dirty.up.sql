@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE queue_items ADD COLUMN IF NOT EXISTS dirty boolean NOT NULL DEFAULT false;
+
+COMMIT;
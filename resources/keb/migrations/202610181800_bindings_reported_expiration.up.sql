@@ -0,0 +1,4 @@
+ALTER TABLE bindings
+    ADD COLUMN reported_expires_at TIMESTAMPTZ;
+
+UPDATE bindings SET reported_expires_at = expires_at WHERE expires_at <= now();
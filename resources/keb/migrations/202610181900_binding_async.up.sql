@@ -0,0 +1,4 @@
+ALTER TABLE bindings
+    ADD COLUMN async BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE bindings SET async = true WHERE state = 'in progress';
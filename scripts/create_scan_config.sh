@@ -30,6 +30,8 @@ bdba:
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-broker-globalaccounts:${TAG}
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-broker-schema-migrator:${TAG}
   - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-service-binding-cleanup-job:${TAG}
+  - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-key-rotation-job:${TAG}
+  - europe-docker.pkg.dev/kyma-project/prod/kyma-environment-orphaned-binding-cleanup-job:${TAG}
 mend:
   language: golang-mod
   exclude:
@@ -27,6 +27,8 @@ SINGLE_JOBS = [
     ("resources/keb/templates/deployment.yaml", "docs/contributor/02-30-keb-configuration.md"),
     ("resources/keb/templates/deprovision-retrigger-job.yaml", "docs/contributor/06-50-deprovision-retrigger-cronjob.md"),
     ("resources/keb/templates/service-binding-cleanup-job.yaml", "docs/contributor/06-70-service-binding-cleanup-cronjob.md"),
+    ("resources/keb/templates/key-rotation-job.yaml", "docs/contributor/06-80-key-rotation-cronjob.md"),
+    ("resources/keb/templates/orphaned-binding-cleanup-job.yaml", "docs/contributor/06-90-orphaned-binding-cleanup-cronjob.md"),
     ("resources/keb/templates/runtime-reconciler-deployment.yaml", "docs/contributor/07-10-runtime-reconciler.md"),
     ("resources/keb/templates/subaccount-sync-deployment.yaml", "docs/contributor/07-20-subaccount-sync.md"),
     ("resources/keb/templates/migrator-job.yaml", "docs/contributor/07-30-schema-migrator.md"),
@@ -26,6 +26,8 @@ declare -a IMAGES=(
     "kyma-environment-globalaccounts:Dockerfile.globalaccounts:BIN=globalaccounts"
     "kyma-environment-broker-schema-migrator:Dockerfile.schemamigrator:"
     "kyma-environment-service-binding-cleanup-job:Dockerfile.job:BIN=servicebindingcleanup"
+    "kyma-environment-key-rotation-job:Dockerfile.job:BIN=keyrotation"
+    "kyma-environment-orphaned-binding-cleanup-job:Dockerfile.job:BIN=orphanedbindingcleanup"
 )
 
 for IMAGE_SPEC in "${IMAGES[@]}"; do